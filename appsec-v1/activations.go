@@ -0,0 +1,132 @@
+package appsec
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ActivationAction is used to create an "enum" of possible Activation.Action values
+type ActivationAction string
+
+// NetworkValue is used to create an "enum" of possible Activation.Network values
+type NetworkValue string
+
+const (
+	// ActivationActivate Activation.Action value ACTIVATE
+	ActivationActivate ActivationAction = "ACTIVATE"
+	// ActivationDeactivate Activation.Action value DEACTIVATE
+	ActivationDeactivate ActivationAction = "DEACTIVATE"
+
+	// NetworkStaging Activation.Network value STAGING
+	NetworkStaging NetworkValue = "STAGING"
+	// NetworkProduction Activation.Network value PRODUCTION
+	NetworkProduction NetworkValue = "PRODUCTION"
+)
+
+// Activation status values of Activation.Status
+const (
+	ActivationReceived  = "RECEIVED"
+	ActivationPending   = "PENDING_ACTIVATION"
+	ActivationActivated = "ACTIVATED"
+	ActivationFailed    = "FAILED"
+	ActivationAborted   = "ABORTED"
+)
+
+// DefaultActivationInterval is the WaitForActivation polling interval used when none is given
+var DefaultActivationInterval = 30 * time.Second
+
+// Activation activates or deactivates security configuration versions on a network
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#activation
+type Activation struct {
+	ActivationID       int                `json:"activationId,omitempty"`
+	Action             ActivationAction   `json:"action"`
+	Network            NetworkValue       `json:"network"`
+	Note               string             `json:"note,omitempty"`
+	NotificationEmails []string           `json:"notificationEmails"`
+	ActivationConfigs  []ActivationConfig `json:"activationConfigs"`
+	Status             string             `json:"status,omitempty"`
+	CreateDate         string             `json:"createDate,omitempty"`
+	CreatedBy          string             `json:"createdBy,omitempty"`
+}
+
+// ActivationConfig is a security configuration version of an Activation
+type ActivationConfig struct {
+	ConfigID              int    `json:"configId"`
+	ConfigName            string `json:"configName,omitempty"`
+	ConfigVersion         int    `json:"configVersion"`
+	PreviousConfigVersion int    `json:"previousConfigVersion,omitempty"`
+}
+
+// Done reports whether the activation reached a final status
+func (activation *Activation) Done() bool {
+	switch activation.Status {
+	case ActivationActivated, ActivationFailed, ActivationAborted:
+		return true
+	}
+
+	return false
+}
+
+// ActivationFailedError is returned by WaitForActivation when an activation fails or is aborted
+type ActivationFailedError struct {
+	Activation *Activation
+}
+
+func (e *ActivationFailedError) Error() string {
+	return fmt.Sprintf("activation %d %s", e.Activation.ActivationID, e.Activation.Status)
+}
+
+// Activate activates or deactivates security configuration versions
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postactivations
+// Endpoint: POST /appsec/v1/activations
+func Activate(activation *Activation) (*Activation, error) {
+	created := &Activation{}
+	if err := doJSON("POST", "/appsec/v1/activations", activation, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetActivation retrieves the status of an activation
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getactivation
+// Endpoint: GET /appsec/v1/activations/{activationId}
+func GetActivation(activationID int) (*Activation, error) {
+	activation := &Activation{}
+	if err := doJSON("GET", fmt.Sprintf("/appsec/v1/activations/%d", activationID), nil, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// WaitForActivation polls the status of an activation every interval until it
+// is ACTIVATED, FAILED or ABORTED, or ctx is done.
+func WaitForActivation(ctx context.Context, activationID int, interval time.Duration) (*Activation, error) {
+	if interval <= 0 {
+		interval = DefaultActivationInterval
+	}
+
+	for {
+		activation, err := GetActivation(activationID)
+		if err != nil {
+			return nil, err
+		}
+		if activation.Done() {
+			if activation.Status != ActivationActivated {
+				return activation, &ActivationFailedError{Activation: activation}
+			}
+			return activation, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return activation, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
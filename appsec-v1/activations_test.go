@@ -0,0 +1,68 @@
+package appsec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestActivateAndWait(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/appsec/v1/activations").
+		JSON(map[string]interface{}{
+			"action":             "ACTIVATE",
+			"network":            "STAGING",
+			"note":               "rate limits",
+			"notificationEmails": []string{"jdoe@example.com"},
+			"activationConfigs":  []map[string]interface{}{{"configId": 43253, "configVersion": 7}},
+		}).
+		Reply(200).
+		JSON(`{"activationId": 2002, "action": "ACTIVATE", "network": "STAGING", "status": "RECEIVED"}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/activations/2002").
+		Reply(200).
+		JSON(`{"activationId": 2002, "status": "PENDING_ACTIVATION"}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/activations/2002").
+		Reply(200).
+		JSON(`{"activationId": 2002, "status": "ACTIVATED"}`)
+
+	Init(config)
+
+	activation, err := Activate(&Activation{
+		Action:             ActivationActivate,
+		Network:            NetworkStaging,
+		Note:               "rate limits",
+		NotificationEmails: []string{"jdoe@example.com"},
+		ActivationConfigs:  []ActivationConfig{{ConfigID: 43253, ConfigVersion: 7}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2002, activation.ActivationID)
+
+	activation, err = WaitForActivation(context.Background(), activation.ActivationID, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, ActivationActivated, activation.Status)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForActivationFailed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/appsec/v1/activations/2003").
+		Reply(200).
+		JSON(`{"activationId": 2003, "status": "FAILED"}`)
+
+	Init(config)
+
+	activation, err := WaitForActivation(context.Background(), 2003, time.Millisecond)
+	require.Error(t, err)
+	assert.IsType(t, &ActivationFailedError{}, err)
+	assert.Equal(t, ActivationFailed, activation.Status)
+}
@@ -0,0 +1,60 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// APIRequestConstraintAction is the action a security policy takes for
+// requests violating the constraints of an API endpoint (body size, parameter
+// types, undefined parameters) defined in API Gateway
+type APIRequestConstraintAction struct {
+	ID     int    `json:"id"`
+	Action string `json:"action"`
+}
+
+func apiRequestConstraintsPath(configID, version int, policyID string) string {
+	return securityPolicyPath(configID, version, policyID) + "/api-request-constraints"
+}
+
+// GetAPIRequestConstraints retrieves the API request constraint actions of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getapirequestconstraints
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/api-request-constraints
+func GetAPIRequestConstraints(configID, version int, policyID string) ([]APIRequestConstraintAction, error) {
+	response := struct {
+		APIEndpoints []APIRequestConstraintAction `json:"apiEndpoints"`
+	}{}
+	if err := doJSON("GET", apiRequestConstraintsPath(configID, version, policyID), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.APIEndpoints, nil
+}
+
+// UpdateAPIRequestConstraints sets the action for the API request constraints
+// of all API endpoints of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putapirequestconstraints
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/api-request-constraints
+func UpdateAPIRequestConstraints(configID, version int, policyID string, action string) error {
+	return doJSON("PUT", apiRequestConstraintsPath(configID, version, policyID), struct {
+		Action string `json:"action"`
+	}{action}, nil)
+}
+
+// UpdateAPIEndpointRequestConstraints overrides the action for the API
+// request constraints of one API endpoint of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putapirequestconstraintsapiid
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/api-request-constraints/{apiId}
+func UpdateAPIEndpointRequestConstraints(configID, version int, policyID string, apiID int, action string) (*APIRequestConstraintAction, error) {
+	updated := &APIRequestConstraintAction{}
+	path := fmt.Sprintf("%s/%d", apiRequestConstraintsPath(configID, version, policyID), apiID)
+	if err := doJSON("PUT", path, struct {
+		Action string `json:"action"`
+	}{action}, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
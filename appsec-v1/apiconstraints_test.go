@@ -0,0 +1,44 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAPIMatchTargetAndRequestConstraints(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/versions/8/match-targets").
+		JSON(map[string]interface{}{"type": "api", "apis": []map[string]interface{}{{"id": 624913}}, "isNegativePathMatch": false, "isNegativeFileExtensionMatch": false, "securityPolicy": map[string]interface{}{"policyId": "AAAA_81230"}}).
+		Reply(201).
+		JSON(`{"targetId": 3002, "type": "api", "apis": [{"id": 624913, "name": "Payments"}], "securityPolicy": {"policyId": "AAAA_81230"}}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/8/security-policies/AAAA_81230/api-request-constraints/624913").
+		JSON(map[string]interface{}{"action": "deny"}).
+		Reply(200).
+		JSON(`{"id": 624913, "action": "deny"}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions/8/security-policies/AAAA_81230/api-request-constraints").
+		Reply(200).
+		JSON(`{"apiEndpoints": [{"id": 624913, "action": "deny"}, {"id": 624914, "action": "alert"}]}`)
+
+	Init(config)
+
+	target, err := CreateMatchTarget(43253, 8, NewAPIMatchTarget("AAAA_81230", 624913))
+	require.NoError(t, err)
+	require.Len(t, target.APIs, 1)
+	assert.Equal(t, "Payments", target.APIs[0].Name)
+
+	action, err := UpdateAPIEndpointRequestConstraints(43253, 8, "AAAA_81230", 624913, "deny")
+	require.NoError(t, err)
+	assert.Equal(t, "deny", action.Action)
+
+	actions, err := GetAPIRequestConstraints(43253, 8, "AAAA_81230")
+	require.NoError(t, err)
+	assert.Len(t, actions, 2)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,171 @@
+package appsec
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ConfigVersion identifies a security configuration version
+type ConfigVersion struct {
+	ConfigID int
+	Version  int
+}
+
+// CloneOptions selects what CloneSecurityPolicy copies besides the policy itself
+type CloneOptions struct {
+	// PolicyName and PolicyPrefix of the new policy, the name defaults to the source policy name
+	PolicyName   string
+	PolicyPrefix string
+	// RuleActions copies the WAF rule and attack group actions
+	RuleActions bool
+	// Exceptions copies the conditions and exceptions of the WAF rules
+	Exceptions bool
+	// RatePolicies copies the rate policies used by the policy, and their actions
+	RatePolicies bool
+	// MatchTargets copies the website and API match targets of the policy
+	MatchTargets bool
+}
+
+// CloneSecurityPolicy copies the security policy policyID of from into a new
+// policy of to, which must be an editable version of another (or the same)
+// security configuration
+//
+// The source is read with GetExport. Rate policies are config level objects
+// and are created anew in to. Match targets are created for the new policy and
+// usually need their hostnames changed afterwards. The new policy is returned
+// even when copying a part fails, along with the error, so that it can be
+// fixed or removed.
+func CloneSecurityPolicy(from ConfigVersion, policyID string, to ConfigVersion, options CloneOptions) (*SecurityPolicy, error) {
+	export, err := GetExport(from.ConfigID, from.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := export.Policy(policyID)
+	if !ok {
+		return nil, fmt.Errorf("security policy %s not found in config %d version %d", policyID, from.ConfigID, from.Version)
+	}
+
+	policy := &SecurityPolicy{PolicyName: options.PolicyName, PolicyPrefix: options.PolicyPrefix, DefaultSettings: true}
+	if policy.PolicyName == "" {
+		policy.PolicyName = source.Name
+	}
+	policy, err = CreateSecurityPolicy(to.ConfigID, to.Version, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	path := securityPolicyPath(to.ConfigID, to.Version, policy.PolicyID)
+
+	if options.RuleActions {
+		for _, action := range source.WebApplicationFirewall.AttackGroupActions {
+			if err := doJSON("PUT", fmt.Sprintf("%s/attack-groups/%s", path, action.Group), JSONObject{"action": action.Action}, nil); err != nil {
+				return policy, err
+			}
+		}
+		for _, action := range source.WebApplicationFirewall.RuleActions {
+			if err := doJSON("PUT", fmt.Sprintf("%s/rules/%d", path, action.ID), JSONObject{"action": action.Action}, nil); err != nil {
+				return policy, err
+			}
+		}
+	}
+
+	if options.Exceptions {
+		for _, action := range source.WebApplicationFirewall.RuleActions {
+			if len(action.Conditions) == 0 && len(action.Exception) == 0 {
+				continue
+			}
+
+			body := JSONObject{"conditions": action.Conditions, "exception": action.Exception}
+			if err := doJSON("PUT", fmt.Sprintf("%s/rules/%d/condition-exception", path, action.ID), body, nil); err != nil {
+				return policy, err
+			}
+		}
+	}
+
+	if options.RatePolicies {
+		if err := cloneRatePolicies(export, source, to, path); err != nil {
+			return policy, err
+		}
+	}
+
+	if options.MatchTargets {
+		if err := cloneMatchTargets(export, policyID, to, policy.PolicyID); err != nil {
+			return policy, err
+		}
+	}
+
+	return policy, nil
+}
+
+// cloneRatePolicies creates the rate policies used by source in to and sets their actions
+func cloneRatePolicies(export *Export, source *ExportedPolicy, to ConfigVersion, path string) error {
+	for _, action := range source.RatePolicyActions {
+		var ratePolicy JSONObject
+		for _, candidate := range export.RatePolicies {
+			if jsonID(candidate["id"]) == action.ID {
+				ratePolicy = candidate
+			}
+		}
+		if ratePolicy == nil {
+			return fmt.Errorf("rate policy %d not found in config %d version %d", action.ID, export.ConfigID, export.Version)
+		}
+
+		body := JSONObject{}
+		for key, value := range ratePolicy {
+			if key != "id" && key != "used" {
+				body[key] = value
+			}
+		}
+
+		created := JSONObject{}
+		if err := doJSON("POST", configVersionPath(to.ConfigID, to.Version)+"/rate-policies", body, &created); err != nil {
+			return err
+		}
+
+		newAction := RatePolicyAction{ID: jsonID(created["id"]), IPv4Action: action.IPv4Action, IPv6Action: action.IPv6Action}
+		if err := doJSON("PUT", fmt.Sprintf("%s/rate-policies/%d", path, newAction.ID), newAction, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneMatchTargets creates copies of the match targets of policyID for newPolicyID in to
+func cloneMatchTargets(export *Export, policyID string, to ConfigVersion, newPolicyID string) error {
+	targets := append(append([]JSONObject{}, export.MatchTargets.WebsiteTargets...), export.MatchTargets.APITargets...)
+	for _, target := range targets {
+		securityPolicy, _ := target["securityPolicy"].(map[string]interface{})
+		if securityPolicy == nil || securityPolicy["policyId"] != policyID {
+			continue
+		}
+
+		body := JSONObject{}
+		for key, value := range target {
+			if key != "targetId" && key != "sequence" && key != "configId" && key != "configVersion" {
+				body[key] = value
+			}
+		}
+		body["securityPolicy"] = JSONObject{"policyId": newPolicyID}
+
+		if err := doJSON("POST", configVersionPath(to.ConfigID, to.Version)+"/match-targets", body, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonID converts a decoded JSON number to an int
+func jsonID(value interface{}) int {
+	switch id := value.(type) {
+	case float64:
+		return int(id)
+	case string:
+		n, _ := strconv.Atoi(id)
+		return n
+	}
+
+	return 0
+}
@@ -0,0 +1,99 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCloneSecurityPolicy(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/appsec/v1/export/configs/43253/versions/7").
+		Reply(200).
+		JSON(`{
+			"configId": 43253,
+			"version": 7,
+			"securityPolicies": [{
+				"id": "AAAA_81230",
+				"name": "Storefront",
+				"webApplicationFirewall": {
+					"ruleActions": [{"id": 950002, "action": "deny", "exception": {"headerCookieOrParamValues": ["abc"]}}],
+					"attackGroupActions": [{"group": "SQL", "action": "alert"}]
+				},
+				"ratePolicyActions": [{"id": 110, "ipv4Action": "deny", "ipv6Action": "alert"}]
+			}],
+			"ratePolicies": [{"id": 110, "name": "Origin Error", "averageThreshold": 5, "used": true}],
+			"matchTargets": {
+				"websiteTargets": [
+					{"targetId": 2712, "type": "website", "hostnames": ["www.example.com"], "securityPolicy": {"policyId": "AAAA_81230"}},
+					{"targetId": 2713, "type": "website", "hostnames": ["api.example.com"], "securityPolicy": {"policyId": "BBBB_12345"}}
+				]
+			}
+		}`)
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/51234/versions/2/security-policies").
+		JSON(map[string]interface{}{"policyName": "Storefront EU", "policyPrefix": "EU01", "defaultSettings": true}).
+		Reply(200).
+		JSON(`{"policyId": "EU01_10001", "policyName": "Storefront EU"}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/51234/versions/2/security-policies/EU01_10001/attack-groups/SQL").
+		JSON(map[string]string{"action": "alert"}).
+		Reply(200).
+		JSON(`{"action": "alert"}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/51234/versions/2/security-policies/EU01_10001/rules/950002$").
+		JSON(map[string]string{"action": "deny"}).
+		Reply(200).
+		JSON(`{"action": "deny"}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/51234/versions/2/security-policies/EU01_10001/rules/950002/condition-exception").
+		JSON(map[string]interface{}{"conditions": nil, "exception": map[string]interface{}{"headerCookieOrParamValues": []string{"abc"}}}).
+		Reply(200).
+		JSON(`{}`)
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/51234/versions/2/rate-policies").
+		JSON(map[string]interface{}{"name": "Origin Error", "averageThreshold": 5}).
+		Reply(201).
+		JSON(`{"id": 220, "name": "Origin Error", "averageThreshold": 5}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/51234/versions/2/security-policies/EU01_10001/rate-policies/220").
+		JSON(map[string]interface{}{"id": 220, "ipv4Action": "deny", "ipv6Action": "alert"}).
+		Reply(200).
+		JSON(`{}`)
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/51234/versions/2/match-targets").
+		JSON(map[string]interface{}{"type": "website", "hostnames": []string{"www.example.com"}, "securityPolicy": map[string]string{"policyId": "EU01_10001"}}).
+		Reply(201).
+		JSON(`{"targetId": 3001}`)
+
+	Init(config)
+
+	policy, err := CloneSecurityPolicy(
+		ConfigVersion{ConfigID: 43253, Version: 7},
+		"AAAA_81230",
+		ConfigVersion{ConfigID: 51234, Version: 2},
+		CloneOptions{PolicyName: "Storefront EU", PolicyPrefix: "EU01", RuleActions: true, Exceptions: true, RatePolicies: true, MatchTargets: true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "EU01_10001", policy.PolicyID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCloneSecurityPolicy_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/appsec/v1/export/configs/43253/versions/7").
+		Reply(200).
+		JSON(`{"configId": 43253, "version": 7, "securityPolicies": []}`)
+
+	Init(config)
+
+	_, err := CloneSecurityPolicy(ConfigVersion{ConfigID: 43253, Version: 7}, "AAAA_81230", ConfigVersion{ConfigID: 51234, Version: 2}, CloneOptions{})
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+}
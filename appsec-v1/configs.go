@@ -0,0 +1,102 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// Configuration is a security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#configuration
+type Configuration struct {
+	ID                  int      `json:"id"`
+	Name                string   `json:"name"`
+	Description         string   `json:"description,omitempty"`
+	FileType            string   `json:"fileType,omitempty"`
+	LatestVersion       int      `json:"latestVersion"`
+	StagingVersion      int      `json:"stagingVersion,omitempty"`
+	ProductionVersion   int      `json:"productionVersion,omitempty"`
+	ProductionHostnames []string `json:"productionHostnames,omitempty"`
+}
+
+// Version is a version of a security configuration
+type Version struct {
+	Version      int               `json:"version"`
+	VersionNotes string            `json:"versionNotes,omitempty"`
+	CreateDate   string            `json:"createDate,omitempty"`
+	CreatedBy    string            `json:"createdBy,omitempty"`
+	BasedOn      int               `json:"basedOn,omitempty"`
+	Staging      VersionActivation `json:"staging"`
+	Production   VersionActivation `json:"production"`
+}
+
+// VersionActivation is the activation status of a version on a network
+type VersionActivation struct {
+	Status string `json:"status"`
+	Time   string `json:"time,omitempty"`
+}
+
+// Editable reports whether the version was never activated, only those can be changed
+func (version *Version) Editable() bool {
+	return version.Staging.Status == "Inactive" && version.Production.Status == "Inactive"
+}
+
+// ListConfigurations lists the security configurations
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getconfigurations
+// Endpoint: GET /appsec/v1/configs
+func ListConfigurations() ([]Configuration, error) {
+	response := struct {
+		Configurations []Configuration `json:"configurations"`
+	}{}
+	if err := doJSON("GET", "/appsec/v1/configs", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Configurations, nil
+}
+
+// ListVersions lists the versions of a security configuration, most recent first
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getversions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions
+func ListVersions(configID int) ([]Version, error) {
+	response := struct {
+		VersionList []Version `json:"versionList"`
+	}{}
+	if err := doJSON("GET", fmt.Sprintf("/appsec/v1/configs/%d/versions", configID), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.VersionList, nil
+}
+
+// GetVersion retrieves a version of a security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getversionnumber
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}
+func GetVersion(configID, version int) (*Version, error) {
+	response := &Version{}
+	if err := doJSON("GET", configVersionPath(configID, version), nil, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateVersion creates a new version of a security configuration as a copy of createFromVersion
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postversions
+// Endpoint: POST /appsec/v1/configs/{configId}/versions
+func CreateVersion(configID, createFromVersion int) (*Version, error) {
+	body := struct {
+		CreateFromVersion int  `json:"createFromVersion"`
+		RuleUpdate        bool `json:"ruleUpdate"`
+	}{CreateFromVersion: createFromVersion}
+
+	created := &Version{}
+	if err := doJSON("POST", fmt.Sprintf("/appsec/v1/configs/%d/versions", configID), body, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,50 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateVersionAndMatchTarget(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions").
+		Reply(200).
+		JSON(`{"configId": 43253, "versionList": [{"version": 7, "production": {"status": "Active"}, "staging": {"status": "Inactive"}}, {"version": 6, "production": {"status": "Inactive"}, "staging": {"status": "Inactive"}}]}`)
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/versions").
+		JSON(map[string]interface{}{"createFromVersion": 7, "ruleUpdate": false}).
+		Reply(201).
+		JSON(`{"version": 8, "basedOn": 7, "production": {"status": "Inactive"}, "staging": {"status": "Inactive"}}`)
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/versions/8/match-targets").
+		JSON(map[string]interface{}{"type": "website", "hostnames": []string{"www.example.com"}, "filePaths": []string{"/*"}, "isNegativePathMatch": false, "isNegativeFileExtensionMatch": false, "securityPolicy": map[string]interface{}{"policyId": "AAAA_81230"}}).
+		Reply(201).
+		JSON(`{"targetId": 3001, "type": "website", "sequence": 1, "hostnames": ["www.example.com"], "filePaths": ["/*"], "securityPolicy": {"policyId": "AAAA_81230"}}`)
+
+	Init(config)
+
+	versions, err := ListVersions(43253)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.False(t, versions[0].Editable())
+	assert.True(t, versions[1].Editable())
+
+	version, err := CreateVersion(43253, versions[0].Version)
+	require.NoError(t, err)
+	assert.Equal(t, 8, version.Version)
+
+	target, err := CreateMatchTarget(43253, version.Version, &MatchTarget{
+		Type:           MatchTargetWebsite,
+		Hostnames:      []string{"www.example.com"},
+		FilePaths:      []string{"/*"},
+		SecurityPolicy: MatchTargetPolicy{PolicyID: "AAAA_81230"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3001, target.TargetID)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,130 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// CustomRule is a configuration level rule, enabled per security policy with a CustomRuleAction
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#customrule
+type CustomRule struct {
+	ID          int                   `json:"id,omitempty"`
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Version     int                   `json:"version,omitempty"`
+	Tag         []string              `json:"tag,omitempty"`
+	Conditions  []CustomRuleCondition `json:"conditions"`
+}
+
+// CustomRuleCondition is a condition of a custom rule, e.g. on the request path or a header
+type CustomRuleCondition struct {
+	Type          string   `json:"type"`
+	PositiveMatch bool     `json:"positiveMatch"`
+	Name          []string `json:"name,omitempty"`
+	NameWildcard  bool     `json:"nameWildcard,omitempty"`
+	Value         []string `json:"value,omitempty"`
+	ValueWildcard bool     `json:"valueWildcard,omitempty"`
+	ValueCase     bool     `json:"valueCase,omitempty"`
+}
+
+// CustomRuleAction is the action a security policy takes for a custom rule
+type CustomRuleAction struct {
+	RuleID int    `json:"ruleId"`
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action"`
+}
+
+func customRulesPath(configID int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d/custom-rules", configID)
+}
+
+// ListCustomRules lists the custom rules of a security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getcustomrules
+// Endpoint: GET /appsec/v1/configs/{configId}/custom-rules
+func ListCustomRules(configID int) ([]CustomRule, error) {
+	response := struct {
+		CustomRules []CustomRule `json:"customRules"`
+	}{}
+	if err := doJSON("GET", customRulesPath(configID), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.CustomRules, nil
+}
+
+// GetCustomRule retrieves a custom rule
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getcustomrule
+// Endpoint: GET /appsec/v1/configs/{configId}/custom-rules/{ruleId}
+func GetCustomRule(configID, ruleID int) (*CustomRule, error) {
+	rule := &CustomRule{}
+	if err := doJSON("GET", fmt.Sprintf("%s/%d", customRulesPath(configID), ruleID), nil, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// CreateCustomRule creates a custom rule
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postcustomrules
+// Endpoint: POST /appsec/v1/configs/{configId}/custom-rules
+func CreateCustomRule(configID int, rule *CustomRule) (*CustomRule, error) {
+	created := &CustomRule{}
+	if err := doJSON("POST", customRulesPath(configID), rule, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateCustomRule updates a custom rule
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putcustomrule
+// Endpoint: PUT /appsec/v1/configs/{configId}/custom-rules/{ruleId}
+func UpdateCustomRule(configID int, rule *CustomRule) (*CustomRule, error) {
+	updated := &CustomRule{}
+	if err := doJSON("PUT", fmt.Sprintf("%s/%d", customRulesPath(configID), rule.ID), rule, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveCustomRule deletes a custom rule, it must not be used by any security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#deletecustomrule
+// Endpoint: DELETE /appsec/v1/configs/{configId}/custom-rules/{ruleId}
+func RemoveCustomRule(configID, ruleID int) error {
+	return doJSON("DELETE", fmt.Sprintf("%s/%d", customRulesPath(configID), ruleID), nil, nil)
+}
+
+// GetCustomRuleActions retrieves the custom rule actions of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getcustomruleactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/custom-rules
+func GetCustomRuleActions(configID, version int, policyID string) ([]CustomRuleAction, error) {
+	var actions []CustomRuleAction
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID)+"/custom-rules", nil, &actions); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// UpdateCustomRuleAction sets the action a security policy takes for a custom rule, "none" disables it
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putcustomruleaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/custom-rules/{ruleId}
+func UpdateCustomRuleAction(configID, version int, policyID string, ruleID int, action string) (*CustomRuleAction, error) {
+	updated := &CustomRuleAction{}
+	path := fmt.Sprintf("%s/custom-rules/%d", securityPolicyPath(configID, version, policyID), ruleID)
+	if err := doJSON("PUT", path, struct {
+		Action string `json:"action"`
+	}{action}, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
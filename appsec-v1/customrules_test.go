@@ -0,0 +1,54 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCustomRuleAndActions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/custom-rules").
+		JSON(map[string]interface{}{"name": "Block admin", "conditions": []map[string]interface{}{{"type": "pathMatch", "positiveMatch": true, "value": []string{"/admin"}}}}).
+		Reply(201).
+		JSON(`{"id": 60012, "name": "Block admin", "version": 1, "conditions": [{"type": "pathMatch", "positiveMatch": true, "value": ["/admin"]}]}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/custom-rules/60012").
+		JSON(map[string]interface{}{"action": "deny"}).
+		Reply(200).
+		JSON(`{"ruleId": 60012, "action": "deny"}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/attack-groups/SQL").
+		JSON(map[string]interface{}{"action": "alert"}).
+		Reply(200).
+		JSON(`{"action": "alert"}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/rules").
+		Reply(200).
+		JSON(`{"ruleActions": [{"id": 950002, "action": "deny"}, {"id": 950006, "action": "alert"}]}`)
+
+	Init(config)
+
+	rule, err := CreateCustomRule(43253, &CustomRule{
+		Name:       "Block admin",
+		Conditions: []CustomRuleCondition{{Type: "pathMatch", PositiveMatch: true, Value: []string{"/admin"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 60012, rule.ID)
+
+	action, err := UpdateCustomRuleAction(43253, 7, "AAAA_81230", rule.ID, "deny")
+	require.NoError(t, err)
+	assert.Equal(t, "deny", action.Action)
+
+	require.NoError(t, UpdateAttackGroupAction(43253, 7, "AAAA_81230", "SQL", "alert"))
+
+	actions, err := GetRuleActions(43253, 7, "AAAA_81230")
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, 950006, actions[1].ID)
+	assert.True(t, gock.IsDone())
+}
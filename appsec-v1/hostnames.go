@@ -0,0 +1,224 @@
+package appsec
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// HostnameCoverage is a hostname of the account and the security
+// configuration protecting it, if any
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#hostnamecoverage
+type HostnameCoverage struct {
+	Hostname       string                 `json:"hostname"`
+	Status         string                 `json:"status"`
+	HasMatchTarget bool                   `json:"hasMatchTarget"`
+	PolicyNames    []string               `json:"policyNames,omitempty"`
+	Configuration  *CoverageConfiguration `json:"configuration,omitempty"`
+}
+
+// CoverageConfiguration is the security configuration version covering a hostname
+type CoverageConfiguration struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// Covered reports whether the hostname is protected by a security configuration
+func (coverage *HostnameCoverage) Covered() bool {
+	return coverage.Configuration != nil && coverage.Configuration.ID != 0
+}
+
+// HostnameOverlap is another security configuration version that also
+// covers a hostname
+type HostnameOverlap struct {
+	ConfigID      int    `json:"configId"`
+	ConfigName    string `json:"configName"`
+	ConfigVersion int    `json:"configVersion"`
+	ContractID    string `json:"contractId"`
+	ContractName  string `json:"contractName"`
+}
+
+// SelectableHostname is a hostname that can be added to a security configuration
+type SelectableHostname struct {
+	Hostname               string `json:"hostname"`
+	ActiveInStaging        bool   `json:"activeInStaging"`
+	ActiveInProduction     bool   `json:"activeInProduction"`
+	ARLInclusion           bool   `json:"arlInclusion"`
+	ConfigIDInProduction   int    `json:"configIdInProduction,omitempty"`
+	ConfigNameInProduction string `json:"configNameInProduction,omitempty"`
+}
+
+// SelectableHostnames are the hostnames that can be added to a security
+// configuration, and those that cannot
+type SelectableHostnames struct {
+	AvailableSet []SelectableHostname `json:"availableSet"`
+	ErrorSet     []SelectableHostname `json:"errorSet,omitempty"`
+	Protect      bool                 `json:"protect"`
+}
+
+// Unprotected returns the sorted available hostnames that no security
+// configuration protects in production
+func (selectable *SelectableHostnames) Unprotected() []string {
+	var hostnames []string
+	for _, hostname := range selectable.AvailableSet {
+		if hostname.ConfigIDInProduction == 0 {
+			hostnames = append(hostnames, hostname.Hostname)
+		}
+	}
+	sort.Strings(hostnames)
+
+	return hostnames
+}
+
+// SelectedHostnamesMode is used to create an "enum" of possible UpdateSelectedHostnames modes
+type SelectedHostnamesMode string
+
+const (
+	// SelectedHostnamesAppend SelectedHostnamesMode value append
+	SelectedHostnamesAppend SelectedHostnamesMode = "append"
+	// SelectedHostnamesRemove SelectedHostnamesMode value remove
+	SelectedHostnamesRemove SelectedHostnamesMode = "remove"
+	// SelectedHostnamesReplace SelectedHostnamesMode value replace
+	SelectedHostnamesReplace SelectedHostnamesMode = "replace"
+)
+
+type selectedHostname struct {
+	Hostname string `json:"hostname"`
+}
+
+type hostnameList struct {
+	HostnameList []selectedHostname `json:"hostnameList"`
+}
+
+func (list hostnameList) hostnames() []string {
+	hostnames := make([]string, 0, len(list.HostnameList))
+	for _, hostname := range list.HostnameList {
+		hostnames = append(hostnames, hostname.Hostname)
+	}
+
+	return hostnames
+}
+
+// ListHostnameCoverage lists the hostnames of the account and the security
+// configurations covering them
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#gethostnamecoverage
+// Endpoint: GET /appsec/v1/hostname-coverage
+func ListHostnameCoverage() ([]HostnameCoverage, error) {
+	response := struct {
+		HostnameCoverage []HostnameCoverage `json:"hostnameCoverage"`
+	}{}
+	if err := doJSON("GET", "/appsec/v1/hostname-coverage", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.HostnameCoverage, nil
+}
+
+// CoveringConfigurations returns the coverage of the given hostnames by
+// hostname, hostnames unknown to the account are left out
+func CoveringConfigurations(hostnames ...string) (map[string]HostnameCoverage, error) {
+	coverage, err := ListHostnameCoverage()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := map[string]bool{}
+	for _, hostname := range hostnames {
+		wanted[strings.ToLower(hostname)] = true
+	}
+
+	covering := map[string]HostnameCoverage{}
+	for _, hostname := range coverage {
+		if wanted[strings.ToLower(hostname.Hostname)] {
+			covering[hostname.Hostname] = hostname
+		}
+	}
+
+	return covering, nil
+}
+
+// GetHostnameOverlap lists the other security configurations covering a
+// hostname of a configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#gethostnamecoverageoverlapping
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/hostname-coverage/overlapping{?hostname}
+func GetHostnameOverlap(configID, version int, hostname string) ([]HostnameOverlap, error) {
+	path := fmt.Sprintf("%s/hostname-coverage/overlapping?hostname=%s", configVersionPath(configID, version), url.QueryEscape(hostname))
+	response := struct {
+		HostnameList []HostnameOverlap `json:"hostnameList"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.HostnameList, nil
+}
+
+// GetSelectableHostnames lists the hostnames that can be added to a security
+// configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getselectablehostnames
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/selectable-hostnames
+func GetSelectableHostnames(configID, version int) (*SelectableHostnames, error) {
+	response := &SelectableHostnames{}
+	if err := doJSON("GET", configVersionPath(configID, version)+"/selectable-hostnames", nil, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetContractSelectableHostnames lists the hostnames of a contract and group
+// that can be added to a new security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getselectablehostnamescontract
+// Endpoint: GET /appsec/v1/contracts/{contractId}/groups/{groupId}/selectable-hostnames
+func GetContractSelectableHostnames(contractID string, groupID int) (*SelectableHostnames, error) {
+	path := fmt.Sprintf("/appsec/v1/contracts/%s/groups/%d/selectable-hostnames", contractID, groupID)
+	response := &SelectableHostnames{}
+	if err := doJSON("GET", path, nil, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetSelectedHostnames lists the hostnames protected by a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getselectedhostnames
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/selected-hostnames
+func GetSelectedHostnames(configID, version int) ([]string, error) {
+	response := hostnameList{}
+	if err := doJSON("GET", configVersionPath(configID, version)+"/selected-hostnames", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.hostnames(), nil
+}
+
+// UpdateSelectedHostnames adds, removes or replaces the hostnames protected
+// by a security configuration version, which must be editable, and returns
+// the resulting hostnames
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putselectedhostnames
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/selected-hostnames
+func UpdateSelectedHostnames(configID, version int, mode SelectedHostnamesMode, hostnames ...string) ([]string, error) {
+	body := struct {
+		HostnameList []selectedHostname    `json:"hostnameList"`
+		Mode         SelectedHostnamesMode `json:"mode"`
+	}{make([]selectedHostname, len(hostnames)), mode}
+	for i, hostname := range hostnames {
+		body.HostnameList[i].Hostname = hostname
+	}
+
+	response := hostnameList{}
+	if err := doJSON("PUT", configVersionPath(configID, version)+"/selected-hostnames", body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.hostnames(), nil
+}
@@ -0,0 +1,63 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestHostnameCoverage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/appsec/v1/hostname-coverage").
+		Reply(200).
+		JSON(`{"hostnameCoverage": [
+			{"hostname": "www.example.com", "status": "covered", "hasMatchTarget": true, "policyNames": ["Site"], "configuration": {"id": 43253, "name": "Example", "version": 7}},
+			{"hostname": "new.example.com", "status": "not_covered", "hasMatchTarget": false}
+		]}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions/8/hostname-coverage/overlapping").
+		MatchParam("hostname", "www.example.com").
+		Reply(200).
+		JSON(`{"hostnameList": [{"configId": 51200, "configName": "Legacy", "configVersion": 3, "contractId": "C-0N7RAC7", "contractName": "Example"}]}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions/8/selectable-hostnames").
+		Reply(200).
+		JSON(`{"protect": true, "availableSet": [
+			{"hostname": "www.example.com", "activeInProduction": true, "configIdInProduction": 43253, "configNameInProduction": "Example"},
+			{"hostname": "new.example.com", "activeInStaging": true}
+		]}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/8/selected-hostnames").
+		JSON(map[string]interface{}{"mode": "append", "hostnameList": []map[string]string{{"hostname": "new.example.com"}}}).
+		Reply(200).
+		JSON(`{"hostnameList": [{"hostname": "www.example.com"}, {"hostname": "new.example.com"}]}`)
+
+	Init(config)
+
+	covering, err := CoveringConfigurations("WWW.example.com", "new.example.com", "unknown.example.com")
+	require.NoError(t, err)
+	require.Len(t, covering, 2)
+	www := covering["www.example.com"]
+	assert.True(t, www.Covered())
+	assert.Equal(t, 43253, www.Configuration.ID)
+	newHost := covering["new.example.com"]
+	assert.False(t, newHost.Covered())
+
+	overlap, err := GetHostnameOverlap(43253, 8, "www.example.com")
+	require.NoError(t, err)
+	require.Len(t, overlap, 1)
+	assert.Equal(t, 51200, overlap[0].ConfigID)
+
+	selectable, err := GetSelectableHostnames(43253, 8)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new.example.com"}, selectable.Unprotected())
+
+	selected, err := UpdateSelectedHostnames(43253, 8, SelectedHostnamesAppend, selectable.Unprotected()...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"www.example.com", "new.example.com"}, selected)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,164 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// MalwarePolicy describes which uploads are scanned for malware
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#malwarepolicy
+type MalwarePolicy struct {
+	MalwarePolicyID int                  `json:"id,omitempty"`
+	Name            string               `json:"name"`
+	Description     string               `json:"description,omitempty"`
+	Hostnames       []string             `json:"hostnames"`
+	Paths           []string             `json:"paths"`
+	ContentTypes    []MalwareContentType `json:"contentTypes,omitempty"`
+	AllowListID     string               `json:"allowListId,omitempty"`
+	BlockListID     string               `json:"blockListId,omitempty"`
+	LogFilename     bool                 `json:"logFilename,omitempty"`
+}
+
+// MalwareContentType is a content type to scan, with the attributes that carry encoded file content
+type MalwareContentType struct {
+	Name                     string                    `json:"name"`
+	EncodedContentAttributes []EncodedContentAttribute `json:"encodedContentAttributes,omitempty"`
+}
+
+// EncodedContentAttribute locates encoded file content within a request body
+type EncodedContentAttribute struct {
+	Path     string   `json:"path"`
+	Encoding []string `json:"encoding"`
+}
+
+// MalwareAction is used to create an "enum" of possible malware policy actions
+type MalwareAction string
+
+const (
+	// MalwareActionAlert logs detections without blocking
+	MalwareActionAlert MalwareAction = "alert"
+	// MalwareActionDeny blocks the request
+	MalwareActionDeny MalwareAction = "deny"
+	// MalwareActionNone takes no action
+	MalwareActionNone MalwareAction = "none"
+)
+
+// MalwarePolicyAction is the action a security policy takes for a malware policy
+type MalwarePolicyAction struct {
+	MalwarePolicyID int           `json:"id"`
+	Action          MalwareAction `json:"action"`
+	// UnscannedAction applies to files that could not be scanned, e.g. too large or encrypted
+	UnscannedAction MalwareAction `json:"unscannedAction"`
+}
+
+func malwarePoliciesPath(configID, version int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d/versions/%d/malware-policies", configID, version)
+}
+
+// ListMalwarePolicies lists the malware policies of a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getmalwarepolicies
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/malware-policies
+func ListMalwarePolicies(configID, version int) ([]MalwarePolicy, error) {
+	response := struct {
+		MalwarePolicies []MalwarePolicy `json:"malwarePolicies"`
+	}{}
+	if err := doJSON("GET", malwarePoliciesPath(configID, version), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.MalwarePolicies, nil
+}
+
+// GetMalwarePolicy retrieves a malware policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getmalwarepolicy
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/malware-policies/{malwarePolicyId}
+func GetMalwarePolicy(configID, version, malwarePolicyID int) (*MalwarePolicy, error) {
+	policy := &MalwarePolicy{}
+	if err := doJSON("GET", fmt.Sprintf("%s/%d", malwarePoliciesPath(configID, version), malwarePolicyID), nil, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// CreateMalwarePolicy creates a malware policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postmalwarepolicies
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/malware-policies
+func CreateMalwarePolicy(configID, version int, policy *MalwarePolicy) (*MalwarePolicy, error) {
+	created := &MalwarePolicy{}
+	if err := doJSON("POST", malwarePoliciesPath(configID, version), policy, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateMalwarePolicy updates a malware policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putmalwarepolicy
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/malware-policies/{malwarePolicyId}
+func UpdateMalwarePolicy(configID, version int, policy *MalwarePolicy) (*MalwarePolicy, error) {
+	updated := &MalwarePolicy{}
+	if err := doJSON("PUT", fmt.Sprintf("%s/%d", malwarePoliciesPath(configID, version), policy.MalwarePolicyID), policy, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveMalwarePolicy deletes a malware policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#deletemalwarepolicy
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/malware-policies/{malwarePolicyId}
+func RemoveMalwarePolicy(configID, version, malwarePolicyID int) error {
+	return doJSON("DELETE", fmt.Sprintf("%s/%d", malwarePoliciesPath(configID, version), malwarePolicyID), nil, nil)
+}
+
+// GetMalwarePolicyActions retrieves the malware policy actions of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getmalwarepolicyactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/malware-policies
+func GetMalwarePolicyActions(configID, version int, policyID string) ([]MalwarePolicyAction, error) {
+	response := struct {
+		MalwarePolicyActions []MalwarePolicyAction `json:"malwarePolicyActions"`
+	}{}
+	path := fmt.Sprintf("/appsec/v1/configs/%d/versions/%d/security-policies/%s/malware-policies", configID, version, policyID)
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.MalwarePolicyActions, nil
+}
+
+// UpdateMalwarePolicyAction sets the actions a security policy takes for a malware policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putmalwarepolicyaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/malware-policies/{malwarePolicyId}
+func UpdateMalwarePolicyAction(configID, version int, policyID string, action MalwarePolicyAction) (*MalwarePolicyAction, error) {
+	updated := &MalwarePolicyAction{}
+	path := fmt.Sprintf("/appsec/v1/configs/%d/versions/%d/security-policies/%s/malware-policies/%d", configID, version, policyID, action.MalwarePolicyID)
+	if err := doJSON("PUT", path, action, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// GetMalwareContentTypes lists the content types that can be scanned
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getmalwarecontenttypes
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/malware-policies/content-types
+func GetMalwareContentTypes(configID, version int, policyID string) ([]string, error) {
+	response := struct {
+		ContentTypes []string `json:"contentTypes"`
+	}{}
+	path := fmt.Sprintf("/appsec/v1/configs/%d/versions/%d/security-policies/%s/malware-policies/content-types", configID, version, policyID)
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.ContentTypes, nil
+}
@@ -0,0 +1,57 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestMalwarePolicies(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/versions/7/malware-policies").
+		JSON(map[string]interface{}{"name": "Uploads", "hostnames": []string{"upload.example.com"}, "paths": []string{"/upload/*"}, "contentTypes": []map[string]interface{}{{"name": "multipart/form-data"}}}).
+		Reply(201).
+		JSON(`{"id": 101, "name": "Uploads", "hostnames": ["upload.example.com"], "paths": ["/upload/*"], "contentTypes": [{"name": "multipart/form-data"}]}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/7/security-policies/AAAA_81230/malware-policies/101").
+		JSON(map[string]interface{}{"id": 101, "action": "deny", "unscannedAction": "alert"}).
+		Reply(200).
+		JSON(`{"id": 101, "action": "deny", "unscannedAction": "alert"}`)
+
+	Init(config)
+
+	policy, err := CreateMalwarePolicy(43253, 7, &MalwarePolicy{
+		Name:         "Uploads",
+		Hostnames:    []string{"upload.example.com"},
+		Paths:        []string{"/upload/*"},
+		ContentTypes: []MalwareContentType{{Name: "multipart/form-data"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 101, policy.MalwarePolicyID)
+
+	action, err := UpdateMalwarePolicyAction(43253, 7, "AAAA_81230", MalwarePolicyAction{
+		MalwarePolicyID: policy.MalwarePolicyID,
+		Action:          MalwareActionDeny,
+		UnscannedAction: MalwareActionAlert,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, MalwareActionDeny, action.Action)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,143 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// MatchTargetType is used to create an "enum" of possible MatchTarget.Type values
+type MatchTargetType string
+
+const (
+	// MatchTargetWebsite MatchTarget.Type value website
+	MatchTargetWebsite MatchTargetType = "website"
+	// MatchTargetAPI MatchTarget.Type value api
+	MatchTargetAPI MatchTargetType = "api"
+)
+
+// MatchTarget applies a security policy to the requests it matches
+//
+// Website targets match Hostnames, FilePaths and FileExtensions, api targets
+// match the API endpoints of APIs (see NewAPIMatchTarget).
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#matchtarget
+type MatchTarget struct {
+	TargetID                     int                 `json:"targetId,omitempty"`
+	Type                         MatchTargetType     `json:"type"`
+	ConfigID                     int                 `json:"configId,omitempty"`
+	ConfigVersion                int                 `json:"configVersion,omitempty"`
+	Sequence                     int                 `json:"sequence,omitempty"`
+	Hostnames                    []string            `json:"hostnames,omitempty"`
+	FilePaths                    []string            `json:"filePaths,omitempty"`
+	FileExtensions               []string            `json:"fileExtensions,omitempty"`
+	DefaultFile                  string              `json:"defaultFile,omitempty"`
+	APIs                         []APIEndpoint       `json:"apis,omitempty"`
+	IsNegativePathMatch          bool                `json:"isNegativePathMatch"`
+	IsNegativeFileExtensionMatch bool                `json:"isNegativeFileExtensionMatch"`
+	SecurityPolicy               MatchTargetPolicy   `json:"securityPolicy"`
+	BypassNetworkLists           []BypassNetworkList `json:"bypassNetworkLists,omitempty"`
+}
+
+// MatchTargetPolicy is the security policy of a match target
+type MatchTargetPolicy struct {
+	PolicyID string `json:"policyId"`
+}
+
+// APIEndpoint is an API Gateway API endpoint matched by an api match target
+type APIEndpoint struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// BypassNetworkList is a network list whose clients skip the security policy
+type BypassNetworkList struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// MatchTargets are the match targets of a security configuration version
+type MatchTargets struct {
+	WebsiteTargets []MatchTarget `json:"websiteTargets"`
+	APITargets     []MatchTarget `json:"apiTargets"`
+}
+
+// NewAPIMatchTarget returns an api match target applying policyID to the API endpoints apiIDs
+func NewAPIMatchTarget(policyID string, apiIDs ...int) *MatchTarget {
+	target := &MatchTarget{Type: MatchTargetAPI, SecurityPolicy: MatchTargetPolicy{PolicyID: policyID}}
+	for _, id := range apiIDs {
+		target.APIs = append(target.APIs, APIEndpoint{ID: id})
+	}
+
+	return target
+}
+
+func matchTargetsPath(configID, version int) string {
+	return configVersionPath(configID, version) + "/match-targets"
+}
+
+// ListMatchTargets lists the match targets of a security configuration
+// version, only those of policyID when it is not empty
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getmatchtargets
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets{?policyId}
+func ListMatchTargets(configID, version int, policyID string) (*MatchTargets, error) {
+	path := matchTargetsPath(configID, version)
+	if policyID != "" {
+		path += "?policyId=" + policyID
+	}
+
+	response := struct {
+		MatchTargets MatchTargets `json:"matchTargets"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.MatchTargets, nil
+}
+
+// GetMatchTarget retrieves a match target
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getmatchtarget
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/{targetId}
+func GetMatchTarget(configID, version, targetID int) (*MatchTarget, error) {
+	target := &MatchTarget{}
+	if err := doJSON("GET", fmt.Sprintf("%s/%d", matchTargetsPath(configID, version), targetID), nil, target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// CreateMatchTarget creates a match target
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postmatchtargets
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets
+func CreateMatchTarget(configID, version int, target *MatchTarget) (*MatchTarget, error) {
+	created := &MatchTarget{}
+	if err := doJSON("POST", matchTargetsPath(configID, version), target, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateMatchTarget updates a match target
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putmatchtarget
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/{targetId}
+func UpdateMatchTarget(configID, version int, target *MatchTarget) (*MatchTarget, error) {
+	updated := &MatchTarget{}
+	if err := doJSON("PUT", fmt.Sprintf("%s/%d", matchTargetsPath(configID, version), target.TargetID), target, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveMatchTarget deletes a match target
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#deletematchtarget
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/match-targets/{targetId}
+func RemoveMatchTarget(configID, version, targetID int) error {
+	return doJSON("DELETE", fmt.Sprintf("%s/%d", matchTargetsPath(configID, version), targetID), nil, nil)
+}
@@ -0,0 +1,166 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// SecurityPolicy is a security policy of a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#securitypolicy
+type SecurityPolicy struct {
+	PolicyID     string `json:"policyId,omitempty"`
+	PolicyName   string `json:"policyName"`
+	PolicyPrefix string `json:"policyPrefix,omitempty"`
+	// DefaultSettings creates the policy with the default protections, only used by CreateSecurityPolicy
+	DefaultSettings bool `json:"defaultSettings,omitempty"`
+	// CreateFromSecurityPolicy copies a policy of the same configuration version, only used by CreateSecurityPolicy
+	CreateFromSecurityPolicy string `json:"createFromSecurityPolicy,omitempty"`
+}
+
+// Export is the complete security configuration version, as exported by GetExport
+//
+// Only the parts needed by CloneSecurityPolicy are typed, rate policies and
+// match targets are kept as-is so that they can be sent back unchanged.
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#configurationversionexport
+type Export struct {
+	ConfigID         int              `json:"configId"`
+	ConfigName       string           `json:"configName"`
+	Version          int              `json:"version"`
+	SecurityPolicies []ExportedPolicy `json:"securityPolicies"`
+	RatePolicies     []JSONObject     `json:"ratePolicies"`
+	MatchTargets     struct {
+		WebsiteTargets []JSONObject `json:"websiteTargets"`
+		APITargets     []JSONObject `json:"apiTargets"`
+	} `json:"matchTargets"`
+}
+
+// JSONObject is an API object copied without interpretation
+type JSONObject map[string]interface{}
+
+// ExportedPolicy is a security policy within an Export
+type ExportedPolicy struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	WebApplicationFirewall struct {
+		RuleActions        []RuleAction        `json:"ruleActions"`
+		AttackGroupActions []AttackGroupAction `json:"attackGroupActions"`
+	} `json:"webApplicationFirewall"`
+	RatePolicyActions []RatePolicyAction `json:"ratePolicyActions"`
+}
+
+// RuleAction is the action of a WAF rule, with its conditions and exceptions
+type RuleAction struct {
+	ID         int          `json:"id"`
+	Action     string       `json:"action"`
+	Conditions []JSONObject `json:"conditions,omitempty"`
+	Exception  JSONObject   `json:"exception,omitempty"`
+}
+
+// AttackGroupAction is the action of a WAF attack group
+type AttackGroupAction struct {
+	Group  string `json:"group"`
+	Action string `json:"action"`
+}
+
+// RatePolicyAction is the action a security policy takes for a rate policy
+type RatePolicyAction struct {
+	ID         int    `json:"id"`
+	IPv4Action string `json:"ipv4Action"`
+	IPv6Action string `json:"ipv6Action"`
+}
+
+func configVersionPath(configID, version int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d/versions/%d", configID, version)
+}
+
+func securityPolicyPath(configID, version int, policyID string) string {
+	return fmt.Sprintf("%s/security-policies/%s", configVersionPath(configID, version), policyID)
+}
+
+// ListSecurityPolicies lists the security policies of a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getsecuritypolicies
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies
+func ListSecurityPolicies(configID, version int) ([]SecurityPolicy, error) {
+	response := struct {
+		Policies []SecurityPolicy `json:"policies"`
+	}{}
+	if err := doJSON("GET", configVersionPath(configID, version)+"/security-policies", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Policies, nil
+}
+
+// CreateSecurityPolicy creates a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postsecuritypolicies
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies
+func CreateSecurityPolicy(configID, version int, policy *SecurityPolicy) (*SecurityPolicy, error) {
+	created := &SecurityPolicy{}
+	if err := doJSON("POST", configVersionPath(configID, version)+"/security-policies", policy, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetSecurityPolicy retrieves a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getsecuritypolicy
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}
+func GetSecurityPolicy(configID, version int, policyID string) (*SecurityPolicy, error) {
+	policy := &SecurityPolicy{}
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID), nil, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// UpdateSecurityPolicy renames a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putsecuritypolicy
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}
+func UpdateSecurityPolicy(configID, version int, policy *SecurityPolicy) (*SecurityPolicy, error) {
+	updated := &SecurityPolicy{}
+	body := &SecurityPolicy{PolicyName: policy.PolicyName, PolicyPrefix: policy.PolicyPrefix}
+	if err := doJSON("PUT", securityPolicyPath(configID, version, policy.PolicyID), body, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveSecurityPolicy deletes a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#deletesecuritypolicy
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}
+func RemoveSecurityPolicy(configID, version int, policyID string) error {
+	return doJSON("DELETE", securityPolicyPath(configID, version, policyID), nil, nil)
+}
+
+// GetExport exports a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getconfigurationversionexport
+// Endpoint: GET /appsec/v1/export/configs/{configId}/versions/{versionNumber}
+func GetExport(configID, version int) (*Export, error) {
+	export := &Export{}
+	if err := doJSON("GET", fmt.Sprintf("/appsec/v1/export/configs/%d/versions/%d", configID, version), nil, export); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// Policy returns the security policy policyID of the export
+func (export *Export) Policy(policyID string) (*ExportedPolicy, bool) {
+	for i := range export.SecurityPolicies {
+		if export.SecurityPolicies[i].ID == policyID {
+			return &export.SecurityPolicies[i], true
+		}
+	}
+
+	return nil, false
+}
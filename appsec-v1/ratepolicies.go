@@ -0,0 +1,137 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// RatePolicy counts requests matching its conditions per client and flags
+// clients above its thresholds
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#ratepolicy
+type RatePolicy struct {
+	ID                     int                     `json:"id,omitempty"`
+	Name                   string                  `json:"name"`
+	Description            string                  `json:"description,omitempty"`
+	Type                   string                  `json:"type"`
+	MatchType              string                  `json:"matchType"`
+	ClientIdentifier       string                  `json:"clientIdentifier"`
+	RequestType            string                  `json:"requestType"`
+	AverageThreshold       int                     `json:"averageThreshold"`
+	BurstThreshold         int                     `json:"burstThreshold"`
+	SameActionOnIpv6       bool                    `json:"sameActionOnIpv6"`
+	UseXForwardForHeaders  bool                    `json:"useXForwardForHeaders"`
+	PathMatchType          string                  `json:"pathMatchType,omitempty"`
+	Path                   *RatePolicyMatch        `json:"path,omitempty"`
+	Hostnames              []string                `json:"hostnames,omitempty"`
+	FileExtensions         *RatePolicyMatch        `json:"fileExtensions,omitempty"`
+	AdditionalMatchOptions []RatePolicyMatchOption `json:"additionalMatchOptions,omitempty"`
+	Used                   bool                    `json:"used,omitempty"`
+}
+
+// RatePolicyMatch matches request paths or file extensions
+type RatePolicyMatch struct {
+	PositiveMatch bool     `json:"positiveMatch"`
+	Values        []string `json:"values"`
+}
+
+// RatePolicyMatchOption is an additional condition of a rate policy, e.g. on the client IP or request method
+type RatePolicyMatchOption struct {
+	Type          string   `json:"type"`
+	PositiveMatch bool     `json:"positiveMatch"`
+	Values        []string `json:"values"`
+}
+
+func ratePoliciesPath(configID, version int) string {
+	return configVersionPath(configID, version) + "/rate-policies"
+}
+
+// ListRatePolicies lists the rate policies of a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getratepolicies
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies
+func ListRatePolicies(configID, version int) ([]RatePolicy, error) {
+	response := struct {
+		RatePolicies []RatePolicy `json:"ratePolicies"`
+	}{}
+	if err := doJSON("GET", ratePoliciesPath(configID, version), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.RatePolicies, nil
+}
+
+// GetRatePolicy retrieves a rate policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getratepolicy
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies/{ratePolicyId}
+func GetRatePolicy(configID, version, ratePolicyID int) (*RatePolicy, error) {
+	policy := &RatePolicy{}
+	if err := doJSON("GET", fmt.Sprintf("%s/%d", ratePoliciesPath(configID, version), ratePolicyID), nil, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// CreateRatePolicy creates a rate policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#postratepolicies
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies
+func CreateRatePolicy(configID, version int, policy *RatePolicy) (*RatePolicy, error) {
+	created := &RatePolicy{}
+	if err := doJSON("POST", ratePoliciesPath(configID, version), policy, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateRatePolicy updates a rate policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putratepolicy
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies/{ratePolicyId}
+func UpdateRatePolicy(configID, version int, policy *RatePolicy) (*RatePolicy, error) {
+	updated := &RatePolicy{}
+	if err := doJSON("PUT", fmt.Sprintf("%s/%d", ratePoliciesPath(configID, version), policy.ID), policy, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveRatePolicy deletes a rate policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#deleteratepolicy
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/rate-policies/{ratePolicyId}
+func RemoveRatePolicy(configID, version, ratePolicyID int) error {
+	return doJSON("DELETE", fmt.Sprintf("%s/%d", ratePoliciesPath(configID, version), ratePolicyID), nil, nil)
+}
+
+// GetRatePolicyActions retrieves the rate policy actions of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getratepolicyactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rate-policies
+func GetRatePolicyActions(configID, version int, policyID string) ([]RatePolicyAction, error) {
+	response := struct {
+		RatePolicyActions []RatePolicyAction `json:"ratePolicyActions"`
+	}{}
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID)+"/rate-policies", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.RatePolicyActions, nil
+}
+
+// UpdateRatePolicyAction sets the actions a security policy takes for a rate policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putratepolicyaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rate-policies/{ratePolicyId}
+func UpdateRatePolicyAction(configID, version int, policyID string, action RatePolicyAction) (*RatePolicyAction, error) {
+	updated := &RatePolicyAction{}
+	path := fmt.Sprintf("%s/rate-policies/%d", securityPolicyPath(configID, version, policyID), action.ID)
+	if err := doJSON("PUT", path, action, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
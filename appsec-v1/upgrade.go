@@ -0,0 +1,282 @@
+package appsec
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// UpgradeDetails are the Kona Rule Set versions of a security policy and the
+// changes of upgrading it
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#upgradedetails
+type UpgradeDetails struct {
+	Current            string          `json:"current"`
+	Evaluating         string          `json:"evaluating,omitempty"`
+	Latest             string          `json:"latest"`
+	KRSToEvalUpdates   *RulesetUpdates `json:"KRSToEvalUpdates,omitempty"`
+	KRSToLatestUpdates *RulesetUpdates `json:"KRSToLatestUpdates,omitempty"`
+}
+
+// RulesetUpdates are the rules and attack groups changed between two rule set versions
+type RulesetUpdates struct {
+	NewRules            []RulesetRule        `json:"newRules,omitempty"`
+	UpdatedRules        []RulesetRule        `json:"updatedRules,omitempty"`
+	DeletedRules        []RulesetRule        `json:"deletedRules,omitempty"`
+	NewAttackGroups     []RulesetAttackGroup `json:"newAttackGroups,omitempty"`
+	UpdatedAttackGroups []RulesetAttackGroup `json:"updatedAttackGroups,omitempty"`
+	DeletedAttackGroups []RulesetAttackGroup `json:"deletedAttackGroups,omitempty"`
+}
+
+// RulesetRule is a rule of RulesetUpdates
+type RulesetRule struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// RulesetAttackGroup is an attack group of RulesetUpdates
+type RulesetAttackGroup struct {
+	Group     string `json:"group"`
+	GroupName string `json:"groupName"`
+}
+
+// Available reports whether a newer rule set than the current one is available
+func (details *UpgradeDetails) Available() bool {
+	return details.Latest != "" && details.Latest != details.Current
+}
+
+// ChangedRules returns the IDs of the rules added, updated or deleted by the
+// upgrade to the latest rule set, in ascending order
+func (details *UpgradeDetails) ChangedRules() []int {
+	if details.KRSToLatestUpdates == nil {
+		return nil
+	}
+
+	updates := details.KRSToLatestUpdates
+	var ids []int
+	for _, rules := range [][]RulesetRule{updates.NewRules, updates.UpdatedRules, updates.DeletedRules} {
+		for _, rule := range rules {
+			ids = append(ids, rule.ID)
+		}
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+// UpgradeResult is the rule set of a security policy after Upgrade
+type UpgradeResult struct {
+	Current string `json:"current"`
+	Mode    string `json:"mode"`
+	Eval    string `json:"eval,omitempty"`
+}
+
+// GetUpgradeDetails retrieves the rule set upgrade available to a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getupgradedetails
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rules/upgrade-details
+func GetUpgradeDetails(configID, version int, policyID string) (*UpgradeDetails, error) {
+	details := &UpgradeDetails{}
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID)+"/rules/upgrade-details", nil, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// Upgrade upgrades the rule set of a security policy to the latest version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putupgrade
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rules/upgrade
+func Upgrade(configID, version int, policyID string) (*UpgradeResult, error) {
+	result := &UpgradeResult{}
+	body := struct {
+		Upgrade bool `json:"upgrade"`
+	}{true}
+	if err := doJSON("PUT", securityPolicyPath(configID, version, policyID)+"/rules/upgrade", body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ActionChange is a rule or attack group whose action was changed by an
+// upgrade, Before is empty for added ones and After for removed ones
+type ActionChange struct {
+	// Kind is "rule" or "attackGroup"
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// UpgradeReport is the outcome of UpgradeWithReport, to be kept for review
+type UpgradeReport struct {
+	ConfigID int             `json:"configId"`
+	Version  int             `json:"version"`
+	PolicyID string          `json:"policyId"`
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Details  *UpgradeDetails `json:"details"`
+	Changes  []ActionChange  `json:"changes"`
+}
+
+// UpgradeWithReport upgrades the rule set of a security policy when a newer
+// one is available, and reports the rule and attack group actions that changed
+//
+// The version must be editable, see Version.Editable(). When no upgrade is
+// available the report has no changes and From equals To. When the actions
+// can't be read after the upgrade, the report without changes is returned with
+// the error.
+func UpgradeWithReport(configID, version int, policyID string) (*UpgradeReport, error) {
+	details, err := GetUpgradeDetails(configID, version, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UpgradeReport{
+		ConfigID: configID,
+		Version:  version,
+		PolicyID: policyID,
+		From:     details.Current,
+		To:       details.Current,
+		Details:  details,
+		Changes:  []ActionChange{},
+	}
+	if !details.Available() {
+		return report, nil
+	}
+
+	before, err := actionsByID(configID, version, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Upgrade(configID, version, policyID)
+	if err != nil {
+		return nil, err
+	}
+	report.To = result.Current
+
+	after, err := actionsByID(configID, version, policyID)
+	if err != nil {
+		return report, fmt.Errorf("rule set upgraded to %s, but its actions could not be read: %w", report.To, err)
+	}
+
+	titles := map[string]string{}
+	if updates := details.KRSToLatestUpdates; updates != nil {
+		for _, rules := range [][]RulesetRule{updates.NewRules, updates.UpdatedRules, updates.DeletedRules} {
+			for _, rule := range rules {
+				titles["rule/"+strconv.Itoa(rule.ID)] = rule.Title
+			}
+		}
+		for _, groups := range [][]RulesetAttackGroup{updates.NewAttackGroups, updates.UpdatedAttackGroups, updates.DeletedAttackGroups} {
+			for _, group := range groups {
+				titles["attackGroup/"+group.Group] = group.GroupName
+			}
+		}
+	}
+
+	keys := map[string]actionKey{}
+	for key, k := range before {
+		keys[key] = k.actionKey
+	}
+	for key, k := range after {
+		keys[key] = k.actionKey
+	}
+	for key, k := range keys {
+		if before[key].action == after[key].action {
+			continue
+		}
+		report.Changes = append(report.Changes, ActionChange{
+			Kind:   k.kind,
+			ID:     k.id,
+			Title:  titles[key],
+			Before: before[key].action,
+			After:  after[key].action,
+		})
+	}
+	sort.Slice(report.Changes, func(i, j int) bool {
+		if report.Changes[i].Kind != report.Changes[j].Kind {
+			return report.Changes[i].Kind > report.Changes[j].Kind
+		}
+		return lessID(report.Changes[i].ID, report.Changes[j].ID)
+	})
+
+	return report, nil
+}
+
+// lessID orders rule IDs numerically, and other IDs (e.g. of attack groups)
+// as strings
+func lessID(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+
+	return x < y
+}
+
+// WriteText writes the report as a table of the changed actions
+func (report *UpgradeReport) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Config %d version %d policy %s: rule set %s -> %s\n", report.ConfigID, report.Version, report.PolicyID, report.From, report.To); err != nil {
+		return err
+	}
+	if len(report.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "No action changes")
+		return err
+	}
+
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "KIND\tID\tTITLE\tBEFORE\tAFTER")
+	for _, change := range report.Changes {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", change.Kind, change.ID, change.Title, orNone(change.Before), orNone(change.After))
+	}
+
+	return table.Flush()
+}
+
+func orNone(action string) string {
+	if action == "" {
+		return "-"
+	}
+	return action
+}
+
+type actionKey struct {
+	kind string
+	id   string
+}
+
+type keyedAction struct {
+	actionKey
+	action string
+}
+
+// actionsByID retrieves the rule and attack group actions of a security
+// policy, keyed by "rule/<id>" and "attackGroup/<group>"
+func actionsByID(configID, version int, policyID string) (map[string]keyedAction, error) {
+	rules, err := GetRuleActions(configID, version, policyID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := GetAttackGroupActions(configID, version, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := map[string]keyedAction{}
+	for _, rule := range rules {
+		id := strconv.Itoa(rule.ID)
+		actions["rule/"+id] = keyedAction{actionKey{"rule", id}, rule.Action}
+	}
+	for _, group := range groups {
+		actions["attackGroup/"+group.Group] = keyedAction{actionKey{"attackGroup", group.Group}, group.Action}
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,121 @@
+package appsec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestUpgradeWithReport(t *testing.T) {
+	defer gock.Off()
+
+	policyPath := "/appsec/v1/configs/43253/versions/8/security-policies/gms1_134637"
+	gock.New(baseURL).
+		Get(policyPath + "/rules/upgrade-details").
+		Reply(200).
+		JSON(`{
+			"current": "KRS 3.0",
+			"latest": "KRS 3.1",
+			"KRSToLatestUpdates": {
+				"newRules": [{"id": 950004, "title": "Cross-site Scripting (XSS) Attack"}],
+				"deletedRules": [{"id": 950002, "title": "System Command Access"}],
+				"updatedAttackGroups": [{"group": "SQL", "groupName": "SQL Injection"}]
+			}
+		}`)
+	gock.New(baseURL).
+		Get(policyPath + "/rules").
+		Reply(200).
+		JSON(`{"ruleActions": [{"id": 950002, "action": "deny"}, {"id": 950003, "action": "alert"}]}`)
+	gock.New(baseURL).
+		Get(policyPath + "/attack-groups").
+		Reply(200).
+		JSON(`{"attackGroupActions": [{"group": "SQL", "action": "alert"}]}`)
+	gock.New(baseURL).
+		Put(policyPath + "/rules/upgrade").
+		JSON(map[string]interface{}{"upgrade": true}).
+		Reply(200).
+		JSON(`{"current": "KRS 3.1", "mode": "KRS"}`)
+	gock.New(baseURL).
+		Get(policyPath + "/rules").
+		Reply(200).
+		JSON(`{"ruleActions": [{"id": 1000001, "action": "deny"}, {"id": 950003, "action": "alert"}, {"id": 950004, "action": "alert"}]}`)
+	gock.New(baseURL).
+		Get(policyPath + "/attack-groups").
+		Reply(200).
+		JSON(`{"attackGroupActions": [{"group": "SQL", "action": "deny"}]}`)
+
+	Init(config)
+
+	report, err := UpgradeWithReport(43253, 8, "gms1_134637")
+	require.NoError(t, err)
+	assert.Equal(t, "KRS 3.0", report.From)
+	assert.Equal(t, "KRS 3.1", report.To)
+	assert.Equal(t, []int{950002, 950004}, report.Details.ChangedRules())
+	assert.Equal(t, []ActionChange{
+		{Kind: "rule", ID: "950002", Title: "System Command Access", Before: "deny", After: ""},
+		{Kind: "rule", ID: "950004", Title: "Cross-site Scripting (XSS) Attack", Before: "", After: "alert"},
+		{Kind: "rule", ID: "1000001", Before: "", After: "deny"},
+		{Kind: "attackGroup", ID: "SQL", Title: "SQL Injection", Before: "alert", After: "deny"},
+	}, report.Changes)
+	assert.True(t, gock.IsDone())
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	assert.Contains(t, text.String(), "rule set KRS 3.0 -> KRS 3.1")
+	assert.Contains(t, text.String(), "attackGroup  SQL")
+}
+
+func TestUpgradeWithReportActionsFailed(t *testing.T) {
+	defer gock.Off()
+
+	policyPath := "/appsec/v1/configs/43253/versions/8/security-policies/gms1_134637"
+	gock.New(baseURL).
+		Get(policyPath + "/rules/upgrade-details").
+		Reply(200).
+		JSON(`{"current": "KRS 3.0", "latest": "KRS 3.1"}`)
+	gock.New(baseURL).
+		Get(policyPath + "/rules").
+		Reply(200).
+		JSON(`{"ruleActions": [{"id": 950002, "action": "deny"}]}`)
+	gock.New(baseURL).
+		Get(policyPath + "/attack-groups").
+		Reply(200).
+		JSON(`{"attackGroupActions": []}`)
+	gock.New(baseURL).
+		Put(policyPath + "/rules/upgrade").
+		Reply(200).
+		JSON(`{"current": "KRS 3.1", "mode": "KRS"}`)
+	gock.New(baseURL).
+		Get(policyPath + "/rules").
+		Reply(500).
+		JSON(`{"title": "Internal Server Error", "status": 500}`)
+
+	Init(config)
+
+	report, err := UpgradeWithReport(43253, 8, "gms1_134637")
+	assert.Error(t, err)
+	require.NotNil(t, report)
+	assert.Equal(t, "KRS 3.0", report.From)
+	assert.Equal(t, "KRS 3.1", report.To)
+	assert.True(t, gock.IsDone())
+}
+
+func TestUpgradeWithReportUpToDate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions/8/security-policies/gms1_134637/rules/upgrade-details").
+		Reply(200).
+		JSON(`{"current": "KRS 3.1", "latest": "KRS 3.1"}`)
+
+	Init(config)
+
+	report, err := UpgradeWithReport(43253, 8, "gms1_134637")
+	require.NoError(t, err)
+	assert.Equal(t, report.From, report.To)
+	assert.Empty(t, report.Changes)
+	assert.True(t, gock.IsDone())
+}
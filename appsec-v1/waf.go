@@ -0,0 +1,57 @@
+package appsec
+
+import (
+	"fmt"
+)
+
+// GetRuleActions retrieves the WAF rule actions of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getruleactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rules
+func GetRuleActions(configID, version int, policyID string) ([]RuleAction, error) {
+	response := struct {
+		RuleActions []RuleAction `json:"ruleActions"`
+	}{}
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID)+"/rules", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.RuleActions, nil
+}
+
+// UpdateRuleAction sets the action of a WAF rule in a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putruleaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/rules/{ruleId}
+func UpdateRuleAction(configID, version int, policyID string, ruleID int, action string) error {
+	path := fmt.Sprintf("%s/rules/%d", securityPolicyPath(configID, version, policyID), ruleID)
+	return doJSON("PUT", path, struct {
+		Action string `json:"action"`
+	}{action}, nil)
+}
+
+// GetAttackGroupActions retrieves the WAF attack group actions of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#getattackgroupactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/attack-groups
+func GetAttackGroupActions(configID, version int, policyID string) ([]AttackGroupAction, error) {
+	response := struct {
+		AttackGroupActions []AttackGroupAction `json:"attackGroupActions"`
+	}{}
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID)+"/attack-groups", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.AttackGroupActions, nil
+}
+
+// UpdateAttackGroupAction sets the action of a WAF attack group in a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/application_security/v1.html#putattackgroupaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/attack-groups/{attackGroupId}
+func UpdateAttackGroupAction(configID, version int, policyID string, group string, action string) error {
+	path := fmt.Sprintf("%s/attack-groups/%s", securityPolicyPath(configID, version, policyID), group)
+	return doJSON("PUT", path, struct {
+		Action string `json:"action"`
+	}{action}, nil)
+}
@@ -0,0 +1,209 @@
+package billing
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// Month is a billing month, formatted YYYY-MM
+type Month string
+
+// MonthOf returns the billing month of t, in UTC
+func MonthOf(t time.Time) Month {
+	return Month(t.UTC().Format("2006-01"))
+}
+
+// Time returns the first instant of the month, in UTC
+func (month Month) Time() (time.Time, error) {
+	return time.Parse("2006-01", string(month))
+}
+
+// Product is a product billed on a contract
+//
+// API Docs: https://developer.akamai.com/api/core_features/billing/v1.html#product
+type Product struct {
+	ProductID   string `json:"productId"`
+	ProductName string `json:"productName"`
+}
+
+// Statistic is a billed quantity, e.g. the bandwidth or hits of a month
+type Statistic struct {
+	StatisticName string  `json:"statisticName"`
+	Value         float64 `json:"value"`
+	Unit          string  `json:"unit"`
+	// Final is false while the month is still being accounted
+	Final bool `json:"final"`
+}
+
+// MonthlyUsage is the usage of a product in a month
+//
+// API Docs: https://developer.akamai.com/api/core_features/billing/v1.html#monthlyusage
+type MonthlyUsage struct {
+	Month      Month       `json:"month"`
+	Statistics []Statistic `json:"statistics"`
+}
+
+// CPCodeUsage is the usage of a product by a CP code in a month
+//
+// API Docs: https://developer.akamai.com/api/core_features/billing/v1.html#cpcodeusage
+type CPCodeUsage struct {
+	CPCode     int         `json:"cpCode"`
+	CPCodeName string      `json:"cpCodeName,omitempty"`
+	Statistics []Statistic `json:"statistics"`
+}
+
+// ListProducts lists the products billed on a contract between two months
+//
+// API Docs: https://developer.akamai.com/api/core_features/billing/v1.html#getproducts
+// Endpoint: GET /billing/v1/contracts/{contractId}/products{?fromMonth,toMonth}
+func ListProducts(contractID string, from, to Month) ([]Product, error) {
+	path, err := client.PathWithQuery(contractPath(contractID)+"/products", monthRange{from, to})
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Products []Product `json:"products"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Products, nil
+}
+
+// GetMonthlyUsage retrieves the usage of a product on a contract, month by month
+//
+// API Docs: https://developer.akamai.com/api/core_features/billing/v1.html#getmonthlyusage
+// Endpoint: GET /billing/v1/contracts/{contractId}/products/{productId}/usage/monthly{?fromMonth,toMonth}
+func GetMonthlyUsage(contractID, productID string, from, to Month) ([]MonthlyUsage, error) {
+	path, err := client.PathWithQuery(productPath(contractID, productID)+"/usage/monthly", monthRange{from, to})
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		UsagePeriods []MonthlyUsage `json:"usagePeriods"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.UsagePeriods, nil
+}
+
+// GetCPCodeUsage retrieves the usage of a product on a contract by CP code in a month
+//
+// API Docs: https://developer.akamai.com/api/core_features/billing/v1.html#getcpcodeusage
+// Endpoint: GET /billing/v1/contracts/{contractId}/products/{productId}/usage/monthly/cp-codes{?month}
+func GetCPCodeUsage(contractID, productID string, month Month) ([]CPCodeUsage, error) {
+	path := productPath(contractID, productID) + "/usage/monthly/cp-codes?month=" + url.QueryEscape(string(month))
+
+	response := struct {
+		CPCodes []CPCodeUsage `json:"cpCodes"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.CPCodes, nil
+}
+
+// UsageRow is a statistic of a CP code for a product in a month, a row of a
+// ContractUsage report
+type UsageRow struct {
+	Month       Month
+	ProductID   string
+	ProductName string
+	CPCode      int
+	CPCodeName  string
+	Statistic
+}
+
+// ContractUsage retrieves the usage of every product of a contract by CP code
+// in a month, sorted by product, CP code and statistic
+func ContractUsage(contractID string, month Month) ([]UsageRow, error) {
+	products, err := ListProducts(contractID, month, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []UsageRow
+	for _, product := range products {
+		usage, err := GetCPCodeUsage(contractID, product.ProductID, month)
+		if err != nil {
+			return nil, fmt.Errorf("product %s: %w", product.ProductID, err)
+		}
+		for _, cpCode := range usage {
+			for _, statistic := range cpCode.Statistics {
+				rows = append(rows, UsageRow{
+					Month:       month,
+					ProductID:   product.ProductID,
+					ProductName: product.ProductName,
+					CPCode:      cpCode.CPCode,
+					CPCodeName:  cpCode.CPCodeName,
+					Statistic:   statistic,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].ProductID != rows[j].ProductID {
+			return rows[i].ProductID < rows[j].ProductID
+		}
+		if rows[i].CPCode != rows[j].CPCode {
+			return rows[i].CPCode < rows[j].CPCode
+		}
+		return rows[i].StatisticName < rows[j].StatisticName
+	})
+
+	return rows, nil
+}
+
+// WriteCSV writes rows as CSV with a header line
+func WriteCSV(w io.Writer, rows []UsageRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"month", "productId", "productName", "cpCode", "cpCodeName", "statistic", "value", "unit", "final"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := []string{
+			string(row.Month),
+			row.ProductID,
+			row.ProductName,
+			strconv.Itoa(row.CPCode),
+			row.CPCodeName,
+			row.StatisticName,
+			strconv.FormatFloat(row.Value, 'f', -1, 64),
+			row.Unit,
+			strconv.FormatBool(row.Final),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+type monthRange struct {
+	From Month `query:"fromMonth,omitempty"`
+	To   Month `query:"toMonth,omitempty"`
+}
+
+func contractPath(contractID string) string {
+	return "/billing/v1/contracts/" + url.PathEscape(contractID)
+}
+
+func productPath(contractID, productID string) string {
+	return contractPath(contractID) + "/products/" + url.PathEscape(productID)
+}
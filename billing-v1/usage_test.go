@@ -0,0 +1,87 @@
+package billing
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestContractUsage(t *testing.T) {
+	defer gock.Off()
+
+	month := MonthOf(time.Date(2020, 5, 31, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, Month("2020-05"), month)
+
+	gock.New(baseURL).
+		Get("/billing/v1/contracts/1-ABCDE/products").
+		MatchParam("fromMonth", "2020-05").
+		MatchParam("toMonth", "2020-05").
+		Reply(200).
+		JSON(`{"products": [{"productId": "M-LC-2", "productName": "Download Delivery"}, {"productId": "M-LC-1", "productName": "Ion"}]}`)
+	gock.New(baseURL).
+		Get("/billing/v1/contracts/1-ABCDE/products/M-LC-1/usage/monthly/cp-codes").
+		MatchParam("month", "2020-05").
+		Reply(200).
+		JSON(`{"cpCodes": [{"cpCode": 222, "statistics": [{"statisticName": "Bandwidth", "value": 12.5, "unit": "GB", "final": true}]},
+			{"cpCode": 111, "cpCodeName": "www", "statistics": [{"statisticName": "Hits", "value": 1000000, "unit": "hits", "final": true}, {"statisticName": "Bandwidth", "value": 40, "unit": "GB", "final": true}]}]}`)
+	gock.New(baseURL).
+		Get("/billing/v1/contracts/1-ABCDE/products/M-LC-2/usage/monthly/cp-codes").
+		Reply(200).
+		JSON(`{"cpCodes": [{"cpCode": 333, "statistics": [{"statisticName": "Bandwidth", "value": 1.25, "unit": "GB"}]}]}`)
+
+	Init(config)
+
+	rows, err := ContractUsage("1-ABCDE", month)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.True(t, gock.IsDone())
+
+	var out bytes.Buffer
+	require.NoError(t, WriteCSV(&out, rows))
+	assert.Equal(t, `month,productId,productName,cpCode,cpCodeName,statistic,value,unit,final
+2020-05,M-LC-1,Ion,111,www,Bandwidth,40,GB,true
+2020-05,M-LC-1,Ion,111,www,Hits,1000000,hits,true
+2020-05,M-LC-1,Ion,222,,Bandwidth,12.5,GB,true
+2020-05,M-LC-2,Download Delivery,333,,Bandwidth,1.25,GB,false
+`, out.String())
+}
+
+func TestGetMonthlyUsage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/billing/v1/contracts/1-ABCDE/products/M-LC-1/usage/monthly").
+		MatchParam("fromMonth", "2020-01").
+		MatchParam("toMonth", "2020-03").
+		Reply(200).
+		JSON(`{"usagePeriods": [{"month": "2020-01", "statistics": [{"statisticName": "Bandwidth", "value": 10, "unit": "GB", "final": true}]},
+			{"month": "2020-02", "statistics": []}, {"month": "2020-03", "statistics": []}]}`)
+
+	Init(config)
+
+	usage, err := GetMonthlyUsage("1-ABCDE", "M-LC-1", "2020-01", "2020-03")
+	require.NoError(t, err)
+	require.Len(t, usage, 3)
+	start, err := usage[0].Month.Time()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, 10.0, usage[0].Statistics[0].Value)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,226 @@
+package botman
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Action is used to create an "enum" of the possible actions taken for bots,
+// custom deny actions are referenced by their ID, e.g. cond_action_123456
+type Action string
+
+const (
+	// ActionMonitor Action value monitor, the request is logged
+	ActionMonitor Action = "monitor"
+	// ActionDeny Action value deny, the request is denied
+	ActionDeny Action = "deny"
+	// ActionSkip Action value skip, no action is taken
+	ActionSkip Action = "skip"
+	// ActionDelay Action value delay, the request is forwarded after a delay
+	ActionDelay Action = "delay"
+	// ActionSlow Action value slow, the response is sent slowly
+	ActionSlow Action = "slow"
+	// ActionTarpit Action value tarpit, the connection is held open
+	ActionTarpit Action = "tarpit"
+)
+
+// BotCategory is an Akamai defined category of known bots
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#akamaibotcategory
+type BotCategory struct {
+	CategoryID   string `json:"categoryId"`
+	CategoryName string `json:"categoryName"`
+}
+
+// CustomBotCategory is a category of bots defined in a security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#custombotcategory
+type CustomBotCategory struct {
+	CategoryID   string                 `json:"categoryId,omitempty"`
+	CategoryName string                 `json:"categoryName"`
+	Description  string                 `json:"description,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CategoryAction is the action a security policy takes for the bots of a category
+type CategoryAction struct {
+	CategoryID string `json:"categoryId"`
+	Action     Action `json:"action"`
+}
+
+func configVersionPath(configID, version int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d/versions/%d", configID, version)
+}
+
+func securityPolicyPath(configID, version int, policyID string) string {
+	return fmt.Sprintf("%s/security-policies/%s", configVersionPath(configID, version), policyID)
+}
+
+// ListAkamaiBotCategories lists the Akamai defined bot categories
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getakamaibotcategories
+// Endpoint: GET /appsec/v1/akamai-bot-categories
+func ListAkamaiBotCategories() ([]BotCategory, error) {
+	response := struct {
+		Categories []BotCategory `json:"categories"`
+	}{}
+	if err := doJSON("GET", "/appsec/v1/akamai-bot-categories", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Categories, nil
+}
+
+// GetAkamaiBotCategoryActions retrieves the actions a security policy takes for the Akamai bot categories
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getakamaibotcategoryactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/akamai-bot-category-actions
+func GetAkamaiBotCategoryActions(configID, version int, policyID string) ([]CategoryAction, error) {
+	return getCategoryActions(securityPolicyPath(configID, version, policyID) + "/akamai-bot-category-actions")
+}
+
+// UpdateAkamaiBotCategoryAction sets the action a security policy takes for an Akamai bot category
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#putakamaibotcategoryaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/akamai-bot-category-actions/{categoryId}
+func UpdateAkamaiBotCategoryAction(configID, version int, policyID, categoryID string, action Action) (*CategoryAction, error) {
+	path := fmt.Sprintf("%s/akamai-bot-category-actions/%s", securityPolicyPath(configID, version, policyID), categoryID)
+	return updateCategoryAction(path, categoryID, action)
+}
+
+// SetAkamaiBotCategoryActions sets the actions a security policy takes for
+// Akamai bot categories given by name, which is matched case insensitively
+//
+// All names are resolved before any action is changed, an unknown name
+// changes nothing.
+func SetAkamaiBotCategoryActions(configID, version int, policyID string, actions map[string]Action) error {
+	categories, err := ListAkamaiBotCategories()
+	if err != nil {
+		return err
+	}
+
+	ids := map[string]string{}
+	for _, category := range categories {
+		ids[strings.ToLower(category.CategoryName)] = category.CategoryID
+	}
+
+	resolved := map[string]Action{}
+	for name, action := range actions {
+		id, ok := ids[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf("unknown Akamai bot category %q", name)
+		}
+		resolved[id] = action
+	}
+
+	for id, action := range resolved {
+		if _, err := UpdateAkamaiBotCategoryAction(configID, version, policyID, id, action); err != nil {
+			return fmt.Errorf("category %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// ListCustomBotCategories lists the custom bot categories of a security configuration version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getcustombotcategories
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/custom-bot-categories
+func ListCustomBotCategories(configID, version int) ([]CustomBotCategory, error) {
+	response := struct {
+		Categories []CustomBotCategory `json:"categories"`
+	}{}
+	if err := doJSON("GET", configVersionPath(configID, version)+"/custom-bot-categories", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Categories, nil
+}
+
+// GetCustomBotCategory retrieves a custom bot category
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getcustombotcategory
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/custom-bot-categories/{categoryId}
+func GetCustomBotCategory(configID, version int, categoryID string) (*CustomBotCategory, error) {
+	category := &CustomBotCategory{}
+	if err := doJSON("GET", customBotCategoryPath(configID, version, categoryID), nil, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// CreateCustomBotCategory creates a custom bot category
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#postcustombotcategories
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/custom-bot-categories
+func CreateCustomBotCategory(configID, version int, category *CustomBotCategory) (*CustomBotCategory, error) {
+	created := &CustomBotCategory{}
+	if err := doJSON("POST", configVersionPath(configID, version)+"/custom-bot-categories", category, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateCustomBotCategory updates a custom bot category
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#putcustombotcategory
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/custom-bot-categories/{categoryId}
+func UpdateCustomBotCategory(configID, version int, category *CustomBotCategory) (*CustomBotCategory, error) {
+	updated := &CustomBotCategory{}
+	if err := doJSON("PUT", customBotCategoryPath(configID, version, category.CategoryID), category, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveCustomBotCategory deletes a custom bot category, which must not be used by a custom bot
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#deletecustombotcategory
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/custom-bot-categories/{categoryId}
+func RemoveCustomBotCategory(configID, version int, categoryID string) error {
+	return doJSON("DELETE", customBotCategoryPath(configID, version, categoryID), nil, nil)
+}
+
+// GetCustomBotCategoryActions retrieves the actions a security policy takes for the custom bot categories
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getcustombotcategoryactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/custom-bot-category-actions
+func GetCustomBotCategoryActions(configID, version int, policyID string) ([]CategoryAction, error) {
+	return getCategoryActions(securityPolicyPath(configID, version, policyID) + "/custom-bot-category-actions")
+}
+
+// UpdateCustomBotCategoryAction sets the action a security policy takes for a custom bot category
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#putcustombotcategoryaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/custom-bot-category-actions/{categoryId}
+func UpdateCustomBotCategoryAction(configID, version int, policyID, categoryID string, action Action) (*CategoryAction, error) {
+	path := fmt.Sprintf("%s/custom-bot-category-actions/%s", securityPolicyPath(configID, version, policyID), categoryID)
+	return updateCategoryAction(path, categoryID, action)
+}
+
+func customBotCategoryPath(configID, version int, categoryID string) string {
+	return fmt.Sprintf("%s/custom-bot-categories/%s", configVersionPath(configID, version), categoryID)
+}
+
+func getCategoryActions(path string) ([]CategoryAction, error) {
+	response := struct {
+		Actions []CategoryAction `json:"actions"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Actions, nil
+}
+
+func updateCategoryAction(path, categoryID string, action Action) (*CategoryAction, error) {
+	updated := &CategoryAction{}
+	if err := doJSON("PUT", path, CategoryAction{CategoryID: categoryID, Action: action}, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
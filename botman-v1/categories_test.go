@@ -0,0 +1,77 @@
+package botman
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestSetAkamaiBotCategoryActions(t *testing.T) {
+	defer gock.Off()
+
+	categories := `{"categories": [{"categoryId": "0c508e1d-73a4-4366-9e48-3c4a080f1c5d", "categoryName": "Web Search Engine Bots"}, {"categoryId": "da005ad3-8bbb-43c8-a783-d97d1fb71ad2", "categoryName": "Web Scrapers"}]}`
+	gock.New(baseURL).
+		Get("/appsec/v1/akamai-bot-categories").
+		Times(2).
+		Reply(200).
+		JSON(categories)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/8/security-policies/AAAA_81230/akamai-bot-category-actions/da005ad3-8bbb-43c8-a783-d97d1fb71ad2").
+		JSON(map[string]string{"categoryId": "da005ad3-8bbb-43c8-a783-d97d1fb71ad2", "action": "deny"}).
+		Reply(200).
+		JSON(`{"categoryId": "da005ad3-8bbb-43c8-a783-d97d1fb71ad2", "action": "deny"}`)
+
+	Init(config)
+
+	require.NoError(t, SetAkamaiBotCategoryActions(43253, 8, "AAAA_81230", map[string]Action{"web scrapers": ActionDeny}))
+	assert.Error(t, SetAkamaiBotCategoryActions(43253, 8, "AAAA_81230", map[string]Action{"Web Scrapers": ActionDeny, "Crawlers": ActionMonitor}))
+	assert.True(t, gock.IsDone())
+}
+
+func TestCustomBotCategory(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/versions/8/custom-bot-categories").
+		JSON(map[string]string{"categoryName": "Partners"}).
+		Reply(201).
+		JSON(`{"categoryId": "fe6a4e6c-4c1c-4a9f-8d0e-0e9c8a2c5b11", "categoryName": "Partners"}`)
+	gock.New(baseURL).
+		Put("/appsec/v1/configs/43253/versions/8/security-policies/AAAA_81230/custom-bot-category-actions/fe6a4e6c-4c1c-4a9f-8d0e-0e9c8a2c5b11").
+		JSON(map[string]string{"categoryId": "fe6a4e6c-4c1c-4a9f-8d0e-0e9c8a2c5b11", "action": "skip"}).
+		Reply(200).
+		JSON(`{"categoryId": "fe6a4e6c-4c1c-4a9f-8d0e-0e9c8a2c5b11", "action": "skip"}`)
+	gock.New(baseURL).
+		Get("/appsec/v1/configs/43253/versions/8/security-policies/AAAA_81230/bot-detection-actions").
+		Reply(200).
+		JSON(`{"actions": [{"detectionId": "b85e3eaa-d334-466d-857e-33308ce416be", "action": "monitor"}]}`)
+
+	Init(config)
+
+	category, err := CreateCustomBotCategory(43253, 8, &CustomBotCategory{CategoryName: "Partners"})
+	require.NoError(t, err)
+	action, err := UpdateCustomBotCategoryAction(43253, 8, "AAAA_81230", category.CategoryID, ActionSkip)
+	require.NoError(t, err)
+	assert.Equal(t, ActionSkip, action.Action)
+
+	detections, err := GetBotDetectionActions(43253, 8, "AAAA_81230")
+	require.NoError(t, err)
+	require.Len(t, detections, 1)
+	assert.Equal(t, ActionMonitor, detections[0].Action)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,67 @@
+package botman
+
+import (
+	"fmt"
+)
+
+// BotDetection is an Akamai detection method of unknown bots, e.g. browser
+// impersonation or a high request rate
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#botdetection
+type BotDetection struct {
+	DetectionID          string `json:"detectionId"`
+	DetectionName        string `json:"detectionName"`
+	DetectionDescription string `json:"detectionDescription,omitempty"`
+	Source               string `json:"source,omitempty"`
+	Active               bool   `json:"active"`
+}
+
+// DetectionAction is the action a security policy takes for the bots found by a detection
+type DetectionAction struct {
+	DetectionID string `json:"detectionId"`
+	Action      Action `json:"action"`
+}
+
+// ListBotDetections lists the bot detections
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getbotdetections
+// Endpoint: GET /appsec/v1/bot-detections
+func ListBotDetections() ([]BotDetection, error) {
+	response := struct {
+		Detections []BotDetection `json:"detections"`
+	}{}
+	if err := doJSON("GET", "/appsec/v1/bot-detections", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Detections, nil
+}
+
+// GetBotDetectionActions retrieves the actions a security policy takes for the bot detections
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#getbotdetectionactions
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/bot-detection-actions
+func GetBotDetectionActions(configID, version int, policyID string) ([]DetectionAction, error) {
+	response := struct {
+		Actions []DetectionAction `json:"actions"`
+	}{}
+	if err := doJSON("GET", securityPolicyPath(configID, version, policyID)+"/bot-detection-actions", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Actions, nil
+}
+
+// UpdateBotDetectionAction sets the action a security policy takes for a bot detection
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#putbotdetectionaction
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/bot-detection-actions/{detectionId}
+func UpdateBotDetectionAction(configID, version int, policyID, detectionID string, action Action) (*DetectionAction, error) {
+	updated := &DetectionAction{}
+	path := fmt.Sprintf("%s/bot-detection-actions/%s", securityPolicyPath(configID, version, policyID), detectionID)
+	if err := doJSON("PUT", path, DetectionAction{DetectionID: detectionID, Action: action}, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,131 @@
+package botman
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TransactionalEndpoint protects an API operation, e.g. a login or checkout,
+// with the bot score of the requests sent to it
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#transactionalendpoint
+type TransactionalEndpoint struct {
+	OperationID               string              `json:"operationId"`
+	APIEndpointID             int64               `json:"apiEndPointId,omitempty"`
+	TraditionalClientSettings *BotScoreThresholds `json:"traditionalClientSettings,omitempty"`
+	MobileClientSettings      *BotScoreThresholds `json:"mobileClientSettings,omitempty"`
+}
+
+// BotScoreThresholds are the actions taken for requests whose bot score is
+// at least the cautious, strict or aggressive threshold
+type BotScoreThresholds struct {
+	CautiousThreshold   int    `json:"cautiousThreshold"`
+	CautiousAction      Action `json:"cautiousAction"`
+	StrictThreshold     int    `json:"strictThreshold"`
+	StrictAction        Action `json:"strictAction"`
+	AggressiveThreshold int    `json:"aggressiveThreshold"`
+	AggressiveAction    Action `json:"aggressiveAction"`
+}
+
+// Validate checks the thresholds are between 0 and 100 and in increasing order
+func (thresholds *BotScoreThresholds) Validate() error {
+	if thresholds.CautiousThreshold < 0 || thresholds.AggressiveThreshold > 100 {
+		return errors.New("bot score thresholds must be between 0 and 100")
+	}
+	if thresholds.CautiousThreshold > thresholds.StrictThreshold || thresholds.StrictThreshold > thresholds.AggressiveThreshold {
+		return fmt.Errorf("bot score thresholds %d, %d, %d must be cautious <= strict <= aggressive",
+			thresholds.CautiousThreshold, thresholds.StrictThreshold, thresholds.AggressiveThreshold)
+	}
+
+	return nil
+}
+
+func (endpoint *TransactionalEndpoint) validate() error {
+	for _, thresholds := range []*BotScoreThresholds{endpoint.TraditionalClientSettings, endpoint.MobileClientSettings} {
+		if thresholds == nil {
+			continue
+		}
+		if err := thresholds.Validate(); err != nil {
+			return fmt.Errorf("operation %s: %w", endpoint.OperationID, err)
+		}
+	}
+
+	return nil
+}
+
+// ListTransactionalEndpoints lists the transactional endpoints protected by a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#gettransactionalendpoints
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/transactional-endpoints/bot-protection
+func ListTransactionalEndpoints(configID, version int, policyID string) ([]TransactionalEndpoint, error) {
+	response := struct {
+		Operations []TransactionalEndpoint `json:"operations"`
+	}{}
+	if err := doJSON("GET", transactionalEndpointsPath(configID, version, policyID), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Operations, nil
+}
+
+// GetTransactionalEndpoint retrieves a transactional endpoint
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#gettransactionalendpoint
+// Endpoint: GET /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/transactional-endpoints/bot-protection/{operationId}
+func GetTransactionalEndpoint(configID, version int, policyID, operationID string) (*TransactionalEndpoint, error) {
+	endpoint := &TransactionalEndpoint{}
+	path := fmt.Sprintf("%s/%s", transactionalEndpointsPath(configID, version, policyID), operationID)
+	if err := doJSON("GET", path, nil, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+// CreateTransactionalEndpoint protects an API operation of a security policy
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#posttransactionalendpoints
+// Endpoint: POST /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/transactional-endpoints/bot-protection
+func CreateTransactionalEndpoint(configID, version int, policyID string, endpoint *TransactionalEndpoint) (*TransactionalEndpoint, error) {
+	if err := endpoint.validate(); err != nil {
+		return nil, err
+	}
+
+	created := &TransactionalEndpoint{}
+	if err := doJSON("POST", transactionalEndpointsPath(configID, version, policyID), endpoint, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateTransactionalEndpoint updates the protection of an API operation
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#puttransactionalendpoint
+// Endpoint: PUT /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/transactional-endpoints/bot-protection/{operationId}
+func UpdateTransactionalEndpoint(configID, version int, policyID string, endpoint *TransactionalEndpoint) (*TransactionalEndpoint, error) {
+	if err := endpoint.validate(); err != nil {
+		return nil, err
+	}
+
+	updated := &TransactionalEndpoint{}
+	path := fmt.Sprintf("%s/%s", transactionalEndpointsPath(configID, version, policyID), endpoint.OperationID)
+	if err := doJSON("PUT", path, endpoint, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveTransactionalEndpoint removes the protection of an API operation
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/bot_manager/v1.html#deletetransactionalendpoint
+// Endpoint: DELETE /appsec/v1/configs/{configId}/versions/{versionNumber}/security-policies/{policyId}/transactional-endpoints/bot-protection/{operationId}
+func RemoveTransactionalEndpoint(configID, version int, policyID, operationID string) error {
+	path := fmt.Sprintf("%s/%s", transactionalEndpointsPath(configID, version, policyID), operationID)
+	return doJSON("DELETE", path, nil, nil)
+}
+
+func transactionalEndpointsPath(configID, version int, policyID string) string {
+	return securityPolicyPath(configID, version, policyID) + "/transactional-endpoints/bot-protection"
+}
@@ -0,0 +1,45 @@
+package botman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateTransactionalEndpoint(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/appsec/v1/configs/43253/versions/8/security-policies/AAAA_81230/transactional-endpoints/bot-protection").
+		JSON(map[string]interface{}{
+			"operationId": "login",
+			"traditionalClientSettings": map[string]interface{}{
+				"cautiousThreshold": 20, "cautiousAction": "monitor",
+				"strictThreshold": 50, "strictAction": "delay",
+				"aggressiveThreshold": 90, "aggressiveAction": "deny",
+			},
+		}).
+		Reply(201).
+		JSON(`{"operationId": "login", "apiEndPointId": 1234}`)
+
+	Init(config)
+
+	endpoint := &TransactionalEndpoint{
+		OperationID: "login",
+		TraditionalClientSettings: &BotScoreThresholds{
+			CautiousThreshold: 20, CautiousAction: ActionMonitor,
+			StrictThreshold: 50, StrictAction: ActionDelay,
+			AggressiveThreshold: 90, AggressiveAction: ActionDeny,
+		},
+	}
+	created, err := CreateTransactionalEndpoint(43253, 8, "AAAA_81230", endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), created.APIEndpointID)
+	assert.True(t, gock.IsDone())
+
+	endpoint.TraditionalClientSettings.StrictThreshold = 95
+	_, err = UpdateTransactionalEndpoint(43253, 8, "AAAA_81230", endpoint)
+	assert.Error(t, err)
+}
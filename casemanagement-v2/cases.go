@@ -0,0 +1,245 @@
+package casemanagement
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Severity is used to create an "enum" of possible Case.Severity values
+type Severity string
+
+const (
+	// SeverityMajor Case.Severity value 1-Major Impact
+	SeverityMajor Severity = "1-Major Impact"
+	// SeverityModerate Case.Severity value 2-Moderate Impact
+	SeverityModerate Severity = "2-Moderate Impact"
+	// SeverityLow Case.Severity value 3-Low Impact
+	SeverityLow Severity = "3-Low Impact"
+)
+
+// CategoryType is used to create an "enum" of possible Case.CategoryType values
+type CategoryType string
+
+const (
+	// CategoryTechnical Case.CategoryType value Technical
+	CategoryTechnical CategoryType = "Technical"
+	// CategoryBilling Case.CategoryType value Billing
+	CategoryBilling CategoryType = "Billing"
+	// CategoryBusiness Case.CategoryType value Business
+	CategoryBusiness CategoryType = "Business"
+)
+
+// Case is an Akamai support case
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#case
+type Case struct {
+	CaseID         string       `json:"caseId,omitempty"`
+	Subject        string       `json:"subject"`
+	Description    string       `json:"description"`
+	CategoryType   CategoryType `json:"categoryType"`
+	Severity       Severity     `json:"severity"`
+	Status         string       `json:"status,omitempty"`
+	Customer       string       `json:"customer,omitempty"`
+	SubmittedBy    string       `json:"submittedBy,omitempty"`
+	ContactEmails  []string     `json:"contactEmails,omitempty"`
+	CreatedDate    string       `json:"createdDate,omitempty"`
+	LastModifiedOn string       `json:"lastModifiedOn,omitempty"`
+	// SubCategories are the product and problem of technical cases, e.g.
+	// {"displayName": "Product", "value": "Property Manager"}
+	SubCategories []SubCategory `json:"subCategories,omitempty"`
+	Comments      []Comment     `json:"comments,omitempty"`
+}
+
+// SubCategory is a sub-category of a Case
+type SubCategory struct {
+	DisplayName string `json:"displayName"`
+	Value       string `json:"value"`
+}
+
+// Comment is a comment of a Case
+type Comment struct {
+	Comment     string `json:"comment"`
+	CreatedBy   string `json:"createdBy,omitempty"`
+	CreatedDate string `json:"createdDate,omitempty"`
+}
+
+// ListCasesOptions filters ListCases
+type ListCasesOptions struct {
+	// ActiveCases only lists the open cases
+	ActiveCases bool `query:"activeCases"`
+	// Duration lists the cases of the last days when not 0
+	Duration int `query:"duration,omitempty"`
+}
+
+// ListCases lists the support cases of the account
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#getcases
+// Endpoint: GET /case-management/v2/cases{?activeCases,duration}
+func ListCases(options ListCasesOptions) ([]Case, error) {
+	path, err := client.PathWithQuery("/case-management/v2/cases", options)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Cases []Case `json:"cases"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Cases, nil
+}
+
+// GetCase retrieves a support case
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#getcase
+// Endpoint: GET /case-management/v2/cases/{caseId}
+func GetCase(caseID string) (*Case, error) {
+	c := &Case{}
+	if err := doJSON("GET", casePath(caseID), nil, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// CreateCase opens a support case and returns its ID
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#postcases
+// Endpoint: POST /case-management/v2/cases
+func CreateCase(c *Case) (string, error) {
+	if c.Subject == "" || c.Description == "" {
+		return "", errors.New("a case needs a subject and a description")
+	}
+	if c.CategoryType == "" {
+		c.CategoryType = CategoryTechnical
+	}
+	if c.Severity == "" {
+		c.Severity = SeverityLow
+	}
+
+	// case ID, status and comments are read-only
+	body := *c
+	body.CaseID, body.Status, body.Comments = "", "", nil
+
+	response := struct {
+		CaseID string `json:"caseId"`
+	}{}
+	if err := doJSON("POST", "/case-management/v2/cases", body, &response); err != nil {
+		return "", err
+	}
+	c.CaseID = response.CaseID
+
+	return response.CaseID, nil
+}
+
+// AddComment adds a comment to a support case
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#postcomment
+// Endpoint: POST /case-management/v2/cases/{caseId}/comments
+func AddComment(caseID string, comment string) error {
+	body := struct {
+		Comment string `json:"comment"`
+	}{comment}
+
+	return doJSON("POST", casePath(caseID)+"/comments", body, nil)
+}
+
+// CloseCase closes a support case
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#postclose
+// Endpoint: POST /case-management/v2/cases/{caseId}/close
+func CloseCase(caseID string, comment string) error {
+	body := struct {
+		Comment string `json:"comment,omitempty"`
+	}{comment}
+
+	return doJSON("POST", casePath(caseID)+"/close", body, nil)
+}
+
+// UploadAttachment attaches a file to a support case, read into memory so
+// that the request can be signed
+//
+// API Docs: https://developer.akamai.com/api/core_features/case_management/v2.html#postattachment
+// Endpoint: POST /case-management/v2/cases/{caseId}/attachments
+func UploadAttachment(caseID string, filename string, content io.Reader) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	req, err := client.NewRequest(Config, "POST", casePath(caseID)+"/attachments", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// The attachment may be binary, only the headers are logged
+	edgegrid.PrintHttpRequest(req, false)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	edgegrid.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	return nil
+}
+
+// CaseFromError returns a technical case describing err, with the request
+// ID, server and instance of an Akamai API error for support to trace it
+func CaseFromError(subject string, err error, severity Severity) *Case {
+	var b strings.Builder
+	b.WriteString(err.Error())
+
+	var apiErr client.APIError
+	if errors.As(err, &apiErr) {
+		b.WriteString("\n")
+		for _, field := range [][2]string{
+			{"Type", apiErr.Type},
+			{"Instance", apiErr.Instance},
+			{"Request ID", apiErr.RequestID},
+			{"Request time", apiErr.RequestTime},
+			{"Server IP", apiErr.ServerIP},
+			{"Client IP", apiErr.ClientIP},
+		} {
+			if field[1] != "" {
+				fmt.Fprintf(&b, "\n%s: %s", field[0], field[1])
+			}
+		}
+	}
+
+	return &Case{
+		Subject:      subject,
+		Description:  b.String(),
+		CategoryType: CategoryTechnical,
+		Severity:     severity,
+	}
+}
+
+func casePath(caseID string) string {
+	return "/case-management/v2/cases/" + url.PathEscape(caseID)
+}
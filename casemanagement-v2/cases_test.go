@@ -0,0 +1,97 @@
+package casemanagement
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestCreateCase(t *testing.T) {
+	defer gock.Off()
+
+	activationErr := fmt.Errorf("activation failed: %w", client.APIError{Title: "Internal Server Error", Status: 500, RequestID: "abc123", Instance: "/papi/v1/properties/prp_1/activations"})
+	c := CaseFromError("Property activation failed", activationErr, SeverityModerate)
+	assert.Contains(t, c.Description, "Request ID: abc123")
+	assert.Contains(t, c.Description, "Instance: /papi/v1/properties/prp_1/activations")
+
+	gock.New(baseURL).
+		Post("/case-management/v2/cases").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			return strings.Contains(string(body), `"severity":"2-Moderate Impact"`) && strings.Contains(string(body), "abc123"), err
+		}).
+		Reply(201).
+		JSON(`{"caseId": "F-CS-1234"}`)
+	gock.New(baseURL).
+		Post("/case-management/v2/cases/F-CS-1234/comments").
+		JSON(map[string]string{"comment": "Retried, still failing"}).
+		Reply(201)
+	gock.New(baseURL).
+		Post("/case-management/v2/cases/F-CS-1234/attachments").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			file, header, err := req.FormFile("file")
+			if err != nil {
+				return false, err
+			}
+			content, err := ioutil.ReadAll(file)
+			return header.Filename == "activation.log" && string(content) == "log lines", err
+		}).
+		Reply(201)
+
+	Init(config)
+
+	caseID, err := CreateCase(c)
+	require.NoError(t, err)
+	assert.Equal(t, "F-CS-1234", caseID)
+	assert.Equal(t, "F-CS-1234", c.CaseID)
+	require.NoError(t, AddComment(caseID, "Retried, still failing"))
+	require.NoError(t, UploadAttachment(caseID, "activation.log", strings.NewReader("log lines")))
+	assert.True(t, gock.IsDone())
+
+	_, err = CreateCase(&Case{Subject: "no description"})
+	assert.Error(t, err)
+}
+
+func TestListCases(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/case-management/v2/cases").
+		MatchParam("activeCases", "true").
+		MatchParam("duration", "7").
+		Reply(200).
+		JSON(`{"cases": [{"caseId": "F-CS-1234", "subject": "Property activation failed", "severity": "2-Moderate Impact", "status": "Assigned"}]}`)
+	gock.New(baseURL).
+		Post("/case-management/v2/cases/F-CS-1234/close").
+		JSON(map[string]string{"comment": "Resolved"}).
+		Reply(200)
+
+	Init(config)
+
+	cases, err := ListCases(ListCasesOptions{ActiveCases: true, Duration: 7})
+	require.NoError(t, err)
+	require.Len(t, cases, 1)
+	assert.Equal(t, SeverityModerate, cases[0].Severity)
+	require.NoError(t, CloseCase("F-CS-1234", "Resolved"))
+	assert.True(t, gock.IsDone())
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
 	"io"
-	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -56,9 +55,13 @@ func NewRequest(config edgegrid.Config, method, path string, body io.Reader) (*h
 
 	u := baseURL.ResolveReference(rel)
 	if config.AccountKey != "" {
-		q := u.Query()
-		q.Add("accountSwitchKey", config.AccountKey)
-		u.RawQuery = q.Encode()
+		// Appended rather than re-encoded, to keep the query as built by EncodeQuery
+		accountSwitchKey := "accountSwitchKey=" + url.QueryEscape(config.AccountKey)
+		if u.RawQuery == "" {
+			u.RawQuery = accountSwitchKey
+		} else {
+			u.RawQuery += "&" + accountSwitchKey
+		}
 	}
 
 	req, err := http.NewRequest(method, u.String(), body)
@@ -130,28 +133,70 @@ func NewMultiPartFormDataRequest(config edgegrid.Config, uriPath, filePath strin
 
 // Do performs a given HTTP Request, signed with the Akamai OPEN Edgegrid
 // Authorization header. An edgegrid.Response or an error is returned.
+//
+// Do is safe for concurrent use: redirects are signed by a copy of Client
+// made for each call, Client itself is not modified.
 func Do(config edgegrid.Config, req *http.Request) (*http.Response, error) {
-	Client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+	httpClient := *Client
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 		req = edgegrid.AddRequestHeader(config, req)
 		return nil
 	}
 
-	req = edgegrid.AddRequestHeader(config, req)
-	res, err := Client.Do(req)
+	if Policy != nil && mutating(req) {
+		if err := checkPolicy(Policy, req); err != nil {
+			return nil, err
+		}
+	}
+
+	var wait func(*http.Request)
+	if Limiter != nil {
+		wait = Limiter.Wait
+		wait(req)
+	}
+
+	sign := func(req *http.Request) *http.Request {
+		return edgegrid.AddRequestHeader(config, req)
+	}
+
+	send := httpClient.Do
+	if Metrics != nil {
+		send = tracedSend(Metrics, send)
+	}
+
+	var res *http.Response
+	var err error
+	if Hedging != nil && hedgeable(req) {
+		res, err = Hedging.do(req, wait, sign, send)
+	} else {
+		res, err = send(sign(req))
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if Limiter != nil {
+		Limiter.Update(req, res)
+	}
+
+	if OnDeprecation != nil {
+		if deprecation := ParseDeprecation(res); deprecation != nil {
+			OnDeprecation(req, deprecation)
+		}
+	}
+
 	return res, nil
 }
 
 // BodyJSON unmarshals the Response.Body into a given data structure
+//
+// The body is read subject to MaxResponseSize and MaxDecodeTime.
 func BodyJSON(r *http.Response, data interface{}) error {
 	if data == nil {
 		return errors.New("You must pass in an interface{}")
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
+	body, err := ReadBody(r)
 	if err != nil {
 		return err
 	}
@@ -159,3 +204,39 @@ func BodyJSON(r *http.Response, data interface{}) error {
 
 	return err
 }
+
+// DoJSON sends body (if not nil) as JSON to path and decodes the response into
+// out (if not nil). An error response is returned as an APIError.
+func DoJSON(config edgegrid.Config, method, path string, body, out interface{}) error {
+	req, err := NewJSONRequest(config, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	return DoJSONRequest(config, req, out)
+}
+
+// DoJSONRequest sends req, for requests that need more than DoJSON (e.g.
+// headers), and decodes the JSON response into out (if not nil). An error
+// response is returned as an APIError.
+func DoJSONRequest(config edgegrid.Config, req *http.Request, out interface{}) error {
+	edgegrid.PrintHttpRequest(req, true)
+
+	res, err := Do(config, req)
+	if err != nil {
+		return err
+	}
+
+	edgegrid.PrintHttpResponse(res, true)
+
+	if IsError(res) {
+		return NewAPIError(res)
+	}
+
+	if out == nil {
+		res.Body.Close()
+		return nil
+	}
+
+	return BodyJSON(res, out)
+}
@@ -2,11 +2,15 @@ package client
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestNewRequest(t *testing.T) {
@@ -81,3 +85,103 @@ func verifyResponseConfig(t *testing.T, config edgegrid.Config, req *http.Reques
 
 	assert.True(t, strings.Contains(json["headers"].(map[string]interface{})["Authorization"].(string), "local-config"))
 }
+
+func TestDo_Concurrent(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v1/groups" {
+			http.Redirect(w, r, "/papi/v1/groups/", http.StatusFound)
+			return
+		}
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	defaultClient := Client
+	Client = server.Client()
+	Limiter = NewHeaderRateLimiter()
+	Hedging = NewHedger()
+	defer func() {
+		Client = defaultClient
+		Limiter = nil
+		Hedging = nil
+	}()
+
+	config := edgegrid.Config{
+		Host:         strings.TrimPrefix(server.URL, "https://"),
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+	}
+
+	var wg sync.WaitGroup
+	statuses := make([]int, 8)
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req, err := NewRequest(config, "GET", "/papi/v1/groups", nil)
+			if err != nil {
+				return
+			}
+			res, err := Do(config, req)
+			if err != nil {
+				return
+			}
+			res.Body.Close()
+			statuses[i] = res.StatusCode
+		}(i)
+	}
+	wg.Wait()
+
+	for _, status := range statuses {
+		assert.Equal(t, http.StatusOK, status)
+	}
+	assert.Nil(t, Client.CheckRedirect)
+}
+
+func TestDoJSON(t *testing.T) {
+	defer gock.Off()
+
+	config := edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+	}
+	baseURL := "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+
+	gock.New(baseURL).
+		Post("/cloudlets/api/v2/policies").
+		JSON(map[string]string{"name": "example"}).
+		Reply(201).
+		JSON(`{"policyId": 1, "name": "example"}`)
+	gock.New(baseURL).
+		Delete("/cloudlets/api/v2/policies/1").
+		Reply(204)
+	gock.New(baseURL).
+		Get("/cloudlets/api/v2/policies/2").
+		Reply(404).
+		JSON(`{"type": "not_found", "title": "Not Found", "status": 404}`)
+
+	var created struct {
+		PolicyID int `json:"policyId"`
+	}
+	require.NoError(t, DoJSON(config, "POST", "/cloudlets/api/v2/policies", map[string]string{"name": "example"}, &created))
+	assert.Equal(t, 1, created.PolicyID)
+
+	require.NoError(t, DoJSON(config, "DELETE", "/cloudlets/api/v2/policies/1", nil, nil))
+
+	err := DoJSON(config, "GET", "/cloudlets/api/v2/policies/2", nil, &created)
+	apiError, ok := err.(APIError)
+	require.True(t, ok)
+	assert.Equal(t, 404, apiError.Status)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Deprecation is the deprecation metadata Akamai APIs return in the
+// Deprecation, Sunset, Link and Warning response headers
+type Deprecation struct {
+	// Deprecated is true when the endpoint sent a Deprecation header
+	Deprecated bool
+	// Date is when the endpoint was (or will be) deprecated, if announced
+	Date *time.Time
+	// Sunset is when the endpoint will stop responding, if announced
+	Sunset *time.Time
+	// Link is the documentation of the deprecation or its replacement
+	Link string
+	// Warnings are the parsed Warning headers
+	Warnings []Warning
+}
+
+// Warning is a single RFC 7234 Warning header value, e.g. 299 - "Deprecated API"
+type Warning struct {
+	Code  int
+	Agent string
+	Text  string
+	Date  *time.Time
+}
+
+// OnDeprecation is called by Do for every response carrying deprecation metadata.
+// The default logs a warning to the edgegrid logger; replace it to collect
+// deprecations, fail CI jobs, etc. Set it to nil to disable.
+var OnDeprecation = LogDeprecation
+
+// LogDeprecation logs deprecation metadata for req to the edgegrid logger
+func LogDeprecation(req *http.Request, deprecation *Deprecation) {
+	if edgegrid.EdgegridLog == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if deprecation.Date != nil {
+		fields["deprecation"] = deprecation.Date.Format(time.RFC3339)
+	}
+	if deprecation.Sunset != nil {
+		fields["sunset"] = deprecation.Sunset.Format(time.RFC3339)
+	}
+	if deprecation.Link != "" {
+		fields["link"] = deprecation.Link
+	}
+
+	entry := edgegrid.EdgegridLog.WithFields(fields)
+	if deprecation.Deprecated || deprecation.Sunset != nil {
+		entry.Warnln("[WARN] API endpoint is deprecated")
+	}
+	for _, warning := range deprecation.Warnings {
+		entry.Warnf("[WARN] API warning %d: %s", warning.Code, warning.Text)
+	}
+}
+
+// ParseDeprecation returns the deprecation metadata of res, or nil if there is none
+func ParseDeprecation(res *http.Response) *Deprecation {
+	if res == nil {
+		return nil
+	}
+
+	deprecation := &Deprecation{}
+	found := false
+
+	if value := strings.TrimSpace(res.Header.Get("Deprecation")); value != "" {
+		found = true
+		if !strings.EqualFold(value, "false") {
+			deprecation.Deprecated = true
+			deprecation.Date = parseDeprecationDate(value)
+		}
+	}
+
+	if value := strings.TrimSpace(res.Header.Get("Sunset")); value != "" {
+		if t, err := http.ParseTime(value); err == nil {
+			found = true
+			deprecation.Sunset = &t
+		}
+	}
+
+	for _, value := range res.Header["Warning"] {
+		for _, warning := range parseWarnings(value) {
+			found = true
+			deprecation.Warnings = append(deprecation.Warnings, warning)
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	for _, link := range ParseLinks(res) {
+		if link.HasRel("deprecation") || link.HasRel("sunset") {
+			deprecation.Link = link.URL
+		}
+	}
+
+	return deprecation
+}
+
+// parseDeprecationDate accepts both "@<epoch seconds>" and HTTP-date values; "true" has no date
+func parseDeprecationDate(value string) *time.Time {
+	if strings.HasPrefix(value, "@") {
+		if seconds, err := strconv.ParseInt(value[1:], 10, 64); err == nil {
+			t := time.Unix(seconds, 0).UTC()
+			return &t
+		}
+		return nil
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return &t
+	}
+
+	return nil
+}
+
+// parseWarnings parses a Warning header value, which may hold several comma separated
+// warn-code warn-agent "warn-text" ["warn-date"] entries
+func parseWarnings(value string) []Warning {
+	var warnings []Warning
+
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		var warning Warning
+
+		fields := strings.SplitN(rest, " ", 3)
+		if len(fields) < 3 {
+			break
+		}
+		code, err := strconv.Atoi(fields[0])
+		if err != nil {
+			break
+		}
+		warning.Code = code
+		warning.Agent = fields[1]
+
+		var ok bool
+		warning.Text, rest, ok = readQuoted(strings.TrimSpace(fields[2]))
+		if !ok {
+			break
+		}
+
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, `"`) {
+			var date string
+			date, rest, ok = readQuoted(rest)
+			if !ok {
+				break
+			}
+			if t, err := http.ParseTime(date); err == nil {
+				warning.Date = &t
+			}
+		}
+		warnings = append(warnings, warning)
+
+		rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+		rest = strings.TrimSpace(rest)
+	}
+
+	return warnings
+}
+
+// readQuoted reads a quoted-string from the start of s, returning its unescaped content and the remainder
+func readQuoted(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", s, false
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", s, false
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	assert.Nil(t, ParseDeprecation(res))
+
+	res.Header.Set("Deprecation", "@1688169599")
+	res.Header.Set("Sunset", "Sun, 31 Dec 2023 23:59:59 GMT")
+	res.Header.Set("Link", `<https://developer.akamai.com/api/v2>; rel="successor-version", <https://techdocs.akamai.com/deprecation>; rel="deprecation"`)
+	res.Header.Add("Warning", `299 - "Deprecated API, use v2" "Sat, 01 Jul 2023 00:00:00 GMT", 199 akamai "Rule format \"latest\" is unstable"`)
+
+	deprecation := ParseDeprecation(res)
+	require.NotNil(t, deprecation)
+	assert.True(t, deprecation.Deprecated)
+	require.NotNil(t, deprecation.Date)
+	assert.Equal(t, time.Unix(1688169599, 0).UTC(), *deprecation.Date)
+	require.NotNil(t, deprecation.Sunset)
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), *deprecation.Sunset)
+	assert.Equal(t, "https://techdocs.akamai.com/deprecation", deprecation.Link)
+
+	require.Len(t, deprecation.Warnings, 2)
+	assert.Equal(t, 299, deprecation.Warnings[0].Code)
+	assert.Equal(t, "Deprecated API, use v2", deprecation.Warnings[0].Text)
+	require.NotNil(t, deprecation.Warnings[0].Date)
+	assert.Equal(t, 199, deprecation.Warnings[1].Code)
+	assert.Equal(t, "akamai", deprecation.Warnings[1].Agent)
+	assert.Equal(t, `Rule format "latest" is unstable`, deprecation.Warnings[1].Text)
+	assert.Nil(t, deprecation.Warnings[1].Date)
+}
+
+func TestParseDeprecation_Boolean(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Deprecation", "true")
+
+	deprecation := ParseDeprecation(res)
+	require.NotNil(t, deprecation)
+	assert.True(t, deprecation.Deprecated)
+	assert.Nil(t, deprecation.Date)
+	assert.Nil(t, deprecation.Sunset)
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -54,7 +53,7 @@ func (error APIError) Error() string {
 // or http.Response-like.
 func NewAPIError(response *http.Response) APIError {
 	// TODO: handle this error
-	body, _ := ioutil.ReadAll(response.Body)
+	body, _ := ReadBody(response)
 
 	return NewAPIErrorFromBody(response, body)
 }
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Hedging is the Hedger used by Do for GET requests; nil (the default) disables hedging
+var Hedging *Hedger
+
+// Hedger sends a second attempt of an idempotent GET request when the first one
+// takes longer than the usual (p95 by default) latency of its API, and uses
+// whichever response arrives first. The slower attempt is cancelled.
+//
+// Latencies are tracked per host and API (the first path segment, e.g. "papi").
+type Hedger struct {
+	// Percentile of the observed latencies after which the hedge is sent
+	Percentile float64
+	// MinSamples is the number of latencies observed before hedging starts
+	MinSamples int
+	// Window is the number of recent latencies kept per API
+	Window int
+	// MinDelay is the lower bound of the hedge delay
+	MinDelay time.Duration
+
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+}
+
+// NewHedger creates a Hedger hedging after the p95 of the last 100 latencies
+func NewHedger() *Hedger {
+	return &Hedger{
+		Percentile: 0.95,
+		MinSamples: 20,
+		Window:     100,
+		MinDelay:   10 * time.Millisecond,
+		latencies:  map[string][]time.Duration{},
+	}
+}
+
+// Delay returns the hedge delay for the API of req; false when not enough
+// latencies were observed yet
+func (hedger *Hedger) Delay(req *http.Request) (time.Duration, bool) {
+	hedger.mu.Lock()
+	samples := append([]time.Duration(nil), hedger.latencies[apiKey(req)]...)
+	hedger.mu.Unlock()
+
+	if len(samples) == 0 || len(samples) < hedger.MinSamples {
+		return 0, false
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	delay := samples[int(float64(len(samples)-1)*hedger.Percentile)]
+	if delay < hedger.MinDelay {
+		delay = hedger.MinDelay
+	}
+
+	return delay, true
+}
+
+// Observe records the latency of a successful request
+func (hedger *Hedger) Observe(req *http.Request, latency time.Duration) {
+	hedger.mu.Lock()
+	defer hedger.mu.Unlock()
+
+	if hedger.latencies == nil {
+		hedger.latencies = map[string][]time.Duration{}
+	}
+	key := apiKey(req)
+	samples := append(hedger.latencies[key], latency)
+	if hedger.Window > 0 && len(samples) > hedger.Window {
+		samples = samples[len(samples)-hedger.Window:]
+	}
+	hedger.latencies[key] = samples
+}
+
+// hedgeable reports whether req is safe to send twice
+func hedgeable(req *http.Request) bool {
+	return (req.Method == "GET" || req.Method == "HEAD") && (req.Body == nil || req.Body == http.NoBody)
+}
+
+type hedgeAttempt struct {
+	index   int
+	res     *http.Response
+	err     error
+	latency time.Duration
+	cancel  context.CancelFunc
+}
+
+// do signs each attempt of req with sign and sends it with send, hedging it
+// once the delay for its API has passed. The hedge waits for wait (if any)
+// before it is signed, so both attempts count against the rate limit.
+func (hedger *Hedger) do(req *http.Request, wait func(*http.Request), sign func(*http.Request) *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	delay, ok := hedger.Delay(req)
+	if !ok {
+		start := time.Now()
+		res, err := send(sign(req))
+		if err == nil {
+			hedger.Observe(req, time.Since(start))
+		}
+		return res, err
+	}
+
+	attempts := make(chan hedgeAttempt, 2)
+	launch := func(index int) context.CancelFunc {
+		ctx, cancel := context.WithCancel(req.Context())
+		go func() {
+			if index > 0 && wait != nil {
+				wait(req)
+			}
+			attempt := sign(req.Clone(ctx))
+			start := time.Now()
+			res, err := send(attempt)
+			attempts <- hedgeAttempt{index: index, res: res, err: err, latency: time.Since(start), cancel: cancel}
+		}()
+		return cancel
+	}
+
+	cancels := []context.CancelFunc{launch(0)}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var firstErr error
+	for pending := 1; pending > 0; {
+		select {
+		case <-timer.C:
+			cancels = append(cancels, launch(1))
+			pending++
+		case attempt := <-attempts:
+			pending--
+			if attempt.err != nil {
+				if firstErr == nil {
+					firstErr = attempt.err
+				}
+				attempt.cancel()
+				if len(cancels) == 1 {
+					// failed before the hedge was sent, errors are not hedged
+					return nil, firstErr
+				}
+				continue
+			}
+
+			hedger.Observe(req, attempt.latency)
+			for index, cancel := range cancels {
+				if index != attempt.index {
+					cancel()
+				}
+			}
+			go discardHedges(attempts, pending)
+
+			attempt.res.Body = &cancelOnClose{ReadCloser: attempt.res.Body, cancel: attempt.cancel}
+			return attempt.res, nil
+		}
+	}
+
+	return nil, firstErr
+}
+
+// discardHedges closes the responses of the attempts that lost the race
+func discardHedges(attempts chan hedgeAttempt, pending int) {
+	for ; pending > 0; pending-- {
+		attempt := <-attempts
+		if attempt.res != nil {
+			attempt.res.Body.Close()
+		}
+		attempt.cancel()
+	}
+}
+
+// cancelOnClose releases the context of the winning attempt once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnClose) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+
+	return err
+}
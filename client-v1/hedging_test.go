@@ -0,0 +1,109 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedger_Delay(t *testing.T) {
+	hedger := NewHedger()
+	req, _ := http.NewRequest("GET", "https://akaa-baseurl.luna.akamaiapis.net/papi/v1/groups", nil)
+
+	_, ok := hedger.Delay(req)
+	assert.False(t, ok)
+
+	for i := 1; i <= 100; i++ {
+		hedger.Observe(req, time.Duration(i)*time.Millisecond)
+	}
+	delay, ok := hedger.Delay(req)
+	assert.True(t, ok)
+	assert.Equal(t, 95*time.Millisecond, delay)
+
+	other, _ := http.NewRequest("GET", "https://akaa-baseurl.luna.akamaiapis.net/ccu/v3/queues", nil)
+	_, ok = hedger.Delay(other)
+	assert.False(t, ok)
+}
+
+func TestHedger_ZeroValue(t *testing.T) {
+	hedger := &Hedger{Percentile: 0.5}
+	req, _ := http.NewRequest("GET", "https://akaa-baseurl.luna.akamaiapis.net/papi/v1/groups", nil)
+
+	hedger.Observe(req, 10*time.Millisecond)
+	delay, ok := hedger.Delay(req)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Millisecond, delay)
+}
+
+// countingLimiter counts the requests Do waits for
+type countingLimiter struct {
+	waits int32
+}
+
+func (limiter *countingLimiter) Wait(req *http.Request) {
+	atomic.AddInt32(&limiter.waits, 1)
+}
+
+func (limiter *countingLimiter) Update(req *http.Request, res *http.Response) {}
+
+func TestDo_Hedged(t *testing.T) {
+	var calls, cancelled int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				atomic.AddInt32(&cancelled, 1)
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	defaultClient, defaultLimiter := Client, Limiter
+	limiter := &countingLimiter{}
+	Client = server.Client()
+	Limiter = limiter
+	Hedging = NewHedger()
+	defer func() {
+		Client = defaultClient
+		Limiter = defaultLimiter
+		Hedging = nil
+	}()
+
+	config := edgegrid.Config{
+		Host:         strings.TrimPrefix(server.URL, "https://"),
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+	}
+
+	req, err := NewRequest(config, "GET", "/papi/v1/groups", nil)
+	require.NoError(t, err)
+	for i := 0; i < Hedging.MinSamples; i++ {
+		Hedging.Observe(req, time.Millisecond)
+	}
+
+	start := time.Now()
+	res, err := Do(config, req)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	assert.Equal(t, "ok", string(body))
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&cancelled) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&limiter.waits))
+}
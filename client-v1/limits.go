@@ -0,0 +1,104 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+var (
+	// MaxResponseSize is the maximum number of bytes of a response body read by
+	// BodyJSON, ReadBody and NewAPIError; 0 (the default) disables the limit
+	MaxResponseSize int64
+	// MaxDecodeTime is the maximum time BodyJSON, ReadBody and NewAPIError
+	// spend reading a response body; 0 (the default) disables the limit
+	MaxDecodeTime time.Duration
+)
+
+// ErrDecodeTimeout is returned by BodyJSON when reading the body takes longer than MaxDecodeTime
+var ErrDecodeTimeout = errors.New("timed out reading the response body")
+
+// ErrResponseTooLarge is returned by BodyJSON when a response body is larger than MaxResponseSize
+type ErrResponseTooLarge struct {
+	// Limit is the MaxResponseSize in effect
+	Limit int64
+	// Size is the Content-Length of the response, or -1 when it wasn't sent
+	Size int64
+	// URL of the request
+	URL string
+}
+
+func (e *ErrResponseTooLarge) Error() string {
+	if e.Size < 0 {
+		return fmt.Sprintf("response of %s is larger than %d bytes", e.URL, e.Limit)
+	}
+
+	return fmt.Sprintf("response of %s is %d bytes, larger than %d bytes", e.URL, e.Size, e.Limit)
+}
+
+// ReadBody reads and closes the body of r, applying MaxResponseSize and
+// MaxDecodeTime, for bodies that are not decoded with BodyJSON
+func ReadBody(r *http.Response) ([]byte, error) {
+	limit := MaxResponseSize
+	if limit > 0 && r.ContentLength > limit {
+		r.Body.Close()
+		return nil, &ErrResponseTooLarge{Limit: limit, Size: r.ContentLength, URL: responseURL(r)}
+	}
+
+	var reader io.Reader = r.Body
+	if limit > 0 {
+		reader = io.LimitReader(r.Body, limit+1)
+	}
+
+	if MaxDecodeTime <= 0 {
+		body, err := ioutil.ReadAll(reader)
+		r.Body.Close()
+		return checkBody(r, limit, body, err)
+	}
+
+	type result struct {
+		body []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		body, err := ioutil.ReadAll(reader)
+		done <- result{body, err}
+	}()
+
+	timer := time.NewTimer(MaxDecodeTime)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		r.Body.Close()
+		return checkBody(r, limit, res.body, res.err)
+	case <-timer.C:
+		// unblocks the read
+		r.Body.Close()
+		return nil, ErrDecodeTimeout
+	}
+}
+
+// checkBody returns the body read from r, or the error of reading it
+func checkBody(r *http.Response, limit int64, body []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, &ErrResponseTooLarge{Limit: limit, Size: -1, URL: responseURL(r)}
+	}
+
+	return body, nil
+}
+
+func responseURL(r *http.Response) string {
+	if r.Request == nil || r.Request.URL == nil {
+		return ""
+	}
+
+	return r.Request.URL.String()
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func limitsResponse(body io.Reader, contentLength int64) *http.Response {
+	req, _ := http.NewRequest("GET", "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/papi/v1/properties", nil)
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(body), ContentLength: contentLength, Request: req}
+}
+
+func TestBodyJSON_MaxResponseSize(t *testing.T) {
+	defer func() { MaxResponseSize = 0 }()
+	MaxResponseSize = 16
+
+	var out map[string]string
+	require.NoError(t, BodyJSON(limitsResponse(strings.NewReader(`{"a": "b"}`), -1), &out))
+	assert.Equal(t, "b", out["a"])
+
+	err := BodyJSON(limitsResponse(strings.NewReader(`{"a": "bbbbbbbbbbbbbbbb"}`), 25), &out)
+	var tooLarge *ErrResponseTooLarge
+	require.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, int64(25), tooLarge.Size)
+
+	// without Content-Length
+	err = BodyJSON(limitsResponse(strings.NewReader(`{"a": "bbbbbbbbbbbbbbbb"}`), -1), &out)
+	require.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, int64(-1), tooLarge.Size)
+	assert.Contains(t, err.Error(), "/papi/v1/properties")
+}
+
+func TestBodyJSON_MaxDecodeTime(t *testing.T) {
+	defer func() { MaxDecodeTime = 0 }()
+	MaxDecodeTime = 10 * time.Millisecond
+
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	go writer.Write([]byte(`{"a": `))
+
+	var out map[string]string
+	res := limitsResponse(reader, -1)
+	res.Body = reader
+	assert.Equal(t, ErrDecodeTimeout, BodyJSON(res, &out))
+}
+
+func TestNewAPIError_MaxDecodeTime(t *testing.T) {
+	defer func() { MaxDecodeTime = 0 }()
+	MaxDecodeTime = 10 * time.Millisecond
+
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	go writer.Write([]byte(`{"title": `))
+
+	res := limitsResponse(reader, -1)
+	res.StatusCode = 500
+	res.Body = reader
+	apiError := NewAPIError(res)
+	assert.Equal(t, 500, apiError.Status)
+	assert.Empty(t, apiError.RawBody)
+}
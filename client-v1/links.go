@@ -0,0 +1,214 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Link is a single RFC 5988 web link from a Link response header
+type Link struct {
+	URL string
+	// Rel holds the link relation types, e.g. "next"
+	Rel []string
+	// Params holds all other link parameters, keyed by lower case name
+	Params map[string]string
+}
+
+// HasRel reports whether the link has relation type rel
+func (link Link) HasRel(rel string) bool {
+	for _, value := range link.Rel {
+		if strings.EqualFold(value, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Links is the list of links of a response
+type Links []Link
+
+// Get returns the URL of the first link with relation type rel, or "" if there is none
+func (links Links) Get(rel string) string {
+	for _, link := range links {
+		if link.HasRel(rel) {
+			return link.URL
+		}
+	}
+
+	return ""
+}
+
+// ParseLinks returns the links from all Link headers of res
+func ParseLinks(res *http.Response) Links {
+	if res == nil {
+		return nil
+	}
+
+	var links Links
+	for _, value := range res.Header["Link"] {
+		links = append(links, parseLinkHeader(value)...)
+	}
+
+	return links
+}
+
+func parseLinkHeader(value string) Links {
+	var links Links
+
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		if !strings.HasPrefix(rest, "<") {
+			// skip malformed link-values up to the next one
+			i := strings.Index(rest, ",")
+			if i == -1 {
+				break
+			}
+			rest = strings.TrimSpace(rest[i+1:])
+			continue
+		}
+
+		end := strings.Index(rest, ">")
+		if end == -1 {
+			break
+		}
+		link := Link{URL: rest[1:end], Params: map[string]string{}}
+		rest = strings.TrimSpace(rest[end+1:])
+
+		for strings.HasPrefix(rest, ";") {
+			rest = strings.TrimSpace(rest[1:])
+
+			i := strings.IndexAny(rest, "=;,")
+			if i == -1 {
+				i = len(rest)
+			}
+			name := strings.ToLower(strings.TrimSpace(rest[:i]))
+			rest = rest[i:]
+
+			var param string
+			if strings.HasPrefix(rest, "=") {
+				rest = strings.TrimSpace(rest[1:])
+				if quoted, remainder, ok := readQuoted(rest); ok {
+					param, rest = quoted, remainder
+				} else {
+					j := strings.IndexAny(rest, ";,")
+					if j == -1 {
+						j = len(rest)
+					}
+					param, rest = strings.TrimSpace(rest[:j]), rest[j:]
+				}
+			}
+			rest = strings.TrimSpace(rest)
+
+			if name == "rel" {
+				link.Rel = append(link.Rel, strings.Fields(param)...)
+			} else if name != "" {
+				link.Params[name] = param
+			}
+		}
+
+		links = append(links, link)
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+	}
+
+	return links
+}
+
+// Pager follows the rel="next" Link headers of a paginated API
+//
+//	pager := client.NewPager(Config, req)
+//	for pager.Next() {
+//		page := Page{}
+//		if err := client.BodyJSON(pager.Response(), &page); err != nil {
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//	}
+type Pager struct {
+	config edgegrid.Config
+	req    *http.Request
+	res    *http.Response
+	links  Links
+	err    error
+}
+
+// NewPager creates a Pager whose first page is fetched with req
+func NewPager(config edgegrid.Config, req *http.Request) *Pager {
+	return &Pager{config: config, req: req}
+}
+
+// Next fetches the next page, returning false when there are no more pages or
+// an error occurred. The body of the previous page is closed.
+func (pager *Pager) Next() bool {
+	if pager.err != nil || pager.req == nil {
+		return false
+	}
+	if pager.res != nil {
+		pager.res.Body.Close()
+		pager.res = nil
+	}
+
+	res, err := Do(pager.config, pager.req)
+	if err != nil {
+		pager.err = err
+		return false
+	}
+	if IsError(res) {
+		pager.err = NewAPIError(res)
+		return false
+	}
+
+	pager.res = res
+	pager.links = ParseLinks(res)
+	pager.req, pager.err = pager.nextRequest(pager.links.Get("next"))
+
+	return true
+}
+
+// nextRequest builds the request for the next page, relative links are resolved
+// against the current request URL
+func (pager *Pager) nextRequest(next string) (*http.Request, error) {
+	if next == "" {
+		return nil, nil
+	}
+
+	target, err := url.Parse(next)
+	if err != nil {
+		return nil, err
+	}
+	target = pager.req.URL.ResolveReference(target)
+	if target.Host != pager.req.URL.Host {
+		return nil, errors.New("next link points to another host: " + target.Host)
+	}
+
+	req, err := NewRequest(pager.config, "GET", target.RequestURI(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// the link already carries the query of the first request, including accountSwitchKey
+	req.URL.RawQuery = target.RawQuery
+	if accept := pager.req.Header.Get("Accept"); accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	return req, nil
+}
+
+// Response returns the current page
+func (pager *Pager) Response() *http.Response {
+	return pager.res
+}
+
+// Links returns the links of the current page
+func (pager *Pager) Links() Links {
+	return pager.links
+}
+
+// Err returns the error that stopped paging, if any
+func (pager *Pager) Err() error {
+	return pager.err
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestParseLinks(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Add("Link", `<https://example.net/items?page=2&a=1,2>; rel="next last"; title="Page, two", </items?page=1>;rel=prev`)
+	res.Header.Add("Link", `broken, <https://example.net/docs>; rel=describedby`)
+
+	links := ParseLinks(res)
+	require.Len(t, links, 3)
+	assert.Equal(t, "https://example.net/items?page=2&a=1,2", links[0].URL)
+	assert.Equal(t, []string{"next", "last"}, links[0].Rel)
+	assert.Equal(t, "Page, two", links[0].Params["title"])
+	assert.Equal(t, "/items?page=1", links.Get("prev"))
+	assert.Equal(t, "https://example.net/docs", links.Get("DescribedBy"))
+	assert.Equal(t, "", links.Get("first"))
+}
+
+func TestPager(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/items").
+		MatchParam("page", "2").
+		Reply(200).
+		JSON(`{"items": [3]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/items").
+		Reply(200).
+		SetHeader("Link", `</items?page=2>; rel="next"`).
+		JSON(`{"items": [1, 2]}`)
+
+	config := edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+	}
+
+	req, err := NewJSONRequest(config, "GET", "/items", nil)
+	require.NoError(t, err)
+
+	items := []int{}
+	pager := NewPager(config, req)
+	for pager.Next() {
+		page := struct {
+			Items []int `json:"items"`
+		}{}
+		require.NoError(t, BodyJSON(pager.Response(), &page))
+		items = append(items, page.Items...)
+	}
+
+	assert.NoError(t, pager.Err())
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// MetricsHook receives the connection metrics of requests, e.g. to tune the
+// MaxIdleConnsPerHost and IdleConnTimeout of the transport of Client
+type MetricsHook interface {
+	Observe(metrics *RequestMetrics)
+}
+
+// MetricsFunc adapts a function to a MetricsHook
+type MetricsFunc func(metrics *RequestMetrics)
+
+// Observe calls f(metrics)
+func (f MetricsFunc) Observe(metrics *RequestMetrics) {
+	f(metrics)
+}
+
+// Metrics is called by Do with the metrics of every request sent, including
+// each attempt of a hedged request; nil (the default) disables tracing
+var Metrics MetricsHook
+
+// RequestMetrics are the connection timings of a request, collected with net/http/httptrace
+type RequestMetrics struct {
+	Host   string
+	Method string
+	// StatusCode is 0 when Err is set
+	StatusCode int
+	Err        error
+	// Total is the time until the response headers were received
+	Total time.Duration
+	// DNS, Connect and TLSHandshake are zero for reused connections
+	DNS          time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// Reused reports whether the connection came from the idle pool, and
+	// IdleTime how long it was idle
+	Reused   bool
+	IdleTime time.Duration
+}
+
+// requestTrace collects the RequestMetrics of one request
+type requestTrace struct {
+	mu      sync.Mutex
+	start   time.Time
+	dns     time.Time
+	connect time.Time
+	tls     time.Time
+	metrics RequestMetrics
+}
+
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dns = time.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.metrics.DNS = time.Since(t.dns)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			t.connect = time.Now()
+			t.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			t.metrics.Connect = time.Since(t.connect)
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tls = time.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.metrics.TLSHandshake = time.Since(t.tls)
+			t.mu.Unlock()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.metrics.Reused = info.Reused
+			t.metrics.IdleTime = info.IdleTime
+			t.mu.Unlock()
+		},
+	}
+}
+
+// tracedSend wraps send to report the RequestMetrics of each request to hook
+func tracedSend(hook MetricsHook, send func(*http.Request) (*http.Response, error)) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		t := &requestTrace{start: time.Now()}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), t.clientTrace()))
+
+		res, err := send(req)
+
+		t.mu.Lock()
+		metrics := t.metrics
+		t.mu.Unlock()
+		metrics.Total = time.Since(t.start)
+		metrics.Host = req.URL.Host
+		metrics.Method = req.Method
+		metrics.Err = err
+		if res != nil {
+			metrics.StatusCode = res.StatusCode
+		}
+		hook.Observe(&metrics)
+
+		return res, err
+	}
+}
+
+// HostStats are the aggregated RequestMetrics of an API host
+type HostStats struct {
+	Host              string
+	Requests          int
+	Errors            int
+	ReusedConnections int
+	NewConnections    int
+	Total             time.Duration
+	DNS               time.Duration
+	Connect           time.Duration
+	TLSHandshake      time.Duration
+}
+
+// ReuseRatio is the share of requests sent on a connection from the idle pool
+func (stats HostStats) ReuseRatio() float64 {
+	if stats.Requests == 0 {
+		return 0
+	}
+
+	return float64(stats.ReusedConnections) / float64(stats.Requests)
+}
+
+// String is a one-line summary of stats
+func (stats HostStats) String() string {
+	average := func(total time.Duration, count int) time.Duration {
+		if count == 0 {
+			return 0
+		}
+		return (total / time.Duration(count)).Round(time.Millisecond)
+	}
+
+	return fmt.Sprintf(
+		"%s: %d requests (%d errors), %.0f%% reused connections, avg %s total, new connections avg %s dns %s connect %s tls",
+		stats.Host,
+		stats.Requests,
+		stats.Errors,
+		100*stats.ReuseRatio(),
+		average(stats.Total, stats.Requests),
+		average(stats.DNS, stats.NewConnections),
+		average(stats.Connect, stats.NewConnections),
+		average(stats.TLSHandshake, stats.NewConnections),
+	)
+}
+
+// ConnectionStats is a MetricsHook aggregating RequestMetrics per API host
+type ConnectionStats struct {
+	// LogEvery logs the summary of a host every LogEvery of its requests, 0 never logs
+	LogEvery int
+	// Log is called with the summaries, the default logs them to the edgegrid logger
+	Log func(stats HostStats)
+
+	mu    sync.Mutex
+	hosts map[string]*HostStats
+}
+
+// NewConnectionStats creates a ConnectionStats logging a summary every logEvery requests to a host,
+// use it with client.Metrics = client.NewConnectionStats(100)
+func NewConnectionStats(logEvery int) *ConnectionStats {
+	return &ConnectionStats{LogEvery: logEvery, Log: LogHostStats, hosts: map[string]*HostStats{}}
+}
+
+// Observe adds metrics to the stats of its host
+func (stats *ConnectionStats) Observe(metrics *RequestMetrics) {
+	stats.mu.Lock()
+	if stats.hosts == nil {
+		stats.hosts = map[string]*HostStats{}
+	}
+	host, ok := stats.hosts[metrics.Host]
+	if !ok {
+		host = &HostStats{Host: metrics.Host}
+		stats.hosts[metrics.Host] = host
+	}
+
+	host.Requests++
+	if metrics.Err != nil {
+		host.Errors++
+	}
+	if metrics.Reused {
+		host.ReusedConnections++
+	} else {
+		host.NewConnections++
+		host.DNS += metrics.DNS
+		host.Connect += metrics.Connect
+		host.TLSHandshake += metrics.TLSHandshake
+	}
+	host.Total += metrics.Total
+
+	summary := *host
+	stats.mu.Unlock()
+
+	if stats.LogEvery > 0 && stats.Log != nil && summary.Requests%stats.LogEvery == 0 {
+		stats.Log(summary)
+	}
+}
+
+// Hosts returns the stats of each host, sorted by host
+func (stats *ConnectionStats) Hosts() []HostStats {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	hosts := make([]HostStats, 0, len(stats.hosts))
+	for _, host := range stats.hosts {
+		hosts = append(hosts, *host)
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Host < hosts[j].Host })
+
+	return hosts
+}
+
+// Reset clears the stats
+func (stats *ConnectionStats) Reset() {
+	stats.mu.Lock()
+	stats.hosts = map[string]*HostStats{}
+	stats.mu.Unlock()
+}
+
+// LogHostStats logs the summary of stats to the edgegrid logger
+func LogHostStats(stats HostStats) {
+	if edgegrid.EdgegridLog == nil {
+		return
+	}
+
+	edgegrid.EdgegridLog.Infof("[INFO] %s", stats)
+}
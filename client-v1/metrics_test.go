@@ -0,0 +1,61 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo_Metrics(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var logged []HostStats
+	stats := NewConnectionStats(3)
+	stats.Log = func(host HostStats) { logged = append(logged, host) }
+
+	defaultClient := Client
+	Client = server.Client()
+	Metrics = stats
+	defer func() {
+		Client = defaultClient
+		Metrics = nil
+	}()
+
+	config := edgegrid.Config{
+		Host:         strings.TrimPrefix(server.URL, "https://"),
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+	}
+
+	for i := 0; i < 3; i++ {
+		req, err := NewRequest(config, "GET", "/papi/v1/groups", nil)
+		require.NoError(t, err)
+		res, err := Do(config, req)
+		require.NoError(t, err)
+		ioutil.ReadAll(res.Body)
+		res.Body.Close()
+	}
+
+	hosts := stats.Hosts()
+	require.Len(t, hosts, 1)
+	assert.Equal(t, strings.TrimPrefix(server.URL, "https://"), hosts[0].Host)
+	assert.Equal(t, 3, hosts[0].Requests)
+	assert.Equal(t, 1, hosts[0].NewConnections)
+	assert.Equal(t, 2, hosts[0].ReusedConnections)
+	assert.True(t, hosts[0].TLSHandshake > 0)
+	assert.InDelta(t, 2.0/3, hosts[0].ReuseRatio(), 0.001)
+
+	require.Len(t, logged, 1)
+	assert.Contains(t, logged[0].String(), "3 requests (0 errors), 67% reused connections")
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PolicyHook vetoes mutating requests, e.g. by evaluating an OPA/rego policy
+// with the Change as input
+type PolicyHook interface {
+	// Allow returns an error to prevent change from being sent
+	Allow(change *Change) error
+}
+
+// PolicyFunc adapts a function to a PolicyHook
+type PolicyFunc func(change *Change) error
+
+// Allow calls f(change)
+func (f PolicyFunc) Allow(change *Change) error {
+	return f(change)
+}
+
+// Policy is consulted by Do before sending every request that is not a GET,
+// HEAD or OPTIONS; nil (the default) allows all requests
+var Policy PolicyHook
+
+// Change is the normalized description of a mutating request
+type Change struct {
+	// Service is the API of the request, e.g. "papi"
+	Service string `json:"service"`
+	Method  string `json:"method"`
+	// Resource is the request path, e.g. "/papi/v1/properties/prp_1/activations"
+	Resource string              `json:"resource"`
+	Query    map[string][]string `json:"query,omitempty"`
+	// Payload is the request body, and PayloadDigest its hex encoded SHA-256
+	Payload       []byte    `json:"payload,omitempty"`
+	PayloadDigest string    `json:"payloadDigest,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// PolicyError is returned by Do when Policy vetoes a request
+type PolicyError struct {
+	Change *Change
+	Err    error
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("policy denied %s %s: %s", e.Change.Method, e.Change.Resource, e.Err)
+}
+
+// Unwrap returns the error returned by the PolicyHook
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// mutating reports whether req may change the state of a resource
+func mutating(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	}
+
+	return true
+}
+
+// checkPolicy describes req as a Change and asks policy to allow it. The
+// body of req is restored after it was read.
+func checkPolicy(policy PolicyHook, req *http.Request) error {
+	change := &Change{
+		Service:  strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)[0],
+		Method:   req.Method,
+		Resource: req.URL.Path,
+		Query:    req.URL.Query(),
+		Time:     time.Now(),
+	}
+	delete(change.Query, "accountSwitchKey")
+
+	if req.Body != nil && req.Body != http.NoBody {
+		payload, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(payload))
+
+		digest := sha256.Sum256(payload)
+		change.Payload = payload
+		change.PayloadDigest = hex.EncodeToString(digest[:])
+	}
+
+	if err := policy.Allow(change); err != nil {
+		return &PolicyError{Change: change, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestDo_Policy(t *testing.T) {
+	defer gock.Off()
+
+	errFriday := errors.New("no production activations on Fridays")
+	var changes []*Change
+	Policy = PolicyFunc(func(change *Change) error {
+		changes = append(changes, change)
+		if strings.Contains(string(change.Payload), `"PRODUCTION"`) {
+			return errFriday
+		}
+		return nil
+	})
+	defer func() { Policy = nil }()
+
+	config := edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+	}
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/properties/prp_1/activations").
+		BodyString(`{"network":"STAGING"}`).
+		Reply(201)
+
+	req, err := NewJSONRequest(config, "POST", "/papi/v1/properties/prp_1/activations?contractId=ctr_1", map[string]string{"network": "STAGING"})
+	require.NoError(t, err)
+	res, err := Do(config, req)
+	require.NoError(t, err)
+	assert.Equal(t, 201, res.StatusCode)
+
+	req, err = NewJSONRequest(config, "POST", "/papi/v1/properties/prp_1/activations", map[string]string{"network": "PRODUCTION"})
+	require.NoError(t, err)
+	_, err = Do(config, req)
+	assert.True(t, errors.Is(err, errFriday))
+	policyErr := &PolicyError{}
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, "papi", policyErr.Change.Service)
+
+	req, err = NewRequest(config, "GET", "/papi/v1/groups", nil)
+	require.NoError(t, err)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/groups").
+		Reply(200)
+	_, err = Do(config, req)
+	require.NoError(t, err)
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, "/papi/v1/properties/prp_1/activations", changes[0].Resource)
+	assert.Equal(t, []string{"ctr_1"}, changes[0].Query["contractId"])
+	assert.Equal(t, "eddee68c472a063364dff4b50113fda2dc40ead6ef294471b5873b50654d4224", changes[0].PayloadDigest)
+	assert.True(t, gock.IsDone())
+}
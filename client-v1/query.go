@@ -0,0 +1,219 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryMarshaler is implemented by request types that encode their own query
+// parameters, for endpoints whose format the query struct tags cannot express
+type QueryMarshaler interface {
+	MarshalQuery() (url.Values, error)
+}
+
+// QueryFormat is used to create an "enum" of possible encodings of a list query parameter
+type QueryFormat string
+
+const (
+	// QueryRepeat encodes a list as repeated keys, ids=1&ids=2 (the default)
+	QueryRepeat QueryFormat = "repeat"
+	// QueryComma encodes a list as a comma joined value, ids=1,2
+	QueryComma QueryFormat = "comma"
+	// QueryBrackets encodes a list as repeated bracketed keys, ids[]=1&ids[]=2
+	QueryBrackets QueryFormat = "brackets"
+)
+
+// EncodeQuery encodes the query parameters of v, a QueryMarshaler or a struct
+// (or pointer to a struct) with query tags, sorted by key
+//
+// The tag is the parameter name followed by options:
+//
+//	Network string    `query:"network"`
+//	Details bool      `query:"details,omitempty"`
+//	IDs     []int     `query:"ids,comma"`
+//	Types   []string  `query:"type,brackets,omitempty"`
+//	From    time.Time `query:"from,omitempty"`
+//
+// omitempty skips zero values and empty lists; comma, brackets and repeat (the
+// default) select the encoding of lists, see QueryFormat. Fields without a tag
+// or tagged "-" are skipped. Strings, booleans, numbers, time.Time (encoded as
+// RFC 3339), fmt.Stringer, pointers to those and slices of those are supported.
+//
+// The [] of bracketed keys and commas are not escaped, so that comma joined
+// lists reach the API as written.
+func EncodeQuery(v interface{}) (string, error) {
+	values, err := queryValues(v)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeValues(values), nil
+}
+
+// PathWithQuery returns path with the query parameters of v appended, path
+// alone when v encodes to no parameters
+func PathWithQuery(path string, v interface{}) (string, error) {
+	query, err := EncodeQuery(v)
+	if err != nil {
+		return "", err
+	}
+	if query == "" {
+		return path, nil
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + query, nil
+	}
+
+	return path + "?" + query, nil
+}
+
+func queryValues(v interface{}) (url.Values, error) {
+	if marshaler, ok := v.(QueryMarshaler); ok {
+		return marshaler.MarshalQuery()
+	}
+	if values, ok := v.(url.Values); ok {
+		return values, nil
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return url.Values{}, nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot encode %T as query parameters", v)
+	}
+
+	values := url.Values{}
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		tag := field.Tag.Get("query")
+		if tag == "" || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		name := options[0]
+		omitEmpty := false
+		format := QueryRepeat
+		for _, option := range options[1:] {
+			switch option {
+			case "omitempty":
+				omitEmpty = true
+			case string(QueryRepeat), string(QueryComma), string(QueryBrackets):
+				format = QueryFormat(option)
+			default:
+				return nil, fmt.Errorf("field %s: unknown query option %q", field.Name, option)
+			}
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Slice || fieldValue.Kind() == reflect.Array {
+			if omitEmpty && fieldValue.Len() == 0 {
+				continue
+			}
+			list := make([]string, 0, fieldValue.Len())
+			for j := 0; j < fieldValue.Len(); j++ {
+				s, _, err := queryString(fieldValue.Index(j))
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", field.Name, err)
+				}
+				list = append(list, s)
+			}
+			switch format {
+			case QueryComma:
+				values.Set(name, strings.Join(list, ","))
+			case QueryBrackets:
+				values[name+"[]"] = list
+			default:
+				values[name] = list
+			}
+			continue
+		}
+
+		s, zero, err := queryString(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if omitEmpty && zero {
+			continue
+		}
+		values.Set(name, s)
+	}
+
+	return values, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// queryString formats a single value, and reports whether it is empty: the
+// zero value or a nil pointer, as with encoding/json a pointer to a zero value
+// is not empty
+func queryString(value reflect.Value) (string, bool, error) {
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", true, nil
+		}
+		s, _, err := queryString(value.Elem())
+		return s, false, err
+	}
+
+	if value.Type() == timeType {
+		t := value.Interface().(time.Time)
+		return t.Format(time.RFC3339), t.IsZero(), nil
+	}
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		s := stringer.String()
+		return s, s == "", nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), value.Len() == 0, nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), !value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), value.Int() == 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), value.Uint() == 0, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), value.Float() == 0, nil
+	}
+
+	return "", false, fmt.Errorf("unsupported query parameter type %s", value.Type())
+}
+
+// encodeValues is url.Values.Encode() that keeps [] key suffixes and commas as is
+func encodeValues(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		escapedKey := url.QueryEscape(strings.TrimSuffix(key, "[]"))
+		if strings.HasSuffix(key, "[]") {
+			escapedKey += "[]"
+		}
+		for _, value := range values[key] {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(escapedKey)
+			b.WriteByte('=')
+			b.WriteString(strings.Replace(url.QueryEscape(value), "%2C", ",", -1))
+		}
+	}
+
+	return b.String()
+}
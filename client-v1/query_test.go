@@ -0,0 +1,79 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type network string
+
+func (n network) String() string { return "net-" + string(n) }
+
+type listQuery struct {
+	Network  network   `query:"network"`
+	Details  bool      `query:"details,omitempty"`
+	IDs      []int     `query:"ids,comma"`
+	Types    []string  `query:"type,brackets,omitempty"`
+	Tags     []string  `query:"tag"`
+	From     time.Time `query:"from,omitempty"`
+	Limit    *int      `query:"limit,omitempty"`
+	Ignored  string
+	Skipped  string `query:"-"`
+	internal string `query:"internal"`
+}
+
+type customQuery struct{}
+
+func (customQuery) MarshalQuery() (url.Values, error) {
+	return url.Values{"search": {"a b"}}, nil
+}
+
+func TestEncodeQuery(t *testing.T) {
+	limit := 10
+	query, err := EncodeQuery(&listQuery{
+		Network: "staging",
+		IDs:     []int{1, 2},
+		Types:   []string{"A", "AAAA"},
+		Tags:    []string{"x", "y&z"},
+		From:    time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		Limit:   &limit,
+		Ignored: "ignored",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "from=2021-03-01T00%3A00%3A00Z&ids=1,2&limit=10&network=net-staging&tag=x&tag=y%26z&type[]=A&type[]=AAAA", query)
+
+	query, err = EncodeQuery(listQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, "ids=&network=net-", query)
+
+	query, err = EncodeQuery(customQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, "search=a+b", query)
+
+	_, err = EncodeQuery(42)
+	assert.EqualError(t, err, "cannot encode int as query parameters")
+
+	_, err = EncodeQuery(struct {
+		IDs []int `query:"ids,semicolon"`
+	}{})
+	assert.EqualError(t, err, `field IDs: unknown query option "semicolon"`)
+}
+
+func TestPathWithQuery(t *testing.T) {
+	query := struct {
+		Details bool `query:"details,omitempty"`
+	}{}
+
+	path, err := PathWithQuery("/papi/v1/groups", query)
+	require.NoError(t, err)
+	assert.Equal(t, "/papi/v1/groups", path)
+
+	query.Details = true
+	path, err = PathWithQuery("/papi/v1/groups?contractId=1", query)
+	require.NoError(t, err)
+	assert.Equal(t, "/papi/v1/groups?contractId=1&details=true", path)
+}
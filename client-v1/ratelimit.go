@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles outbound requests
+type RateLimiter interface {
+	// Wait blocks until req may be sent, or its context is done; it is called
+	// for both attempts of a hedged request
+	Wait(req *http.Request)
+	// Update is called with the response to every request sent
+	Update(req *http.Request, res *http.Response)
+}
+
+// Limiter is the RateLimiter used by Do; nil (the default) disables rate
+// limiting, set it to NewHeaderRateLimiter() to enable it
+var Limiter RateLimiter
+
+// HeaderRateLimiter is a RateLimiter driven by the X-RateLimit-Limit and
+// X-RateLimit-Remaining response headers (as returned by PAPI)
+//
+// Limits are tracked per host and API (the first path segment, e.g. "papi").
+// Requests are sent unthrottled while plenty of quota remains; below
+// MinRemaining they are spread evenly over Window, and once the quota is exhausted
+// (or a 429 is received) they wait for the window, or Retry-After, to pass.
+type HeaderRateLimiter struct {
+	// Window is the period the limit applies to
+	Window time.Duration
+	// MinRemaining is the remaining quota below which requests are paced
+	MinRemaining int
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+	sleep   func(ctx context.Context, d time.Duration)
+	now     func() time.Time
+}
+
+type rateBucket struct {
+	limit     int
+	remaining int
+	resetAt   time.Time
+	next      time.Time
+}
+
+// NewHeaderRateLimiter creates a HeaderRateLimiter with a one minute window
+func NewHeaderRateLimiter() *HeaderRateLimiter {
+	return &HeaderRateLimiter{
+		Window:       time.Minute,
+		MinRemaining: 10,
+		buckets:      make(map[string]*rateBucket),
+		sleep:        sleepContext,
+		now:          time.Now,
+	}
+}
+
+// apiKey identifies the API of req by host and first path segment, e.g. "host/papi"
+func apiKey(req *http.Request) string {
+	api := strings.TrimPrefix(req.URL.Path, "/")
+	if i := strings.Index(api, "/"); i != -1 {
+		api = api[:i]
+	}
+
+	return req.URL.Host + "/" + api
+}
+
+// Wait blocks while the API's quota is exhausted, or paces requests when it
+// runs low, and returns early once the context of req is done
+func (limiter *HeaderRateLimiter) Wait(req *http.Request) {
+	limiter.mu.Lock()
+	bucket, ok := limiter.buckets[apiKey(req)]
+	if !ok {
+		limiter.mu.Unlock()
+		return
+	}
+
+	now := limiter.now()
+	var delay time.Duration
+	switch {
+	case bucket.remaining <= 0 && now.Before(bucket.resetAt):
+		delay = bucket.resetAt.Sub(now)
+	case bucket.remaining <= limiter.MinRemaining && bucket.limit > 0:
+		interval := limiter.Window / time.Duration(bucket.limit)
+		if bucket.next.Before(now) {
+			bucket.next = now
+		}
+		delay = bucket.next.Sub(now)
+		bucket.next = bucket.next.Add(interval)
+	}
+	// account for this request until the response reports the real value
+	if bucket.remaining > 0 {
+		bucket.remaining--
+	}
+	limiter.mu.Unlock()
+
+	if delay > 0 {
+		limiter.sleep(req.Context(), delay)
+	}
+}
+
+// sleepContext sleeps for d, or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// Update records the quota reported by the response headers
+func (limiter *HeaderRateLimiter) Update(req *http.Request, res *http.Response) {
+	if res == nil {
+		return
+	}
+
+	limit, limitErr := strconv.Atoi(res.Header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if res.StatusCode != http.StatusTooManyRequests && (limitErr != nil || remainingErr != nil) {
+		return
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	key := apiKey(req)
+	bucket, ok := limiter.buckets[key]
+	if !ok {
+		bucket = &rateBucket{}
+		limiter.buckets[key] = bucket
+	}
+
+	now := limiter.now()
+	if limitErr == nil {
+		bucket.limit = limit
+	}
+	if remainingErr == nil {
+		bucket.remaining = remaining
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		bucket.remaining = 0
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			bucket.resetAt = now.Add(time.Duration(seconds) * time.Second)
+			return
+		}
+	}
+	if bucket.remaining <= 0 && !bucket.resetAt.After(now) {
+		bucket.resetAt = now.Add(limiter.Window)
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRateLimiter(now time.Time) (*HeaderRateLimiter, *[]time.Duration) {
+	slept := []time.Duration{}
+	limiter := NewHeaderRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(ctx context.Context, d time.Duration) { slept = append(slept, d) }
+
+	return limiter, &slept
+}
+
+func rateLimitResponse(status int, limit, remaining string) *http.Response {
+	res := &http.Response{StatusCode: status, Header: http.Header{}}
+	if limit != "" {
+		res.Header.Set("X-RateLimit-Limit", limit)
+	}
+	if remaining != "" {
+		res.Header.Set("X-RateLimit-Remaining", remaining)
+	}
+
+	return res
+}
+
+func TestHeaderRateLimiter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter, slept := newTestRateLimiter(now)
+
+	req, _ := http.NewRequest("GET", "https://akaa-baseurl.luna.akamaiapis.net/papi/v1/properties/prp_1/versions", nil)
+	other, _ := http.NewRequest("GET", "https://akaa-baseurl.luna.akamaiapis.net/config-dns/v2/zones", nil)
+
+	// no headers seen yet
+	limiter.Wait(req)
+	assert.Empty(t, *slept)
+
+	// plenty of quota left
+	limiter.Update(req, rateLimitResponse(200, "60", "50"))
+	limiter.Wait(req)
+	assert.Empty(t, *slept)
+
+	// running low: requests are paced one second apart
+	limiter.Update(req, rateLimitResponse(200, "60", "5"))
+	limiter.Wait(req)
+	limiter.Wait(req)
+	assert.Equal(t, []time.Duration{time.Second}, *slept)
+
+	// exhausted: wait for the window
+	limiter.Update(req, rateLimitResponse(200, "60", "0"))
+	limiter.Wait(req)
+	assert.Equal(t, time.Minute, (*slept)[len(*slept)-1])
+
+	// other APIs are tracked separately
+	count := len(*slept)
+	limiter.Wait(other)
+	assert.Len(t, *slept, count)
+}
+
+func TestHeaderRateLimiter_RetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter, slept := newTestRateLimiter(now)
+
+	req, _ := http.NewRequest("POST", "https://akaa-baseurl.luna.akamaiapis.net/papi/v1/properties/prp_1/versions", nil)
+	res := rateLimitResponse(http.StatusTooManyRequests, "", "")
+	res.Header.Set("Retry-After", "15")
+	limiter.Update(req, res)
+
+	limiter.Wait(req)
+	assert.Equal(t, []time.Duration{15 * time.Second}, *slept)
+}
+
+func TestHeaderRateLimiter_Cancelled(t *testing.T) {
+	limiter := NewHeaderRateLimiter()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequest("GET", "https://akaa-baseurl.luna.akamaiapis.net/papi/v1/properties", nil)
+	req = req.WithContext(ctx)
+	limiter.Update(req, rateLimitResponse(http.StatusTooManyRequests, "", ""))
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	limiter.Wait(req)
+	assert.True(t, time.Since(start) < time.Second)
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// RetryRule is used to create an "enum" of possible retry rules of a
+// RetryClassification status or error type
+type RetryRule int
+
+const (
+	// RetryNever does not retry the request
+	RetryNever RetryRule = iota
+	// RetryIdempotent retries the request only when its method is idempotent,
+	// the first attempt may have been processed
+	RetryIdempotent
+	// RetryAlways retries the request whatever its method, the first attempt
+	// was rejected before being processed
+	RetryAlways
+)
+
+// Retries is the RetryClassification of the retrying helpers of the API
+// packages, e.g. edgekv, extend it to change what they retry
+var Retries = NewRetryClassification()
+
+// RetryClassification tells whether a failed request may be retried, from the
+// status code or Akamai problem type of its response, or its transport error,
+// and the idempotency of its method
+//
+// A problem type rule applies to the APIError types ending with it, e.g.
+// "/rate-limit", and takes precedence over the status code rule. Transport
+// errors (timeouts, reset or refused connections, truncated responses) follow
+// RetryIdempotent, except for a refused connection which follows RetryAlways.
+//
+// It is safe for concurrent use.
+type RetryClassification struct {
+	mu         sync.RWMutex
+	statuses   map[int]RetryRule
+	errorTypes map[string]RetryRule
+	methods    map[string]bool
+}
+
+// NewRetryClassification creates the default RetryClassification: 408 and 429
+// are always retried; 500, 502, 503 and 504 only for GET, HEAD, OPTIONS, PUT
+// and DELETE requests
+func NewRetryClassification() *RetryClassification {
+	return &RetryClassification{
+		statuses: map[int]RetryRule{
+			http.StatusRequestTimeout:      RetryAlways,
+			http.StatusTooManyRequests:     RetryAlways,
+			http.StatusInternalServerError: RetryIdempotent,
+			http.StatusBadGateway:          RetryIdempotent,
+			http.StatusServiceUnavailable:  RetryIdempotent,
+			http.StatusGatewayTimeout:      RetryIdempotent,
+		},
+		errorTypes: map[string]RetryRule{
+			"/rate-limit": RetryAlways,
+		},
+		methods: map[string]bool{
+			"GET":     true,
+			"HEAD":    true,
+			"OPTIONS": true,
+			"PUT":     true,
+			"DELETE":  true,
+		},
+	}
+}
+
+// SetStatus sets the rule of a response status code
+func (classification *RetryClassification) SetStatus(status int, rule RetryRule) {
+	classification.mu.Lock()
+	defer classification.mu.Unlock()
+
+	classification.statuses[status] = rule
+}
+
+// SetErrorType sets the rule of the Akamai problem types ending with suffix
+func (classification *RetryClassification) SetErrorType(suffix string, rule RetryRule) {
+	classification.mu.Lock()
+	defer classification.mu.Unlock()
+
+	classification.errorTypes[suffix] = rule
+}
+
+// SetIdempotent sets whether requests of method can be sent twice, e.g. POST
+// for an API whose creation requests are keyed by the client
+func (classification *RetryClassification) SetIdempotent(method string, idempotent bool) {
+	classification.mu.Lock()
+	defer classification.mu.Unlock()
+
+	classification.methods[strings.ToUpper(method)] = idempotent
+}
+
+// Idempotent reports whether req can be sent twice
+func (classification *RetryClassification) Idempotent(req *http.Request) bool {
+	classification.mu.RLock()
+	defer classification.mu.RUnlock()
+
+	return classification.methods[req.Method]
+}
+
+// StatusRule returns the rule of a response status code
+func (classification *RetryClassification) StatusRule(status int) RetryRule {
+	classification.mu.RLock()
+	defer classification.mu.RUnlock()
+
+	return classification.statuses[status]
+}
+
+// ErrorRule returns the rule of err: an APIError (by problem type, then
+// status code) or a transport error
+func (classification *RetryClassification) ErrorRule(err error) RetryRule {
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		if rule, ok := classification.errorTypeRule(apiErr.Type); ok {
+			return rule
+		}
+		return classification.StatusRule(apiErr.Status)
+	}
+	var apiErrPtr *APIError
+	if errors.As(err, &apiErrPtr) {
+		return classification.ErrorRule(*apiErrPtr)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return RetryNever
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return RetryAlways
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return RetryIdempotent
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return RetryIdempotent
+	}
+
+	return RetryNever
+}
+
+// Retryable reports whether req may be retried after it returned res or,
+// when res is nil, failed with err
+//
+// A successful response is never retried. The body of res is not read, so
+// the problem type of an error response is only known from err.
+func (classification *RetryClassification) Retryable(req *http.Request, res *http.Response, err error) bool {
+	var rule RetryRule
+	switch {
+	case err != nil:
+		rule = classification.ErrorRule(err)
+	case res != nil && IsError(res):
+		rule = classification.StatusRule(res.StatusCode)
+	}
+
+	switch rule {
+	case RetryAlways:
+		return true
+	case RetryIdempotent:
+		return classification.Idempotent(req)
+	}
+
+	return false
+}
+
+func (classification *RetryClassification) errorTypeRule(errorType string) (RetryRule, bool) {
+	if errorType == "" {
+		return RetryNever, false
+	}
+
+	classification.mu.RLock()
+	defer classification.mu.RUnlock()
+
+	// the longest matching suffix wins, so that rules do not depend on map order
+	best := ""
+	for suffix := range classification.errorTypes {
+		if strings.HasSuffix(errorType, suffix) && len(suffix) > len(best) {
+			best = suffix
+		}
+	}
+	if best == "" {
+		return RetryNever, false
+	}
+
+	return classification.errorTypes[best], true
+}
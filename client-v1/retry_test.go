@@ -0,0 +1,67 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryClassification_Retryable(t *testing.T) {
+	classification := NewRetryClassification()
+	get, _ := http.NewRequest("GET", "https://example.com/papi/v1/groups", nil)
+	post, _ := http.NewRequest("POST", "https://example.com/papi/v1/properties", nil)
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		status   int
+		err      error
+		expected bool
+	}{
+		{"success", get, 200, nil, false},
+		{"not found", get, 404, nil, false},
+		{"too many requests", post, 429, nil, true},
+		{"server error GET", get, 500, nil, true},
+		{"server error POST", post, 500, nil, false},
+		{"not implemented", get, 501, nil, false},
+		{"reset GET", get, 0, &url.Error{Op: "Get", Err: syscall.ECONNRESET}, true},
+		{"reset POST", post, 0, &url.Error{Op: "Post", Err: syscall.ECONNRESET}, false},
+		{"refused POST", post, 0, &url.Error{Op: "Post", Err: syscall.ECONNREFUSED}, true},
+		{"truncated GET", get, 0, io.ErrUnexpectedEOF, true},
+		{"other", get, 0, errors.New("boom"), false},
+		{"rate limit problem", post, 0, APIError{Type: "https://problems.luna.akamaiapis.net/papi/v0/rate-limit", Status: 400}, true},
+		{"wrapped API error", get, 0, fmt.Errorf("list: %w", APIError{Status: 503}), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res *http.Response
+			if test.err == nil {
+				res = &http.Response{StatusCode: test.status}
+			}
+			assert.Equal(t, test.expected, classification.Retryable(test.req, res, test.err))
+		})
+	}
+}
+
+func TestRetryClassification_Extend(t *testing.T) {
+	classification := NewRetryClassification()
+	post, _ := http.NewRequest("POST", "https://example.com/papi/v1/properties", nil)
+
+	classification.SetIdempotent("post", true)
+	assert.True(t, classification.Retryable(post, &http.Response{StatusCode: 502}, nil))
+
+	classification.SetStatus(409, RetryAlways)
+	assert.True(t, classification.Retryable(post, &http.Response{StatusCode: 409}, nil))
+
+	classification.SetErrorType("/papi/v0/rate-limit", RetryNever)
+	assert.False(t, classification.Retryable(post, nil, APIError{Type: "https://problems.luna.akamaiapis.net/papi/v0/rate-limit", Status: 429}))
+	assert.True(t, classification.Retryable(post, nil, APIError{Type: "https://problems.luna.akamaiapis.net/ccu/v3/rate-limit", Status: 429}))
+
+	assert.False(t, NewRetryClassification().Idempotent(post))
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureTransport_CABundle(t *testing.T) {
+	defer func(client *http.Client) { Client = client }(Client)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "cabundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bundle := filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	_, err = Client.Get(server.URL)
+	require.Error(t, err)
+
+	require.NoError(t, ConfigureTransport(TransportOptions{CABundle: bundle}))
+	assert.True(t, Client != http.DefaultClient)
+	assert.Nil(t, http.DefaultClient.Transport)
+
+	res, err := Client.Get(server.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	require.NoError(t, ioutil.WriteFile(bundle, []byte("not a certificate"), 0600))
+	assert.Error(t, ConfigureTransport(TransportOptions{CABundle: bundle}))
+}
+
+func TestConfigureTransport_Proxy(t *testing.T) {
+	defer func(client *http.Client) { Client = client }(Client)
+
+	var proxied *http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r
+	}))
+	defer proxy.Close()
+
+	require.NoError(t, ConfigureTransport(TransportOptions{ProxyURL: proxy.URL, ProxyUser: "jdoe", ProxyPassword: "secret"}))
+
+	res, err := Client.Get("http://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/papi/v1/groups")
+	require.NoError(t, err)
+	res.Body.Close()
+
+	require.NotNil(t, proxied)
+	assert.Equal(t, "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net", proxied.Host)
+	assert.Equal(t, "Basic amRvZTpzZWNyZXQ=", proxied.Header.Get("Proxy-Authorization"))
+
+	assert.Error(t, ConfigureTransport(TransportOptions{ProxyUser: "jdoe"}))
+	assert.Error(t, ConfigureTransport(TransportOptions{ProxyURL: "socks5://proxy:1080"}))
+}
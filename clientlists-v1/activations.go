@@ -0,0 +1,134 @@
+package clientlists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Network is used to create an "enum" of possible activation networks
+type Network string
+
+// ActivationStatus is used to create an "enum" of possible Activation.ActivationStatus values
+type ActivationStatus string
+
+const (
+	// NetworkStaging the staging network
+	NetworkStaging Network = "STAGING"
+	// NetworkProduction the production network
+	NetworkProduction Network = "PRODUCTION"
+
+	// StatusInactive Activation.ActivationStatus value INACTIVE
+	StatusInactive ActivationStatus = "INACTIVE"
+	// StatusPendingActivation Activation.ActivationStatus value PENDING_ACTIVATION
+	StatusPendingActivation ActivationStatus = "PENDING_ACTIVATION"
+	// StatusActive Activation.ActivationStatus value ACTIVE
+	StatusActive ActivationStatus = "ACTIVE"
+	// StatusModified Activation.ActivationStatus value MODIFIED
+	StatusModified ActivationStatus = "MODIFIED"
+	// StatusPendingDeactivation Activation.ActivationStatus value PENDING_DEACTIVATION
+	StatusPendingDeactivation ActivationStatus = "PENDING_DEACTIVATION"
+	// StatusFailed Activation.ActivationStatus value FAILED
+	StatusFailed ActivationStatus = "FAILED"
+)
+
+var (
+	// ErrActivationFailed is returned by WaitForActivation for FAILED activations
+	ErrActivationFailed = errors.New("client list activation failed")
+
+	// DefaultActivationInterval is the WaitForActivation polling interval used when none is given
+	DefaultActivationInterval = 30 * time.Second
+)
+
+// ActivationRequest are the parameters of a client list activation
+type ActivationRequest struct {
+	Comments               string   `json:"comments,omitempty"`
+	NotificationRecipients []string `json:"notificationRecipients,omitempty"`
+	SiebelTicketID         string   `json:"siebelTicketId,omitempty"`
+}
+
+// Activation is the status of a client list activation
+type Activation struct {
+	ActivationID     int64            `json:"activationId"`
+	ListID           string           `json:"listId"`
+	Version          int64            `json:"version"`
+	Network          Network          `json:"network"`
+	Action           string           `json:"action"`
+	ActivationStatus ActivationStatus `json:"activationStatus"`
+	Comments         string           `json:"comments,omitempty"`
+	CreateDate       string           `json:"createDate,omitempty"`
+	CreatedBy        string           `json:"createdBy,omitempty"`
+}
+
+// ActivateList activates the latest version of a client list on network
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#postactivations
+// Endpoint: POST /client-list/v1/lists/{listId}/activations
+func ActivateList(listID string, network Network, request ActivationRequest) (*Activation, error) {
+	body := struct {
+		ActivationRequest
+		Action  string  `json:"action"`
+		Network Network `json:"network"`
+	}{request, "ACTIVATE", network}
+
+	activation := &Activation{}
+	if err := doJSON("POST", listPath(listID)+"/activations", body, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// GetActivation retrieves the status of an activation
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#getactivation
+// Endpoint: GET /client-list/v1/activations/{activationId}
+func GetActivation(activationID int64) (*Activation, error) {
+	activation := &Activation{}
+	if err := doJSON("GET", fmt.Sprintf("/client-list/v1/activations/%d", activationID), nil, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// GetActivationStatus retrieves the status of the latest activation of a client list on network
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#getactivationstatus
+// Endpoint: GET /client-list/v1/lists/{listId}/environments/{environment}/status
+func GetActivationStatus(listID string, network Network) (*Activation, error) {
+	activation := &Activation{}
+	if err := doJSON("GET", fmt.Sprintf("%s/environments/%s/status", listPath(listID), network), nil, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// WaitForActivation polls the status of an activation every interval until it
+// is ACTIVE or FAILED, or ctx is done. A FAILED activation returns ErrActivationFailed.
+func WaitForActivation(ctx context.Context, activationID int64, interval time.Duration) (*Activation, error) {
+	if interval <= 0 {
+		interval = DefaultActivationInterval
+	}
+
+	for {
+		activation, err := GetActivation(activationID)
+		if err != nil {
+			return nil, err
+		}
+		switch activation.ActivationStatus {
+		case StatusActive:
+			return activation, nil
+		case StatusFailed:
+			return activation, fmt.Errorf("%w: activation %d of %s version %d", ErrActivationFailed, activation.ActivationID, activation.ListID, activation.Version)
+		}
+
+		select {
+		case <-ctx.Done():
+			return activation, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package clientlists
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestActivateList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/client-list/v1/lists/12345_BLOCKED/activations").
+		JSON(map[string]interface{}{"action": "ACTIVATE", "network": "STAGING", "comments": "block botnet"}).
+		Reply(200).
+		JSON(`{"activationId": 9001, "listId": "12345_BLOCKED", "version": 3, "network": "STAGING", "action": "ACTIVATE", "activationStatus": "PENDING_ACTIVATION"}`)
+	gock.New(baseURL).
+		Get("/client-list/v1/activations/9001").
+		Reply(200).
+		JSON(`{"activationId": 9001, "listId": "12345_BLOCKED", "version": 3, "network": "STAGING", "activationStatus": "FAILED"}`)
+
+	Init(config)
+
+	activation, err := ActivateList("12345_BLOCKED", NetworkStaging, ActivationRequest{Comments: "block botnet"})
+	require.NoError(t, err)
+	assert.Equal(t, StatusPendingActivation, activation.ActivationStatus)
+
+	_, err = WaitForActivation(context.Background(), activation.ActivationID, 1)
+	assert.True(t, errors.Is(err, ErrActivationFailed))
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,227 @@
+package clientlists
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// ListType is used to create an "enum" of possible List.Type values
+type ListType string
+
+const (
+	// ListTypeIP List.Type value IP, IP addresses and CIDR blocks
+	ListTypeIP ListType = "IP"
+	// ListTypeGeo List.Type value GEO, ISO 3166 country codes
+	ListTypeGeo ListType = "GEO"
+	// ListTypeASN List.Type value ASN, autonomous system numbers
+	ListTypeASN ListType = "ASN"
+	// ListTypeTLSFingerprint List.Type value TLS_FINGERPRINT, TLS client fingerprints
+	ListTypeTLSFingerprint ListType = "TLS_FINGERPRINT"
+	// ListTypeFileHash List.Type value FILE_HASH, SHA-256 file hashes
+	ListTypeFileHash ListType = "FILE_HASH"
+)
+
+// List is a client list, the successor of network lists
+//
+// Version is incremented by each change to the list or its items, the
+// activations of a list deploy its latest version.
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#list
+type List struct {
+	ListID                     string           `json:"listId,omitempty"`
+	Name                       string           `json:"name"`
+	Type                       ListType         `json:"type"`
+	Notes                      string           `json:"notes,omitempty"`
+	Tags                       []string         `json:"tags,omitempty"`
+	ContractID                 string           `json:"contractId,omitempty"`
+	GroupID                    int64            `json:"groupId,omitempty"`
+	Version                    int64            `json:"version,omitempty"`
+	ItemsCount                 int64            `json:"itemsCount,omitempty"`
+	Items                      []Item           `json:"items,omitempty"`
+	ReadOnly                   bool             `json:"readOnly,omitempty"`
+	Shared                     bool             `json:"shared,omitempty"`
+	Deprecated                 bool             `json:"deprecated,omitempty"`
+	StagingActivationStatus    ActivationStatus `json:"stagingActivationStatus,omitempty"`
+	ProductionActivationStatus ActivationStatus `json:"productionActivationStatus,omitempty"`
+	CreateDate                 string           `json:"createDate,omitempty"`
+	UpdateDate                 string           `json:"updateDate,omitempty"`
+}
+
+// Item is an entry of a client list, ExpirationDate removes it from the list
+// at that time when set
+type Item struct {
+	Value          string     `json:"value"`
+	Description    string     `json:"description,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+}
+
+// Expired reports whether the item expired at t
+func (item *Item) Expired(t time.Time) bool {
+	return item.ExpirationDate != nil && !item.ExpirationDate.After(t)
+}
+
+// ItemsUpdate are the items UpdateItems adds to, changes in and removes from
+// a list, items are identified by their Value
+type ItemsUpdate struct {
+	Append []Item `json:"append,omitempty"`
+	Update []Item `json:"update,omitempty"`
+	Delete []Item `json:"delete,omitempty"`
+}
+
+// ItemsUpdateResult are the items changed by UpdateItems
+type ItemsUpdateResult struct {
+	Appended []Item `json:"appended"`
+	Updated  []Item `json:"updated"`
+	Deleted  []Item `json:"deleted"`
+}
+
+// ListOptions filters ListLists
+type ListOptions struct {
+	// Name matches list names
+	Name string `query:"name,omitempty"`
+	// Search matches list names, notes, tags and items
+	Search            string     `query:"search,omitempty"`
+	Type              []ListType `query:"type,omitempty"`
+	IncludeItems      bool       `query:"includeItems,omitempty"`
+	IncludeDeprecated bool       `query:"includeDeprecated,omitempty"`
+}
+
+// ListLists lists the client lists
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#getlists
+// Endpoint: GET /client-list/v1/lists{?name,search,type,includeItems,includeDeprecated}
+func ListLists(options ListOptions) ([]List, error) {
+	path, err := client.PathWithQuery("/client-list/v1/lists", options)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Content []List `json:"content"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Content, nil
+}
+
+// GetList retrieves a client list, along with its items when includeItems is set
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#getlist
+// Endpoint: GET /client-list/v1/lists/{listId}{?includeItems}
+func GetList(listID string, includeItems bool) (*List, error) {
+	list := &List{}
+	if err := doJSON("GET", fmt.Sprintf("%s?includeItems=%t", listPath(listID), includeItems), nil, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// CreateList creates a client list, with its items, in the contract and group of list
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#postlists
+// Endpoint: POST /client-list/v1/lists
+func CreateList(list *List) (*List, error) {
+	if list.Name == "" || list.Type == "" {
+		return nil, errors.New("a client list needs a name and a type")
+	}
+	if list.ContractID == "" || list.GroupID == 0 {
+		return nil, errors.New("a client list needs a contract and a group")
+	}
+
+	created := &List{}
+	if err := doJSON("POST", "/client-list/v1/lists", list, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateList updates the name, notes and tags of a client list
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#putlist
+// Endpoint: PUT /client-list/v1/lists/{listId}
+func UpdateList(list *List) (*List, error) {
+	body := struct {
+		Name  string   `json:"name"`
+		Notes string   `json:"notes"`
+		Tags  []string `json:"tags"`
+	}{list.Name, list.Notes, list.Tags}
+	if body.Tags == nil {
+		body.Tags = []string{}
+	}
+
+	updated := &List{}
+	if err := doJSON("PUT", listPath(list.ListID), body, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// TagList adds tags to a client list, keeping its other tags
+func TagList(listID string, tags ...string) (*List, error) {
+	list, err := GetList(listID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, tag := range list.Tags {
+		known[tag] = true
+	}
+	for _, tag := range tags {
+		if !known[tag] {
+			known[tag] = true
+			list.Tags = append(list.Tags, tag)
+		}
+	}
+
+	return UpdateList(list)
+}
+
+// DeleteList deletes a client list, which must not be active or used by a security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#deletelist
+// Endpoint: DELETE /client-list/v1/lists/{listId}
+func DeleteList(listID string) error {
+	return doJSON("DELETE", listPath(listID), nil, nil)
+}
+
+// UpdateItems adds, changes and removes items of a client list in one version
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/client_lists/v1.html#postitems
+// Endpoint: POST /client-list/v1/lists/{listId}/items
+func UpdateItems(listID string, update ItemsUpdate) (*ItemsUpdateResult, error) {
+	result := &ItemsUpdateResult{}
+	if err := doJSON("POST", listPath(listID)+"/items", update, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AppendItems adds items to a client list, e.g. to block addresses until their ExpirationDate
+func AppendItems(listID string, items ...Item) (*ItemsUpdateResult, error) {
+	return UpdateItems(listID, ItemsUpdate{Append: items})
+}
+
+// RemoveItems removes the items with the given values from a client list
+func RemoveItems(listID string, values ...string) (*ItemsUpdateResult, error) {
+	update := ItemsUpdate{}
+	for _, value := range values {
+		update.Delete = append(update.Delete, Item{Value: value})
+	}
+
+	return UpdateItems(listID, update)
+}
+
+func listPath(listID string) string {
+	return fmt.Sprintf("/client-list/v1/lists/%s", listID)
+}
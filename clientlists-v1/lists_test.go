@@ -0,0 +1,97 @@
+package clientlists
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+	config  = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+)
+
+func TestCreateListAndItems(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/client-list/v1/lists").
+		JSON(map[string]interface{}{"name": "blocked", "type": "IP", "contractId": "C-0N7RAC7", "groupId": 12345, "tags": []string{"incident"}}).
+		Reply(201).
+		JSON(`{"listId": "12345_BLOCKED", "name": "blocked", "type": "IP", "tags": ["incident"], "version": 1}`)
+	gock.New(baseURL).
+		Post("/client-list/v1/lists/12345_BLOCKED/items").
+		JSON(map[string]interface{}{"append": []map[string]interface{}{{"value": "192.0.2.0/24", "expirationDate": "2021-03-02T00:00:00Z"}}}).
+		Reply(200).
+		JSON(`{"appended": [{"value": "192.0.2.0/24", "expirationDate": "2021-03-02T00:00:00Z"}], "updated": [], "deleted": []}`)
+	gock.New(baseURL).
+		Post("/client-list/v1/lists/12345_BLOCKED/items").
+		JSON(map[string]interface{}{"delete": []map[string]interface{}{{"value": "192.0.2.0/24"}}}).
+		Reply(200).
+		JSON(`{"appended": [], "updated": [], "deleted": [{"value": "192.0.2.0/24"}]}`)
+	gock.New(baseURL).
+		Get("/client-list/v1/lists").
+		MatchParam("type", "IP").
+		MatchParam("includeItems", "true").
+		Reply(200).
+		JSON(`{"content": [{"listId": "12345_BLOCKED", "name": "blocked", "type": "IP", "version": 3, "items": []}]}`)
+
+	Init(config)
+
+	_, err := CreateList(&List{Name: "blocked", Type: ListTypeIP})
+	assert.Error(t, err)
+
+	list, err := CreateList(&List{Name: "blocked", Type: ListTypeIP, ContractID: "C-0N7RAC7", GroupID: 12345, Tags: []string{"incident"}})
+	require.NoError(t, err)
+	assert.Equal(t, "12345_BLOCKED", list.ListID)
+
+	expires := time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)
+	result, err := AppendItems(list.ListID, Item{Value: "192.0.2.0/24", ExpirationDate: &expires})
+	require.NoError(t, err)
+	require.Len(t, result.Appended, 1)
+	assert.False(t, result.Appended[0].Expired(expires.Add(-time.Second)))
+	assert.True(t, result.Appended[0].Expired(expires))
+
+	result, err = RemoveItems(list.ListID, "192.0.2.0/24")
+	require.NoError(t, err)
+	assert.Len(t, result.Deleted, 1)
+
+	lists, err := ListLists(ListOptions{Type: []ListType{ListTypeIP}, IncludeItems: true})
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, int64(3), lists[0].Version)
+	assert.True(t, gock.IsDone())
+}
+
+func TestTagList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/client-list/v1/lists/12345_BLOCKED").
+		MatchParam("includeItems", "false").
+		Reply(200).
+		JSON(`{"listId": "12345_BLOCKED", "name": "blocked", "type": "IP", "notes": "SOC", "tags": ["incident"]}`)
+	gock.New(baseURL).
+		Put("/client-list/v1/lists/12345_BLOCKED").
+		JSON(map[string]interface{}{"name": "blocked", "notes": "SOC", "tags": []string{"incident", "botnet"}}).
+		Reply(200).
+		JSON(`{"listId": "12345_BLOCKED", "name": "blocked", "type": "IP", "notes": "SOC", "tags": ["incident", "botnet"]}`)
+
+	Init(config)
+
+	list, err := TagList("12345_BLOCKED", "incident", "botnet")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"incident", "botnet"}, list.Tags)
+	assert.True(t, gock.IsDone())
+}
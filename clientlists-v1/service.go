@@ -0,0 +1,23 @@
+package clientlists
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+var (
+	// Config contains the Akamai OPEN Edgegrid API credentials
+	// for automatic signing of requests
+	Config edgegrid.Config
+)
+
+// Init sets the Client Lists edgegrid Config
+func Init(config edgegrid.Config) {
+	Config = config
+	edgegrid.SetupLogging()
+}
+
+// doJSON sends body (if not nil) as JSON to path and decodes the response into out (if not nil)
+func doJSON(method, path string, body, out interface{}) error {
+	return client.DoJSON(Config, method, path, body, out)
+}
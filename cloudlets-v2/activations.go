@@ -0,0 +1,95 @@
+package cloudlets
+
+import (
+	"fmt"
+)
+
+// NetworkValue is used to create an "enum" of possible activation networks
+type NetworkValue string
+
+const (
+	// NetworkStaging activation network value staging
+	NetworkStaging NetworkValue = "staging"
+	// NetworkProduction activation network value prod
+	NetworkProduction NetworkValue = "prod"
+)
+
+// PolicyActivation is the activation of a policy version, and of the property
+// it is associated with, on a network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#policyactivation
+type PolicyActivation struct {
+	APIVersion   string       `json:"apiVersion,omitempty"`
+	Network      NetworkValue `json:"network"`
+	PolicyInfo   PolicyInfo   `json:"policyInfo"`
+	PropertyInfo PropertyInfo `json:"propertyInfo"`
+}
+
+// PolicyInfo is the policy version part of a PolicyActivation
+type PolicyInfo struct {
+	PolicyID       int64  `json:"policyId"`
+	Name           string `json:"name"`
+	Version        int64  `json:"version"`
+	Status         string `json:"status"`
+	StatusDetail   string `json:"statusDetail,omitempty"`
+	ActivatedBy    string `json:"activatedBy,omitempty"`
+	ActivationDate int64  `json:"activationDate,omitempty"`
+}
+
+// PropertyInfo is the property part of a PolicyActivation
+type PropertyInfo struct {
+	Name           string `json:"name"`
+	Version        int64  `json:"version"`
+	GroupID        int64  `json:"groupId"`
+	Status         string `json:"status"`
+	ActivatedBy    string `json:"activatedBy,omitempty"`
+	ActivationDate int64  `json:"activationDate,omitempty"`
+}
+
+// Activation statuses of PolicyInfo.Status and PropertyInfo.Status
+const (
+	ActivationStatusPending  = "pending"
+	ActivationStatusActive   = "active"
+	ActivationStatusDeactive = "deactivated"
+	ActivationStatusInactive = "inactive"
+	ActivationStatusFailed   = "failed"
+)
+
+// ActivatePolicyVersion activates a policy version on network, along with the
+// properties that use the policy. additionalPropertyNames associates the policy
+// with more properties first.
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#postpolicyversionactivations
+// Endpoint: POST /cloudlets/api/v2/policies/{policyId}/versions/{version}/activations
+func ActivatePolicyVersion(policyID, version int64, network NetworkValue, additionalPropertyNames ...string) ([]PolicyActivation, error) {
+	body := struct {
+		Network                 NetworkValue `json:"network"`
+		AdditionalPropertyNames []string     `json:"additionalPropertyNames,omitempty"`
+	}{network, additionalPropertyNames}
+
+	var activations []PolicyActivation
+	if err := doJSON("POST", policyVersionPath(policyID, version)+"/activations", body, &activations); err != nil {
+		return nil, err
+	}
+
+	return activations, nil
+}
+
+// ListPolicyActivations lists the activations of a policy, on network only
+// when it is not empty
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getpolicyactivations
+// Endpoint: GET /cloudlets/api/v2/policies/{policyId}/activations{?network,propertyName}
+func ListPolicyActivations(policyID int64, network NetworkValue) ([]PolicyActivation, error) {
+	path := policyPath(policyID) + "/activations"
+	if network != "" {
+		path += fmt.Sprintf("?network=%s", network)
+	}
+
+	var activations []PolicyActivation
+	if err := doJSON("GET", path, nil, &activations); err != nil {
+		return nil, err
+	}
+
+	return activations, nil
+}
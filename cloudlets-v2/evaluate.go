@@ -0,0 +1,485 @@
+package cloudlets
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TestRequest is a synthetic request evaluated against match rules locally
+type TestRequest struct {
+	Method  string
+	URL     *url.URL
+	Header  http.Header
+	Cookies map[string]string
+	// ClientIP is the connecting IP, X-Forwarded-For is read from Header
+	ClientIP net.IP
+	// Bucket is the population bucket of the user, from 1 to 100
+	Bucket int
+	// Time is the time of the request, used for the Start and End of rules. The
+	// zero value is the current time.
+	Time time.Time
+}
+
+// NewTestRequest creates a GET TestRequest for rawURL
+func NewTestRequest(rawURL string) (*TestRequest, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestRequest{
+		Method:  "GET",
+		URL:     u,
+		Header:  http.Header{},
+		Cookies: map[string]string{},
+		Bucket:  1,
+	}, nil
+}
+
+// check returns an error when request can't be evaluated
+func (request *TestRequest) check() error {
+	if request == nil || request.URL == nil {
+		return errors.New("test request has no URL")
+	}
+
+	return nil
+}
+
+// EvaluationAction is used to create an "enum" of possible Evaluation.Action values
+type EvaluationAction string
+
+const (
+	// ActionNone Evaluation.Action value none, no rule matched or the rule does not apply
+	ActionNone EvaluationAction = "none"
+	// ActionForward Evaluation.Action value forward
+	ActionForward EvaluationAction = "forward"
+	// ActionRedirect Evaluation.Action value redirect
+	ActionRedirect EvaluationAction = "redirect"
+)
+
+// Evaluation is the outcome of evaluating a TestRequest against match rules
+type Evaluation struct {
+	Matched bool
+	// RuleIndex is the index of the matching rule, -1 when no rule matched
+	RuleIndex   int
+	RuleName    string
+	Action      EvaluationAction
+	OriginID    string
+	PathAndQS   string
+	RedirectURL string
+	StatusCode  int
+}
+
+// ruleCondition is the part common to the match rules of every cloudlet
+type ruleCondition struct {
+	start, end int64
+	disabled   bool
+	matchURL   string
+	criteria   []MatchCriteria
+}
+
+// EvaluateER evaluates request against Edge Redirector match rules, the first
+// matching rule wins
+func EvaluateER(rules []MatchRuleER, request *TestRequest) (*Evaluation, error) {
+	if err := request.check(); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		ok, err := ruleCondition{rule.Start, rule.End, rule.Disabled, rule.MatchURL, rule.Matches}.matches(request)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if !ok {
+			continue
+		}
+
+		redirectURL := rule.RedirectURL
+		if rule.UseIncomingQueryString && request.URL.RawQuery != "" {
+			redirectURL = appendQuery(redirectURL, request.URL.RawQuery)
+		}
+
+		return &Evaluation{
+			Matched:     true,
+			RuleIndex:   i,
+			RuleName:    rule.Name,
+			Action:      ActionRedirect,
+			RedirectURL: redirectURL,
+			StatusCode:  rule.StatusCode,
+		}, nil
+	}
+
+	return noMatch(), nil
+}
+
+// EvaluateFR evaluates request against Forward Rewrite match rules, the first
+// matching rule wins
+func EvaluateFR(rules []MatchRuleFR, request *TestRequest) (*Evaluation, error) {
+	if err := request.check(); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		ok, err := ruleCondition{rule.Start, rule.End, rule.Disabled, rule.MatchURL, rule.Matches}.matches(request)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if !ok {
+			continue
+		}
+
+		return forward(i, rule.Name, rule.ForwardSettings.OriginID, rule.ForwardSettings.PathAndQS, rule.ForwardSettings.UseIncomingQueryString, request), nil
+	}
+
+	return noMatch(), nil
+}
+
+// EvaluateAS evaluates request against Audience Segmentation match rules, the
+// first matching rule wins
+func EvaluateAS(rules []MatchRuleAS, request *TestRequest) (*Evaluation, error) {
+	if err := request.check(); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		ok, err := ruleCondition{rule.Start, rule.End, rule.Disabled, rule.MatchURL, rule.Matches}.matches(request)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if !ok {
+			continue
+		}
+
+		return forward(i, rule.Name, rule.ForwardSettings.OriginID, rule.ForwardSettings.PathAndQS, rule.ForwardSettings.UseIncomingQueryString, request), nil
+	}
+
+	return noMatch(), nil
+}
+
+// EvaluateCD evaluates request against Phased Release match rules
+//
+// The first matching rule wins; it forwards to its origin when the bucket of
+// the request is within the rule Percent, otherwise the Evaluation is Matched
+// with ActionNone and the request stays on the default origin.
+func EvaluateCD(rules []MatchRuleCD, request *TestRequest) (*Evaluation, error) {
+	if err := request.check(); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		ok, err := ruleCondition{rule.Start, rule.End, rule.Disabled, rule.MatchURL, rule.Matches}.matches(request)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if request.Bucket > rule.ForwardSettings.Percent {
+			return &Evaluation{Matched: true, RuleIndex: i, RuleName: rule.Name, Action: ActionNone}, nil
+		}
+
+		return forward(i, rule.Name, rule.ForwardSettings.OriginID, "", false, request), nil
+	}
+
+	return noMatch(), nil
+}
+
+func noMatch() *Evaluation {
+	return &Evaluation{RuleIndex: -1, Action: ActionNone}
+}
+
+func forward(index int, name string, originID string, pathAndQS string, useIncomingQueryString bool, request *TestRequest) *Evaluation {
+	if useIncomingQueryString && request.URL.RawQuery != "" {
+		if pathAndQS == "" {
+			pathAndQS = request.URL.EscapedPath()
+		}
+		pathAndQS = appendQuery(pathAndQS, request.URL.RawQuery)
+	}
+
+	return &Evaluation{
+		Matched:   true,
+		RuleIndex: index,
+		RuleName:  name,
+		Action:    ActionForward,
+		OriginID:  originID,
+		PathAndQS: pathAndQS,
+	}
+}
+
+func appendQuery(target string, rawQuery string) string {
+	if strings.Contains(target, "?") {
+		return target + "&" + rawQuery
+	}
+
+	return target + "?" + rawQuery
+}
+
+// matches reports whether request matches the rule: the rule is enabled and
+// active at the request time, and the request matches MatchURL and every
+// criteria
+func (condition ruleCondition) matches(request *TestRequest) (bool, error) {
+	if condition.disabled {
+		return false, nil
+	}
+
+	at := request.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if condition.start > 0 && at.Unix() < condition.start {
+		return false, nil
+	}
+	if condition.end > 0 && at.Unix() >= condition.end {
+		return false, nil
+	}
+
+	if condition.matchURL != "" {
+		requestURL := request.URL.Host + request.URL.EscapedPath()
+		if request.URL.RawQuery != "" {
+			requestURL += "?" + request.URL.RawQuery
+		}
+		pattern := strings.TrimPrefix(strings.TrimPrefix(condition.matchURL, "https://"), "http://")
+		if !wildcardMatch(pattern, requestURL, true) {
+			return false, nil
+		}
+	}
+
+	for _, criteria := range condition.criteria {
+		ok, err := criteria.evaluate(request)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// evaluate reports whether request matches the criteria
+func (criteria MatchCriteria) evaluate(request *TestRequest) (bool, error) {
+	ok, err := criteria.evaluateValue(request)
+	if err != nil {
+		return false, err
+	}
+
+	return ok != criteria.Negate, nil
+}
+
+func (criteria MatchCriteria) evaluateValue(request *TestRequest) (bool, error) {
+	switch criteria.MatchType {
+	case "hostname":
+		return criteria.matchValues([]string{request.URL.Hostname()}), nil
+	case "path":
+		return criteria.matchValues([]string{request.URL.Path}), nil
+	case "extension":
+		return criteria.matchValues([]string{strings.TrimPrefix(path.Ext(request.URL.Path), ".")}), nil
+	case "protocol":
+		return criteria.matchValues([]string{request.URL.Scheme}), nil
+	case "method":
+		return criteria.matchValues([]string{request.Method}), nil
+	case "query":
+		name, value := criteria.nameAndValue()
+		values, present := request.URL.Query()[name]
+		return criteria.matchNamed(values, present, value), nil
+	case "header":
+		name, value := criteria.nameAndValue()
+		values, present := request.Header[http.CanonicalHeaderKey(name)]
+		return criteria.matchNamed(values, present, value), nil
+	case "cookie":
+		name, value := criteria.nameAndValue()
+		cookie, present := request.Cookies[name]
+		return criteria.matchNamed([]string{cookie}, present, value), nil
+	case "regex":
+		pattern := criteria.MatchValue
+		if !criteria.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex %q: %w", criteria.MatchValue, err)
+		}
+		return re.MatchString(request.URL.String()), nil
+	case "clientip":
+		return criteria.matchClientIP(request)
+	case "range":
+		return criteria.matchRange(request)
+	}
+
+	return false, fmt.Errorf("match type %q cannot be evaluated locally", criteria.MatchType)
+}
+
+// nameAndValue returns the name and expected value of a query, header or cookie
+// criteria, from ObjectMatchValue or from a "name=value" MatchValue
+func (criteria MatchCriteria) nameAndValue() (string, string) {
+	if criteria.ObjectMatchValue != nil {
+		value := ""
+		switch v := criteria.ObjectMatchValue.Value.(type) {
+		case string:
+			value = v
+		case []string:
+			value = strings.Join(v, " ")
+		case []interface{}:
+			values := make([]string, 0, len(v))
+			for _, item := range v {
+				values = append(values, fmt.Sprint(item))
+			}
+			value = strings.Join(values, " ")
+		}
+		return criteria.ObjectMatchValue.Name, value
+	}
+
+	parts := strings.SplitN(criteria.MatchValue, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// matchNamed matches the values of a named query, header or cookie
+func (criteria MatchCriteria) matchNamed(values []string, present bool, expected string) bool {
+	if criteria.MatchOperator == "exists" || expected == "" {
+		return present
+	}
+	if !present {
+		return false
+	}
+
+	return matchAny(criteria.MatchOperator, strings.Fields(expected), values, criteria.CaseSensitive)
+}
+
+// matchValues matches actual against the space separated values of MatchValue
+func (criteria MatchCriteria) matchValues(actual []string) bool {
+	expected := strings.Fields(criteria.MatchValue)
+	if criteria.ObjectMatchValue != nil {
+		_, value := criteria.nameAndValue()
+		expected = strings.Fields(value)
+	}
+	if criteria.MatchOperator == "exists" {
+		return len(actual) > 0 && actual[0] != ""
+	}
+
+	return matchAny(criteria.MatchOperator, expected, actual, criteria.CaseSensitive)
+}
+
+func matchAny(operator string, expected []string, actual []string, caseSensitive bool) bool {
+	for _, a := range actual {
+		for _, e := range expected {
+			switch operator {
+			case "contains":
+				if !caseSensitive {
+					a, e = strings.ToLower(a), strings.ToLower(e)
+				}
+				if strings.Contains(a, e) {
+					return true
+				}
+			default:
+				if wildcardMatch(e, a, caseSensitive) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// wildcardMatch matches value against pattern, where * matches any sequence
+// of characters and ? a single character
+func wildcardMatch(pattern string, value string, caseSensitive bool) bool {
+	if !caseSensitive {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()).MatchString(value)
+}
+
+// matchClientIP matches the connecting IP, and the X-Forwarded-For IPs when
+// CheckIPs includes them, against the IPs and CIDR blocks of MatchValue
+func (criteria MatchCriteria) matchClientIP(request *TestRequest) (bool, error) {
+	var ips []net.IP
+	if criteria.CheckIPs != "XFF_HEADERS" && request.ClientIP != nil {
+		ips = append(ips, request.ClientIP)
+	}
+	if strings.Contains(criteria.CheckIPs, "XFF_HEADERS") {
+		for _, header := range request.Header.Values("X-Forwarded-For") {
+			for _, field := range strings.Split(header, ",") {
+				if ip := net.ParseIP(strings.TrimSpace(field)); ip != nil {
+					ips = append(ips, ip)
+				}
+			}
+		}
+	}
+
+	for _, value := range strings.Fields(strings.Replace(criteria.MatchValue, ",", " ", -1)) {
+		if !strings.Contains(value, "/") {
+			if strings.Contains(value, ":") {
+				value += "/128"
+			} else {
+				value += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid IP %q: %w", value, err)
+		}
+		for _, ip := range ips {
+			if network.Contains(ip) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// matchRange matches the bucket of the request against a range ObjectMatchValue
+func (criteria MatchCriteria) matchRange(request *TestRequest) (bool, error) {
+	if criteria.ObjectMatchValue == nil {
+		return false, fmt.Errorf("range match has no objectMatchValue")
+	}
+
+	var bounds []int
+	switch v := criteria.ObjectMatchValue.Value.(type) {
+	case []int:
+		bounds = v
+	case []interface{}:
+		for _, item := range v {
+			bound, err := strconv.Atoi(fmt.Sprint(item))
+			if err != nil {
+				return false, fmt.Errorf("invalid range bound %v", item)
+			}
+			bounds = append(bounds, bound)
+		}
+	}
+	if len(bounds) != 2 {
+		return false, fmt.Errorf("range match needs a start and an end, got %v", criteria.ObjectMatchValue.Value)
+	}
+
+	return request.Bucket >= bounds[0] && request.Bucket <= bounds[1], nil
+}
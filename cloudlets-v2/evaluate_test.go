@@ -0,0 +1,224 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRequest(t *testing.T, rawURL string) *TestRequest {
+	request, err := NewTestRequest(rawURL)
+	require.NoError(t, err)
+	return request
+}
+
+func TestEvaluateER(t *testing.T) {
+	rules := []MatchRuleER{
+		{
+			Type:     MatchRuleTypeER,
+			Name:     "disabled",
+			Disabled: true,
+			MatchURL: "*",
+		},
+		{
+			Type: MatchRuleTypeER,
+			Name: "old images",
+			Matches: []MatchCriteria{
+				{MatchType: "path", MatchValue: "/images/* /img/*"},
+				{MatchType: "extension", MatchValue: "gif", Negate: true},
+			},
+			RedirectURL:            "https://cdn.example.com/images",
+			StatusCode:             301,
+			UseIncomingQueryString: true,
+		},
+		{
+			Type:        MatchRuleTypeER,
+			Name:        "blog",
+			MatchURL:    "www.example.com/blog/*",
+			RedirectURL: "https://blog.example.com/",
+			StatusCode:  302,
+		},
+	}
+
+	evaluation, err := EvaluateER(rules, testRequest(t, "https://www.example.com/img/logo.png?v=2"))
+	require.NoError(t, err)
+	assert.Equal(t, &Evaluation{
+		Matched:     true,
+		RuleIndex:   1,
+		RuleName:    "old images",
+		Action:      ActionRedirect,
+		RedirectURL: "https://cdn.example.com/images?v=2",
+		StatusCode:  301,
+	}, evaluation)
+
+	evaluation, err = EvaluateER(rules, testRequest(t, "https://www.example.com/img/logo.gif"))
+	require.NoError(t, err)
+	assert.False(t, evaluation.Matched)
+	assert.Equal(t, -1, evaluation.RuleIndex)
+	assert.Equal(t, ActionNone, evaluation.Action)
+
+	evaluation, err = EvaluateER(rules, testRequest(t, "http://www.example.com/blog/post"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, evaluation.RuleIndex)
+	assert.Equal(t, "https://blog.example.com/", evaluation.RedirectURL)
+}
+
+func TestEvaluateERSchedule(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	rules := []MatchRuleER{{
+		Type:        MatchRuleTypeER,
+		Start:       start.Unix(),
+		End:         start.Add(24 * time.Hour).Unix(),
+		RedirectURL: "/sale",
+		StatusCode:  302,
+	}}
+
+	request := testRequest(t, "https://www.example.com/")
+	request.Time = start.Add(-time.Minute)
+	evaluation, err := EvaluateER(rules, request)
+	require.NoError(t, err)
+	assert.False(t, evaluation.Matched)
+
+	request.Time = start.Add(time.Hour)
+	evaluation, err = EvaluateER(rules, request)
+	require.NoError(t, err)
+	assert.True(t, evaluation.Matched)
+
+	request.Time = start.Add(24 * time.Hour)
+	evaluation, err = EvaluateER(rules, request)
+	require.NoError(t, err)
+	assert.False(t, evaluation.Matched)
+}
+
+func TestEvaluateNoURL(t *testing.T) {
+	rules := []MatchRuleER{{Type: MatchRuleTypeER, MatchURL: "*", RedirectURL: "/sale", StatusCode: 302}}
+
+	_, err := EvaluateER(rules, &TestRequest{Method: "GET", Bucket: 1})
+	assert.Error(t, err)
+	_, err = EvaluateCD(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestEvaluateFR(t *testing.T) {
+	var rules []MatchRuleFR
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{
+			"type": "frMatchRule",
+			"name": "mobile",
+			"matches": [
+				{"matchType": "header", "objectMatchValue": {"type": "simple", "name": "X-Device", "value": ["mobile", "tablet"]}},
+				{"matchType": "cookie", "matchValue": "beta=true", "caseSensitive": true}
+			],
+			"forwardSettings": {"originId": "mobile", "pathAndQS": "/m/index.html", "useIncomingQueryString": true}
+		},
+		{
+			"type": "frMatchRule",
+			"name": "api",
+			"matches": [{"matchType": "query", "matchValue": "format", "matchOperator": "exists"}],
+			"forwardSettings": {"pathAndQS": "/api"}
+		}
+	]`), &rules))
+
+	request := testRequest(t, "https://www.example.com/?lang=en")
+	request.Header.Set("x-device", "Tablet")
+	request.Cookies["beta"] = "true"
+	evaluation, err := EvaluateFR(rules, request)
+	require.NoError(t, err)
+	assert.Equal(t, &Evaluation{
+		Matched:   true,
+		RuleIndex: 0,
+		RuleName:  "mobile",
+		Action:    ActionForward,
+		OriginID:  "mobile",
+		PathAndQS: "/m/index.html?lang=en",
+	}, evaluation)
+
+	request.Cookies["beta"] = "TRUE"
+	evaluation, err = EvaluateFR(rules, request)
+	require.NoError(t, err)
+	assert.False(t, evaluation.Matched)
+
+	request = testRequest(t, "https://www.example.com/?format=json")
+	evaluation, err = EvaluateFR(rules, request)
+	require.NoError(t, err)
+	assert.Equal(t, 1, evaluation.RuleIndex)
+	assert.Equal(t, "/api", evaluation.PathAndQS)
+}
+
+func TestEvaluateCD(t *testing.T) {
+	rules := []MatchRuleCD{{
+		Type: MatchRuleTypeCD,
+		Name: "canary",
+		Matches: []MatchCriteria{
+			{MatchType: "clientip", MatchValue: "10.0.0.0/8 192.0.2.1", CheckIPs: "CONNECTING_IP XFF_HEADERS"},
+		},
+		ForwardSettings: ForwardSettingsCD{OriginID: "canary", Percent: 10},
+	}}
+
+	request := testRequest(t, "https://www.example.com/")
+	request.ClientIP = net.ParseIP("203.0.113.5")
+	request.Header.Set("X-Forwarded-For", "198.51.100.7, 10.1.2.3")
+	request.Bucket = 10
+	evaluation, err := EvaluateCD(rules, request)
+	require.NoError(t, err)
+	assert.Equal(t, ActionForward, evaluation.Action)
+	assert.Equal(t, "canary", evaluation.OriginID)
+
+	request.Bucket = 11
+	evaluation, err = EvaluateCD(rules, request)
+	require.NoError(t, err)
+	assert.True(t, evaluation.Matched)
+	assert.Equal(t, ActionNone, evaluation.Action)
+
+	request.Header.Del("X-Forwarded-For")
+	request.Bucket = 1
+	evaluation, err = EvaluateCD(rules, request)
+	require.NoError(t, err)
+	assert.False(t, evaluation.Matched)
+}
+
+func TestEvaluateAS(t *testing.T) {
+	rules, err := AudienceSegmentationRules([]Split{
+		{OriginID: "origin_b", Percent: 10},
+		{Name: "beta", OriginID: "origin_c", Percent: 5},
+	})
+	require.NoError(t, err)
+
+	// Round trip through JSON, as rules read from a policy version
+	body, err := json.Marshal(rules)
+	require.NoError(t, err)
+	var decoded []MatchRuleAS
+	require.NoError(t, json.Unmarshal(body, &decoded))
+
+	for _, tt := range []struct {
+		bucket   int
+		originID string
+	}{
+		{1, "origin_b"},
+		{10, "origin_b"},
+		{11, "origin_c"},
+		{15, "origin_c"},
+		{16, ""},
+	} {
+		request := testRequest(t, "https://www.example.com/")
+		request.Bucket = tt.bucket
+
+		evaluation, err := EvaluateAS(decoded, request)
+		require.NoError(t, err)
+		assert.Equal(t, tt.originID, evaluation.OriginID, "bucket %d", tt.bucket)
+	}
+}
+
+func TestEvaluateUnsupported(t *testing.T) {
+	rules := []MatchRuleER{{
+		Type:    MatchRuleTypeER,
+		Matches: []MatchCriteria{{MatchType: "geo", MatchValue: "US"}},
+	}}
+
+	_, err := EvaluateER(rules, testRequest(t, "https://www.example.com/"))
+	assert.EqualError(t, err, `rule 0: match type "geo" cannot be evaluated locally`)
+}
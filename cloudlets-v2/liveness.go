@@ -0,0 +1,163 @@
+package cloudlets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LivenessProtocol is used to create an "enum" of possible LivenessSettings.Protocol values
+type LivenessProtocol string
+
+const (
+	// LivenessProtocolHTTP LivenessSettings.Protocol value HTTP
+	LivenessProtocolHTTP LivenessProtocol = "HTTP"
+	// LivenessProtocolHTTPS LivenessSettings.Protocol value HTTPS
+	LivenessProtocolHTTPS LivenessProtocol = "HTTPS"
+	// LivenessProtocolTCP LivenessSettings.Protocol value TCP
+	LivenessProtocolTCP LivenessProtocol = "TCP"
+	// LivenessProtocolTCPS LivenessSettings.Protocol value TCPS
+	LivenessProtocolTCPS LivenessProtocol = "TCPS"
+)
+
+// Limits of the liveness test interval and timeout, in seconds
+const (
+	MinLivenessInterval = 10
+	MaxLivenessInterval = 3600
+	MaxLivenessTimeout  = 60
+)
+
+// LivenessSettings is the liveness test of the data centers of an
+// Application Load Balancer origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#livenesssettings
+type LivenessSettings struct {
+	HostHeader        string            `json:"hostHeader,omitempty"`
+	AdditionalHeaders map[string]string `json:"additionalHeaders,omitempty"`
+	// Interval between tests, in seconds
+	Interval int `json:"interval"`
+	// Path is requested by HTTP and HTTPS tests
+	Path     string           `json:"path,omitempty"`
+	Port     int              `json:"port"`
+	Protocol LivenessProtocol `json:"protocol"`
+	// RequestString and ResponseString are sent and expected by TCP and TCPS tests
+	RequestString    string `json:"requestString,omitempty"`
+	ResponseString   string `json:"responseString,omitempty"`
+	Status3xxFailure bool   `json:"status3xxFailure"`
+	Status4xxFailure bool   `json:"status4xxFailure"`
+	Status5xxFailure bool   `json:"status5xxFailure"`
+	// Timeout of a test, in seconds
+	Timeout float64 `json:"timeout"`
+}
+
+// HTTPLivenessTest is a template for an HTTP test of path on port 80, failing on 5xx responses
+func HTTPLivenessTest(path string) LivenessSettings {
+	return LivenessSettings{
+		Protocol:         LivenessProtocolHTTP,
+		Port:             80,
+		Path:             path,
+		Interval:         60,
+		Timeout:          10,
+		Status5xxFailure: true,
+	}
+}
+
+// HTTPSLivenessTest is a template for an HTTPS test of path on port 443 with
+// hostHeader (used for SNI and Host), failing on 4xx and 5xx responses
+func HTTPSLivenessTest(path, hostHeader string) LivenessSettings {
+	return LivenessSettings{
+		Protocol:         LivenessProtocolHTTPS,
+		Port:             443,
+		Path:             path,
+		HostHeader:       hostHeader,
+		Interval:         60,
+		Timeout:          10,
+		Status4xxFailure: true,
+		Status5xxFailure: true,
+	}
+}
+
+// TCPLivenessTest is a template for a TCP connect test on port
+func TCPLivenessTest(port int) LivenessSettings {
+	return LivenessSettings{
+		Protocol: LivenessProtocolTCP,
+		Port:     port,
+		Interval: 60,
+		Timeout:  10,
+	}
+}
+
+// LivenessValidationError lists the problems found by LivenessSettings.Validate()
+type LivenessValidationError struct {
+	Problems []string
+}
+
+func (e *LivenessValidationError) Error() string {
+	return "invalid liveness settings: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the settings are consistent, returning a
+// *LivenessValidationError listing every problem found
+//
+// Besides the API limits, this rejects tests that are accepted but fail
+// against most origins, e.g. HTTP on port 443 or a timeout longer than the
+// interval, which take data centers out of rotation.
+func (settings *LivenessSettings) Validate() error {
+	problems := []string{}
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	httpTest := false
+	switch settings.Protocol {
+	case LivenessProtocolHTTP, LivenessProtocolHTTPS:
+		httpTest = true
+	case LivenessProtocolTCP, LivenessProtocolTCPS:
+	default:
+		add("protocol must be one of HTTP, HTTPS, TCP or TCPS, got %q", settings.Protocol)
+	}
+
+	if settings.Port < 1 || settings.Port > 65535 {
+		add("port must be between 1 and 65535, got %d", settings.Port)
+	}
+	switch {
+	case settings.Port == 443 && (settings.Protocol == LivenessProtocolHTTP || settings.Protocol == LivenessProtocolTCP):
+		add("port 443 is usually TLS, use %sS", settings.Protocol)
+	case settings.Port == 80 && (settings.Protocol == LivenessProtocolHTTPS || settings.Protocol == LivenessProtocolTCPS):
+		add("port 80 is usually not TLS, use %s", strings.TrimSuffix(string(settings.Protocol), "S"))
+	}
+
+	if httpTest {
+		if !strings.HasPrefix(settings.Path, "/") {
+			add("path must start with /, got %q", settings.Path)
+		}
+		if settings.RequestString != "" || settings.ResponseString != "" {
+			add("requestString and responseString are only used by TCP tests")
+		}
+	} else {
+		if settings.Path != "" {
+			add("path is only used by HTTP tests")
+		}
+		if settings.Status3xxFailure || settings.Status4xxFailure || settings.Status5xxFailure {
+			add("status failures are only used by HTTP tests")
+		}
+		if settings.ResponseString != "" && settings.RequestString == "" {
+			add("responseString requires a requestString")
+		}
+	}
+
+	if settings.Interval < MinLivenessInterval || settings.Interval > MaxLivenessInterval {
+		add("interval must be between %d and %d seconds, got %d", MinLivenessInterval, MaxLivenessInterval, settings.Interval)
+	}
+	switch {
+	case settings.Timeout <= 0 || settings.Timeout > MaxLivenessTimeout:
+		add("timeout must be between 0 and %d seconds, got %g", MaxLivenessTimeout, settings.Timeout)
+	case settings.Timeout >= float64(settings.Interval):
+		add("timeout (%gs) must be shorter than the interval (%ds)", settings.Timeout, settings.Interval)
+	}
+
+	if len(problems) > 0 {
+		return &LivenessValidationError{Problems: problems}
+	}
+
+	return nil
+}
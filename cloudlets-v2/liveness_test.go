@@ -0,0 +1,45 @@
+package cloudlets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivenessTemplates(t *testing.T) {
+	for _, settings := range []LivenessSettings{
+		HTTPLivenessTest("/health"),
+		HTTPSLivenessTest("/health", "origin.example.com"),
+		TCPLivenessTest(8443),
+	} {
+		assert.NoError(t, settings.Validate(), settings.Protocol)
+	}
+}
+
+func TestLivenessSettings_Validate(t *testing.T) {
+	settings := HTTPLivenessTest("health")
+	settings.Port = 443
+	settings.Timeout = 90
+	settings.RequestString = "PING"
+
+	err := settings.Validate()
+	require.IsType(t, &LivenessValidationError{}, err)
+	assert.Equal(t, []string{
+		"port 443 is usually TLS, use HTTPS",
+		`path must start with /, got "health"`,
+		"requestString and responseString are only used by TCP tests",
+		"timeout must be between 0 and 60 seconds, got 90",
+	}, err.(*LivenessValidationError).Problems)
+
+	settings = TCPLivenessTest(80)
+	settings.Interval = 10
+	settings.Timeout = 10
+	settings.Status5xxFailure = true
+	err = settings.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"status failures are only used by HTTP tests",
+		"timeout (10s) must be shorter than the interval (10s)",
+	}, err.(*LivenessValidationError).Problems)
+}
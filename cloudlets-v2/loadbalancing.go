@@ -0,0 +1,287 @@
+package cloudlets
+
+import (
+	"fmt"
+)
+
+// OriginType is used to create an "enum" of possible Origin.Type values
+type OriginType string
+
+const (
+	// OriginTypeApplicationLoadBalancer Origin.Type value APPLICATION_LOAD_BALANCER
+	OriginTypeApplicationLoadBalancer OriginType = "APPLICATION_LOAD_BALANCER"
+	// OriginTypeCustomer Origin.Type value CUSTOMER
+	OriginTypeCustomer OriginType = "CUSTOMER"
+	// OriginTypeNetStorage Origin.Type value NETSTORAGE
+	OriginTypeNetStorage OriginType = "NETSTORAGE"
+)
+
+// BalancingType is used to create an "enum" of possible LoadBalancerVersion.BalancingType values
+type BalancingType string
+
+const (
+	// BalancingTypeWeighted LoadBalancerVersion.BalancingType value WEIGHTED
+	BalancingTypeWeighted BalancingType = "WEIGHTED"
+	// BalancingTypePerformance LoadBalancerVersion.BalancingType value PERFORMANCE
+	BalancingTypePerformance BalancingType = "PERFORMANCE"
+)
+
+// Origin is a conditional origin, the load balancing origins are the ones of
+// type OriginTypeApplicationLoadBalancer
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#origin
+type Origin struct {
+	OriginID    string     `json:"originId"`
+	Description string     `json:"description,omitempty"`
+	Akamaized   bool       `json:"akamaized,omitempty"`
+	Checksum    string     `json:"checksum,omitempty"`
+	Type        OriginType `json:"type,omitempty"`
+}
+
+// LoadBalancerVersion is a version of the data centers and liveness test of a
+// load balancing origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#loadbalancerversion
+type LoadBalancerVersion struct {
+	OriginID         string            `json:"originId,omitempty"`
+	Version          int64             `json:"version,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	BalancingType    BalancingType     `json:"balancingType,omitempty"`
+	DataCenters      []DataCenter      `json:"dataCenters"`
+	LivenessSettings *LivenessSettings `json:"livenessSettings,omitempty"`
+	Deleted          bool              `json:"deleted,omitempty"`
+	Immutable        bool              `json:"immutable,omitempty"`
+	Warnings         []Warning         `json:"warnings,omitempty"`
+	CreatedBy        string            `json:"createdBy,omitempty"`
+	CreatedDate      string            `json:"createdDate,omitempty"`
+	LastModifiedBy   string            `json:"lastModifiedBy,omitempty"`
+	LastModifiedDate string            `json:"lastModifiedDate,omitempty"`
+}
+
+// DataCenter is a data center of a LoadBalancerVersion and the share of
+// traffic it gets
+type DataCenter struct {
+	OriginID                      string  `json:"originId"`
+	Percent                       float64 `json:"percent"`
+	Hostname                      string  `json:"hostname,omitempty"`
+	CloudService                  bool    `json:"cloudService"`
+	CloudServerHostHeaderOverride bool    `json:"cloudServerHostHeaderOverride,omitempty"`
+	ContinentCode                 string  `json:"continent"`
+	Country                       string  `json:"country"`
+	StateOrProvince               string  `json:"stateOrProvince,omitempty"`
+	City                          string  `json:"city"`
+	Latitude                      float64 `json:"latitude"`
+	Longitude                     float64 `json:"longitude"`
+}
+
+// LoadBalancerActivation is the activation of a LoadBalancerVersion on a network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#loadbalanceractivation
+type LoadBalancerActivation struct {
+	OriginID    string       `json:"originId,omitempty"`
+	Version     int64        `json:"version"`
+	Network     NetworkValue `json:"network"`
+	Status      string       `json:"status,omitempty"`
+	Dryrun      bool         `json:"dryrun,omitempty"`
+	ActivatedBy string       `json:"activatedBy,omitempty"`
+	// ActivatedDate is an ISO 8601 date
+	ActivatedDate string `json:"activatedDate,omitempty"`
+}
+
+// ListOrigins lists the conditional origins, of type originType only when not empty
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getorigins
+// Endpoint: GET /cloudlets/api/v2/origins{?type}
+func ListOrigins(originType OriginType) ([]Origin, error) {
+	path := "/cloudlets/api/v2/origins"
+	if originType != "" {
+		path += "?type=" + string(originType)
+	}
+
+	var origins []Origin
+	if err := doJSON("GET", path, nil, &origins); err != nil {
+		return nil, err
+	}
+
+	return origins, nil
+}
+
+// GetOrigin retrieves a conditional origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getorigin
+// Endpoint: GET /cloudlets/api/v2/origins/{originId}
+func GetOrigin(originID string) (*Origin, error) {
+	origin := &Origin{}
+	if err := doJSON("GET", originPath(originID), nil, origin); err != nil {
+		return nil, err
+	}
+
+	return origin, nil
+}
+
+// CreateLoadBalancer creates a load balancing origin, its data centers are set
+// by CreateLoadBalancerVersion
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#postloadbalancing
+// Endpoint: POST /cloudlets/api/v2/origins
+func CreateLoadBalancer(originID, description string) (*Origin, error) {
+	body := struct {
+		OriginID    string `json:"originId"`
+		Description string `json:"description,omitempty"`
+	}{originID, description}
+
+	origin := &Origin{}
+	if err := doJSON("POST", "/cloudlets/api/v2/origins", body, origin); err != nil {
+		return nil, err
+	}
+
+	return origin, nil
+}
+
+// UpdateLoadBalancer updates the description of a load balancing origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#putloadbalancing
+// Endpoint: PUT /cloudlets/api/v2/origins/{originId}
+func UpdateLoadBalancer(originID, description string) (*Origin, error) {
+	body := struct {
+		Description string `json:"description"`
+	}{description}
+
+	origin := &Origin{}
+	if err := doJSON("PUT", originPath(originID), body, origin); err != nil {
+		return nil, err
+	}
+
+	return origin, nil
+}
+
+// ListLoadBalancerVersions lists the versions of a load balancing origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getloadbalancingversions
+// Endpoint: GET /cloudlets/api/v2/origins/{originId}/versions{?includeModel}
+func ListLoadBalancerVersions(originID string) ([]LoadBalancerVersion, error) {
+	var versions []LoadBalancerVersion
+	if err := doJSON("GET", originPath(originID)+"/versions?includeModel=true", nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetLoadBalancerVersion retrieves a version of a load balancing origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getloadbalancingversion
+// Endpoint: GET /cloudlets/api/v2/origins/{originId}/versions/{version}{?validate}
+func GetLoadBalancerVersion(originID string, version int64) (*LoadBalancerVersion, error) {
+	balancerVersion := &LoadBalancerVersion{}
+	if err := doJSON("GET", loadBalancerVersionPath(originID, version), nil, balancerVersion); err != nil {
+		return nil, err
+	}
+
+	return balancerVersion, nil
+}
+
+// CreateLoadBalancerVersion creates a version of a load balancing origin. The
+// liveness settings, if any, are checked with LivenessSettings.Validate() first.
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#postloadbalancingversions
+// Endpoint: POST /cloudlets/api/v2/origins/{originId}/versions
+func CreateLoadBalancerVersion(originID string, balancerVersion *LoadBalancerVersion) (*LoadBalancerVersion, error) {
+	if err := balancerVersion.validate(); err != nil {
+		return nil, err
+	}
+
+	created := &LoadBalancerVersion{}
+	if err := doJSON("POST", originPath(originID)+"/versions", balancerVersion.body(), created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateLoadBalancerVersion replaces a version of a load balancing origin that
+// was never activated
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#putloadbalancingversion
+// Endpoint: PUT /cloudlets/api/v2/origins/{originId}/versions/{version}
+func UpdateLoadBalancerVersion(balancerVersion *LoadBalancerVersion) (*LoadBalancerVersion, error) {
+	if err := balancerVersion.validate(); err != nil {
+		return nil, err
+	}
+
+	updated := &LoadBalancerVersion{}
+	path := loadBalancerVersionPath(balancerVersion.OriginID, balancerVersion.Version)
+	if err := doJSON("PUT", path, balancerVersion.body(), updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ActivateLoadBalancerVersion activates a version of a load balancing origin on network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#postloadbalancingactivations
+// Endpoint: POST /cloudlets/api/v2/origins/{originId}/activations
+func ActivateLoadBalancerVersion(originID string, version int64, network NetworkValue) (*LoadBalancerActivation, error) {
+	body := &LoadBalancerActivation{Version: version, Network: network}
+
+	activation := &LoadBalancerActivation{}
+	if err := doJSON("POST", originPath(originID)+"/activations", body, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// ListLoadBalancerActivations lists the activations of a load balancing origin
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getloadbalancingactivations
+// Endpoint: GET /cloudlets/api/v2/origins/{originId}/activations
+func ListLoadBalancerActivations(originID string) ([]LoadBalancerActivation, error) {
+	var activations []LoadBalancerActivation
+	if err := doJSON("GET", originPath(originID)+"/activations", nil, &activations); err != nil {
+		return nil, err
+	}
+
+	return activations, nil
+}
+
+func originPath(originID string) string {
+	return fmt.Sprintf("/cloudlets/api/v2/origins/%s", originID)
+}
+
+func loadBalancerVersionPath(originID string, version int64) string {
+	return fmt.Sprintf("%s/versions/%d", originPath(originID), version)
+}
+
+// validate checks the liveness settings and that the data center percentages
+// add up to 100
+func (balancerVersion *LoadBalancerVersion) validate() error {
+	if balancerVersion.LivenessSettings != nil {
+		if err := balancerVersion.LivenessSettings.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(balancerVersion.DataCenters) > 0 {
+		total := 0.0
+		for _, dataCenter := range balancerVersion.DataCenters {
+			total += dataCenter.Percent
+		}
+		if total < 99.99 || total > 100.01 {
+			return fmt.Errorf("data center percentages must add up to 100, got %g", total)
+		}
+	}
+
+	return nil
+}
+
+// body is balancerVersion without the read-only fields
+func (balancerVersion *LoadBalancerVersion) body() *LoadBalancerVersion {
+	return &LoadBalancerVersion{
+		Description:      balancerVersion.Description,
+		BalancingType:    balancerVersion.BalancingType,
+		DataCenters:      balancerVersion.DataCenters,
+		LivenessSettings: balancerVersion.LivenessSettings,
+	}
+}
@@ -0,0 +1,49 @@
+package cloudlets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateLoadBalancerVersion(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/cloudlets/api/v2/origins/api_lb/versions").
+		Reply(201).
+		JSON(`{"originId": "api_lb", "version": 2, "balancingType": "WEIGHTED", "dataCenters": [{"originId": "dc_east", "percent": 60}, {"originId": "dc_west", "percent": 40}]}`)
+	gock.New(baseURL).
+		Post("/cloudlets/api/v2/origins/api_lb/activations").
+		JSON(map[string]interface{}{"version": 2, "network": "staging"}).
+		Reply(200).
+		JSON(`{"originId": "api_lb", "version": 2, "network": "staging", "status": "active"}`)
+
+	Init(config)
+
+	liveness := HTTPSLivenessTest("/health", "api.example.com")
+	version, err := CreateLoadBalancerVersion("api_lb", &LoadBalancerVersion{
+		BalancingType: BalancingTypeWeighted,
+		DataCenters: []DataCenter{
+			{OriginID: "dc_east", Percent: 60},
+			{OriginID: "dc_west", Percent: 40},
+		},
+		LivenessSettings: &liveness,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version.Version)
+
+	activation, err := ActivateLoadBalancerVersion("api_lb", version.Version, NetworkStaging)
+	require.NoError(t, err)
+	assert.Equal(t, "active", activation.Status)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreateLoadBalancerVersionInvalid(t *testing.T) {
+	_, err := CreateLoadBalancerVersion("api_lb", &LoadBalancerVersion{
+		DataCenters: []DataCenter{{OriginID: "dc_east", Percent: 60}},
+	})
+	assert.EqualError(t, err, "data center percentages must add up to 100, got 60")
+}
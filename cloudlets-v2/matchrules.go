@@ -0,0 +1,269 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MatchRule is a match rule of any cloudlet, one of *MatchRuleALB,
+// *MatchRuleAS, *MatchRuleCD, *MatchRuleER, *MatchRuleFR, *MatchRuleVP or
+// *UnknownMatchRule
+type MatchRule interface {
+	cloudletType() string
+}
+
+// MatchRules is the list of match rules of a policy version, decoded to the
+// match rule type of each rule
+type MatchRules []MatchRule
+
+// MatchRuleALB is an Application Load Balancer cloudlet match rule
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#albmatchrule
+type MatchRuleALB struct {
+	Type            string             `json:"type"`
+	Name            string             `json:"name,omitempty"`
+	Start           int64              `json:"start,omitempty"`
+	End             int64              `json:"end,omitempty"`
+	ID              int64              `json:"id,omitempty"`
+	MatchURL        string             `json:"matchURL,omitempty"`
+	Matches         []MatchCriteria    `json:"matches,omitempty"`
+	MatchesAlways   bool               `json:"matchesAlways,omitempty"`
+	Disabled        bool               `json:"disabled,omitempty"`
+	ForwardSettings ForwardSettingsALB `json:"forwardSettings"`
+}
+
+// ForwardSettingsALB sends the matching requests to the load balancing origin OriginID
+type ForwardSettingsALB struct {
+	OriginID string `json:"originId"`
+}
+
+// MatchRuleVP is a Visitor Prioritization cloudlet match rule
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#vpmatchrule
+type MatchRuleVP struct {
+	Type     string          `json:"type"`
+	Name     string          `json:"name,omitempty"`
+	Start    int64           `json:"start,omitempty"`
+	End      int64           `json:"end,omitempty"`
+	ID       int64           `json:"id,omitempty"`
+	MatchURL string          `json:"matchURL,omitempty"`
+	Matches  []MatchCriteria `json:"matches,omitempty"`
+	Disabled bool            `json:"disabled,omitempty"`
+	// PassThroughPercent of the matching users reach the origin, the others
+	// get the waiting room. -1 sends every user to the waiting room.
+	PassThroughPercent float64 `json:"passThroughPercent"`
+}
+
+// MatchRuleCD is a Phased Release (continuous deployment) cloudlet match rule
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#cdmatchrule
+type MatchRuleCD struct {
+	Type            string            `json:"type"`
+	Name            string            `json:"name,omitempty"`
+	Start           int64             `json:"start,omitempty"`
+	End             int64             `json:"end,omitempty"`
+	ID              int64             `json:"id,omitempty"`
+	MatchURL        string            `json:"matchURL,omitempty"`
+	Matches         []MatchCriteria   `json:"matches,omitempty"`
+	Disabled        bool              `json:"disabled,omitempty"`
+	ForwardSettings ForwardSettingsCD `json:"forwardSettings"`
+}
+
+// ForwardSettingsCD sends Percent of the matching population to OriginID
+type ForwardSettingsCD struct {
+	OriginID string `json:"originId"`
+	Percent  int    `json:"percent"`
+}
+
+// MatchRuleAS is an Audience Segmentation cloudlet match rule
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#asmatchrule
+type MatchRuleAS struct {
+	Type            string            `json:"type"`
+	Name            string            `json:"name,omitempty"`
+	Start           int64             `json:"start,omitempty"`
+	End             int64             `json:"end,omitempty"`
+	ID              int64             `json:"id,omitempty"`
+	MatchURL        string            `json:"matchURL,omitempty"`
+	Matches         []MatchCriteria   `json:"matches,omitempty"`
+	Disabled        bool              `json:"disabled,omitempty"`
+	ForwardSettings ForwardSettingsAS `json:"forwardSettings"`
+}
+
+// ForwardSettingsAS sends the matching segment to OriginID
+type ForwardSettingsAS struct {
+	OriginID               string `json:"originId,omitempty"`
+	PathAndQS              string `json:"pathAndQS,omitempty"`
+	UseIncomingQueryString bool   `json:"useIncomingQueryString,omitempty"`
+}
+
+// MatchRuleER is an Edge Redirector cloudlet match rule
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#ermatchrule
+type MatchRuleER struct {
+	Type                   string          `json:"type"`
+	Name                   string          `json:"name,omitempty"`
+	Start                  int64           `json:"start,omitempty"`
+	End                    int64           `json:"end,omitempty"`
+	ID                     int64           `json:"id,omitempty"`
+	MatchURL               string          `json:"matchURL,omitempty"`
+	Matches                []MatchCriteria `json:"matches,omitempty"`
+	Disabled               bool            `json:"disabled,omitempty"`
+	RedirectURL            string          `json:"redirectURL"`
+	StatusCode             int             `json:"statusCode"`
+	UseIncomingQueryString bool            `json:"useIncomingQueryString"`
+	UseRelativeURL         string          `json:"useRelativeUrl,omitempty"`
+}
+
+// MatchRuleFR is a Forward Rewrite cloudlet match rule
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#frmatchrule
+type MatchRuleFR struct {
+	Type            string            `json:"type"`
+	Name            string            `json:"name,omitempty"`
+	Start           int64             `json:"start,omitempty"`
+	End             int64             `json:"end,omitempty"`
+	ID              int64             `json:"id,omitempty"`
+	MatchURL        string            `json:"matchURL,omitempty"`
+	Matches         []MatchCriteria   `json:"matches,omitempty"`
+	Disabled        bool              `json:"disabled,omitempty"`
+	ForwardSettings ForwardSettingsFR `json:"forwardSettings"`
+}
+
+// ForwardSettingsFR rewrites the forward path and query string, optionally to OriginID
+type ForwardSettingsFR struct {
+	OriginID               string `json:"originId,omitempty"`
+	PathAndQS              string `json:"pathAndQS,omitempty"`
+	UseIncomingQueryString bool   `json:"useIncomingQueryString,omitempty"`
+}
+
+// MatchCriteria is a single condition of a match rule
+type MatchCriteria struct {
+	MatchType        string            `json:"matchType,omitempty"`
+	MatchValue       string            `json:"matchValue,omitempty"`
+	MatchOperator    string            `json:"matchOperator,omitempty"`
+	CaseSensitive    bool              `json:"caseSensitive"`
+	Negate           bool              `json:"negate"`
+	CheckIPs         string            `json:"checkIPs,omitempty"`
+	ObjectMatchValue *ObjectMatchValue `json:"objectMatchValue,omitempty"`
+}
+
+// ObjectMatchValue is a structured match value, e.g. a range or a list of values
+type ObjectMatchValue struct {
+	Type  string      `json:"type"`
+	Name  string      `json:"name,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Match rule types
+const (
+	MatchRuleTypeCD  = "cdMatchRule"
+	MatchRuleTypeAS  = "asMatchRule"
+	MatchRuleTypeER  = "erMatchRule"
+	MatchRuleTypeFR  = "frMatchRule"
+	MatchRuleTypeALB = "albMatchRule"
+	MatchRuleTypeVP  = "vpMatchRule"
+)
+
+// UnknownMatchRule keeps a match rule of a cloudlet this package has no
+// struct for (e.g. Request Control or API Prioritization), so that it is sent
+// back unchanged
+type UnknownMatchRule struct {
+	Type string
+	JSON json.RawMessage
+}
+
+func (*MatchRuleALB) cloudletType() string { return MatchRuleTypeALB }
+func (*MatchRuleAS) cloudletType() string  { return MatchRuleTypeAS }
+func (*MatchRuleCD) cloudletType() string  { return MatchRuleTypeCD }
+func (*MatchRuleER) cloudletType() string  { return MatchRuleTypeER }
+func (*MatchRuleFR) cloudletType() string  { return MatchRuleTypeFR }
+func (*MatchRuleVP) cloudletType() string  { return MatchRuleTypeVP }
+
+func (rule *UnknownMatchRule) cloudletType() string {
+	return rule.Type
+}
+
+// MarshalJSON encodes the rules, setting the "type" of rules that have none
+func (rules MatchRules) MarshalJSON() ([]byte, error) {
+	encoded := make([]interface{}, len(rules))
+	for i, rule := range rules {
+		switch r := rule.(type) {
+		case *MatchRuleALB:
+			typed := *r
+			typed.Type = r.cloudletType()
+			encoded[i] = typed
+		case *MatchRuleAS:
+			typed := *r
+			typed.Type = r.cloudletType()
+			encoded[i] = typed
+		case *MatchRuleCD:
+			typed := *r
+			typed.Type = r.cloudletType()
+			encoded[i] = typed
+		case *MatchRuleER:
+			typed := *r
+			typed.Type = r.cloudletType()
+			encoded[i] = typed
+		case *MatchRuleFR:
+			typed := *r
+			typed.Type = r.cloudletType()
+			encoded[i] = typed
+		case *MatchRuleVP:
+			typed := *r
+			typed.Type = r.cloudletType()
+			encoded[i] = typed
+		case *UnknownMatchRule:
+			encoded[i] = r.JSON
+		default:
+			return nil, fmt.Errorf("match rule %d: unsupported type %T", i, rule)
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes each rule to the match rule type of its "type", an
+// UnknownMatchRule for the types this package has no struct for
+func (rules *MatchRules) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	decoded := make(MatchRules, 0, len(raw))
+	for i, item := range raw {
+		header := struct {
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(item, &header); err != nil {
+			return fmt.Errorf("match rule %d: %w", i, err)
+		}
+
+		var rule MatchRule
+		switch header.Type {
+		case MatchRuleTypeALB:
+			rule = &MatchRuleALB{}
+		case MatchRuleTypeAS:
+			rule = &MatchRuleAS{}
+		case MatchRuleTypeCD:
+			rule = &MatchRuleCD{}
+		case MatchRuleTypeER:
+			rule = &MatchRuleER{}
+		case MatchRuleTypeFR:
+			rule = &MatchRuleFR{}
+		case MatchRuleTypeVP:
+			rule = &MatchRuleVP{}
+		default:
+			decoded = append(decoded, &UnknownMatchRule{Type: header.Type, JSON: item})
+			continue
+		}
+		if err := json.Unmarshal(item, rule); err != nil {
+			return fmt.Errorf("match rule %d: %w", i, err)
+		}
+		decoded = append(decoded, rule)
+	}
+
+	*rules = decoded
+	return nil
+}
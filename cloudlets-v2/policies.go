@@ -0,0 +1,135 @@
+package cloudlets
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// Cloudlet IDs, as used by Policy.CloudletID
+const (
+	CloudletIDER  = 0
+	CloudletIDVP  = 1
+	CloudletIDFR  = 3
+	CloudletIDAS  = 4
+	CloudletIDCD  = 5
+	CloudletIDALB = 9
+)
+
+// Policy is a cloudlet policy
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#policy
+type Policy struct {
+	PolicyID         int64              `json:"policyId,omitempty"`
+	GroupID          int64              `json:"groupId"`
+	Name             string             `json:"name"`
+	Description      string             `json:"description,omitempty"`
+	CloudletID       int                `json:"cloudletId"`
+	CloudletCode     string             `json:"cloudletCode,omitempty"`
+	APIVersion       string             `json:"apiVersion,omitempty"`
+	PropertyName     string             `json:"propertyName,omitempty"`
+	Deleted          bool               `json:"deleted,omitempty"`
+	CreatedBy        string             `json:"createdBy,omitempty"`
+	CreateDate       int64              `json:"createDate,omitempty"`
+	LastModifiedBy   string             `json:"lastModifiedBy,omitempty"`
+	LastModifiedDate int64              `json:"lastModifiedDate,omitempty"`
+	Activations      []PolicyActivation `json:"activations,omitempty"`
+}
+
+// ListPoliciesOptions filters and pages ListPolicies
+type ListPoliciesOptions struct {
+	GroupID        int64 `query:"gid,omitempty"`
+	CloudletID     *int  `query:"cloudletId,omitempty"`
+	IncludeDeleted bool  `query:"includeDeleted,omitempty"`
+	Offset         int   `query:"offset,omitempty"`
+	PageSize       int   `query:"pageSize,omitempty"`
+}
+
+// ListPolicies lists the cloudlet policies, optionally filtered by group and cloudlet
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getpolicies
+// Endpoint: GET /cloudlets/api/v2/policies{?gid,includeDeleted,cloudletId,offset,pageSize}
+func ListPolicies(options ListPoliciesOptions) ([]Policy, error) {
+	path, err := client.PathWithQuery("/cloudlets/api/v2/policies", options)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []Policy
+	if err := doJSON("GET", path, nil, &policies); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// GetPolicy retrieves a cloudlet policy
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getpolicy
+// Endpoint: GET /cloudlets/api/v2/policies/{policyId}
+func GetPolicy(policyID int64) (*Policy, error) {
+	policy := &Policy{}
+	if err := doJSON("GET", policyPath(policyID), nil, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// CreatePolicy creates a cloudlet policy, ClonePolicyID copies the versions of
+// an existing policy when not 0
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#postpolicies
+// Endpoint: POST /cloudlets/api/v2/policies{?clonePolicyId}
+func CreatePolicy(policy *Policy, clonePolicyID int64) (*Policy, error) {
+	path := "/cloudlets/api/v2/policies"
+	if clonePolicyID != 0 {
+		path += "?clonePolicyId=" + strconv.FormatInt(clonePolicyID, 10)
+	}
+
+	body := struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		CloudletID  int    `json:"cloudletId"`
+		GroupID     int64  `json:"groupId"`
+	}{policy.Name, policy.Description, policy.CloudletID, policy.GroupID}
+
+	created := &Policy{}
+	if err := doJSON("POST", path, body, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdatePolicy updates the name, description and group of a cloudlet policy
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#putpolicy
+// Endpoint: PUT /cloudlets/api/v2/policies/{policyId}
+func UpdatePolicy(policy *Policy) (*Policy, error) {
+	body := struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		GroupID     int64  `json:"groupId,omitempty"`
+	}{policy.Name, policy.Description, policy.GroupID}
+
+	updated := &Policy{}
+	if err := doJSON("PUT", policyPath(policy.PolicyID), body, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemovePolicy deletes a cloudlet policy, which must not be active on any network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#deletepolicy
+// Endpoint: DELETE /cloudlets/api/v2/policies/{policyId}
+func RemovePolicy(policyID int64) error {
+	return doJSON("DELETE", policyPath(policyID), nil, nil)
+}
+
+func policyPath(policyID int64) string {
+	return fmt.Sprintf("/cloudlets/api/v2/policies/%d", policyID)
+}
@@ -0,0 +1,76 @@
+package cloudlets
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestListPolicies(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/cloudlets/api/v2/policies").
+		MatchParam("gid", "1234").
+		MatchParam("cloudletId", "0").
+		Reply(200).
+		JSON(`[{"policyId": 1001, "groupId": 1234, "name": "redirects", "cloudletId": 0, "cloudletCode": "ER"}]`)
+
+	Init(config)
+
+	cloudletID := CloudletIDER
+	policies, err := ListPolicies(ListPoliciesOptions{GroupID: 1234, CloudletID: &cloudletID})
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, int64(1001), policies[0].PolicyID)
+	assert.Equal(t, "ER", policies[0].CloudletCode)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreateAndActivatePolicy(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/cloudlets/api/v2/policies").
+		JSON(map[string]interface{}{"name": "redirects", "cloudletId": 0, "groupId": 1234}).
+		Reply(201).
+		JSON(`{"policyId": 1001, "groupId": 1234, "name": "redirects", "cloudletId": 0}`)
+	gock.New(baseURL).
+		Post("/cloudlets/api/v2/policies/1001/versions/1/activations").
+		JSON(map[string]interface{}{"network": "staging", "additionalPropertyNames": []string{"www.example.com"}}).
+		Reply(200).
+		JSON(`[{"network": "staging", "policyInfo": {"policyId": 1001, "version": 1, "status": "pending"}, "propertyInfo": {"name": "www.example.com", "status": "pending"}}]`)
+	gock.New(baseURL).
+		Delete("/cloudlets/api/v2/policies/1001").
+		Reply(204)
+
+	Init(config)
+
+	policy, err := CreatePolicy(&Policy{Name: "redirects", CloudletID: CloudletIDER, GroupID: 1234}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1001), policy.PolicyID)
+
+	activations, err := ActivatePolicyVersion(policy.PolicyID, 1, NetworkStaging, "www.example.com")
+	require.NoError(t, err)
+	require.Len(t, activations, 1)
+	assert.Equal(t, ActivationStatusPending, activations[0].PolicyInfo.Status)
+	assert.Equal(t, "www.example.com", activations[0].PropertyInfo.Name)
+
+	require.NoError(t, RemovePolicy(policy.PolicyID))
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,179 @@
+package cloudlets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Buckets is the number of population buckets the cloudlets divide users into
+//
+// Users are assigned a bucket from 1 to Buckets on their first request, and the
+// bucket is kept in the population cookie so the user stays in their segment.
+const Buckets = 100
+
+// BucketRange is an inclusive range of population buckets
+type BucketRange struct {
+	Start int
+	End   int
+}
+
+// Percent returns the share of the population in the range
+func (bucketRange BucketRange) Percent() int {
+	if bucketRange.End < bucketRange.Start {
+		return 0
+	}
+
+	return bucketRange.End - bucketRange.Start + 1
+}
+
+// BucketRanges assigns consecutive bucket ranges to the given percentages
+//
+// A zero percentage gets an empty range (Start > End). The percentages must not
+// add up to more than 100; the remainder stays on the default origin.
+func BucketRanges(percents ...int) ([]BucketRange, error) {
+	ranges := make([]BucketRange, len(percents))
+	next := 1
+	for i, percent := range percents {
+		if percent < 0 || percent > Buckets {
+			return nil, fmt.Errorf("percent must be between 0 and %d, got %d", Buckets, percent)
+		}
+		if next+percent-1 > Buckets {
+			return nil, errors.New("percentages add up to more than 100")
+		}
+		ranges[i] = BucketRange{Start: next, End: next + percent - 1}
+		next += percent
+	}
+
+	return ranges, nil
+}
+
+// Split sends Percent of the population to OriginID
+type Split struct {
+	Name     string
+	OriginID string
+	Percent  int
+}
+
+// AudienceSegmentationRules generates Audience Segmentation match rules for the given splits
+//
+// Each split gets a range match over its buckets, e.g. a single {"b", "origin_b", 10}
+// split sends buckets 1-10 (10%) to origin_b. Users outside every range stay on the
+// default origin. Additional criteria, e.g. a path match, are added to every rule.
+func AudienceSegmentationRules(splits []Split, criteria ...MatchCriteria) ([]MatchRuleAS, error) {
+	percents := make([]int, len(splits))
+	for i, split := range splits {
+		if split.OriginID == "" {
+			return nil, fmt.Errorf("split %q has no origin", split.Name)
+		}
+		percents[i] = split.Percent
+	}
+
+	ranges, err := BucketRanges(percents...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []MatchRuleAS
+	for i, split := range splits {
+		if ranges[i].Percent() == 0 {
+			continue
+		}
+
+		name := split.Name
+		if name == "" {
+			name = fmt.Sprintf("%d%% to %s", split.Percent, split.OriginID)
+		}
+
+		matches := []MatchCriteria{{
+			MatchType: "range",
+			ObjectMatchValue: &ObjectMatchValue{
+				Type:  "range",
+				Value: []int{ranges[i].Start, ranges[i].End},
+			},
+		}}
+		matches = append(matches, criteria...)
+
+		rules = append(rules, MatchRuleAS{
+			Type:            MatchRuleTypeAS,
+			Name:            name,
+			Matches:         matches,
+			ForwardSettings: ForwardSettingsAS{OriginID: split.OriginID},
+		})
+	}
+
+	return rules, nil
+}
+
+// PhasedReleaseRule generates a Phased Release match rule sending percent of the
+// matching population to originID, e.g. PhasedReleaseRule("canary", "origin_b", 10)
+func PhasedReleaseRule(name, originID string, percent int, criteria ...MatchCriteria) (*MatchRuleCD, error) {
+	if originID == "" {
+		return nil, errors.New("origin is required")
+	}
+	if percent < 0 || percent > Buckets {
+		return nil, fmt.Errorf("percent must be between 0 and %d, got %d", Buckets, percent)
+	}
+	if name == "" {
+		name = fmt.Sprintf("%d%% to %s", percent, originID)
+	}
+
+	return &MatchRuleCD{
+		Type:            MatchRuleTypeCD,
+		Name:            name,
+		Matches:         criteria,
+		ForwardSettings: ForwardSettingsCD{OriginID: originID, Percent: percent},
+	}, nil
+}
+
+// PopulationCookieType is used to create an "enum" of possible PopulationCookie.Type values
+type PopulationCookieType string
+
+const (
+	// PopulationCookieNone does not keep users in their bucket, every request is assigned anew
+	PopulationCookieNone PopulationCookieType = "NONE"
+	// PopulationCookieNever keeps users in their bucket indefinitely
+	PopulationCookieNever PopulationCookieType = "NEVER"
+	// PopulationCookieOnBrowserClose keeps users in their bucket for the browser session
+	PopulationCookieOnBrowserClose PopulationCookieType = "ON_BROWSER_CLOSE"
+	// PopulationCookieFixedDate keeps users in their bucket until ExpirationDate
+	PopulationCookieFixedDate PopulationCookieType = "FIXED_DATE"
+	// PopulationCookieDuration keeps users in their bucket for Duration
+	PopulationCookieDuration PopulationCookieType = "DURATION"
+)
+
+// PopulationCookie are the sticky cookie settings of the phasedRelease and
+// audienceSegmentation property behaviors
+type PopulationCookie struct {
+	Type           PopulationCookieType
+	Duration       time.Duration
+	ExpirationDate time.Time
+	// Refresh extends the cookie lifetime on every request
+	Refresh bool
+}
+
+// Options returns the cookie settings as property behavior options
+func (cookie PopulationCookie) Options() (map[string]interface{}, error) {
+	options := map[string]interface{}{
+		"populationCookieType": string(cookie.Type),
+	}
+
+	switch cookie.Type {
+	case PopulationCookieNone, PopulationCookieNever, PopulationCookieOnBrowserClose:
+	case PopulationCookieFixedDate:
+		if cookie.ExpirationDate.IsZero() {
+			return nil, errors.New("FIXED_DATE population cookie requires an expiration date")
+		}
+		options["populationExpirationDate"] = cookie.ExpirationDate.UTC().Format(time.RFC3339)
+	case PopulationCookieDuration:
+		if cookie.Duration < time.Second {
+			return nil, errors.New("DURATION population cookie requires a duration of at least one second")
+		}
+		options["populationDuration"] = fmt.Sprintf("%ds", int64(cookie.Duration/time.Second))
+		options["populationRefresh"] = cookie.Refresh
+	default:
+		return nil, fmt.Errorf("unknown population cookie type %q", cookie.Type)
+	}
+
+	return options, nil
+}
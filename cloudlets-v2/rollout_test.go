@@ -0,0 +1,69 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketRanges(t *testing.T) {
+	ranges, err := BucketRanges(10, 0, 25)
+	require.NoError(t, err)
+	assert.Equal(t, []BucketRange{{1, 10}, {11, 10}, {11, 35}}, ranges)
+	assert.Equal(t, 0, ranges[1].Percent())
+	assert.Equal(t, 25, ranges[2].Percent())
+
+	_, err = BucketRanges(60, 41)
+	assert.Error(t, err)
+	_, err = BucketRanges(-1)
+	assert.Error(t, err)
+}
+
+func TestAudienceSegmentationRules(t *testing.T) {
+	rules, err := AudienceSegmentationRules([]Split{
+		{OriginID: "origin_b", Percent: 10},
+		{Name: "beta", OriginID: "origin_c", Percent: 5},
+	})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+
+	assert.Equal(t, "10% to origin_b", rules[0].Name)
+	b, err := json.Marshal(rules[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "asMatchRule",
+		"name": "beta",
+		"matches": [{"matchType": "range", "caseSensitive": false, "negate": false, "objectMatchValue": {"type": "range", "value": [11, 15]}}],
+		"forwardSettings": {"originId": "origin_c"}
+	}`, string(b))
+
+	_, err = AudienceSegmentationRules([]Split{{Percent: 10}})
+	assert.Error(t, err)
+}
+
+func TestPhasedReleaseRule(t *testing.T) {
+	rule, err := PhasedReleaseRule("", "origin_b", 10)
+	require.NoError(t, err)
+	assert.Equal(t, MatchRuleTypeCD, rule.Type)
+	assert.Equal(t, "10% to origin_b", rule.Name)
+	assert.Equal(t, 10, rule.ForwardSettings.Percent)
+
+	_, err = PhasedReleaseRule("", "origin_b", 101)
+	assert.Error(t, err)
+}
+
+func TestPopulationCookieOptions(t *testing.T) {
+	options, err := PopulationCookie{Type: PopulationCookieDuration, Duration: 24 * time.Hour, Refresh: true}.Options()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"populationCookieType": "DURATION",
+		"populationDuration":   "86400s",
+		"populationRefresh":    true,
+	}, options)
+
+	_, err = PopulationCookie{Type: PopulationCookieFixedDate}.Options()
+	assert.Error(t, err)
+}
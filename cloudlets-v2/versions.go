@@ -0,0 +1,133 @@
+package cloudlets
+
+import (
+	"fmt"
+)
+
+// PolicyVersion is a version of a cloudlet policy and its match rules
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#policyversion
+type PolicyVersion struct {
+	Location         string             `json:"location,omitempty"`
+	RevisionID       int64              `json:"revisionId,omitempty"`
+	PolicyID         int64              `json:"policyId,omitempty"`
+	Version          int64              `json:"version,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	CreatedBy        string             `json:"createdBy,omitempty"`
+	CreateDate       int64              `json:"createDate,omitempty"`
+	LastModifiedBy   string             `json:"lastModifiedBy,omitempty"`
+	LastModifiedDate int64              `json:"lastModifiedDate,omitempty"`
+	RulesLocked      bool               `json:"rulesLocked,omitempty"`
+	Activations      []PolicyActivation `json:"activations,omitempty"`
+	MatchRuleFormat  string             `json:"matchRuleFormat,omitempty"`
+	MatchRules       MatchRules         `json:"matchRules"`
+	Deleted          bool               `json:"deleted,omitempty"`
+	Warnings         []Warning          `json:"warnings,omitempty"`
+}
+
+// Warning is a problem found in the match rules of a saved policy version
+type Warning struct {
+	Detail      string `json:"detail"`
+	JSONPointer string `json:"jsonPointer,omitempty"`
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+}
+
+// MatchRuleFormat10 is the current match rule format of policy versions
+const MatchRuleFormat10 = "1.0"
+
+// ListPolicyVersions lists the versions of a cloudlet policy, with their match
+// rules when includeRules is true
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getpolicyversions
+// Endpoint: GET /cloudlets/api/v2/policies/{policyId}/versions{?includeRules}
+func ListPolicyVersions(policyID int64, includeRules bool) ([]PolicyVersion, error) {
+	path := policyPath(policyID) + "/versions"
+	if includeRules {
+		path += "?includeRules=true"
+	}
+
+	var versions []PolicyVersion
+	if err := doJSON("GET", path, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetPolicyVersion retrieves a version of a cloudlet policy and its match rules
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#getpolicyversion
+// Endpoint: GET /cloudlets/api/v2/policies/{policyId}/versions/{version}
+func GetPolicyVersion(policyID, version int64) (*PolicyVersion, error) {
+	policyVersion := &PolicyVersion{}
+	if err := doJSON("GET", policyVersionPath(policyID, version), nil, policyVersion); err != nil {
+		return nil, err
+	}
+
+	return policyVersion, nil
+}
+
+// CreatePolicyVersion creates a version of a cloudlet policy with the
+// description and match rules of policyVersion, cloned from cloneVersion when
+// not 0. The API returns the match rule problems in PolicyVersion.Warnings.
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#postpolicyversions
+// Endpoint: POST /cloudlets/api/v2/policies/{policyId}/versions{?cloneVersion}
+func CreatePolicyVersion(policyID int64, policyVersion *PolicyVersion, cloneVersion int64) (*PolicyVersion, error) {
+	path := policyPath(policyID) + "/versions"
+	if cloneVersion != 0 {
+		path += fmt.Sprintf("?cloneVersion=%d", cloneVersion)
+	}
+
+	created := &PolicyVersion{}
+	if err := doJSON("POST", path, policyVersionBody(policyVersion), created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdatePolicyVersion replaces the description and match rules of a policy
+// version that was never activated
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#putpolicyversion
+// Endpoint: PUT /cloudlets/api/v2/policies/{policyId}/versions/{version}
+func UpdatePolicyVersion(policyVersion *PolicyVersion) (*PolicyVersion, error) {
+	updated := &PolicyVersion{}
+	path := policyVersionPath(policyVersion.PolicyID, policyVersion.Version)
+	if err := doJSON("PUT", path, policyVersionBody(policyVersion), updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemovePolicyVersion deletes a policy version that was never activated
+//
+// API Docs: https://developer.akamai.com/api/web_performance/cloudlets/v2.html#deletepolicyversion
+// Endpoint: DELETE /cloudlets/api/v2/policies/{policyId}/versions/{version}
+func RemovePolicyVersion(policyID, version int64) error {
+	return doJSON("DELETE", policyVersionPath(policyID, version), nil, nil)
+}
+
+func policyVersionPath(policyID, version int64) string {
+	return fmt.Sprintf("%s/versions/%d", policyPath(policyID), version)
+}
+
+func policyVersionBody(policyVersion *PolicyVersion) interface{} {
+	matchRuleFormat := policyVersion.MatchRuleFormat
+	if matchRuleFormat == "" && len(policyVersion.MatchRules) > 0 {
+		matchRuleFormat = MatchRuleFormat10
+	}
+	matchRules := policyVersion.MatchRules
+	if matchRules == nil {
+		matchRules = MatchRules{}
+	}
+
+	return struct {
+		Description     string     `json:"description,omitempty"`
+		MatchRuleFormat string     `json:"matchRuleFormat,omitempty"`
+		MatchRules      MatchRules `json:"matchRules"`
+	}{policyVersion.Description, matchRuleFormat, matchRules}
+}
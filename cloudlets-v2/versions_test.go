@@ -0,0 +1,86 @@
+package cloudlets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGetPolicyVersion(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/cloudlets/api/v2/policies/1001/versions/3").
+		Reply(200).
+		JSON(`{
+			"policyId": 1001,
+			"version": 3,
+			"matchRuleFormat": "1.0",
+			"matchRules": [
+				{"type": "erMatchRule", "name": "blog", "matchURL": "www.example.com/blog/*", "redirectURL": "https://blog.example.com/", "statusCode": 301},
+				{"type": "albMatchRule", "name": "api", "matchesAlways": true, "forwardSettings": {"originId": "api_lb"}}
+			]
+		}`)
+
+	Init(config)
+
+	version, err := GetPolicyVersion(1001, 3)
+	require.NoError(t, err)
+	require.Len(t, version.MatchRules, 2)
+
+	redirect, ok := version.MatchRules[0].(*MatchRuleER)
+	require.True(t, ok)
+	assert.Equal(t, 301, redirect.StatusCode)
+
+	balance, ok := version.MatchRules[1].(*MatchRuleALB)
+	require.True(t, ok)
+	assert.Equal(t, "api_lb", balance.ForwardSettings.OriginID)
+}
+
+func TestCreatePolicyVersion(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/cloudlets/api/v2/policies/1001/versions").
+		JSON(map[string]interface{}{
+			"description":     "waiting room",
+			"matchRuleFormat": "1.0",
+			"matchRules": []map[string]interface{}{{
+				"type":               "vpMatchRule",
+				"matches":            []map[string]interface{}{{"matchType": "path", "matchValue": "/checkout/*", "caseSensitive": false, "negate": false}},
+				"passThroughPercent": 80,
+			}},
+		}).
+		Reply(201).
+		JSON(`{"policyId": 1001, "version": 4, "matchRules": [], "warnings": [{"title": "Unused", "detail": "rule never matches", "type": "warning"}]}`)
+
+	Init(config)
+
+	version, err := CreatePolicyVersion(1001, &PolicyVersion{
+		Description: "waiting room",
+		MatchRules: MatchRules{&MatchRuleVP{
+			Matches:            []MatchCriteria{{MatchType: "path", MatchValue: "/checkout/*"}},
+			PassThroughPercent: 80,
+		}},
+	}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), version.Version)
+	assert.Len(t, version.Warnings, 1)
+	assert.True(t, gock.IsDone())
+}
+
+func TestMatchRulesUnknownType(t *testing.T) {
+	var rules MatchRules
+	require.NoError(t, rules.UnmarshalJSON([]byte(`[{"type": "igMatchRule", "name": "allow", "allowDeny": "allow"}]`)))
+	require.Len(t, rules, 1)
+
+	unknown, ok := rules[0].(*UnknownMatchRule)
+	require.True(t, ok)
+	assert.Equal(t, "igMatchRule", unknown.Type)
+
+	body, err := rules.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"type": "igMatchRule", "name": "allow", "allowDeny": "allow"}]`, string(body))
+}
@@ -0,0 +1,212 @@
+package cloudwrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is used to create an "enum" of possible Configuration.Status values
+type Status string
+
+const (
+	// StatusSaved Configuration.Status value SAVED, the configuration was never activated
+	StatusSaved Status = "SAVED"
+	// StatusInProgress Configuration.Status value IN_PROGRESS
+	StatusInProgress Status = "IN_PROGRESS"
+	// StatusActive Configuration.Status value ACTIVE
+	StatusActive Status = "ACTIVE"
+	// StatusFailed Configuration.Status value FAILED
+	StatusFailed Status = "FAILED"
+	// StatusDeleteInProgress Configuration.Status value DELETE_IN_PROGRESS
+	StatusDeleteInProgress Status = "DELETE_IN_PROGRESS"
+)
+
+var (
+	// ErrActivationFailed is returned by WaitForActivation for FAILED configurations
+	ErrActivationFailed = errors.New("cloud wrapper configuration activation failed")
+
+	// DefaultActivationInterval is the WaitForActivation polling interval used when none is given
+	DefaultActivationInterval = time.Minute
+)
+
+// Configuration offloads the origin traffic of properties to Cloud Wrapper
+// locations, each with the capacity it reserves
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-configuration
+type Configuration struct {
+	ConfigID                int64                   `json:"configId,omitempty"`
+	ConfigName              string                  `json:"configName"`
+	ContractID              string                  `json:"contractId"`
+	PropertyIDs             []string                `json:"propertyIds"`
+	Locations               []ConfigurationLocation `json:"locations"`
+	Comments                string                  `json:"comments"`
+	NotificationEmails      []string                `json:"notificationEmails,omitempty"`
+	RetainIdleObjects       bool                    `json:"retainIdleObjects"`
+	CapacityAlertsThreshold *int                    `json:"capacityAlertsThreshold,omitempty"`
+	Status                  Status                  `json:"status,omitempty"`
+	LastUpdatedBy           string                  `json:"lastUpdatedBy,omitempty"`
+	LastUpdatedDate         string                  `json:"lastUpdatedDate,omitempty"`
+	LastActivatedBy         string                  `json:"lastActivatedBy,omitempty"`
+	LastActivatedDate       string                  `json:"lastActivatedDate,omitempty"`
+}
+
+// ConfigurationLocation is the capacity a configuration reserves at a
+// location for a traffic type, see Location.TrafficTypeID
+type ConfigurationLocation struct {
+	TrafficTypeID int      `json:"trafficTypeId"`
+	Comments      string   `json:"comments"`
+	Capacity      Capacity `json:"capacity"`
+}
+
+func (configuration *Configuration) validate() error {
+	if configuration.ConfigName == "" || configuration.ContractID == "" {
+		return errors.New("a cloud wrapper configuration needs a name and a contract")
+	}
+	if len(configuration.PropertyIDs) == 0 || len(configuration.Locations) == 0 {
+		return errors.New("a cloud wrapper configuration needs at least one property and one location")
+	}
+
+	return nil
+}
+
+// ListConfigurations lists the Cloud Wrapper configurations
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-configurations
+// Endpoint: GET /cloud-wrapper/v1/configurations
+func ListConfigurations() ([]Configuration, error) {
+	response := struct {
+		Configurations []Configuration `json:"configurations"`
+	}{}
+	if err := doJSON("GET", "/cloud-wrapper/v1/configurations", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Configurations, nil
+}
+
+// GetConfiguration retrieves a configuration
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-configuration
+// Endpoint: GET /cloud-wrapper/v1/configurations/{configId}
+func GetConfiguration(configID int64) (*Configuration, error) {
+	configuration := &Configuration{}
+	if err := doJSON("GET", configurationPath(configID), nil, configuration); err != nil {
+		return nil, err
+	}
+
+	return configuration, nil
+}
+
+// CreateConfiguration creates a configuration, and activates it when activate is set
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/post-configuration
+// Endpoint: POST /cloud-wrapper/v1/configurations{?activate}
+func CreateConfiguration(configuration *Configuration, activate bool) (*Configuration, error) {
+	if err := configuration.validate(); err != nil {
+		return nil, err
+	}
+
+	created := &Configuration{}
+	path := fmt.Sprintf("/cloud-wrapper/v1/configurations?activate=%t", activate)
+	if err := doJSON("POST", path, configuration, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateConfiguration updates a configuration, and activates it when activate is set
+//
+// The contract of a configuration cannot change, and the capacity of its
+// locations can only grow while it is active.
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/put-configuration
+// Endpoint: PUT /cloud-wrapper/v1/configurations/{configId}{?activate}
+func UpdateConfiguration(configuration *Configuration, activate bool) (*Configuration, error) {
+	if err := configuration.validate(); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		ConfigName              string                  `json:"configName"`
+		PropertyIDs             []string                `json:"propertyIds"`
+		Locations               []ConfigurationLocation `json:"locations"`
+		Comments                string                  `json:"comments"`
+		NotificationEmails      []string                `json:"notificationEmails,omitempty"`
+		RetainIdleObjects       bool                    `json:"retainIdleObjects"`
+		CapacityAlertsThreshold *int                    `json:"capacityAlertsThreshold,omitempty"`
+	}{
+		configuration.ConfigName,
+		configuration.PropertyIDs,
+		configuration.Locations,
+		configuration.Comments,
+		configuration.NotificationEmails,
+		configuration.RetainIdleObjects,
+		configuration.CapacityAlertsThreshold,
+	}
+
+	updated := &Configuration{}
+	path := fmt.Sprintf("%s?activate=%t", configurationPath(configuration.ConfigID), activate)
+	if err := doJSON("PUT", path, body, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteConfiguration deletes a configuration, which frees the capacity of its locations
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/delete-configuration
+// Endpoint: DELETE /cloud-wrapper/v1/configurations/{configId}
+func DeleteConfiguration(configID int64) error {
+	return doJSON("DELETE", configurationPath(configID), nil, nil)
+}
+
+// ActivateConfigurations activates the latest version of configurations
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/post-configuration-activations
+// Endpoint: POST /cloud-wrapper/v1/configurations/activate
+func ActivateConfigurations(configIDs ...int64) error {
+	if len(configIDs) == 0 {
+		return errors.New("no cloud wrapper configuration to activate")
+	}
+
+	body := struct {
+		ConfigurationIDs []int64 `json:"configurationIds"`
+	}{configIDs}
+
+	return doJSON("POST", "/cloud-wrapper/v1/configurations/activate", body, nil)
+}
+
+// WaitForActivation polls a configuration every interval until it is ACTIVE
+// or FAILED, or ctx is done. A FAILED configuration returns ErrActivationFailed.
+func WaitForActivation(ctx context.Context, configID int64, interval time.Duration) (*Configuration, error) {
+	if interval <= 0 {
+		interval = DefaultActivationInterval
+	}
+
+	for {
+		configuration, err := GetConfiguration(configID)
+		if err != nil {
+			return nil, err
+		}
+		switch configuration.Status {
+		case StatusActive:
+			return configuration, nil
+		case StatusFailed:
+			return configuration, fmt.Errorf("%w: configuration %d", ErrActivationFailed, configuration.ConfigID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return configuration, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func configurationPath(configID int64) string {
+	return fmt.Sprintf("/cloud-wrapper/v1/configurations/%d", configID)
+}
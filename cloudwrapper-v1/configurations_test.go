@@ -0,0 +1,97 @@
+package cloudwrapper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateAndActivateConfiguration(t *testing.T) {
+	defer gock.Off()
+
+	location := map[string]interface{}{"trafficTypeId": 2, "comments": "media", "capacity": map[string]interface{}{"value": 500, "unit": "GB"}}
+	gock.New(baseURL).
+		Post("/cloud-wrapper/v1/configurations").
+		MatchParam("activate", "false").
+		JSON(map[string]interface{}{"configName": "media", "contractId": "C-0N7RAC7", "propertyIds": []string{"123456"},
+			"locations": []interface{}{location}, "comments": "origin offload", "retainIdleObjects": false}).
+		Reply(201).
+		JSON(`{"configId": 1234, "configName": "media", "contractId": "C-0N7RAC7", "propertyIds": ["123456"], "status": "SAVED"}`)
+	gock.New(baseURL).
+		Post("/cloud-wrapper/v1/configurations/activate").
+		JSON(map[string]interface{}{"configurationIds": []int64{1234}}).
+		Reply(204)
+	for _, status := range []string{"IN_PROGRESS", "ACTIVE"} {
+		gock.New(baseURL).
+			Get("/cloud-wrapper/v1/configurations/1234").
+			Reply(200).
+			JSON(`{"configId": 1234, "configName": "media", "status": "` + status + `"}`)
+	}
+
+	Init(config)
+	_, err := CreateConfiguration(&Configuration{ConfigName: "media", ContractID: "C-0N7RAC7"}, false)
+	assert.Error(t, err)
+
+	configuration, err := CreateConfiguration(&Configuration{
+		ConfigName:  "media",
+		ContractID:  "C-0N7RAC7",
+		PropertyIDs: []string{"123456"},
+		Locations:   []ConfigurationLocation{{TrafficTypeID: 2, Comments: "media", Capacity: Capacity{Value: 500, Unit: UnitGB}}},
+		Comments:    "origin offload",
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSaved, configuration.Status)
+
+	assert.Error(t, ActivateConfigurations())
+	require.NoError(t, ActivateConfigurations(configuration.ConfigID))
+	configuration, err = WaitForActivation(context.Background(), configuration.ConfigID, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, configuration.Status)
+	assert.True(t, gock.IsDone())
+
+	gock.New(baseURL).
+		Get("/cloud-wrapper/v1/configurations/1234").
+		Reply(200).
+		JSON(`{"configId": 1234, "configName": "media", "status": "FAILED"}`)
+
+	_, err = WaitForActivation(context.Background(), 1234, time.Millisecond)
+	assert.True(t, errors.Is(err, ErrActivationFailed))
+}
+
+func TestUpdateConfiguration(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/cloud-wrapper/v1/configurations/1234").
+		MatchParam("activate", "true").
+		JSON(map[string]interface{}{"configName": "media", "propertyIds": []string{"123456", "234567"},
+			"locations":         []interface{}{map[string]interface{}{"trafficTypeId": 2, "comments": "", "capacity": map[string]interface{}{"value": 1, "unit": "TB"}}},
+			"comments":          "",
+			"retainIdleObjects": true}).
+		Reply(200).
+		JSON(`{"configId": 1234, "configName": "media", "status": "IN_PROGRESS"}`)
+	gock.New(baseURL).
+		Delete("/cloud-wrapper/v1/configurations/1234").
+		Reply(204)
+
+	Init(config)
+	configuration, err := UpdateConfiguration(&Configuration{
+		ConfigID:          1234,
+		ConfigName:        "media",
+		ContractID:        "C-0N7RAC7",
+		PropertyIDs:       []string{"123456", "234567"},
+		Locations:         []ConfigurationLocation{{TrafficTypeID: 2, Capacity: Capacity{Value: 1, Unit: UnitTB}}},
+		RetainIdleObjects: true,
+		Status:            StatusActive,
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, StatusInProgress, configuration.Status)
+
+	assert.NoError(t, DeleteConfiguration(1234))
+	assert.True(t, gock.IsDone())
+}
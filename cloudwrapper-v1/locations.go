@@ -0,0 +1,150 @@
+package cloudwrapper
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// TrafficType is used to create an "enum" of possible LocationTrafficType.TrafficType values
+type TrafficType string
+
+// Unit is used to create an "enum" of possible Capacity.Unit values
+type Unit string
+
+const (
+	// TrafficTypeMedia LocationTrafficType.TrafficType value MEDIA
+	TrafficTypeMedia TrafficType = "MEDIA"
+	// TrafficTypeWebStandardTLS LocationTrafficType.TrafficType value WEB_STANDARD_TLS
+	TrafficTypeWebStandardTLS TrafficType = "WEB_STANDARD_TLS"
+	// TrafficTypeWebEnhancedTLS LocationTrafficType.TrafficType value WEB_ENHANCED_TLS
+	TrafficTypeWebEnhancedTLS TrafficType = "WEB_ENHANCED_TLS"
+
+	// UnitGB Capacity.Unit value GB
+	UnitGB Unit = "GB"
+	// UnitTB Capacity.Unit value TB
+	UnitTB Unit = "TB"
+)
+
+// Location is a Cloud Wrapper location, a set of Akamai edge regions close
+// to a cloud provider region
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-locations
+type Location struct {
+	LocationID         int                   `json:"locationId"`
+	LocationName       string                `json:"locationName"`
+	MultiCDNLocationID string                `json:"multiCdnLocationId,omitempty"`
+	TrafficTypes       []LocationTrafficType `json:"trafficTypes"`
+}
+
+// LocationTrafficType is a traffic type served by a location, its
+// TrafficTypeID identifies the location in a configuration
+type LocationTrafficType struct {
+	TrafficTypeID int         `json:"trafficTypeId"`
+	TrafficType   TrafficType `json:"trafficType"`
+	MapName       string      `json:"mapName,omitempty"`
+}
+
+// Capacity is an amount of traffic
+type Capacity struct {
+	Value int64 `json:"value"`
+	Unit  Unit  `json:"unit"`
+}
+
+// LocationCapacity is the capacity of a location and traffic type a contract
+// is entitled to, and how much of it configurations use
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-capacity-inventory
+type LocationCapacity struct {
+	LocationID         int         `json:"locationId"`
+	LocationName       string      `json:"locationName"`
+	ContractID         string      `json:"contractId"`
+	Type               TrafficType `json:"type"`
+	ApprovedCapacity   Capacity    `json:"approvedCapacity"`
+	AssignedCapacity   Capacity    `json:"assignedCapacity"`
+	UnassignedCapacity Capacity    `json:"unassignedCapacity"`
+}
+
+// Property is a property usable as the origin of a configuration
+type Property struct {
+	PropertyID   int64  `json:"propertyId"`
+	PropertyName string `json:"propertyName"`
+	ContractID   string `json:"contractId"`
+	GroupID      int64  `json:"groupId"`
+	Type         string `json:"type,omitempty"`
+}
+
+// PropertyOptions filters ListProperties
+type PropertyOptions struct {
+	// Unused lists only the properties not used by a configuration
+	Unused      bool     `query:"unused,omitempty"`
+	ContractIDs []string `query:"contractIds,comma,omitempty"`
+}
+
+// TrafficTypeID returns the ID of the traffic type of the location, or false
+// when the location does not serve it
+func (location *Location) TrafficTypeID(trafficType TrafficType) (int, bool) {
+	for _, locationTrafficType := range location.TrafficTypes {
+		if locationTrafficType.TrafficType == trafficType {
+			return locationTrafficType.TrafficTypeID, true
+		}
+	}
+
+	return 0, false
+}
+
+// ListLocations lists the Cloud Wrapper locations
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-locations
+// Endpoint: GET /cloud-wrapper/v1/locations
+func ListLocations() ([]Location, error) {
+	response := struct {
+		Locations []Location `json:"locations"`
+	}{}
+	if err := doJSON("GET", "/cloud-wrapper/v1/locations", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Locations, nil
+}
+
+// ListCapacities lists the capacity of each location and traffic type of
+// contractIDs, or of all contracts when none are given
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-capacity-inventory
+// Endpoint: GET /cloud-wrapper/v1/capacity{?contractIds}
+func ListCapacities(contractIDs ...string) ([]LocationCapacity, error) {
+	path, err := client.PathWithQuery("/cloud-wrapper/v1/capacity", struct {
+		ContractIDs []string `query:"contractIds,comma,omitempty"`
+	}{contractIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Capacities []LocationCapacity `json:"capacities"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Capacities, nil
+}
+
+// ListProperties lists the properties that can be used by configurations
+//
+// API Docs: https://techdocs.akamai.com/cloud-wrapper/reference/get-properties
+// Endpoint: GET /cloud-wrapper/v1/properties{?unused,contractIds}
+func ListProperties(options PropertyOptions) ([]Property, error) {
+	path, err := client.PathWithQuery("/cloud-wrapper/v1/properties", options)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Properties []Property `json:"properties"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Properties, nil
+}
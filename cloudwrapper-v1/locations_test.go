@@ -0,0 +1,77 @@
+package cloudwrapper
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+	config  = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+)
+
+func TestListLocations(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/cloud-wrapper/v1/locations").
+		Reply(200).
+		JSON(`{"locations": [{"locationId": 1, "locationName": "US East", "multiCdnLocationId": "0001", "trafficTypes": [
+			{"trafficTypeId": 1, "trafficType": "WEB_STANDARD_TLS", "mapName": "cw-s-use"},
+			{"trafficTypeId": 2, "trafficType": "MEDIA", "mapName": "cw-m-use"}
+		]}]}`)
+
+	Init(config)
+	locations, err := ListLocations()
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, "US East", locations[0].LocationName)
+
+	id, ok := locations[0].TrafficTypeID(TrafficTypeMedia)
+	assert.True(t, ok)
+	assert.Equal(t, 2, id)
+	_, ok = locations[0].TrafficTypeID(TrafficTypeWebEnhancedTLS)
+	assert.False(t, ok)
+	assert.True(t, gock.IsDone())
+}
+
+func TestListCapacitiesAndProperties(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/cloud-wrapper/v1/capacity").
+		MatchParam("contractIds", "C-0N7RAC7,C-0N7RAC8").
+		Reply(200).
+		JSON(`{"capacities": [{"locationId": 1, "locationName": "US East", "contractId": "C-0N7RAC7", "type": "MEDIA",
+			"approvedCapacity": {"value": 2, "unit": "TB"},
+			"assignedCapacity": {"value": 500, "unit": "GB"},
+			"unassignedCapacity": {"value": 1500, "unit": "GB"}}]}`)
+	gock.New(baseURL).
+		Get("/cloud-wrapper/v1/properties").
+		MatchParam("unused", "true").
+		Reply(200).
+		JSON(`{"properties": [{"propertyId": 123456, "propertyName": "www.example.com", "contractId": "C-0N7RAC7", "groupId": 54321, "type": "MEDIA"}]}`)
+
+	Init(config)
+	capacities, err := ListCapacities("C-0N7RAC7", "C-0N7RAC8")
+	require.NoError(t, err)
+	require.Len(t, capacities, 1)
+	assert.Equal(t, Capacity{Value: 1500, Unit: UnitGB}, capacities[0].UnassignedCapacity)
+
+	properties, err := ListProperties(PropertyOptions{Unused: true})
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	assert.Equal(t, int64(123456), properties[0].PropertyID)
+	assert.True(t, gock.IsDone())
+}
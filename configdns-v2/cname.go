@@ -0,0 +1,41 @@
+package dnsv2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxCNAMEChain is the maximum number of CNAME records CNAMEChain follows
+var MaxCNAMEChain = 8
+
+// CNAMEChain follows the CNAME records of name within zone and returns the targets in order
+//
+// The chain ends at the first target without a CNAME record in the zone, which
+// is usually a name outside the zone such as an Akamai edge hostname. An empty
+// chain means name itself has no CNAME record.
+func CNAMEChain(zone string, name string) ([]string, error) {
+	var chain []string
+
+	current := strings.TrimSuffix(name, ".")
+	for i := 0; i < MaxCNAMEChain; i++ {
+		if current != zone && !strings.HasSuffix(current, "."+zone) {
+			return chain, nil
+		}
+
+		record, err := GetRecord(zone, current, "CNAME")
+		if err != nil {
+			if recordError, ok := err.(*RecordError); ok && recordError.NotFound() {
+				return chain, nil
+			}
+			return nil, err
+		}
+		if len(record.Target) == 0 {
+			return chain, nil
+		}
+
+		current = strings.TrimSuffix(record.Target[0], ".")
+		chain = append(chain, current)
+	}
+
+	return nil, fmt.Errorf("CNAME chain for %s is longer than %d records", name, MaxCNAMEChain)
+}
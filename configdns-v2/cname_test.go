@@ -0,0 +1,29 @@
+package dnsv2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCNAMEChain(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/config-dns/v2/zones/example.com/names/www.example.com/types/CNAME").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "www.example.com", "type": "CNAME", "ttl": 300, "rdata": ["cdn.example.com."]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/config-dns/v2/zones/example.com/names/cdn.example.com/types/CNAME").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "cdn.example.com", "type": "CNAME", "ttl": 300, "rdata": ["www.example.com.edgekey.net."]}`)
+
+	Init(config)
+	chain, err := CNAMEChain("example.com", "www.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cdn.example.com", "www.example.com.edgekey.net"}, chain)
+	assert.True(t, gock.IsDone())
+}
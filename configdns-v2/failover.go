@@ -0,0 +1,148 @@
+package dnsv2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultFailoverInterval is the RecordFailover propagation polling interval used when none is given
+var DefaultFailoverInterval = 10 * time.Second
+
+// ErrNotPromoted is returned by RecordFailover.Restore when no secondary is promoted
+var ErrNotPromoted = errors.New("no secondary is promoted")
+
+// ResolveFunc answers the rdata of name and type as served by DNS, e.g. by
+// querying the zone's authoritative name servers
+type ResolveFunc func(ctx context.Context, name string, recordType string) ([]string, error)
+
+// RecordFailover points a record set at secondary targets and back, e.g.
+// from a DR runbook driven by external health checks
+//
+// The rdata found by the first Promote is kept in Primary and put back by
+// Restore. When Resolve is set, Promote and Restore wait until it answers the
+// new rdata. A RecordFailover is not safe for concurrent use.
+type RecordFailover struct {
+	Zone       string
+	Name       string
+	RecordType string
+	Resolve    ResolveFunc
+	// Interval is the Resolve polling interval
+	Interval time.Duration
+	// Primary is the rdata Restore puts back, nil when no secondary is
+	// promoted. Save it with the runbook state to Restore after a restart.
+	Primary []string
+}
+
+// NewRecordFailover returns a RecordFailover of the name and type record set of zone
+func NewRecordFailover(zone string, name string, recordType string) *RecordFailover {
+	return &RecordFailover{Zone: zone, Name: name, RecordType: recordType}
+}
+
+// Promoted reports whether the record set points at a secondary
+func (failover *RecordFailover) Promoted() bool {
+	return failover.Primary != nil
+}
+
+// Promote replaces the rdata of the record set with secondary. Promoting
+// another secondary keeps the rdata of the first Promote for Restore.
+func (failover *RecordFailover) Promote(ctx context.Context, secondary ...string) error {
+	if len(secondary) == 0 {
+		return errors.New("a secondary needs at least one target")
+	}
+
+	record, err := GetRecord(failover.Zone, failover.Name, failover.RecordType)
+	if err != nil {
+		return err
+	}
+	primary := record.Target
+	if err := failover.set(record, secondary); err != nil {
+		return err
+	}
+	if !failover.Promoted() {
+		failover.Primary = primary
+	}
+
+	return failover.waitForRdata(ctx, secondary)
+}
+
+// Restore puts back the rdata the record set had before Promote
+func (failover *RecordFailover) Restore(ctx context.Context) error {
+	if !failover.Promoted() {
+		return ErrNotPromoted
+	}
+
+	record, err := GetRecord(failover.Zone, failover.Name, failover.RecordType)
+	if err != nil {
+		return err
+	}
+	primary := failover.Primary
+	if err := failover.set(record, primary); err != nil {
+		return err
+	}
+
+	failover.Primary = nil
+	return failover.waitForRdata(ctx, primary)
+}
+
+// Apply promotes secondary when healthy is false and restores the primary
+// when it is true, a signal matching the current state changes nothing
+func (failover *RecordFailover) Apply(ctx context.Context, healthy bool, secondary ...string) error {
+	switch {
+	case !healthy && !failover.Promoted():
+		return failover.Promote(ctx, secondary...)
+	case healthy && failover.Promoted():
+		return failover.Restore(ctx)
+	}
+
+	return nil
+}
+
+// set updates the rdata of record, unless it already is rdata
+func (failover *RecordFailover) set(record *RecordBody, rdata []string) error {
+	if sameRdata(normalizeRdata(record.Target), normalizeRdata(rdata)) {
+		return nil
+	}
+
+	record.Target = rdata
+	return record.Update(failover.Zone)
+}
+
+func (failover *RecordFailover) waitForRdata(ctx context.Context, rdata []string) error {
+	if failover.Resolve == nil {
+		return nil
+	}
+
+	interval := failover.Interval
+	if interval <= 0 {
+		interval = DefaultFailoverInterval
+	}
+
+	for {
+		answer, err := failover.Resolve(ctx, failover.Name, failover.RecordType)
+		if err != nil {
+			return err
+		}
+		if sameRdata(normalizeRdata(answer), normalizeRdata(rdata)) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// normalizeRdata lower cases rdata and drops trailing dots, answers of
+// resolvers are usually fully qualified
+func normalizeRdata(rdata []string) []string {
+	normalized := make([]string, len(rdata))
+	for i, value := range rdata {
+		normalized[i] = strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+
+	return normalized
+}
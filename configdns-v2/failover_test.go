@@ -0,0 +1,92 @@
+package dnsv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestRecordFailover(t *testing.T) {
+	defer gock.Off()
+
+	recordPath := "/config-dns/v2/zones/example.com/names/www.example.com/types/A"
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(recordPath).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": ["192.0.2.1"]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put(recordPath).
+		JSON(map[string]interface{}{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": []string{"198.51.100.1"}}).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": ["198.51.100.1"]}`)
+
+	Init(config)
+	answers := [][]string{{"192.0.2.1"}, {"198.51.100.1"}}
+	failover := NewRecordFailover("example.com", "www.example.com", "A")
+	failover.Interval = time.Millisecond
+	failover.Resolve = func(ctx context.Context, name string, recordType string) ([]string, error) {
+		answer := answers[0]
+		if len(answers) > 1 {
+			answers = answers[1:]
+		}
+		return answer, nil
+	}
+
+	assert.NoError(t, failover.Apply(context.Background(), true, "198.51.100.1"))
+	assert.Equal(t, ErrNotPromoted, failover.Restore(context.Background()))
+
+	assert.NoError(t, failover.Apply(context.Background(), false, "198.51.100.1"))
+	assert.True(t, failover.Promoted())
+	assert.Empty(t, answers[1:])
+	assert.True(t, gock.IsDone())
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(recordPath).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": ["198.51.100.1"]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put(recordPath).
+		JSON(map[string]interface{}{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": []string{"192.0.2.1"}}).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": ["192.0.2.1"]}`)
+
+	// a restarted runbook restores from the saved Primary
+	restarted := NewRecordFailover("example.com", "www.example.com", "A")
+	restarted.Primary = failover.Primary
+	assert.NoError(t, restarted.Apply(context.Background(), true))
+	assert.False(t, restarted.Promoted())
+	assert.True(t, gock.IsDone())
+}
+
+func TestRecordFailover_UpdateFailed(t *testing.T) {
+	defer gock.Off()
+
+	recordPath := "/config-dns/v2/zones/example.com/names/www.example.com/types/A"
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(recordPath).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"name": "www.example.com", "type": "A", "ttl": 60, "rdata": ["192.0.2.1"]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put(recordPath).
+		Reply(500).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"title": "Internal Server Error", "status": 500}`)
+
+	Init(config)
+	failover := NewRecordFailover("example.com", "www.example.com", "A")
+	assert.Error(t, failover.Apply(context.Background(), false, "198.51.100.1"))
+	assert.False(t, failover.Promoted())
+	assert.True(t, gock.IsDone())
+}
+
+func TestNormalizeRdata(t *testing.T) {
+	assert.Equal(t, []string{"a.example.com", "192.0.2.1"}, normalizeRdata([]string{"A.example.com.", "192.0.2.1"}))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -13,7 +14,9 @@ var (
 	zoneRecordsetsWriteLock sync.Mutex
 )
 
-// Recordset Query args struct
+// Recordset Query args struct. Types is a comma separated list of record
+// types, e.g. "A,AAAA", and Search matches part of the record names. SortBy is
+// a comma separated list of name and type, prefixed with - to sort descending.
 type RecordsetQueryArgs struct {
 	Page     int
 	PageSize int
@@ -117,6 +120,93 @@ func GetRecordsets(zone string, queryArgs ...RecordsetQueryArgs) (*RecordSetResp
 	}
 }
 
+// DefaultRecordsetsPageSize is the page size used by RecordsetIterator when none is given
+const DefaultRecordsetsPageSize = 500
+
+// RecordsetIterator pages through the recordsets of a zone matching query
+// args, one page in memory at a time
+//
+//	iterator := dnsv2.NewRecordsetIterator("example.com", dnsv2.RecordsetQueryArgs{Types: "A,AAAA"})
+//	for iterator.Next() {
+//		recordset := iterator.Recordset()
+//	}
+//	if err := iterator.Err(); err != nil {
+//	}
+type RecordsetIterator struct {
+	zone string
+	args RecordsetQueryArgs
+
+	metadata *MetadataH
+	page     []Recordset
+	current  *Recordset
+	done     bool
+	err      error
+}
+
+// NewRecordsetIterator creates a RecordsetIterator for zone, starting at
+// args.Page (the first page when zero) and fetching args.PageSize recordsets
+// per request (DefaultRecordsetsPageSize when zero). args.ShowAll is ignored.
+func NewRecordsetIterator(zone string, args RecordsetQueryArgs) *RecordsetIterator {
+	if args.Page <= 0 {
+		args.Page = 1
+	}
+	if args.PageSize <= 0 {
+		args.PageSize = DefaultRecordsetsPageSize
+	}
+	args.ShowAll = false
+
+	return &RecordsetIterator{zone: zone, args: args}
+}
+
+// Next advances to the next recordset, fetching the next page when needed. It
+// returns false once the recordsets are exhausted or an error occurred.
+func (iterator *RecordsetIterator) Next() bool {
+	if iterator.err != nil {
+		return false
+	}
+
+	if len(iterator.page) == 0 && !iterator.done {
+		var resp *RecordSetResponse
+		resp, iterator.err = GetRecordsets(iterator.zone, iterator.args)
+		if iterator.err != nil {
+			return false
+		}
+		iterator.metadata = &resp.Metadata
+		iterator.page = resp.Recordsets
+		iterator.done = len(resp.Recordsets) < iterator.args.PageSize || (resp.Metadata.LastPage > 0 && iterator.args.Page >= resp.Metadata.LastPage)
+		iterator.args.Page++
+	}
+
+	if len(iterator.page) == 0 {
+		iterator.current = nil
+		return false
+	}
+
+	iterator.current, iterator.page = &iterator.page[0], iterator.page[1:]
+
+	return true
+}
+
+// Recordset returns the current recordset
+func (iterator *RecordsetIterator) Recordset() *Recordset {
+	return iterator.current
+}
+
+// Total returns the number of recordsets matching the query args, known once
+// Next fetched the first page
+func (iterator *RecordsetIterator) Total() int {
+	if iterator.metadata == nil {
+		return 0
+	}
+
+	return iterator.metadata.TotalElements
+}
+
+// Err returns the error that stopped the iteration, if any
+func (iterator *RecordsetIterator) Err() error {
+	return iterator.err
+}
+
 // Create Recordstes
 func (recordsets *Recordsets) Save(zone string, recLock ...bool) error {
 	// This lock will restrict the concurrency of API calls
@@ -130,6 +220,10 @@ func (recordsets *Recordsets) Save(zone string, recLock ...bool) error {
 		defer zoneRecordsetsWriteLock.Unlock()
 	}
 
+	if err := ValidateRecordsets(recordsets); err != nil {
+		return &ZoneError{zoneName: zone, apiErrorMessage: err.Error(), err: err}
+	}
+
 	req, err := client.NewJSONRequest(
 		Config,
 		"POST",
@@ -176,6 +270,10 @@ func (recordsets *Recordsets) Update(zone string, recLock ...bool) error {
 		defer zoneRecordsetsWriteLock.Unlock()
 	}
 
+	if err := ValidateRecordsets(recordsets); err != nil {
+		return &ZoneError{zoneName: zone, apiErrorMessage: err.Error(), err: err}
+	}
+
 	req, err := client.NewJSONRequest(
 		Config,
 		"PUT",
@@ -209,3 +307,62 @@ func (recordsets *Recordsets) Update(zone string, recLock ...bool) error {
 
 	return nil
 }
+
+// Delete Recordsets. The recordsets are deleted one at a time, stopping at the first error
+func DeleteRecordsets(zone string, recordsets []Recordset, recLock ...bool) error {
+	if localLock(recLock) {
+		zoneRecordsetsWriteLock.Lock()
+		defer zoneRecordsetsWriteLock.Unlock()
+	}
+
+	for _, recordset := range recordsets {
+		record := &RecordBody{Name: recordset.Name, RecordType: recordset.Type}
+		if err := record.Delete(zone, recLock...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate Recordsets Object
+func ValidateRecordsets(recordsets *Recordsets) error {
+
+	if len(recordsets.Recordsets) == 0 {
+		return fmt.Errorf("At least one recordset is required")
+	}
+	seen := make(map[string]bool)
+	for _, recordset := range recordsets.Recordsets {
+		if err := ValidateRecordset(&recordset); err != nil {
+			return err
+		}
+		key := strings.ToLower(strings.TrimSuffix(recordset.Name, ".")) + " " + strings.ToUpper(recordset.Type)
+		if seen[key] {
+			return fmt.Errorf("Duplicate recordset %s %s", recordset.Name, recordset.Type)
+		}
+		seen[key] = true
+	}
+
+	return nil
+
+}
+
+// Validate Recordset Object
+func ValidateRecordset(recordset *Recordset) error {
+
+	if len(recordset.Name) == 0 {
+		return fmt.Errorf("Recordset name is required")
+	}
+	if len(recordset.Type) == 0 {
+		return fmt.Errorf("Recordset type is required for %s", recordset.Name)
+	}
+	if recordset.TTL < 0 {
+		return fmt.Errorf("Invalid TTL %d for recordset %s %s", recordset.TTL, recordset.Name, recordset.Type)
+	}
+	if len(recordset.Rdata) == 0 {
+		return fmt.Errorf("Rdata is required for recordset %s %s", recordset.Name, recordset.Type)
+	}
+
+	return nil
+
+}
@@ -75,6 +75,47 @@ func TestListRecordsets(t *testing.T) {
 
 }
 
+func TestRecordsetIterator(t *testing.T) {
+
+	defer gock.Off()
+
+	page := func(page int, names ...string) string {
+		recordsets := ""
+		for i, name := range names {
+			if i > 0 {
+				recordsets += ","
+			}
+			recordsets += fmt.Sprintf(`{"name": "%s.%s", "type": "A", "ttl": 300, "rdata": ["10.0.0.1"]}`, name, dnsTestZone)
+		}
+		return fmt.Sprintf(`{"metadata": {"page": %d, "pageSize": 2, "lastPage": 2, "totalElements": 3}, "recordsets": [%s]}`, page, recordsets)
+	}
+
+	for i, body := range []string{page(1, "a", "b"), page(2, "c")} {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Get(fmt.Sprintf("/config-dns/v2/zones/%s/recordsets", dnsTestZone)).
+			MatchParam("page", fmt.Sprintf("^%d$", i+1)).
+			MatchParam("pageSize", "^2$").
+			MatchParam("types", "^A,AAAA$").
+			MatchParam("search", "^www$").
+			MatchParam("showAll", "^false$").
+			Reply(200).
+			SetHeader("Content-Type", "application/json;charset=UTF-8").
+			BodyString(body)
+	}
+
+	Init(config)
+	iterator := NewRecordsetIterator(dnsTestZone, RecordsetQueryArgs{PageSize: 2, Types: "A,AAAA", Search: "www", ShowAll: true})
+	var names []string
+	for iterator.Next() {
+		names = append(names, iterator.Recordset().Name)
+	}
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{"a." + dnsTestZone, "b." + dnsTestZone, "c." + dnsTestZone}, names)
+	assert.Equal(t, 3, iterator.Total())
+	assert.True(t, gock.IsDone())
+
+}
+
 func TestCreateRecordsets(t *testing.T) {
 
 	defer gock.Off()
@@ -110,3 +151,42 @@ func TestUpdateRecordsets(t *testing.T) {
 	assert.NoError(t, err)
 
 }
+
+func TestValidateRecordsets(t *testing.T) {
+
+	assert.NoError(t, ValidateRecordsets(createTestRecordsets()))
+
+	sets := createTestRecordsets()
+	sets.Recordsets[1].Rdata = nil
+	assert.Error(t, ValidateRecordsets(sets))
+
+	sets = createTestRecordsets()
+	sets.Recordsets = append(sets.Recordsets, sets.Recordsets[0])
+	assert.Error(t, ValidateRecordsets(sets))
+
+	defer gock.Off()
+	Init(config)
+	err := (&Recordsets{}).Save(dnsTestZone)
+	assert.Error(t, err)
+	assert.True(t, err.(ConfigDNSError).ValidationFailed())
+
+}
+
+func TestDeleteRecordsets(t *testing.T) {
+
+	defer gock.Off()
+
+	sets := createTestRecordsets()
+	for _, set := range sets.Recordsets {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Delete(fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s", dnsTestZone, set.Name, set.Type)).
+			HeaderPresent("Authorization").
+			Reply(204)
+	}
+
+	Init(config)
+	err := DeleteRecordsets(dnsTestZone, sets.Recordsets)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+}
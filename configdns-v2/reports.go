@@ -0,0 +1,165 @@
+package dnsv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+//
+// Support Edge DNS traffic reports thru the Reporting API
+// Based on 1.0 Schema
+//
+
+// ReportInterval is used to create an "enum" of possible TrafficReportOptions.Interval values
+type ReportInterval string
+
+const (
+	ReportIntervalFiveMinutes ReportInterval = "FIVE_MINUTES"
+	ReportIntervalHour        ReportInterval = "HOUR"
+	ReportIntervalDay         ReportInterval = "DAY"
+	ReportIntervalWeek        ReportInterval = "WEEK"
+	ReportIntervalMonth       ReportInterval = "MONTH"
+)
+
+// Reporting API reports for Edge DNS traffic
+var (
+	// TrafficByZoneReport is the name of the queries per zone report
+	TrafficByZoneReport = "authoritative-dns-traffic-by-zone"
+	// TrafficByRecordTypeReport is the name of the queries per zone and record type report
+	TrafficByRecordTypeReport = "authoritative-dns-traffic-by-zone-and-record-type"
+	// TrafficReportVersion is the version of the traffic reports
+	TrafficReportVersion = 1
+)
+
+// TrafficReportOptions selects the time range of a traffic report
+type TrafficReportOptions struct {
+	Start time.Time
+	End   time.Time
+	// Interval defaults to HOUR
+	Interval ReportInterval
+	// RecordTypes optionally restricts the rows to the given record types, e.g. "A", "AAAA"
+	RecordTypes []string
+}
+
+// TrafficReportMetadata describes the returned traffic report
+type TrafficReportMetadata struct {
+	Name              string   `json:"name"`
+	Version           string   `json:"version"`
+	Start             string   `json:"start"`
+	End               string   `json:"end"`
+	Interval          string   `json:"interval"`
+	AvailableDataEnds string   `json:"availableDataEnds,omitempty"`
+	ObjectType        string   `json:"objectType"`
+	ObjectIDs         []string `json:"objectIds"`
+	RowCount          int      `json:"rowCount"`
+}
+
+// TrafficReportRow is the number of queries answered for a zone (and record type) during an interval
+type TrafficReportRow struct {
+	StartDateTime string      `json:"startdatetime"`
+	Zone          string      `json:"zone"`
+	RecordType    string      `json:"recordtype,omitempty"`
+	Queries       json.Number `json:"queries"`
+}
+
+// TrafficReport is the Edge DNS traffic report returned by the Reporting API
+type TrafficReport struct {
+	Metadata TrafficReportMetadata `json:"metadata"`
+	Data     []*TrafficReportRow   `json:"data"`
+}
+
+// QueriesByZone sums the queries of all rows per zone
+func (report *TrafficReport) QueriesByZone() map[string]int64 {
+	totals := map[string]int64{}
+	for _, row := range report.Data {
+		queries, _ := row.Queries.Int64()
+		totals[row.Zone] += queries
+	}
+
+	return totals
+}
+
+// QueriesByRecordType sums the queries of all rows of zone per record type
+func (report *TrafficReport) QueriesByRecordType(zone string) map[string]int64 {
+	totals := map[string]int64{}
+	for _, row := range report.Data {
+		if !strings.EqualFold(row.Zone, zone) {
+			continue
+		}
+		queries, _ := row.Queries.Int64()
+		totals[row.RecordType] += queries
+	}
+
+	return totals
+}
+
+// GetZoneTrafficReport retrieves the number of queries per zone over time
+//
+// Endpoint: GET /reporting-api/v1/reports/{reportName}/versions/{version}/report-data{?start,end,interval,objectIds,filters}
+func GetZoneTrafficReport(zones []string, options TrafficReportOptions) (*TrafficReport, error) {
+	return getTrafficReport(TrafficByZoneReport, zones, options)
+}
+
+// GetRecordTypeTrafficReport retrieves the number of queries per record type of zone over time
+//
+// Endpoint: GET /reporting-api/v1/reports/{reportName}/versions/{version}/report-data{?start,end,interval,objectIds,filters}
+func GetRecordTypeTrafficReport(zone string, options TrafficReportOptions) (*TrafficReport, error) {
+	return getTrafficReport(TrafficByRecordTypeReport, []string{zone}, options)
+}
+
+func getTrafficReport(reportName string, zones []string, options TrafficReportOptions) (*TrafficReport, error) {
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("traffic report requires at least one zone")
+	}
+	if options.Start.IsZero() || options.End.IsZero() || !options.End.After(options.Start) {
+		return nil, fmt.Errorf("traffic report requires a start before its end")
+	}
+	if options.Interval == "" {
+		options.Interval = ReportIntervalHour
+	}
+
+	report := &TrafficReport{}
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/reporting-api/v1/reports/%s/versions/%d/report-data", reportName, TrafficReportVersion),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("start", options.Start.UTC().Format(time.RFC3339))
+	q.Add("end", options.End.UTC().Format(time.RFC3339))
+	q.Add("interval", string(options.Interval))
+	q.Add("objectIds", strings.Join(zones, ","))
+	if len(options.RecordTypes) > 0 {
+		q.Add("filters", "recordtype="+strings.Join(options.RecordTypes, ","))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	if err = client.BodyJSON(res, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
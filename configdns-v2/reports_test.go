@@ -0,0 +1,47 @@
+package dnsv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGetZoneTrafficReport(t *testing.T) {
+	defer gock.Off()
+
+	mock := gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net")
+	mock.
+		Get("/reporting-api/v1/reports/authoritative-dns-traffic-by-zone/versions/1/report-data").
+		MatchParam("start", "2020-06-01T00:00:00Z").
+		MatchParam("end", "2020-06-02T00:00:00Z").
+		MatchParam("interval", "HOUR").
+		MatchParam("objectIds", "example.com,example.net").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+			"metadata": {"name": "authoritative-dns-traffic-by-zone", "version": "1", "interval": "HOUR", "objectType": "zone", "objectIds": ["example.com", "example.net"], "rowCount": 3},
+			"data": [
+				{"startdatetime": "2020-06-01T00:00:00Z", "zone": "example.com", "queries": "1200"},
+				{"startdatetime": "2020-06-01T01:00:00Z", "zone": "example.com", "queries": 800},
+				{"startdatetime": "2020-06-01T00:00:00Z", "zone": "example.net", "queries": "15"}
+			]
+		}`)
+
+	Init(config)
+
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	report, err := GetZoneTrafficReport([]string{"example.com", "example.net"}, TrafficReportOptions{Start: start, End: start.Add(24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Metadata.RowCount)
+	assert.Equal(t, map[string]int64{"example.com": 2000, "example.net": 15}, report.QueriesByZone())
+	assert.True(t, gock.IsDone())
+}
+
+func TestGetZoneTrafficReport_InvalidRange(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := GetZoneTrafficReport([]string{"example.com"}, TrafficReportOptions{Start: start, End: start})
+	assert.Error(t, err)
+}
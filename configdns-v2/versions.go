@@ -0,0 +1,269 @@
+package dnsv2
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+//
+// Support the zone version history (audit trail) of primary zones. A version
+// is created each time the zone is changed, e.g. by a recordset update.
+//
+
+// ZoneVersion is an entry of a zone history
+type ZoneVersion struct {
+	VersionId          string    `json:"versionId"`
+	LastModifiedBy     string    `json:"lastModifiedBy"`
+	LastModifiedDate   time.Time `json:"lastModifiedDate"`
+	LastActivationDate time.Time `json:"lastActivationDate,omitempty"`
+	ActivationState    string    `json:"activationState,omitempty"`
+	Comment            string    `json:"comment,omitempty"`
+}
+
+// ZoneVersionListResponse is a page of zone versions, most recent first
+type ZoneVersionListResponse struct {
+	Metadata MetadataH      `json:"metadata"`
+	Versions []*ZoneVersion `json:"versions"`
+}
+
+// ZoneVersionQueryArgs pages through the zone history
+type ZoneVersionQueryArgs struct {
+	Page     int
+	PageSize int
+	ShowAll  bool
+}
+
+// List Zone Versions. Returns one page, or all versions with ShowAll
+func ListZoneVersions(zone string, queryArgs ...ZoneVersionQueryArgs) (*ZoneVersionListResponse, error) {
+
+	if len(queryArgs) > 1 {
+		return nil, fmt.Errorf("ListZoneVersions QueryArgs invalid.")
+	}
+
+	q := url.Values{}
+	if len(queryArgs) > 0 {
+		if queryArgs[0].Page > 0 {
+			q.Add("page", strconv.Itoa(queryArgs[0].Page))
+		}
+		if queryArgs[0].PageSize > 0 {
+			q.Add("pageSize", strconv.Itoa(queryArgs[0].PageSize))
+		}
+		q.Add("showAll", strconv.FormatBool(queryArgs[0].ShowAll))
+	}
+
+	versions := &ZoneVersionListResponse{}
+	if err := getZoneHistory(zone, fmt.Sprintf("/config-dns/v2/zones/%s/versions", zone), q, versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// List all Zone Versions, page by page
+func ListAllZoneVersions(zone string, pageSize int) ([]*ZoneVersion, error) {
+
+	var versions []*ZoneVersion
+	for page := 1; ; page++ {
+		resp, err := ListZoneVersions(zone, ZoneVersionQueryArgs{Page: page, PageSize: pageSize})
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, resp.Versions...)
+		if len(resp.Versions) == 0 || page >= resp.Metadata.LastPage {
+			return versions, nil
+		}
+	}
+}
+
+// Get Zone Version
+func GetZoneVersion(zone string, versionId string) (*ZoneVersion, error) {
+
+	version := &ZoneVersion{}
+	if err := getZoneHistory(zone, fmt.Sprintf("/config-dns/v2/zones/%s/versions/%s", zone, versionId), nil, version); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// Get the Recordsets of a Zone Version
+func GetZoneVersionRecordsets(zone string, versionId string) (*RecordSetResponse, error) {
+
+	q := url.Values{}
+	q.Add("showAll", "true")
+
+	recordsets := &RecordSetResponse{}
+	if err := getZoneHistory(zone, fmt.Sprintf("/config-dns/v2/zones/%s/versions/%s/recordsets", zone, versionId), q, recordsets); err != nil {
+		return nil, err
+	}
+
+	return recordsets, nil
+}
+
+// RecordsetChange is used to create an "enum" of possible RecordsetDiff.Change values
+type RecordsetChange string
+
+const (
+	RecordsetAdded   RecordsetChange = "ADDED"
+	RecordsetRemoved RecordsetChange = "REMOVED"
+	RecordsetChanged RecordsetChange = "CHANGED"
+)
+
+// RecordsetDiff is a recordset that differs between two zone versions
+type RecordsetDiff struct {
+	Name   string          `json:"name"`
+	Type   string          `json:"type"`
+	Change RecordsetChange `json:"change"`
+	// From is nil for added recordsets
+	From *Recordset `json:"from,omitempty"`
+	// To is nil for removed recordsets
+	To *Recordset `json:"to,omitempty"`
+}
+
+// ZoneVersionDiff are the recordset changes from one zone version to another
+type ZoneVersionDiff struct {
+	Zone string       `json:"zone"`
+	From *ZoneVersion `json:"from"`
+	To   *ZoneVersion `json:"to"`
+	// Changes are sorted by name and type
+	Changes []RecordsetDiff `json:"changes"`
+}
+
+// Diff Zone Versions. Compares the recordsets of two versions, e.g. a version
+// of ListZoneVersions with the one before it, to report who changed what and when
+func DiffZoneVersions(zone string, fromVersionId string, toVersionId string) (*ZoneVersionDiff, error) {
+
+	diff := &ZoneVersionDiff{Zone: zone}
+	var err error
+	if diff.From, err = GetZoneVersion(zone, fromVersionId); err != nil {
+		return nil, err
+	}
+	if diff.To, err = GetZoneVersion(zone, toVersionId); err != nil {
+		return nil, err
+	}
+
+	from, err := GetZoneVersionRecordsets(zone, fromVersionId)
+	if err != nil {
+		return nil, err
+	}
+	to, err := GetZoneVersionRecordsets(zone, toVersionId)
+	if err != nil {
+		return nil, err
+	}
+	diff.Changes = DiffRecordsets(from.Recordsets, to.Recordsets)
+
+	return diff, nil
+}
+
+// Diff Recordsets. Recordsets are matched by name and type, rdata order is ignored
+func DiffRecordsets(from []Recordset, to []Recordset) []RecordsetDiff {
+
+	key := func(recordset Recordset) string {
+		return strings.ToLower(strings.TrimSuffix(recordset.Name, ".")) + " " + strings.ToUpper(recordset.Type)
+	}
+	fromSets := make(map[string]Recordset)
+	for _, recordset := range from {
+		fromSets[key(recordset)] = recordset
+	}
+
+	changes := []RecordsetDiff{}
+	seen := make(map[string]bool)
+	for _, recordset := range to {
+		k := key(recordset)
+		seen[k] = true
+		toSet := recordset
+		previous, ok := fromSets[k]
+		if !ok {
+			changes = append(changes, RecordsetDiff{Name: recordset.Name, Type: recordset.Type, Change: RecordsetAdded, To: &toSet})
+			continue
+		}
+		if previous.TTL != recordset.TTL || !sameRdata(previous.Rdata, recordset.Rdata) {
+			fromSet := previous
+			changes = append(changes, RecordsetDiff{Name: recordset.Name, Type: recordset.Type, Change: RecordsetChanged, From: &fromSet, To: &toSet})
+		}
+	}
+	for _, recordset := range from {
+		if !seen[key(recordset)] {
+			fromSet := recordset
+			changes = append(changes, RecordsetDiff{Name: recordset.Name, Type: recordset.Type, Change: RecordsetRemoved, From: &fromSet})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Type < changes[j].Type
+	})
+
+	return changes
+}
+
+func sameRdata(a []string, b []string) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getZoneHistory(zone string, path string, q url.Values, out interface{}) error {
+
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		path,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	if len(q) > 0 {
+		query := req.URL.Query()
+		for name, values := range q {
+			for _, value := range values {
+				query.Add(name, value)
+			}
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return &ZoneError{
+			zoneName:         zone,
+			httpErrorMessage: err.Error(),
+			err:              err,
+		}
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) && res.StatusCode != 404 {
+		err := client.NewAPIError(res)
+		return &ZoneError{zoneName: zone, apiErrorMessage: err.Detail, err: err}
+	} else if res.StatusCode == 404 {
+		return &ZoneError{zoneName: zone}
+	}
+
+	return client.BodyJSON(res, out)
+}
@@ -0,0 +1,107 @@
+package dnsv2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestListAllZoneVersions(t *testing.T) {
+
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-dns/v2/zones/%s/versions", dnsTestZone)).
+		MatchParam("page", "1").
+		MatchParam("pageSize", "1").
+		Reply(200).
+		SetHeader("Content-Type", "application/json;charset=UTF-8").
+		BodyString(`{"metadata": {"page": 1, "pageSize": 1, "lastPage": 2},
+			"versions": [{"versionId": "v2", "lastModifiedBy": "jdoe", "lastModifiedDate": "2020-10-01T07:42:18Z", "activationState": "ACTIVE"}]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-dns/v2/zones/%s/versions", dnsTestZone)).
+		MatchParam("page", "2").
+		Reply(200).
+		SetHeader("Content-Type", "application/json;charset=UTF-8").
+		BodyString(`{"metadata": {"page": 2, "pageSize": 1, "lastPage": 2},
+			"versions": [{"versionId": "v1", "lastModifiedBy": "asmith", "lastModifiedDate": "2020-09-01T07:42:18Z", "activationState": "INACTIVE"}]}`)
+
+	Init(config)
+	versions, err := ListAllZoneVersions(dnsTestZone, 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "jdoe", versions[0].LastModifiedBy)
+	assert.Equal(t, "v1", versions[1].VersionId)
+	assert.True(t, gock.IsDone())
+
+}
+
+func TestDiffZoneVersions(t *testing.T) {
+
+	defer gock.Off()
+
+	for _, v := range []string{"v1", "v2"} {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Get(fmt.Sprintf("/config-dns/v2/zones/%s/versions/%s", dnsTestZone, v)).
+			Reply(200).
+			SetHeader("Content-Type", "application/json;charset=UTF-8").
+			BodyString(fmt.Sprintf(`{"versionId": "%s", "lastModifiedBy": "jdoe", "lastModifiedDate": "2020-10-01T07:42:18Z"}`, v))
+	}
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-dns/v2/zones/%s/versions/v1/recordsets", dnsTestZone)).
+		MatchParam("showAll", "true").
+		Reply(200).
+		SetHeader("Content-Type", "application/json;charset=UTF-8").
+		BodyString(`{"recordsets": [
+			{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.1", "10.0.0.2"]},
+			{"name": "old.example.com", "type": "CNAME", "ttl": 300, "rdata": ["www.example.com."]},
+			{"name": "mail.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.3"]}]}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-dns/v2/zones/%s/versions/v2/recordsets", dnsTestZone)).
+		Reply(200).
+		SetHeader("Content-Type", "application/json;charset=UTF-8").
+		BodyString(`{"recordsets": [
+			{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": ["10.0.0.2", "10.0.0.1"]},
+			{"name": "new.example.com", "type": "CNAME", "ttl": 300, "rdata": ["www.example.com."]},
+			{"name": "mail.example.com", "type": "A", "ttl": 600, "rdata": ["10.0.0.3"]}]}`)
+
+	Init(config)
+	diff, err := DiffZoneVersions(dnsTestZone, "v1", "v2")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", diff.To.VersionId)
+	require.Len(t, diff.Changes, 3)
+	assert.Equal(t, RecordsetChanged, diff.Changes[0].Change)
+	assert.Equal(t, 600, diff.Changes[0].To.TTL)
+	assert.Equal(t, "new.example.com", diff.Changes[1].Name)
+	assert.Equal(t, RecordsetAdded, diff.Changes[1].Change)
+	assert.Nil(t, diff.Changes[1].From)
+	assert.Equal(t, RecordsetRemoved, diff.Changes[2].Change)
+	assert.True(t, gock.IsDone())
+
+}
+
+func TestListZoneVersions_AccountSwitchKey(t *testing.T) {
+
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-dns/v2/zones/%s/versions", dnsTestZone)).
+		MatchParam("accountSwitchKey", "1-ABCD").
+		MatchParam("page", "1").
+		Reply(200).
+		SetHeader("Content-Type", "application/json;charset=UTF-8").
+		BodyString(`{"metadata": {"page": 1, "pageSize": 25, "lastPage": 1}, "versions": []}`)
+
+	switched := config
+	switched.AccountKey = "1-ABCD"
+	Init(switched)
+	defer Init(config)
+
+	_, err := ListZoneVersions(dnsTestZone, ZoneVersionQueryArgs{Page: 1})
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+}
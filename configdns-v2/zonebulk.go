@@ -240,6 +240,12 @@ func GetBulkZoneDeleteResult(requestid string) (*BulkDeleteResultResponse, error
 // Bulk Create Zones
 func CreateBulkZones(bulkzones *BulkZonesCreate, zonequerystring ZoneQueryString) (*BulkZonesResponse, error) {
 
+	for _, zone := range bulkzones.Zones {
+		if err := ValidateZone(zone); err != nil {
+			return nil, &ZoneError{zoneName: zone.Zone, apiErrorMessage: err.Error(), err: err}
+		}
+	}
+
 	bulkzonesurl := "/config-dns/v2/zones/create-requests?contractId=" + zonequerystring.Contract
 	if len(zonequerystring.Group) > 0 {
 		bulkzonesurl += "&gid=" + zonequerystring.Group
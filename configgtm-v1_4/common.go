@@ -24,9 +24,26 @@ func appendReqArgs(req *http.Request, queryArgs map[string]string) {
 
 }
 
-// default schema version
-// TODO: retrieve from environment or elsewhere in Service Init
-var schemaVersion string = "1.4"
+// Supported schema versions
+const (
+	SchemaVersion14 = "1.4"
+	SchemaVersion15 = "1.5"
+)
+
+// default schema version, see SetSchemaVersion
+var schemaVersion string = SchemaVersion14
+
+// SetSchemaVersion sets the schema version of the requests, SchemaVersion14 (the default) or SchemaVersion15
+func SetSchemaVersion(version string) error {
+
+	if version != SchemaVersion14 && version != SchemaVersion15 {
+		return fmt.Errorf("unsupported GTM schema version %s", version)
+	}
+	schemaVersion = version
+
+	return nil
+
+}
 
 // internal method to set version. passed in as string
 func setVersionHeader(req *http.Request, version string) {
@@ -15,6 +15,7 @@ import (
 //
 
 // The Domain data structure represents a GTM domain
+// SignAndServe and SignAndServeAlgorithm require SchemaVersion15
 type Domain struct {
 	Name                         string          `json:"name"`
 	Type                         string          `json:"type"`
@@ -57,6 +58,8 @@ type Domain struct {
 	PingPacketSize               int             `json:"pingPacketSize,omitempty"`
 	DefaultSslClientCertificate  string          `json:"defaultSslClientCertificate,omitempty"`
 	EndUserMappingEnabled        bool            `json:"endUserMappingEnabled"`
+	SignAndServe                 bool            `json:"signAndServe,omitempty"`
+	SignAndServeAlgorithm        string          `json:"signAndServeAlgorithm,omitempty"`
 }
 
 type DomainsList struct {
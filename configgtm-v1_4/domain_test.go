@@ -1,7 +1,10 @@
 package configgtm
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
 
@@ -788,3 +791,48 @@ func TestDeleteDomain(t *testing.T) {
 
 }
 */
+
+func TestWaitForPropagation(t *testing.T) {
+
+	defer gock.Off()
+
+	for _, status := range []string{"PENDING", "COMPLETE"} {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Get(fmt.Sprintf("/config-gtm/v1/domains/%s/status/current", gtmTestDomain)).
+			MatchHeader("Accept", "application/vnd.config-gtm.v1.5\\+json").
+			Reply(200).
+			SetHeader("Content-Type", "application/vnd.config-gtm.v1.5+json;charset=UTF-8").
+			BodyString(fmt.Sprintf(`{"changeId": "40e36abd", "propagationStatus": "%s", "passingValidation": true}`, status))
+	}
+
+	Init(config)
+	assert.Error(t, SetSchemaVersion("1.6"))
+	assert.NoError(t, SetSchemaVersion(SchemaVersion15))
+	defer SetSchemaVersion(SchemaVersion14)
+
+	status, err := WaitForPropagation(context.Background(), gtmTestDomain, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, PropagationComplete, status.PropagationStatus)
+	assert.True(t, gock.IsDone())
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-gtm/v1/domains/%s/status/current", gtmTestDomain)).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.5+json;charset=UTF-8").
+		BodyString(`{"changeId": "40e36abd", "propagationStatus": "DENIED", "message": "invalid liveness test"}`)
+
+	_, err = WaitForPropagation(context.Background(), gtmTestDomain, time.Millisecond)
+	assert.IsType(t, &PropagationDeniedError{}, err)
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-gtm/v1/domains/%s/status/current", gtmTestDomain)).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.5+json;charset=UTF-8").
+		BodyString(`{"changeId": "40e36abd", "propagationStatus": "PENDING"}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitForPropagation(ctx, gtmTestDomain, time.Millisecond)
+	assert.Equal(t, context.Canceled, err)
+
+}
@@ -0,0 +1,124 @@
+package configgtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//
+// Support moving the traffic of a property to a secondary datacenter and back
+//
+
+// ErrNotPromoted is returned by Failover.Restore when no secondary is promoted
+var ErrNotPromoted = errors.New("no secondary datacenter is promoted")
+
+// Failover sends all traffic of a property to a secondary datacenter and
+// back, e.g. from a DR runbook driven by external health checks
+//
+// Promote enables only the traffic target of the secondary, with all the
+// weight, and Restore puts back the traffic targets found by the first
+// Promote, kept in Primary. Both wait for the change to propagate. A Failover
+// is not safe for concurrent use.
+type Failover struct {
+	DomainName   string
+	PropertyName string
+	// Interval is the WaitForPropagation polling interval
+	Interval time.Duration
+	// Primary are the traffic targets Restore puts back by datacenter ID, nil
+	// when no secondary is promoted. Save it with the runbook state to
+	// Restore after a restart.
+	Primary map[int]TrafficTarget
+}
+
+// NewFailover returns a Failover of property propertyName of domainName
+func NewFailover(domainName, propertyName string) *Failover {
+	return &Failover{DomainName: domainName, PropertyName: propertyName}
+}
+
+// Promoted reports whether the traffic of the property goes to a secondary datacenter
+func (failover *Failover) Promoted() bool {
+	return failover.Primary != nil
+}
+
+// Promote sends all traffic of the property to the traffic target of
+// datacenterID. Promoting another secondary keeps the traffic targets of the
+// first Promote for Restore.
+func (failover *Failover) Promote(ctx context.Context, datacenterID int) (*ResponseStatus, error) {
+	property, err := GetProperty(failover.PropertyName, failover.DomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var secondary *TrafficTarget
+	for _, target := range property.TrafficTargets {
+		if target.DatacenterId == datacenterID {
+			secondary = target
+		}
+	}
+	if secondary == nil {
+		return nil, fmt.Errorf("property %s has no traffic target for datacenter %d", failover.PropertyName, datacenterID)
+	}
+
+	primary := failover.Primary
+	if !failover.Promoted() {
+		primary = map[int]TrafficTarget{}
+		for _, target := range property.TrafficTargets {
+			primary[target.DatacenterId] = *target
+		}
+	}
+
+	for _, target := range property.TrafficTargets {
+		target.Enabled = false
+		target.Weight = 0
+	}
+	secondary.Enabled = true
+	secondary.Weight = 100
+
+	if _, err := property.Update(failover.DomainName); err != nil {
+		return nil, err
+	}
+	failover.Primary = primary
+
+	return WaitForPropagation(ctx, failover.DomainName, failover.Interval)
+}
+
+// Restore puts back the enabled traffic targets and weights the property had before Promote
+func (failover *Failover) Restore(ctx context.Context) (*ResponseStatus, error) {
+	if !failover.Promoted() {
+		return nil, ErrNotPromoted
+	}
+
+	property, err := GetProperty(failover.PropertyName, failover.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range property.TrafficTargets {
+		if primary, ok := failover.Primary[target.DatacenterId]; ok {
+			target.Enabled = primary.Enabled
+			target.Weight = primary.Weight
+		}
+	}
+
+	if _, err := property.Update(failover.DomainName); err != nil {
+		return nil, err
+	}
+	failover.Primary = nil
+
+	return WaitForPropagation(ctx, failover.DomainName, failover.Interval)
+}
+
+// Apply promotes datacenterID when healthy is false and restores the
+// property when it is true, a signal matching the current state changes
+// nothing and returns a nil status
+func (failover *Failover) Apply(ctx context.Context, healthy bool, datacenterID int) (*ResponseStatus, error) {
+	switch {
+	case !healthy && !failover.Promoted():
+		return failover.Promote(ctx, datacenterID)
+	case healthy && failover.Promoted():
+		return failover.Restore(ctx)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package configgtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const failoverTargets = `[
+	{"datacenterId": 3131, "enabled": true, "weight": 80.0, "servers": ["192.0.2.1"]},
+	{"datacenterId": 3132, "enabled": true, "weight": 20.0, "servers": ["198.51.100.1"]},
+	{"datacenterId": 3133, "enabled": false, "servers": ["203.0.113.1"]}
+]`
+
+const failoverPromotedTargets = `[
+	{"datacenterId": 3131, "enabled": false, "servers": ["192.0.2.1"]},
+	{"datacenterId": 3132, "enabled": false, "servers": ["198.51.100.1"]},
+	{"datacenterId": 3133, "enabled": true, "weight": 100.0, "servers": ["203.0.113.1"]}
+]`
+
+// mockFailoverUpdate mocks the GET and PUT of the failover property and the
+// propagation of the change, the PUT must send wantTargets
+func mockFailoverUpdate(t *testing.T, targets string, wantTargets string) {
+	propertyPath := fmt.Sprintf("/config-gtm/v1/domains/%s/properties/%s", gtmTestDomain, GtmTestProperty)
+	property := fmt.Sprintf(`{"name": "%s", "type": "weighted-round-robin", "scoreAggregationType": "median", "handoutLimit": 1, "handoutMode": "normal", "trafficTargets": %s}`, GtmTestProperty, targets)
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(propertyPath).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.4+json;charset=UTF-8").
+		BodyString(property)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put(propertyPath).
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return false, err
+			}
+			sent := struct {
+				TrafficTargets []TrafficTarget `json:"trafficTargets"`
+			}{}
+			var want []TrafficTarget
+			if err := json.Unmarshal(body, &sent); err != nil {
+				return false, err
+			}
+			if err := json.Unmarshal([]byte(wantTargets), &want); err != nil {
+				return false, err
+			}
+			return assert.Equal(t, want, sent.TrafficTargets), nil
+		}).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.4+json;charset=UTF-8").
+		BodyString(fmt.Sprintf(`{"resource": %s, "status": {"changeId": "ca4ca7a9", "propagationStatus": "PENDING"}}`, property))
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-gtm/v1/domains/%s/status/current", gtmTestDomain)).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.4+json;charset=UTF-8").
+		BodyString(`{"changeId": "ca4ca7a9", "propagationStatus": "COMPLETE", "passingValidation": true}`)
+}
+
+func TestFailover(t *testing.T) {
+
+	defer gock.Off()
+
+	Init(config)
+	failover := NewFailover(gtmTestDomain, GtmTestProperty)
+	failover.Interval = time.Millisecond
+
+	status, err := failover.Apply(context.Background(), true, 3133)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+	_, err = failover.Restore(context.Background())
+	assert.Equal(t, ErrNotPromoted, err)
+
+	mockFailoverUpdate(t, failoverTargets, failoverPromotedTargets)
+	status, err = failover.Apply(context.Background(), false, 3133)
+	assert.NoError(t, err)
+	assert.Equal(t, PropagationComplete, status.PropagationStatus)
+	assert.True(t, failover.Promoted())
+	assert.True(t, gock.IsDone())
+
+	// a restarted runbook restores from the saved Primary
+	restarted := NewFailover(gtmTestDomain, GtmTestProperty)
+	restarted.Interval = time.Millisecond
+	restarted.Primary = failover.Primary
+
+	mockFailoverUpdate(t, failoverPromotedTargets, failoverTargets)
+	status, err = restarted.Apply(context.Background(), true, 3133)
+	assert.NoError(t, err)
+	assert.Equal(t, PropagationComplete, status.PropagationStatus)
+	assert.False(t, restarted.Promoted())
+	assert.True(t, gock.IsDone())
+
+}
+
+func TestFailoverUnknownDatacenter(t *testing.T) {
+
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get(fmt.Sprintf("/config-gtm/v1/domains/%s/properties/%s", gtmTestDomain, GtmTestProperty)).
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.4+json;charset=UTF-8").
+		BodyString(fmt.Sprintf(`{"name": "%s", "type": "weighted-round-robin", "trafficTargets": %s}`, GtmTestProperty, failoverTargets))
+
+	Init(config)
+	_, err := NewFailover(gtmTestDomain, GtmTestProperty).Promote(context.Background(), 9999)
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+
+}
@@ -0,0 +1,241 @@
+package configgtm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//
+// Build gtm geomap assignments from region sets
+// Based on 1.4 schema
+//
+
+// continentCountries are the ISO 3166-1 alpha-2 country codes GTM geographic
+// maps accept, by continent
+var continentCountries = map[string][]string{
+	"AFRICA": {"AO", "BF", "BI", "BJ", "BW", "CD", "CF", "CG", "CI", "CM", "CV", "DJ", "DZ", "EG", "EH", "ER",
+		"ET", "GA", "GH", "GM", "GN", "GQ", "GW", "KE", "KM", "LR", "LS", "LY", "MA", "MG", "ML", "MR", "MU",
+		"MW", "MZ", "NA", "NE", "NG", "RE", "RW", "SC", "SD", "SH", "SL", "SN", "SO", "SS", "ST", "SZ", "TD",
+		"TG", "TN", "TZ", "UG", "YT", "ZA", "ZM", "ZW"},
+	"ANTARCTICA": {"AQ", "BV", "GS", "HM", "TF"},
+	"ASIA": {"AE", "AF", "AM", "AZ", "BD", "BH", "BN", "BT", "CC", "CN", "CX", "CY", "GE", "HK", "ID", "IL",
+		"IN", "IO", "IQ", "IR", "JO", "JP", "KG", "KH", "KP", "KR", "KW", "KZ", "LA", "LB", "LK", "MM", "MN",
+		"MO", "MV", "MY", "NP", "OM", "PH", "PK", "PS", "QA", "SA", "SG", "SY", "TH", "TJ", "TL", "TM", "TR",
+		"TW", "UZ", "VN", "YE"},
+	"EUROPE": {"AD", "AL", "AT", "AX", "BA", "BE", "BG", "BY", "CH", "CZ", "DE", "DK", "EE", "ES", "FI", "FO",
+		"FR", "GB", "GG", "GI", "GR", "HR", "HU", "IE", "IM", "IS", "IT", "JE", "LI", "LT", "LU", "LV", "MC",
+		"MD", "ME", "MK", "MT", "NL", "NO", "PL", "PT", "RO", "RS", "RU", "SE", "SI", "SJ", "SK", "SM", "UA",
+		"VA"},
+	"NORTH_AMERICA": {"AG", "AI", "AW", "BB", "BL", "BM", "BQ", "BS", "BZ", "CA", "CR", "CU", "CW", "DM", "DO",
+		"GD", "GL", "GP", "GT", "HN", "HT", "JM", "KN", "KY", "LC", "MF", "MQ", "MS", "MX", "NI", "PA", "PM",
+		"PR", "SV", "SX", "TC", "TT", "UM", "US", "VC", "VG", "VI"},
+	"OCEANIA": {"AS", "AU", "CK", "FJ", "FM", "GU", "KI", "MH", "MP", "NC", "NF", "NR", "NU", "NZ", "PF", "PG",
+		"PN", "PW", "SB", "TK", "TO", "TV", "VU", "WF", "WS"},
+	"SOUTH_AMERICA": {"AR", "BO", "BR", "CL", "CO", "EC", "FK", "GF", "GY", "PE", "PY", "SR", "UY", "VE"},
+}
+
+// middleEast are the countries of ASIA that APAC excludes and EMEA includes
+var middleEast = []string{"AE", "BH", "CY", "IL", "IQ", "IR", "JO", "KW", "LB", "OM", "PS", "QA", "SA", "SY", "TR", "YE"}
+
+// regionExpressions are the regions that are not continents, as region expressions
+var regionExpressions = map[string]string{
+	"EU":          "EUROPE",
+	"MIDDLE_EAST": strings.Join(middleEast, " "),
+	"APAC":        "ASIA OCEANIA minus MIDDLE_EAST",
+	"EMEA":        "EUROPE MIDDLE_EAST AFRICA",
+	"AMERICAS":    "NORTH_AMERICA SOUTH_AMERICA",
+	"LATAM":       "AMERICAS minus US CA BM GL PM UM",
+	"WORLD":       "AFRICA ANTARCTICA ASIA EUROPE NORTH_AMERICA OCEANIA SOUTH_AMERICA",
+}
+
+// IsSupportedCountry reports whether code is a country code GTM geographic maps accept
+func IsSupportedCountry(code string) bool {
+	for _, countries := range continentCountries {
+		for _, country := range countries {
+			if country == code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Regions returns the sorted names of the regions ParseRegion accepts
+func Regions() []string {
+	names := make([]string, 0, len(continentCountries)+len(regionExpressions))
+	for name := range continentCountries {
+		names = append(names, name)
+	}
+	for name := range regionExpressions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ParseRegion returns the sorted country codes of a region expression, e.g.
+// "EU", "APAC minus CN" or "EMEA - RU, BY"
+//
+// An expression is a list of region names (see Regions) and country codes,
+// case insensitive and separated by spaces or commas. Terms are added to the
+// set, or removed from it after "minus", "except" or "-" until the next
+// "plus", "and" or "+". Continent codes that are also country codes, such as
+// AS (American Samoa) or SA (Saudi Arabia), are always country codes: use the
+// region names instead.
+func ParseRegion(expression string) ([]string, error) {
+	countries, err := parseRegion(expression, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(countries))
+	for code := range countries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes, nil
+}
+
+func parseRegion(expression string, depth int) (map[string]bool, error) {
+	if depth > len(regionExpressions) {
+		return nil, fmt.Errorf("region %q is recursive", expression)
+	}
+
+	spaced := strings.NewReplacer("+", " + ", "-", " - ").Replace(expression)
+	terms := strings.FieldsFunc(spaced, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty region")
+	}
+
+	countries := map[string]bool{}
+	remove := false
+	operator := ""
+	for _, term := range terms {
+		term = strings.ToUpper(term)
+		switch term {
+		case "MINUS", "EXCEPT", "-":
+			remove, operator = true, term
+			continue
+		case "PLUS", "AND", "+":
+			remove, operator = false, term
+			continue
+		}
+		operator = ""
+
+		var codes []string
+		if continent, ok := continentCountries[term]; ok {
+			codes = continent
+		} else if region, ok := regionExpressions[term]; ok {
+			set, err := parseRegion(region, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			for code := range set {
+				codes = append(codes, code)
+			}
+		} else if IsSupportedCountry(term) {
+			codes = []string{term}
+		} else {
+			return nil, fmt.Errorf("unknown region or country code %q", term)
+		}
+
+		for _, code := range codes {
+			if remove {
+				delete(countries, code)
+			} else {
+				countries[code] = true
+			}
+		}
+	}
+	if operator != "" {
+		return nil, fmt.Errorf("region %q ends with %q", strings.TrimSpace(expression), strings.ToLower(operator))
+	}
+
+	return countries, nil
+}
+
+// GeoMapBuilder builds a GeoMap from region expressions, see ParseRegion
+type GeoMapBuilder struct {
+	geo *GeoMap
+	// datacenters are the datacenter IDs by assigned country code
+	datacenters map[string]int
+}
+
+// NewGeoMapBuilder creates a GeoMapBuilder for a GeoMap sending the countries
+// no assignment covers to defaultDatacenterID
+func NewGeoMapBuilder(name string, defaultDatacenterID int, defaultNickname string) *GeoMapBuilder {
+	geo := NewGeoMap(name)
+	geo.DefaultDatacenter = geo.NewDefaultDatacenter(defaultDatacenterID)
+	geo.DefaultDatacenter.Nickname = defaultNickname
+
+	return &GeoMapBuilder{geo: geo, datacenters: map[string]int{}}
+}
+
+// Assign assigns the countries of the region expressions to a datacenter
+//
+// A country can only be assigned to one datacenter, assigning a region to
+// the same datacenter again adds its countries to the existing assignment.
+func (builder *GeoMapBuilder) Assign(dcID int, nickname string, regions ...string) error {
+	if len(regions) == 0 {
+		return fmt.Errorf("datacenter %d: no region to assign", dcID)
+	}
+
+	var countries []string
+	for _, region := range regions {
+		codes, err := ParseRegion(region)
+		if err != nil {
+			return fmt.Errorf("datacenter %d: %w", dcID, err)
+		}
+		countries = append(countries, codes...)
+	}
+	for _, code := range countries {
+		if assigned, ok := builder.datacenters[code]; ok && assigned != dcID {
+			return fmt.Errorf("datacenter %d: country %s is already assigned to datacenter %d", dcID, code, assigned)
+		}
+	}
+
+	var assignment *GeoAssignment
+	for _, existing := range builder.geo.Assignments {
+		if existing.DatacenterId == dcID {
+			assignment = existing
+		}
+	}
+	if assignment == nil {
+		assignment = builder.geo.NewAssignment(dcID, nickname)
+		builder.geo.Assignments = append(builder.geo.Assignments, assignment)
+	}
+	for _, code := range countries {
+		if _, ok := builder.datacenters[code]; !ok {
+			builder.datacenters[code] = dcID
+			assignment.Countries = append(assignment.Countries, code)
+		}
+	}
+	sort.Strings(assignment.Countries)
+
+	return nil
+}
+
+// Build returns the GeoMap, its assignments sorted by datacenter ID
+func (builder *GeoMapBuilder) Build() (*GeoMap, error) {
+	if len(builder.geo.Assignments) == 0 {
+		return nil, fmt.Errorf("geographic map %s has no assignments", builder.geo.Name)
+	}
+
+	geo := *builder.geo
+	geo.Assignments = make([]*GeoAssignment, 0, len(builder.geo.Assignments))
+	for _, assignment := range builder.geo.Assignments {
+		countries := append([]string{}, assignment.Countries...)
+		geo.Assignments = append(geo.Assignments, &GeoAssignment{DatacenterBase: assignment.DatacenterBase, Countries: countries})
+	}
+	sort.Slice(geo.Assignments, func(i, j int) bool {
+		return geo.Assignments[i].DatacenterId < geo.Assignments[j].DatacenterId
+	})
+
+	return &geo, nil
+}
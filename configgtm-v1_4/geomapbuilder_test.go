@@ -0,0 +1,110 @@
+package configgtm
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegion(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		contains   []string
+		excludes   []string
+		count      int
+	}{
+		"continent": {
+			expression: "EU",
+			contains:   []string{"GB", "FR", "DE", "RU"},
+			excludes:   []string{"US", "TR"},
+			count:      51,
+		},
+		"minus": {
+			expression: "APAC minus CN",
+			contains:   []string{"JP", "AU", "IN", "SG"},
+			excludes:   []string{"CN", "AE", "IL", "SA"},
+		},
+		"operators and commas": {
+			expression: "emea - RU, BY + us",
+			contains:   []string{"GB", "ZA", "AE", "US"},
+			excludes:   []string{"RU", "BY"},
+		},
+		"country codes": {
+			expression: "GB IE",
+			contains:   []string{"GB", "IE"},
+			count:      2,
+		},
+		"latam": {
+			expression: "LATAM",
+			contains:   []string{"MX", "BR", "PA"},
+			excludes:   []string{"US", "CA"},
+		},
+		"world": {
+			expression: "WORLD",
+			count:      249,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			codes, err := ParseRegion(test.expression)
+			require.NoError(t, err)
+			for _, code := range test.contains {
+				assert.Contains(t, codes, code)
+			}
+			for _, code := range test.excludes {
+				assert.NotContains(t, codes, code)
+			}
+			if test.count != 0 {
+				assert.Len(t, codes, test.count)
+			}
+			assert.True(t, sort.StringsAreSorted(codes))
+		})
+	}
+}
+
+func TestParseRegionErrors(t *testing.T) {
+	tests := map[string]string{
+		"":                "empty region",
+		"EU minus XX":     `unknown region or country code "XX"`,
+		"APAC minus":      `region "APAC minus" ends with "minus"`,
+		"north america":   `unknown region or country code "NORTH"`,
+		"EUROPE, NOWHERE": `unknown region or country code "NOWHERE"`,
+	}
+
+	for expression, expected := range tests {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseRegion(expression)
+			assert.EqualError(t, err, expected)
+		})
+	}
+}
+
+func TestGeoMapBuilder(t *testing.T) {
+	builder := NewGeoMapBuilder(GtmTestGeoMap, 5400, "Default Mapping")
+	require.NoError(t, builder.Assign(3133, "Europe", "EU minus GB, IE"))
+	require.NoError(t, builder.Assign(3131, "UK and Ireland", "GB IE"))
+	require.NoError(t, builder.Assign(3133, "Europe", "MIDDLE_EAST"))
+
+	err := builder.Assign(3132, "Asia", "APAC", "TR")
+	assert.EqualError(t, err, "datacenter 3132: country TR is already assigned to datacenter 3133")
+	err = builder.Assign(3132, "Asia", "APAC minus XX")
+	assert.EqualError(t, err, `datacenter 3132: unknown region or country code "XX"`)
+
+	geo, err := builder.Build()
+	require.NoError(t, err)
+	assert.Equal(t, GtmTestGeoMap, geo.Name)
+	assert.Equal(t, &DatacenterBase{DatacenterId: 5400, Nickname: "Default Mapping"}, geo.DefaultDatacenter)
+	require.Len(t, geo.Assignments, 2)
+	assert.Equal(t, 3131, geo.Assignments[0].DatacenterId)
+	assert.Equal(t, []string{"GB", "IE"}, geo.Assignments[0].Countries)
+	assert.Equal(t, 3133, geo.Assignments[1].DatacenterId)
+	assert.Contains(t, geo.Assignments[1].Countries, "TR")
+	assert.NotContains(t, geo.Assignments[1].Countries, "GB")
+	assert.Len(t, geo.Assignments[1].Countries, 49+len(middleEast))
+
+	_, err = NewGeoMapBuilder("empty", 5400, "Default Mapping").Build()
+	assert.EqualError(t, err, "geographic map empty has no assignments")
+}
@@ -0,0 +1,60 @@
+package configgtm
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//
+// Support waiting for domain changes to propagate
+//
+
+// Propagation status values of ResponseStatus.PropagationStatus
+const (
+	PropagationPending  = "PENDING"
+	PropagationComplete = "COMPLETE"
+	PropagationDenied   = "DENIED"
+)
+
+// DefaultPropagationInterval is the WaitForPropagation polling interval used when none is given
+var DefaultPropagationInterval = 30 * time.Second
+
+// PropagationDeniedError is returned by WaitForPropagation when a change is denied
+type PropagationDeniedError struct {
+	DomainName string
+	Status     *ResponseStatus
+}
+
+func (e *PropagationDeniedError) Error() string {
+	return fmt.Sprintf("Domain \"%s\" change %s denied: %s", e.DomainName, e.Status.ChangeId, e.Status.Message)
+}
+
+// WaitForPropagation polls the status of domainName every interval until the
+// last change is COMPLETE, it is DENIED, or ctx is done.
+func WaitForPropagation(ctx context.Context, domainName string, interval time.Duration) (*ResponseStatus, error) {
+
+	if interval <= 0 {
+		interval = DefaultPropagationInterval
+	}
+
+	for {
+		status, err := GetDomainStatus(domainName)
+		if err != nil {
+			return nil, err
+		}
+		switch status.PropagationStatus {
+		case PropagationComplete:
+			return status, nil
+		case PropagationDenied:
+			return status, &PropagationDeniedError{DomainName: domainName, Status: status}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+}
@@ -0,0 +1,160 @@
+package configgtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
+	"strings"
+)
+
+//
+// Support gtm domain validation (dry-run) thru Edgegrid
+// Based on 1.4 Schema
+//
+
+// ValidationProblem is a single problem found by GTM validation
+type ValidationProblem struct {
+	Type   string `json:"type,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	// Path of the offending object, e.g. properties[www].trafficTargets[0].servers
+	Path string `json:"path,omitempty"`
+	// Field is reported by older schema versions instead of Path
+	Field string `json:"field,omitempty"`
+}
+
+// ObjectPath returns the path of the offending object
+func (problem ValidationProblem) ObjectPath() string {
+	if problem.Path != "" {
+		return problem.Path
+	}
+
+	return problem.Field
+}
+
+func (problem ValidationProblem) String() string {
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+	if path := problem.ObjectPath(); path != "" {
+		return fmt.Sprintf("%s: %s", path, message)
+	}
+
+	return message
+}
+
+// ValidationResult is the outcome of validating a domain without saving it
+type ValidationResult struct {
+	PassingValidation bool
+	Message           string
+	Problems          []ValidationProblem
+}
+
+// Error returns the problems as a single string, one per line
+func (result *ValidationResult) Error() string {
+	problems := make([]string, 0, len(result.Problems))
+	for _, problem := range result.Problems {
+		problems = append(problems, problem.String())
+	}
+	if len(problems) == 0 {
+		return result.Message
+	}
+
+	return strings.Join(problems, "\n")
+}
+
+// Validate is a method applied to a domain object that validates the
+// complete domain configuration without saving it.
+//
+// A configuration that fails validation is not an error: check
+// ValidationResult.PassingValidation and ValidationResult.Problems.
+func (domain *Domain) Validate(queryArgs map[string]string) (*ValidationResult, error) {
+
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		"/config-gtm/v1/domains/validate",
+		domain,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// set schema version
+	setVersionHeader(req, schemaVersion)
+
+	// Look for optional args
+	if len(queryArgs) > 0 {
+		q := req.URL.Query()
+		if val, ok := queryArgs["contractId"]; ok {
+			q.Add("contractId", strings.TrimPrefix(val, "ctr_"))
+		}
+		if val, ok := queryArgs["gid"]; ok {
+			q.Add("gid", strings.TrimPrefix(val, "grp_"))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	printHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+
+	// Network error
+	if err != nil {
+		return nil, CommonError{
+			entityName:       "Domain",
+			name:             domain.Name,
+			httpErrorMessage: err.Error(),
+			err:              err,
+		}
+	}
+
+	printHttpResponse(res, true)
+
+	body, err := client.ReadBody(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validation failures are reported as a 400 problem with per object errors
+	if res.StatusCode == 400 {
+		problem := struct {
+			Title    string              `json:"title"`
+			Detail   string              `json:"detail"`
+			Errors   []ValidationProblem `json:"errors"`
+			Problems []ValidationProblem `json:"problems"`
+		}{}
+		if err := json.Unmarshal(body, &problem); err == nil && len(problem.Errors)+len(problem.Problems) > 0 {
+			message := problem.Detail
+			if message == "" {
+				message = problem.Title
+			}
+			return &ValidationResult{
+				Message:  message,
+				Problems: append(problem.Errors, problem.Problems...),
+			}, nil
+		}
+	}
+
+	// API error
+	if client.IsError(res) {
+		err := client.NewAPIErrorFromBody(res, body)
+		return nil, CommonError{entityName: "Domain", name: domain.Name, apiErrorMessage: err.Detail, err: err}
+	}
+
+	responseBody := &DomainResponse{}
+	if err := jsonhooks.Unmarshal(body, responseBody); err != nil {
+		return nil, err
+	}
+
+	result := &ValidationResult{PassingValidation: true}
+	if responseBody.Status != nil {
+		result.PassingValidation = responseBody.Status.PassingValidation
+		result.Message = responseBody.Status.Message
+	}
+
+	return result, nil
+
+}
@@ -0,0 +1,81 @@
+package configgtm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// Verify Validate reports problems with object paths
+func TestValidateDomain(t *testing.T) {
+
+	defer gock.Off()
+
+	mock := gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/config-gtm/v1/domains/validate")
+	mock.
+		Post("/config-gtm/v1/domains/validate").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			domain := struct {
+				Name string `json:"name"`
+			}{}
+			body, err := ioutil.ReadAll(req.Body)
+			if err == nil {
+				err = json.Unmarshal(body, &domain)
+			}
+			return domain.Name == gtmTestDomain, err
+		}).
+		HeaderPresent("Authorization").
+		Reply(400).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{
+			"type": "https://problems.luna.akamaiapis.net/config-gtm/v1/validationFailed",
+			"title": "Validation Failed",
+			"status": 400,
+			"detail": "Domain validation failed",
+			"errors": [
+				{"type": "invalidValue", "detail": "Traffic target weights must add up to more than 0", "path": "properties[testproperty].trafficTargets"},
+				{"type": "missingValue", "title": "Handout CNAME required", "field": "properties[testproperty].trafficTargets[1].handoutCName"}
+			]
+		}`)
+
+	Init(config)
+
+	result, err := instantiateDomain().Validate(map[string]string{"contractId": "ctr_1-3CV382"})
+	require.NoError(t, err)
+	assert.False(t, result.PassingValidation)
+	assert.Equal(t, "Domain validation failed", result.Message)
+	require.Len(t, result.Problems, 2)
+	assert.Equal(t, "properties[testproperty].trafficTargets", result.Problems[0].ObjectPath())
+	assert.Equal(t, "properties[testproperty].trafficTargets[1].handoutCName: Handout CNAME required", result.Problems[1].String())
+
+}
+
+// Verify Validate passes a valid domain
+func TestValidateDomainPassing(t *testing.T) {
+
+	defer gock.Off()
+
+	mock := gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/config-gtm/v1/domains/validate")
+	mock.
+		Post("/config-gtm/v1/domains/validate").
+		HeaderPresent("Authorization").
+		Reply(200).
+		SetHeader("Content-Type", "application/vnd.config-gtm.v1.4+json;charset=UTF-8").
+		BodyString(`{
+			"resource": {"name": "gtmdomtest.akadns.net", "type": "basic"},
+			"status": {"message": "Validation Passed", "passingValidation": true}
+		}`)
+
+	Init(config)
+
+	result, err := instantiateDomain().Validate(nil)
+	require.NoError(t, err)
+	assert.True(t, result.PassingValidation)
+	assert.Empty(t, result.Problems)
+
+}
@@ -0,0 +1,186 @@
+package contracts
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// Depth is used to create an "enum" of possible ListContracts depth values
+type Depth string
+
+const (
+	// DepthTop Depth value TOP, the top-level contracts only
+	DepthTop Depth = "TOP"
+	// DepthAll Depth value ALL, the top-level contracts and their children
+	DepthAll Depth = "ALL"
+)
+
+// Product is a marketing product of a contract
+//
+// Its ID (e.g. M-LC-2) is not a PAPI product ID (e.g. prd_Fresca), use
+// ListPAPIProducts for the products PAPI accepts.
+//
+// API Docs: https://developer.akamai.com/api/core_features/contracts/v1.html#marketingproduct
+type Product struct {
+	ProductID   string `json:"marketingProductId"`
+	ProductName string `json:"marketingProductName"`
+}
+
+// ContractProduct is a contract and one of its products
+type ContractProduct struct {
+	// ContractID is the PAPI form of the contract ID, e.g. ctr_1-1ABCD
+	ContractID string
+	// ProductID is the marketing product ID, see Product
+	ProductID   string
+	ProductName string
+}
+
+// PAPIProduct is a product PAPI creates properties with
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getproducts
+type PAPIProduct struct {
+	ProductID   string `json:"productId"`
+	ProductName string `json:"productName"`
+}
+
+// ContractID returns a contract ID without the ctr_ prefix of other APIs,
+// e.g. PAPI, as the Contracts API expects it
+func ContractID(id string) string {
+	return strings.TrimPrefix(id, "ctr_")
+}
+
+// PAPIContractID returns a contract ID with the ctr_ prefix PAPI expects
+func PAPIContractID(id string) string {
+	return "ctr_" + ContractID(id)
+}
+
+// ListContracts lists the IDs of the contracts of the account
+//
+// API Docs: https://developer.akamai.com/api/core_features/contracts/v1.html#getcontractidentifiers
+// Endpoint: GET /contract-api/v1/contracts/identifiers{?depth}
+func ListContracts(depth Depth) ([]string, error) {
+	path, err := client.PathWithQuery("/contract-api/v1/contracts/identifiers", struct {
+		Depth Depth `query:"depth,omitempty"`
+	}{depth})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if err := doJSON("GET", path, nil, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ListProducts lists the products of a contract, for the current month unless
+// from and to are set
+//
+// API Docs: https://developer.akamai.com/api/core_features/contracts/v1.html#getproductspercontract
+// Endpoint: GET /contract-api/v1/contracts/{contractId}/products/summaries{?from,to}
+func ListProducts(contractID string, from, to time.Time) ([]Product, error) {
+	path, err := client.PathWithQuery(
+		fmt.Sprintf("/contract-api/v1/contracts/%s/products/summaries", url.PathEscape(ContractID(contractID))),
+		struct {
+			From string `query:"from,omitempty"`
+			To   string `query:"to,omitempty"`
+		}{formatDate(from), formatDate(to)},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Products struct {
+			ContractID        string    `json:"contractId"`
+			MarketingProducts []Product `json:"marketing-products"`
+		} `json:"products"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Products.MarketingProducts, nil
+}
+
+// ListPAPIProducts lists the products of a contract with their PAPI IDs
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getproducts
+// Endpoint: GET /papi/v1/products{?contractId}
+func ListPAPIProducts(contractID string) ([]PAPIProduct, error) {
+	path, err := client.PathWithQuery("/papi/v1/products", struct {
+		ContractID string `query:"contractId"`
+	}{PAPIContractID(contractID)})
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Products struct {
+			Items []PAPIProduct `json:"items"`
+		} `json:"products"`
+	}{}
+	if err := doJSON("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Products.Items, nil
+}
+
+// ListContractProducts lists the contracts of the account at depth and their
+// current marketing products, sorted by contract and product ID
+//
+// The contract IDs are in PAPI form, the product IDs are not (see Product).
+func ListContractProducts(depth Depth) ([]ContractProduct, error) {
+	ids, err := ListContracts(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs []ContractProduct
+	for _, id := range ids {
+		products, err := ListProducts(id, time.Time{}, time.Time{})
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", id, err)
+		}
+		for _, product := range products {
+			pairs = append(pairs, ContractProduct{ContractID: PAPIContractID(id), ProductID: product.ProductID, ProductName: product.ProductName})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].ContractID != pairs[j].ContractID {
+			return pairs[i].ContractID < pairs[j].ContractID
+		}
+		return pairs[i].ProductID < pairs[j].ProductID
+	})
+
+	return pairs, nil
+}
+
+// FindProduct returns the contracts with a product, by product ID or name
+// (case-insensitive)
+func FindProduct(pairs []ContractProduct, product string) []ContractProduct {
+	var found []ContractProduct
+	for _, pair := range pairs {
+		if strings.EqualFold(pair.ProductID, product) || strings.EqualFold(pair.ProductName, product) {
+			found = append(found, pair)
+		}
+	}
+
+	return found
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,95 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestListContractProducts(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/contract-api/v1/contracts/identifiers").
+		MatchParam("depth", "ALL").
+		Reply(200).
+		JSON(`["1-2ABCD", "1-1ABCD"]`)
+	gock.New(baseURL).
+		Get("/contract-api/v1/contracts/1-2ABCD/products/summaries").
+		Reply(200).
+		JSON(`{"products": {"contractId": "1-2ABCD", "marketing-products": [{"marketingProductId": "M-LC-2", "marketingProductName": "Ion Standard"}]}}`)
+	gock.New(baseURL).
+		Get("/contract-api/v1/contracts/1-1ABCD/products/summaries").
+		Reply(200).
+		JSON(`{"products": {"contractId": "1-1ABCD", "marketing-products": [
+			{"marketingProductId": "M-LC-3", "marketingProductName": "Download Delivery"},
+			{"marketingProductId": "M-LC-2", "marketingProductName": "Ion Standard"}]}}`)
+
+	Init(config)
+
+	pairs, err := ListContractProducts(DepthAll)
+	require.NoError(t, err)
+	assert.Equal(t, []ContractProduct{
+		{ContractID: "ctr_1-1ABCD", ProductID: "M-LC-2", ProductName: "Ion Standard"},
+		{ContractID: "ctr_1-1ABCD", ProductID: "M-LC-3", ProductName: "Download Delivery"},
+		{ContractID: "ctr_1-2ABCD", ProductID: "M-LC-2", ProductName: "Ion Standard"},
+	}, pairs)
+	assert.Len(t, FindProduct(pairs, "ion standard"), 2)
+	assert.True(t, gock.IsDone())
+}
+
+func TestListProducts(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/contract-api/v1/contracts/1-1ABCD/products/summaries").
+		MatchParam("from", "2020-05-01").
+		MatchParam("to", "2020-05-31").
+		Reply(200).
+		JSON(`{"products": {"contractId": "1-1ABCD", "marketing-products": [{"marketingProductId": "M-LC-3", "marketingProductName": "Download Delivery"}]}}`)
+
+	Init(config)
+
+	products, err := ListProducts("ctr_1-1ABCD", time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 5, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []Product{{ProductID: "M-LC-3", ProductName: "Download Delivery"}}, products)
+	assert.True(t, gock.IsDone())
+}
+
+func TestListPAPIProducts(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/papi/v1/products").
+		MatchParam("contractId", "ctr_1-1ABCD").
+		Reply(200).
+		JSON(`{"accountId": "act_1-1ABCD", "contractId": "ctr_1-1ABCD", "products": {"items": [{"productId": "prd_Download_Delivery", "productName": "Download_Delivery"}]}}`)
+
+	Init(config)
+
+	products, err := ListPAPIProducts("1-1ABCD")
+	require.NoError(t, err)
+	assert.Equal(t, []PAPIProduct{{ProductID: "prd_Download_Delivery", ProductName: "Download_Delivery"}}, products)
+	assert.True(t, gock.IsDone())
+}
+
+func TestPAPIContractID(t *testing.T) {
+	assert.Equal(t, "ctr_1-1ABCD", PAPIContractID("1-1ABCD"))
+	assert.Equal(t, "ctr_1-1ABCD", PAPIContractID("ctr_1-1ABCD"))
+}
@@ -0,0 +1,305 @@
+package cps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	client "github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// AllowedInputType is used to create an "enum" of possible AllowedInput.Type values
+type AllowedInputType string
+
+const (
+	// InputDVChallenges AllowedInput.Type value lets-encrypt-challenges, the DV challenges to fulfill
+	InputDVChallenges AllowedInputType = "lets-encrypt-challenges"
+	// InputThirdPartyCSR AllowedInput.Type value third-party-csr, the CSR to get signed
+	InputThirdPartyCSR AllowedInputType = "third-party-csr"
+	// InputPreVerificationWarnings AllowedInput.Type value pre-verification-warnings
+	InputPreVerificationWarnings AllowedInputType = "pre-verification-warnings"
+	// InputPostVerificationWarnings AllowedInput.Type value post-verification-warnings
+	InputPostVerificationWarnings AllowedInputType = "post-verification-warnings"
+	// InputChangeManagementInfo AllowedInput.Type value change-management-info
+	InputChangeManagementInfo AllowedInputType = "change-management-info"
+)
+
+// ChangeStatus is the status of an enrollment change, the location of a
+// change is one of the CreateEnrollmentResponse.Changes
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#changestatus
+type ChangeStatus struct {
+	StatusInfo   *StatusInfo    `json:"statusInfo"`
+	AllowedInput []AllowedInput `json:"allowedInput"`
+}
+
+// StatusInfo is the state of a change
+type StatusInfo struct {
+	State              string              `json:"state"`
+	Status             string              `json:"status"`
+	Description        string              `json:"description"`
+	Error              *ChangeError        `json:"error,omitempty"`
+	DeploymentSchedule *DeploymentSchedule `json:"deploymentSchedule,omitempty"`
+}
+
+// ChangeError is the error of a failed change
+type ChangeError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// DeploymentSchedule is the time window a change is deployed in
+type DeploymentSchedule struct {
+	NotAfter  *string `json:"notAfter,omitempty"`
+	NotBefore *string `json:"notBefore,omitempty"`
+}
+
+// AllowedInput is an input the change waits for before it can proceed
+type AllowedInput struct {
+	Type              AllowedInputType `json:"type"`
+	RequiredToProceed bool             `json:"requiredToProceed"`
+	Info              string           `json:"info"`
+	Update            string           `json:"update"`
+}
+
+// ChangeResponse is the response of a change input update or cancellation
+type ChangeResponse struct {
+	Change string `json:"change"`
+}
+
+// Input returns the allowed input of type inputType, if the change waits for it
+func (status *ChangeStatus) Input(inputType AllowedInputType) (*AllowedInput, bool) {
+	for i := range status.AllowedInput {
+		if status.AllowedInput[i].Type == inputType {
+			return &status.AllowedInput[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// GetChangeStatus retrieves the status of the change at changeLocation
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getchangestatus
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}
+func GetChangeStatus(changeLocation string) (*ChangeStatus, error) {
+	var response ChangeStatus
+	if err := doCPS("GET", changeLocation, "application/vnd.akamai.cps.change.v2+json", "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CancelChange cancels the change at changeLocation
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#deleteachange
+// Endpoint: DELETE /cps/v2/enrollments/{enrollmentId}/changes/{changeId}
+func CancelChange(changeLocation string) (*ChangeResponse, error) {
+	var response ChangeResponse
+	if err := doCPS("DELETE", changeLocation, "application/vnd.akamai.cps.change-id.v1+json", "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetVerificationWarnings retrieves the pre or post verification warnings of
+// the change at changeLocation, one warning per line
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getpreverificationwarnings
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/info/{pre,post}-verification-warnings
+func GetVerificationWarnings(changeLocation string, inputType AllowedInputType) ([]string, error) {
+	if inputType != InputPreVerificationWarnings && inputType != InputPostVerificationWarnings {
+		return nil, fmt.Errorf("%s is not a verification warnings input", inputType)
+	}
+
+	response := struct {
+		Warnings string `json:"warnings"`
+	}{}
+	if err := doCPS("GET", inputInfoPath(changeLocation, inputType), "application/vnd.akamai.cps.warnings.v1+json", "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, warning := range strings.Split(response.Warnings, "\n") {
+		if warning = strings.TrimSpace(warning); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings, nil
+}
+
+// AcknowledgeVerificationWarnings acknowledges the pre or post verification
+// warnings of the change at changeLocation so it proceeds
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#postpreverificationwarningsack
+// Endpoint: POST /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/update/{pre,post}-verification-warnings-ack
+func AcknowledgeVerificationWarnings(changeLocation string, inputType AllowedInputType) (*ChangeResponse, error) {
+	if inputType != InputPreVerificationWarnings && inputType != InputPostVerificationWarnings {
+		return nil, fmt.Errorf("%s is not a verification warnings input", inputType)
+	}
+
+	var response ChangeResponse
+	err := doCPS(
+		"POST",
+		inputUpdatePath(changeLocation, string(inputType)+"-ack"),
+		"application/vnd.akamai.cps.change-id.v1+json",
+		"application/vnd.akamai.cps.acknowledgement.v1+json",
+		struct {
+			Acknowledgement string `json:"acknowledgement"`
+		}{"acknowledge"},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ThirdPartyCSR is a CSR of a third-party enrollment change, to be signed by a CA
+type ThirdPartyCSR struct {
+	CSR          string `json:"csr"`
+	KeyAlgorithm string `json:"keyAlgorithm"`
+}
+
+// CertificateAndTrustChain is a signed third-party certificate and its trust chain
+type CertificateAndTrustChain struct {
+	Certificate  string `json:"certificate"`
+	TrustChain   string `json:"trustChain,omitempty"`
+	KeyAlgorithm string `json:"keyAlgorithm"`
+}
+
+// GetThirdPartyCSRs retrieves the CSRs of the third-party change at
+// changeLocation, one per key algorithm of a multi-stacked enrollment
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getthirdpartycsr
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/info/third-party-csr
+func GetThirdPartyCSRs(changeLocation string) ([]ThirdPartyCSR, error) {
+	response := struct {
+		CSRs []ThirdPartyCSR `json:"csrs"`
+	}{}
+	if err := doCPS("GET", inputInfoPath(changeLocation, InputThirdPartyCSR), "application/vnd.akamai.cps.csr.v2+json", "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.CSRs, nil
+}
+
+// UploadThirdPartyCertificates uploads the certificates signed for the CSRs
+// of the third-party change at changeLocation
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#postthirdpartycertandtrustchain
+// Endpoint: POST /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/update/third-party-cert-and-trust-chain
+func UploadThirdPartyCertificates(changeLocation string, certificates []CertificateAndTrustChain) (*ChangeResponse, error) {
+	var response ChangeResponse
+	err := doCPS(
+		"POST",
+		inputUpdatePath(changeLocation, "third-party-cert-and-trust-chain"),
+		"application/vnd.akamai.cps.change-id.v1+json",
+		"application/vnd.akamai.cps.certificate-and-trust-chain.v2+json",
+		struct {
+			CertificatesAndTrustChains []CertificateAndTrustChain `json:"certificatesAndTrustChains"`
+		}{certificates},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DVChallenges are the domain validation challenges of a domain of a change
+type DVChallenges struct {
+	Domain             string        `json:"domain"`
+	ValidationStatus   string        `json:"validationStatus"`
+	Expires            string        `json:"expires,omitempty"`
+	Error              string        `json:"error,omitempty"`
+	RequestTimestamp   string        `json:"requestTimestamp,omitempty"`
+	ValidatedTimestamp string        `json:"validatedTimestamp,omitempty"`
+	Challenges         []DVChallenge `json:"challenges"`
+}
+
+// DVChallenge is a way to prove control of a domain, publishing either
+// ResponseBody at FullPath (http-01) or a TXT record (dns-01)
+type DVChallenge struct {
+	Type             string `json:"type"`
+	Status           string `json:"status"`
+	Error            string `json:"error,omitempty"`
+	FullPath         string `json:"fullPath"`
+	RedirectFullPath string `json:"redirectFullPath,omitempty"`
+	ResponseBody     string `json:"responseBody"`
+	Token            string `json:"token"`
+}
+
+// Challenge returns the challenge of type challengeType (http-01 or dns-01), if any
+func (challenges *DVChallenges) Challenge(challengeType string) (*DVChallenge, bool) {
+	for i := range challenges.Challenges {
+		if challenges.Challenges[i].Type == challengeType {
+			return &challenges.Challenges[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// GetDVChallenges retrieves the domain validation challenges of the DV change at changeLocation
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getletsencryptchallenges
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/input/info/lets-encrypt-challenges
+func GetDVChallenges(changeLocation string) ([]DVChallenges, error) {
+	response := struct {
+		DV []DVChallenges `json:"dv"`
+	}{}
+	if err := doCPS("GET", inputInfoPath(changeLocation, InputDVChallenges), "application/vnd.akamai.cps.dv-challenges.v2+json", "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.DV, nil
+}
+
+func inputInfoPath(changeLocation string, inputType AllowedInputType) string {
+	return fmt.Sprintf("%s/input/info/%s", strings.TrimSuffix(changeLocation, "/"), inputType)
+}
+
+func inputUpdatePath(changeLocation string, update string) string {
+	return fmt.Sprintf("%s/input/update/%s", strings.TrimSuffix(changeLocation, "/"), update)
+}
+
+// doCPS sends body (if not nil) as contentType to path and decodes the accept response into out
+func doCPS(method, path, accept, contentType string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return err
+		}
+		reader = buf
+	}
+
+	req, err := client.NewRequest(Config, method, path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Accept", accept)
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	return client.BodyJSON(res, out)
+}
@@ -0,0 +1,94 @@
+package cps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestUpdateEnrollmentAndAcknowledgeWarnings(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/cps/v2/enrollments/10002").
+		MatchParam("allow-cancel-pending-changes", "true").
+		MatchHeader("Content-Type", "application/vnd.akamai.cps.enrollment.v7\\+json").
+		Reply(202).
+		JSON(`{"enrollment": "/cps/v2/enrollments/10002", "changes": ["/cps/v2/enrollments/10002/changes/10002"]}`)
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments/10002/changes/10002").
+		MatchHeader("Accept", "application/vnd.akamai.cps.change.v2\\+json").
+		Reply(200).
+		JSON(`{"statusInfo": {"state": "awaiting-input", "status": "wait-review-pre-verification-safety-checks", "description": "Waiting for you to review pre-verification warnings"}, "allowedInput": [{"type": "pre-verification-warnings", "requiredToProceed": true, "info": "/cps/v2/enrollments/10002/changes/10002/input/info/pre-verification-warnings", "update": "/cps/v2/enrollments/10002/changes/10002/input/update/pre-verification-warnings-ack"}]}`)
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments/10002/changes/10002/input/info/pre-verification-warnings").
+		Reply(200).
+		JSON(`{"warnings": "The key for 'RSA' certificate has expired.\nThe certificate has a SAN that is not in DNS.\n"}`)
+	gock.New(baseURL).
+		Post("/cps/v2/enrollments/10002/changes/10002/input/update/pre-verification-warnings-ack").
+		MatchHeader("Content-Type", "application/vnd.akamai.cps.acknowledgement.v1\\+json").
+		Reply(200).
+		JSON(`{"change": "/cps/v2/enrollments/10002/changes/10002"}`)
+
+	Init(config)
+
+	location := "/cps/v2/enrollments/10002"
+	enrollment := &Enrollment{Location: &location, CertificateType: "san"}
+	response, err := enrollment.Update(UpdateEnrollmentQueryParams{AllowCancelPendingChanges: true})
+	require.NoError(t, err)
+	require.Len(t, response.Changes, 1)
+
+	status, err := GetChangeStatus(response.Changes[0])
+	require.NoError(t, err)
+	input, ok := status.Input(InputPreVerificationWarnings)
+	require.True(t, ok)
+	assert.True(t, input.RequiredToProceed)
+
+	warnings, err := GetVerificationWarnings(response.Changes[0], input.Type)
+	require.NoError(t, err)
+	assert.Len(t, warnings, 2)
+
+	_, err = AcknowledgeVerificationWarnings(response.Changes[0], input.Type)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestThirdPartyCSRAndDVChallenges(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments/10003/changes/20003/input/info/third-party-csr").
+		Reply(200).
+		JSON(`{"csrs": [{"csr": "-----BEGIN CERTIFICATE REQUEST-----\n...", "keyAlgorithm": "RSA"}]}`)
+	gock.New(baseURL).
+		Post("/cps/v2/enrollments/10003/changes/20003/input/update/third-party-cert-and-trust-chain").
+		MatchHeader("Content-Type", "application/vnd.akamai.cps.certificate-and-trust-chain.v2\\+json").
+		Reply(200).
+		JSON(`{"change": "/cps/v2/enrollments/10003/changes/20003"}`)
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments/10004/changes/20004/input/info/lets-encrypt-challenges").
+		MatchHeader("Accept", "application/vnd.akamai.cps.dv-challenges.v2\\+json").
+		Reply(200).
+		JSON(`{"dv": [{"domain": "www.example.com", "validationStatus": "RESPONSE_GENERATED", "challenges": [{"type": "http-01", "status": "pending", "fullPath": "http://www.example.com/.well-known/acme-challenge/abc", "responseBody": "abc.def", "token": "abc"}, {"type": "dns-01", "status": "pending", "fullPath": "_acme-challenge.www.example.com.", "responseBody": "xyz", "token": "xyz"}]}]}`)
+
+	Init(config)
+
+	csrs, err := GetThirdPartyCSRs("/cps/v2/enrollments/10003/changes/20003")
+	require.NoError(t, err)
+	require.Len(t, csrs, 1)
+
+	_, err = UploadThirdPartyCertificates("/cps/v2/enrollments/10003/changes/20003", []CertificateAndTrustChain{
+		{Certificate: "-----BEGIN CERTIFICATE-----\n...", KeyAlgorithm: csrs[0].KeyAlgorithm},
+	})
+	require.NoError(t, err)
+
+	dv, err := GetDVChallenges("/cps/v2/enrollments/10004/changes/20004")
+	require.NoError(t, err)
+	require.Len(t, dv, 1)
+	challenge, ok := dv[0].Challenge("dns-01")
+	require.True(t, ok)
+	assert.Equal(t, "_acme-challenge.www.example.com.", challenge.FullPath)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,119 @@
+package cps
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	client "github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// DeploymentNetwork is used to create an "enum" of networks an enrollment is deployed to
+type DeploymentNetwork string
+
+const (
+	// DeploymentProduction the production network
+	DeploymentProduction DeploymentNetwork = "production"
+	// DeploymentStaging the staging network
+	DeploymentStaging DeploymentNetwork = "staging"
+)
+
+// Deployment represents the certificates of an enrollment deployed on a network
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#deployment
+type Deployment struct {
+	PrimaryCertificate       DeployedCertificate   `json:"primaryCertificate"`
+	MultiStackedCertificates []DeployedCertificate `json:"multiStackedCertificates"`
+	NetworkConfiguration     *NetworkConfiguration `json:"networkConfiguration"`
+}
+
+// DeployedCertificate is a PEM encoded leaf certificate and its trust chain
+type DeployedCertificate struct {
+	Certificate  string `json:"certificate"`
+	TrustChain   string `json:"trustChain"`
+	Expiry       string `json:"expiry,omitempty"`
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+}
+
+// Certificates returns the primary and any multi-stacked certificates
+func (deployment *Deployment) Certificates() []DeployedCertificate {
+	return append([]DeployedCertificate{deployment.PrimaryCertificate}, deployment.MultiStackedCertificates...)
+}
+
+// Parse decodes the leaf certificate and its trust chain
+func (certificate *DeployedCertificate) Parse() (*x509.Certificate, []*x509.Certificate, error) {
+	leaves, err := parsePEMCertificates(certificate.Certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(leaves) == 0 {
+		return nil, nil, errors.New("no certificate found in deployment")
+	}
+
+	chain, err := parsePEMCertificates(certificate.TrustChain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return leaves[0], chain, nil
+}
+
+func parsePEMCertificates(data string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// GetDeployment retrieves the certificates of the enrollment at location deployed on network
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getproductiondeployment
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/deployments/{network}
+func GetDeployment(location string, network DeploymentNetwork) (*Deployment, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("%s/deployments/%s", strings.TrimSuffix(location, "/"), network),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", "application/vnd.akamai.cps.deployment.v3+json")
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	var response Deployment
+	if err = client.BodyJSON(res, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
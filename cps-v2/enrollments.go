@@ -2,7 +2,10 @@ package cps
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"time"
 
 	client "github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
@@ -202,3 +205,94 @@ func CreateEnrollment(data []byte, params CreateEnrollmentQueryParams) (*CreateE
 
 	return enrollment.Create(params)
 }
+
+// UpdateEnrollmentQueryParams are the options of Enrollment.Update
+type UpdateEnrollmentQueryParams struct {
+	// AllowCancelPendingChanges cancels the pending change of the enrollment, if any
+	AllowCancelPendingChanges bool
+	// AllowStagingBypass deploys the change to production without a staging deployment
+	AllowStagingBypass bool
+	DeployNotAfter     *string
+	DeployNotBefore    *string
+}
+
+// Update an Enrollment on CPS, starting a change
+//
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#putasingleenrollment
+// Endpoint: PUT /cps/v2/enrollments/{enrollmentId}{?allow-cancel-pending-changes,allow-staging-bypass,deploy-not-after,deploy-not-before}
+func (enrollment *Enrollment) Update(params UpdateEnrollmentQueryParams) (*CreateEnrollmentResponse, error) {
+	if enrollment.Location == nil {
+		return nil, errors.New("enrollment has no location")
+	}
+
+	q := url.Values{}
+	q.Set("allow-cancel-pending-changes", strconv.FormatBool(params.AllowCancelPendingChanges))
+	q.Set("allow-staging-bypass", strconv.FormatBool(params.AllowStagingBypass))
+	if params.DeployNotAfter != nil {
+		q.Set("deploy-not-after", *params.DeployNotAfter)
+	}
+	if params.DeployNotBefore != nil {
+		q.Set("deploy-not-before", *params.DeployNotBefore)
+	}
+
+	req, err := newRequest(
+		"PUT",
+		fmt.Sprintf("%s?%s", *enrollment.Location, q.Encode()),
+		enrollment,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	var response CreateEnrollmentResponse
+	if err = client.BodyJSON(res, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// RemoveEnrollment deletes the enrollment at location, and its certificates
+// once they are no longer deployed
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#deleteasingleenrollment
+// Endpoint: DELETE /cps/v2/enrollments/{enrollmentId}{?allow-cancel-pending-changes}
+func RemoveEnrollment(location string, allowCancelPendingChanges bool) (*CreateEnrollmentResponse, error) {
+	req, err := client.NewRequest(
+		Config,
+		"DELETE",
+		fmt.Sprintf("%s?allow-cancel-pending-changes=%t", location, allowCancelPendingChanges),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", "application/vnd.akamai.cps.enrollment-status.v1+json")
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	var response CreateEnrollmentResponse
+	if err = client.BodyJSON(res, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,243 @@
+package cps
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// oidSCTList is the X.509 extension holding embedded Signed Certificate Timestamps (RFC 6962)
+var oidSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// OCSPStatus is used to create an "enum" of possible CertificateHealth.OCSPStatus values
+type OCSPStatus string
+
+const (
+	// OCSPGood the responder reports the certificate as valid
+	OCSPGood OCSPStatus = "GOOD"
+	// OCSPRevoked the responder reports the certificate as revoked
+	OCSPRevoked OCSPStatus = "REVOKED"
+	// OCSPUnknown the responder does not know the certificate
+	OCSPUnknown OCSPStatus = "UNKNOWN"
+	// OCSPNotChecked OCSP was skipped or could not be queried
+	OCSPNotChecked OCSPStatus = "NOT_CHECKED"
+)
+
+// SCT is a Signed Certificate Timestamp embedded in a certificate
+//
+// The log signature is not verified, an SCT only shows the certificate was
+// submitted to the log identified by LogID.
+type SCT struct {
+	LogID     string
+	Timestamp time.Time
+}
+
+// CertificateHealth reports the CT and OCSP status of a deployed certificate
+type CertificateHealth struct {
+	Network        DeploymentNetwork
+	CommonName     string
+	SerialNumber   string
+	NotAfter       time.Time
+	SCTs           []SCT
+	OCSPStatus     OCSPStatus
+	OCSPResponder  string
+	OCSPNextUpdate time.Time
+	// Problems lists everything found wrong, empty when healthy
+	Problems []string
+}
+
+// Healthy reports whether no problems were found
+func (health *CertificateHealth) Healthy() bool {
+	return len(health.Problems) == 0
+}
+
+// DaysUntilExpiry returns the number of days left until the certificate expires
+func (health *CertificateHealth) DaysUntilExpiry(now time.Time) int {
+	return int(health.NotAfter.Sub(now).Hours() / 24)
+}
+
+// HealthOptions configures CheckCertificateHealth
+type HealthOptions struct {
+	// MinSCTs is the number of embedded SCTs required, defaults to 2
+	MinSCTs int
+	// SkipOCSP skips querying the OCSP responder
+	SkipOCSP bool
+	// HTTPClient is used to query the OCSP responder, defaults to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// CheckCertificateHealth retrieves the certificates of the enrollment at location
+// deployed on network and inspects each of them
+func CheckCertificateHealth(location string, network DeploymentNetwork, options HealthOptions) ([]CertificateHealth, error) {
+	deployment, err := GetDeployment(location, network)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []CertificateHealth{}
+	for _, certificate := range deployment.Certificates() {
+		report := InspectCertificate(certificate, options)
+		report.Network = network
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// InspectCertificate checks a deployed certificate for embedded SCTs and its OCSP status
+func InspectCertificate(certificate DeployedCertificate, options HealthOptions) CertificateHealth {
+	report := CertificateHealth{OCSPStatus: OCSPNotChecked}
+
+	leaf, chain, err := certificate.Parse()
+	if err != nil {
+		report.Problems = append(report.Problems, err.Error())
+		return report
+	}
+
+	report.CommonName = leaf.Subject.CommonName
+	report.SerialNumber = leaf.SerialNumber.Text(16)
+	report.NotAfter = leaf.NotAfter
+
+	minSCTs := options.MinSCTs
+	if minSCTs <= 0 {
+		minSCTs = 2
+	}
+	if report.SCTs, err = EmbeddedSCTs(leaf); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	} else if len(report.SCTs) < minSCTs {
+		report.Problems = append(report.Problems, fmt.Sprintf("certificate has %d embedded SCTs, %d required", len(report.SCTs), minSCTs))
+	}
+
+	if options.SkipOCSP {
+		return report
+	}
+
+	if len(leaf.OCSPServer) == 0 {
+		report.Problems = append(report.Problems, "certificate has no OCSP responder")
+		return report
+	}
+	report.OCSPResponder = leaf.OCSPServer[0]
+
+	if len(chain) == 0 {
+		report.Problems = append(report.Problems, "trust chain is missing, cannot check OCSP")
+		return report
+	}
+
+	response, err := queryOCSP(options.HTTPClient, report.OCSPResponder, leaf, chain[0])
+	if err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("OCSP check failed: %s", err))
+		return report
+	}
+
+	report.OCSPNextUpdate = response.NextUpdate
+	switch response.Status {
+	case ocsp.Good:
+		report.OCSPStatus = OCSPGood
+	case ocsp.Revoked:
+		report.OCSPStatus = OCSPRevoked
+		report.Problems = append(report.Problems, fmt.Sprintf("certificate was revoked on %s", response.RevokedAt.Format(time.RFC3339)))
+	default:
+		report.OCSPStatus = OCSPUnknown
+		report.Problems = append(report.Problems, "OCSP responder does not know the certificate")
+	}
+
+	return report
+}
+
+// EmbeddedSCTs decodes the Signed Certificate Timestamps embedded in certificate
+func EmbeddedSCTs(certificate *x509.Certificate) ([]SCT, error) {
+	var raw []byte
+	for _, extension := range certificate.Extensions {
+		if extension.Id.Equal(oidSCTList) {
+			raw = extension.Value
+			break
+		}
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var list []byte
+	if _, err := asn1.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("invalid SCT list: %w", err)
+	}
+
+	list, err := readUint16Prefixed(list, true)
+	if err != nil {
+		return nil, err
+	}
+
+	scts := []SCT{}
+	for len(list) > 0 {
+		entry, err := readUint16Prefixed(list, false)
+		if err != nil {
+			return nil, err
+		}
+		list = list[2+len(entry):]
+
+		// version (1), log id (32), timestamp (8)
+		if len(entry) < 41 || entry[0] != 0 {
+			return nil, errors.New("invalid SCT list: unsupported SCT")
+		}
+		millis := int64(binary.BigEndian.Uint64(entry[33:41]))
+		scts = append(scts, SCT{
+			LogID:     hex.EncodeToString(entry[1:33]),
+			Timestamp: time.Unix(millis/1000, (millis%1000)*int64(time.Millisecond)).UTC(),
+		})
+	}
+
+	return scts, nil
+}
+
+// readUint16Prefixed returns the data following a big endian length prefix; exact
+// requires the prefix to cover all of data
+func readUint16Prefixed(data []byte, exact bool) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, errors.New("invalid SCT list: truncated")
+	}
+
+	length := int(binary.BigEndian.Uint16(data))
+	if len(data)-2 < length || (exact && len(data)-2 != length) {
+		return nil, errors.New("invalid SCT list: bad length")
+	}
+
+	return data[2 : 2+length], nil
+}
+
+func queryOCSP(httpClient *http.Client, responder string, leaf, issuer *x509.Certificate) (*ocsp.Response, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	request, err := ocsp.CreateRequest(leaf, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := httpClient.Post(responder, "application/ocsp-request", bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("responder returned %s", res.Status)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ocsp.ParseResponseForCert(body, leaf, issuer)
+}
@@ -0,0 +1,158 @@
+package cps
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+// testSCTList builds an SCT list extension value with count entries
+func testSCTList(t *testing.T, count int) []byte {
+	list := []byte{}
+	for i := 0; i < count; i++ {
+		entry := make([]byte, 47)
+		entry[1] = byte(i + 1)
+		binary.BigEndian.PutUint64(entry[33:41], uint64(1577836800000))
+		list = append(list, 0, byte(len(entry)))
+		list = append(list, entry...)
+	}
+	list = append([]byte{byte(len(list) >> 8), byte(len(list))}, list...)
+
+	value, err := asn1.Marshal(list)
+	require.NoError(t, err)
+
+	return value
+}
+
+func testDeployedCertificate(t *testing.T, responder string, scts int) (DeployedCertificate, *x509.Certificate, *x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, key.Public(), key)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(42),
+		Subject:         pkix.Name{CommonName: "www.example.com"},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(10 * 24 * time.Hour),
+		OCSPServer:      []string{responder},
+		ExtraExtensions: []pkix.Extension{{Id: oidSCTList, Value: testSCTList(t, scts)}},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, key.Public(), key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	return DeployedCertificate{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})),
+		TrustChain:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})),
+	}, leaf, ca, key
+}
+
+func TestInspectCertificate(t *testing.T) {
+	var leaf, ca *x509.Certificate
+	var key crypto.Signer
+	status := ocsp.Good
+
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		response, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       status,
+			SerialNumber: leaf.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, key)
+		require.NoError(t, err)
+		w.Write(response)
+	}))
+	defer responder.Close()
+
+	var certificate DeployedCertificate
+	certificate, leaf, ca, key = testDeployedCertificate(t, responder.URL, 2)
+	options := HealthOptions{HTTPClient: responder.Client()}
+
+	health := InspectCertificate(certificate, options)
+	assert.Empty(t, health.Problems)
+	assert.True(t, health.Healthy())
+	assert.Equal(t, "www.example.com", health.CommonName)
+	assert.Equal(t, "2a", health.SerialNumber)
+	assert.Equal(t, OCSPGood, health.OCSPStatus)
+	require.Len(t, health.SCTs, 2)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), health.SCTs[0].Timestamp)
+	assert.Equal(t, 9, health.DaysUntilExpiry(time.Now()))
+
+	status = ocsp.Revoked
+	options.MinSCTs = 3
+	health = InspectCertificate(certificate, options)
+	assert.False(t, health.Healthy())
+	assert.Equal(t, OCSPRevoked, health.OCSPStatus)
+	assert.Len(t, health.Problems, 2)
+}
+
+func TestCheckCertificateHealth(t *testing.T) {
+	defer gock.Off()
+
+	certificate, _, _, _ := testDeployedCertificate(t, "http://ocsp.example.com", 0)
+
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments/10000/deployments/production").
+		MatchHeader("Accept", `deployment\.v3\+json`).
+		Reply(200).
+		JSON(map[string]interface{}{"primaryCertificate": certificate})
+
+	Init(config)
+
+	reports, err := CheckCertificateHealth("/cps/v2/enrollments/10000", DeploymentProduction, HealthOptions{SkipOCSP: true})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, DeploymentProduction, reports[0].Network)
+	assert.Equal(t, OCSPNotChecked, reports[0].OCSPStatus)
+	assert.Equal(t, []string{"certificate has 0 embedded SCTs, 2 required"}, reports[0].Problems)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,215 @@
+package cps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SANLimits are the sizing rules of an enrollment, the common name counts as a SAN
+type SANLimits struct {
+	// MaxSANs is the maximum number of names of an enrollment
+	MaxSANs int
+	// MaxWildcardSANs is the maximum number of wildcard names, 0 if wildcards are not allowed
+	MaxWildcardSANs int
+}
+
+// DefaultSANLimits are the CPS limits of each validation type
+var DefaultSANLimits = map[ValidationType]SANLimits{
+	DomainValidation:       {MaxSANs: 100, MaxWildcardSANs: 0},
+	OrganizationValidation: {MaxSANs: 100, MaxWildcardSANs: 100},
+	ExtendedValidation:     {MaxSANs: 25, MaxWildcardSANs: 0},
+}
+
+// Limits returns the SAN limits of enrollment, from MaxAllowedSans and
+// MaxAllowedWildcardSans when CPS returned them, or DefaultSANLimits
+func (enrollment *Enrollment) Limits() SANLimits {
+	limits := DefaultSANLimits[enrollment.ValidationType]
+	if enrollment.MaxAllowedSans != nil {
+		limits.MaxSANs = *enrollment.MaxAllowedSans
+	}
+	if enrollment.MaxAllowedWildcardSans != nil {
+		limits.MaxWildcardSANs = *enrollment.MaxAllowedWildcardSans
+	}
+
+	return limits
+}
+
+// AdvisedEnrollment is an enrollment proposed by AdviseEnrollments
+type AdvisedEnrollment struct {
+	CommonName string
+	// SANs include the common name
+	SANs []string
+}
+
+// EnrollmentAdvice is how AdviseEnrollments splits a list of names across enrollments
+type EnrollmentAdvice struct {
+	Enrollments []AdvisedEnrollment
+	// Covered are the names left out as a wildcard covers them, by wildcard
+	Covered map[string][]string
+	// Unsupported are the wildcards that cannot be used with the limits
+	Unsupported []string
+}
+
+// AdviseEnrollments splits names across as few enrollments as limits allow
+//
+// Names are lower cased and de-duplicated. A name covered by a wildcard of the
+// list (www.example.com by *.example.com, but neither example.com nor
+// a.www.example.com) is left out. Names of the same domain (e.g. example.com
+// and its subdomains) are kept in the same enrollment where possible, the
+// first name that isn't a wildcard is the common name.
+func AdviseEnrollments(names []string, limits SANLimits) (*EnrollmentAdvice, error) {
+	if limits.MaxSANs < 1 {
+		return nil, fmt.Errorf("invalid SAN limit %d", limits.MaxSANs)
+	}
+
+	advice := &EnrollmentAdvice{Covered: map[string][]string{}}
+
+	seen := map[string]bool{}
+	wildcards := map[string]bool{}
+	var unique []string
+	for _, name := range names {
+		name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if strings.HasPrefix(name, "*.") {
+			if limits.MaxWildcardSANs == 0 {
+				advice.Unsupported = append(advice.Unsupported, name)
+				continue
+			}
+			wildcards[name] = true
+		}
+		unique = append(unique, name)
+	}
+
+	// group the names to keep by domain, in order of appearance
+	groups := map[string][]string{}
+	var domains []string
+	for _, name := range unique {
+		if wildcard := coveringWildcard(name); wildcards[wildcard] {
+			advice.Covered[wildcard] = append(advice.Covered[wildcard], name)
+			continue
+		}
+
+		domain := baseDomain(name)
+		if _, ok := groups[domain]; !ok {
+			domains = append(domains, domain)
+		}
+		groups[domain] = append(groups[domain], name)
+	}
+
+	// first fit decreasing, largest domains first
+	sort.SliceStable(domains, func(i, j int) bool { return len(groups[domains[i]]) > len(groups[domains[j]]) })
+
+	type bin struct {
+		names     []string
+		wildcards int
+	}
+	var bins []*bin
+	fits := func(b *bin, names []string) bool {
+		count := 0
+		for _, name := range names {
+			if strings.HasPrefix(name, "*.") {
+				count++
+			}
+		}
+		return len(b.names)+len(names) <= limits.MaxSANs && b.wildcards+count <= limits.MaxWildcardSANs
+	}
+	add := func(b *bin, names []string) {
+		for _, name := range names {
+			if strings.HasPrefix(name, "*.") {
+				b.wildcards++
+			}
+		}
+		b.names = append(b.names, names...)
+	}
+
+	for _, domain := range domains {
+		group := groups[domain]
+
+		placed := false
+		for _, b := range bins {
+			if fits(b, group) {
+				add(b, group)
+				placed = true
+				break
+			}
+		}
+		if placed {
+			continue
+		}
+
+		// split the domain over new enrollments one name at a time
+		for _, name := range group {
+			if len(bins) == 0 || !fits(bins[len(bins)-1], []string{name}) {
+				bins = append(bins, &bin{})
+			}
+			add(bins[len(bins)-1], []string{name})
+		}
+	}
+
+	for _, b := range bins {
+		enrollment := AdvisedEnrollment{CommonName: b.names[0], SANs: b.names}
+		for _, name := range b.names {
+			if !strings.HasPrefix(name, "*.") {
+				enrollment.CommonName = name
+				break
+			}
+		}
+		advice.Enrollments = append(advice.Enrollments, enrollment)
+	}
+
+	return advice, nil
+}
+
+// CreateEnrollments creates an enrollment for each advised enrollment, as a
+// copy of template with the common name and SANs of the advice
+func (advice *EnrollmentAdvice) CreateEnrollments(template Enrollment, params CreateEnrollmentQueryParams) ([]*CreateEnrollmentResponse, error) {
+	var responses []*CreateEnrollmentResponse
+	for _, advised := range advice.Enrollments {
+		enrollment := template
+		csr := CSR{}
+		if template.CertificateSigningRequest != nil {
+			csr = *template.CertificateSigningRequest
+		}
+		sans := append([]string(nil), advised.SANs...)
+		csr.CommonName = advised.CommonName
+		csr.AlternativeNames = &sans
+		enrollment.CertificateSigningRequest = &csr
+
+		response, err := enrollment.Create(params)
+		if err != nil {
+			return responses, fmt.Errorf("enrollment %s: %w", advised.CommonName, err)
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// coveringWildcard returns the wildcard that would cover name
+func coveringWildcard(name string) string {
+	if strings.HasPrefix(name, "*.") {
+		return ""
+	}
+
+	index := strings.Index(name, ".")
+	if index < 0 || !strings.Contains(name[index+1:], ".") {
+		return ""
+	}
+
+	return "*" + name[index:]
+}
+
+// baseDomain returns the last two labels of name
+func baseDomain(name string) string {
+	labels := strings.Split(strings.TrimPrefix(name, "*."), ".")
+	if len(labels) <= 2 {
+		return strings.Join(labels, ".")
+	}
+
+	return strings.Join(labels[len(labels)-2:], ".")
+}
@@ -0,0 +1,62 @@
+package cps
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAdviseEnrollments(t *testing.T) {
+	names := []string{"*.example.com", "www.example.com", "Example.com", "a.www.example.com", "www.example.org", "www.example.com."}
+
+	advice, err := AdviseEnrollments(names, SANLimits{MaxSANs: 3, MaxWildcardSANs: 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"*.example.com": {"www.example.com"}}, advice.Covered)
+	require.Len(t, advice.Enrollments, 2)
+	assert.Equal(t, AdvisedEnrollment{CommonName: "example.com", SANs: []string{"*.example.com", "example.com", "a.www.example.com"}}, advice.Enrollments[0])
+	assert.Equal(t, AdvisedEnrollment{CommonName: "www.example.org", SANs: []string{"www.example.org"}}, advice.Enrollments[1])
+
+	advice, err = AdviseEnrollments(names, DefaultSANLimits[DomainValidation])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.example.com"}, advice.Unsupported)
+	require.Len(t, advice.Enrollments, 1)
+	assert.Len(t, advice.Enrollments[0].SANs, 4)
+}
+
+func TestAdviseEnrollments_Split(t *testing.T) {
+	var names []string
+	for i := 0; i < 250; i++ {
+		names = append(names, fmt.Sprintf("host%d.example.com", i))
+	}
+	names = append(names, "www.example.net")
+
+	advice, err := AdviseEnrollments(names, DefaultSANLimits[DomainValidation])
+	require.NoError(t, err)
+	require.Len(t, advice.Enrollments, 3)
+	assert.Len(t, advice.Enrollments[0].SANs, 100)
+	assert.Len(t, advice.Enrollments[1].SANs, 100)
+	assert.Equal(t, []string{"host200.example.com"}, advice.Enrollments[2].SANs[:1])
+	assert.Contains(t, advice.Enrollments[2].SANs, "www.example.net")
+}
+
+func TestEnrollmentAdvice_CreateEnrollments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/cps/v2/enrollments").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		Reply(202).
+		JSON(map[string]interface{}{"enrollment": "/cps/v2/enrollments/10002", "changes": []string{"/cps/v2/enrollments/10002/changes/10002"}})
+
+	Init(config)
+
+	advice := &EnrollmentAdvice{Enrollments: []AdvisedEnrollment{{CommonName: "www.example.org", SANs: []string{"www.example.org"}}}}
+	responses, err := advice.CreateEnrollments(Enrollment{ValidationType: DomainValidation}, CreateEnrollmentQueryParams{ContractID: "ctr_1-1TJZH5"})
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "/cps/v2/enrollments/10002", responses[0].Location)
+	assert.True(t, gock.IsDone())
+}
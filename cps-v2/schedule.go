@@ -0,0 +1,121 @@
+package cps
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewDeploymentSchedule returns the schedule deploying a change between
+// notBefore and notAfter, either can be zero for no bound
+func NewDeploymentSchedule(notBefore, notAfter time.Time) *DeploymentSchedule {
+	schedule := &DeploymentSchedule{}
+	if !notBefore.IsZero() {
+		value := notBefore.UTC().Format(time.RFC3339)
+		schedule.NotBefore = &value
+	}
+	if !notAfter.IsZero() {
+		value := notAfter.UTC().Format(time.RFC3339)
+		schedule.NotAfter = &value
+	}
+
+	return schedule
+}
+
+// Window returns the bounds of the schedule, zero when not set
+func (schedule *DeploymentSchedule) Window() (notBefore time.Time, notAfter time.Time, err error) {
+	if schedule.NotBefore != nil && *schedule.NotBefore != "" {
+		if notBefore, err = time.Parse(time.RFC3339, *schedule.NotBefore); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("notBefore: %w", err)
+		}
+	}
+	if schedule.NotAfter != nil && *schedule.NotAfter != "" {
+		if notAfter, err = time.Parse(time.RFC3339, *schedule.NotAfter); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("notAfter: %w", err)
+		}
+	}
+
+	return notBefore, notAfter, nil
+}
+
+// MaintenanceWindow is a weekly window changes may be deployed in, e.g.
+// Sundays from 02:00 for 4 hours
+type MaintenanceWindow struct {
+	Weekday time.Weekday
+	// Start is the start of the window after midnight
+	Start    time.Duration
+	Duration time.Duration
+	// Location is the time zone of the window, UTC when nil
+	Location *time.Location
+}
+
+// Next returns the schedule of the first window that ends after after, from
+// after when the window has already started
+func (window MaintenanceWindow) Next(after time.Time) (*DeploymentSchedule, error) {
+	if window.Duration <= 0 || window.Duration > 7*24*time.Hour {
+		return nil, fmt.Errorf("maintenance window duration %s must be positive and at most a week", window.Duration)
+	}
+	if window.Start < 0 || window.Start >= 24*time.Hour {
+		return nil, fmt.Errorf("maintenance window start %s must be within a day", window.Start)
+	}
+
+	location := window.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	local := after.In(location)
+	days := int(window.Weekday - local.Weekday())
+	// start from the previous week, in case its window is still open
+	day := time.Date(local.Year(), local.Month(), local.Day()+days-7, 0, 0, 0, 0, location)
+	for {
+		start := day.Add(window.Start)
+		end := start.Add(window.Duration)
+		if end.After(after) {
+			if start.Before(after) {
+				start = after
+			}
+			return NewDeploymentSchedule(start, end), nil
+		}
+		day = day.AddDate(0, 0, 7)
+	}
+}
+
+// GetDeploymentSchedule retrieves the deployment schedule of the change at changeLocation
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getdeploymentschedule
+// Endpoint: GET /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/deployment-schedule
+func GetDeploymentSchedule(changeLocation string) (*DeploymentSchedule, error) {
+	var response DeploymentSchedule
+	if err := doCPS("GET", deploymentSchedulePath(changeLocation), "application/vnd.akamai.cps.deployment-schedule.v1+json", "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// UpdateDeploymentSchedule sets the deployment schedule of the change at
+// changeLocation, see NewDeploymentSchedule and MaintenanceWindow.Next
+//
+// API Docs: https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#putdeploymentschedule
+// Endpoint: PUT /cps/v2/enrollments/{enrollmentId}/changes/{changeId}/deployment-schedule
+func UpdateDeploymentSchedule(changeLocation string, schedule *DeploymentSchedule) (*ChangeResponse, error) {
+	notBefore, notAfter, err := schedule.Window()
+	if err != nil {
+		return nil, err
+	}
+	if !notBefore.IsZero() && !notAfter.IsZero() && !notAfter.After(notBefore) {
+		return nil, fmt.Errorf("deployment schedule notAfter %s is not after notBefore %s", *schedule.NotAfter, *schedule.NotBefore)
+	}
+
+	var response ChangeResponse
+	if err := doCPS("PUT", deploymentSchedulePath(changeLocation), "application/vnd.akamai.cps.change-id.v1+json", "application/vnd.akamai.cps.deployment-schedule.v1+json", schedule, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func deploymentSchedulePath(changeLocation string) string {
+	return strings.TrimSuffix(changeLocation, "/") + "/deployment-schedule"
+}
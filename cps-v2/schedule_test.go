@@ -0,0 +1,94 @@
+package cps
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestMaintenanceWindowNext(t *testing.T) {
+	window := MaintenanceWindow{Weekday: time.Sunday, Start: 2 * time.Hour, Duration: 4 * time.Hour}
+
+	tests := map[string]struct {
+		after               time.Time
+		notBefore, notAfter string
+	}{
+		"before the window": {
+			after:     time.Date(2020, 6, 3, 12, 0, 0, 0, time.UTC), // Wednesday
+			notBefore: "2020-06-07T02:00:00Z",
+			notAfter:  "2020-06-07T06:00:00Z",
+		},
+		"within the window": {
+			after:     time.Date(2020, 6, 7, 3, 30, 0, 0, time.UTC),
+			notBefore: "2020-06-07T03:30:00Z",
+			notAfter:  "2020-06-07T06:00:00Z",
+		},
+		"after the window": {
+			after:     time.Date(2020, 6, 7, 6, 0, 0, 0, time.UTC),
+			notBefore: "2020-06-14T02:00:00Z",
+			notAfter:  "2020-06-14T06:00:00Z",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := window.Next(test.after)
+			require.NoError(t, err)
+			assert.Equal(t, test.notBefore, *schedule.NotBefore)
+			assert.Equal(t, test.notAfter, *schedule.NotAfter)
+		})
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	schedule, err := MaintenanceWindow{Weekday: time.Saturday, Start: 23 * time.Hour, Duration: 3 * time.Hour, Location: newYork}.
+		Next(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2020-06-07T03:00:00Z", *schedule.NotBefore)
+	assert.Equal(t, "2020-06-07T06:00:00Z", *schedule.NotAfter)
+
+	_, err = MaintenanceWindow{Duration: 0}.Next(time.Now())
+	assert.EqualError(t, err, "maintenance window duration 0s must be positive and at most a week")
+}
+
+func TestDeploymentSchedule(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments/10002/changes/10002/deployment-schedule").
+		MatchHeader("Accept", "application/vnd.akamai.cps.deployment-schedule.v1\\+json").
+		Reply(200).
+		JSON(`{"notBefore": "2020-06-07T02:00:00Z", "notAfter": null}`)
+	gock.New(baseURL).
+		Put("/cps/v2/enrollments/10002/changes/10002/deployment-schedule").
+		MatchHeader("Content-Type", "application/vnd.akamai.cps.deployment-schedule.v1\\+json").
+		MatchHeader("Accept", "application/vnd.akamai.cps.change-id.v1\\+json").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			return string(body) == "{\"notAfter\":\"2020-06-07T06:00:00Z\",\"notBefore\":\"2020-06-07T02:00:00Z\"}\n", err
+		}).
+		Reply(200).
+		JSON(`{"change": "/cps/v2/enrollments/10002/changes/10002"}`)
+
+	Init(config)
+
+	schedule, err := GetDeploymentSchedule("/cps/v2/enrollments/10002/changes/10002")
+	require.NoError(t, err)
+	notBefore, notAfter, err := schedule.Window()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 6, 7, 2, 0, 0, 0, time.UTC), notBefore)
+	assert.True(t, notAfter.IsZero())
+
+	response, err := UpdateDeploymentSchedule("/cps/v2/enrollments/10002/changes/10002", NewDeploymentSchedule(notBefore, notBefore.Add(4*time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, "/cps/v2/enrollments/10002/changes/10002", response.Change)
+	assert.True(t, gock.IsDone())
+
+	_, err = UpdateDeploymentSchedule("/cps/v2/enrollments/10002/changes/10002", NewDeploymentSchedule(notBefore, notBefore))
+	assert.EqualError(t, err, "deployment schedule notAfter 2020-06-07T02:00:00Z is not after notBefore 2020-06-07T02:00:00Z")
+}
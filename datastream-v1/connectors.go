@@ -0,0 +1,116 @@
+package datastream
+
+import (
+	"encoding/json"
+)
+
+// ConnectorType is used to create an "enum" of possible destination connector types
+type ConnectorType string
+
+const (
+	// ConnectorTypeS3 an Amazon S3 bucket
+	ConnectorTypeS3 ConnectorType = "S3"
+	// ConnectorTypeSplunk a Splunk HTTP Event Collector
+	ConnectorTypeSplunk ConnectorType = "SPLUNK"
+	// ConnectorTypeDatadog a Datadog logs intake
+	ConnectorTypeDatadog ConnectorType = "DATADOG"
+)
+
+// Connector is the configuration of a stream destination, one of
+// *S3Connector, *SplunkConnector or *DatadogConnector
+type Connector interface {
+	ConnectorType() ConnectorType
+}
+
+// S3Connector uploads the logs to an Amazon S3 bucket
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#s3connector
+type S3Connector struct {
+	ConnectorName   string `json:"connectorName"`
+	Bucket          string `json:"bucket"`
+	Path            string `json:"path"`
+	Region          string `json:"region"`
+	AccessKey       string `json:"accessKey"`
+	SecretAccessKey string `json:"secretAccessKey"`
+}
+
+// SplunkConnector sends the logs to a Splunk HTTP Event Collector
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#splunkconnector
+type SplunkConnector struct {
+	ConnectorName       string `json:"connectorName"`
+	URL                 string `json:"url"`
+	EventCollectorToken string `json:"eventCollectorToken"`
+	CompressLogs        bool   `json:"compressLogs"`
+}
+
+// DatadogConnector sends the logs to the Datadog logs intake
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#datadogconnector
+type DatadogConnector struct {
+	ConnectorName string `json:"connectorName"`
+	URL           string `json:"url"`
+	AuthToken     string `json:"authToken"`
+	Service       string `json:"service,omitempty"`
+	Source        string `json:"source,omitempty"`
+	Tags          string `json:"tags,omitempty"`
+	CompressLogs  bool   `json:"compressLogs"`
+}
+
+// ConnectorType returns ConnectorTypeS3
+func (*S3Connector) ConnectorType() ConnectorType { return ConnectorTypeS3 }
+
+// ConnectorType returns ConnectorTypeSplunk
+func (*SplunkConnector) ConnectorType() ConnectorType { return ConnectorTypeSplunk }
+
+// ConnectorType returns ConnectorTypeDatadog
+func (*DatadogConnector) ConnectorType() ConnectorType { return ConnectorTypeDatadog }
+
+// MarshalJSON adds the connectorType of the connector
+func (connector *S3Connector) MarshalJSON() ([]byte, error) {
+	type s3Connector S3Connector
+	return marshalConnector(connector.ConnectorType(), (*s3Connector)(connector))
+}
+
+// MarshalJSON adds the connectorType of the connector
+func (connector *SplunkConnector) MarshalJSON() ([]byte, error) {
+	type splunkConnector SplunkConnector
+	return marshalConnector(connector.ConnectorType(), (*splunkConnector)(connector))
+}
+
+// MarshalJSON adds the connectorType of the connector
+func (connector *DatadogConnector) MarshalJSON() ([]byte, error) {
+	type datadogConnector DatadogConnector
+	return marshalConnector(connector.ConnectorType(), (*datadogConnector)(connector))
+}
+
+func marshalConnector(connectorType ConnectorType, connector interface{}) ([]byte, error) {
+	body, err := json.Marshal(connector)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	fields["connectorType"], _ = json.Marshal(connectorType)
+
+	return json.Marshal(fields)
+}
+
+// ConnectorDetails is a destination of a stream as returned by the API,
+// secrets are not returned
+type ConnectorDetails struct {
+	ConnectorID   int           `json:"connectorId"`
+	ConnectorType ConnectorType `json:"connectorType"`
+	ConnectorName string        `json:"connectorName"`
+	CompressLogs  bool          `json:"compressLogs"`
+	Bucket        string        `json:"bucket,omitempty"`
+	Path          string        `json:"path,omitempty"`
+	Region        string        `json:"region,omitempty"`
+	URL           string        `json:"url,omitempty"`
+	Service       string        `json:"service,omitempty"`
+	Source        string        `json:"source,omitempty"`
+	Tags          string        `json:"tags,omitempty"`
+}
@@ -0,0 +1,58 @@
+package datastream
+
+import (
+	"fmt"
+)
+
+// TemplateEdgeLogs is the template of the edge log data set fields
+const TemplateEdgeLogs = "EDGE_LOGS"
+
+// DataSetGroup is a group of data set fields a stream can log
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#datasets
+type DataSetGroup struct {
+	DatasetGroupName        string         `json:"datasetGroupName"`
+	DatasetGroupDescription string         `json:"datasetGroupDescription,omitempty"`
+	DatasetFields           []DataSetField `json:"datasetFields"`
+}
+
+// DataSetField is a field a stream can log
+type DataSetField struct {
+	DatasetFieldID          int    `json:"datasetFieldId"`
+	DatasetFieldName        string `json:"datasetFieldName"`
+	DatasetFieldDescription string `json:"datasetFieldDescription,omitempty"`
+}
+
+// ListDataSets lists the data set fields of a template, see TemplateEdgeLogs
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#getdatasets
+// Endpoint: GET /datastream-config-api/v1/log/datasets/template/{templateName}
+func ListDataSets(templateName string) ([]DataSetGroup, error) {
+	var groups []DataSetGroup
+	if err := doJSON("GET", fmt.Sprintf("/datastream-config-api/v1/log/datasets/template/%s", templateName), nil, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// FieldIDs returns the IDs of the fields named names, in the order of names
+func FieldIDs(groups []DataSetGroup, names ...string) ([]int, error) {
+	byName := map[string]int{}
+	for _, group := range groups {
+		for _, field := range group.DatasetFields {
+			byName[field.DatasetFieldName] = field.DatasetFieldID
+		}
+	}
+
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("data set field %q not found", name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,215 @@
+package datastream
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// DeliveryQueryArgs are the query parameters for GetDeliveryMetrics and GetDeliveryFailures
+//
+// Start and End default to the last hour when zero.
+type DeliveryQueryArgs struct {
+	Start       time.Time
+	End         time.Time
+	ConnectorID int
+}
+
+// DeliveryMetrics are the delivery counters of a stream, per destination
+type DeliveryMetrics struct {
+	StreamID     int                  `json:"streamId"`
+	Start        time.Time            `json:"start"`
+	End          time.Time            `json:"end"`
+	Destinations []DestinationMetrics `json:"destinations"`
+}
+
+// DestinationMetrics are the delivery counters of a single destination (connector)
+type DestinationMetrics struct {
+	ConnectorID      int        `json:"connectorId"`
+	ConnectorType    string     `json:"connectorType"`
+	ConnectorName    string     `json:"connectorName"`
+	RecordsDelivered int64      `json:"recordsDelivered"`
+	RecordsFailed    int64      `json:"recordsFailed"`
+	BytesDelivered   int64      `json:"bytesDelivered"`
+	LastDeliveryTime *time.Time `json:"lastDeliveryTime,omitempty"`
+	LastFailureTime  *time.Time `json:"lastFailureTime,omitempty"`
+}
+
+// FailureRate returns the fraction of records that failed to deliver
+func (metrics DestinationMetrics) FailureRate() float64 {
+	total := metrics.RecordsDelivered + metrics.RecordsFailed
+	if total == 0 {
+		return 0
+	}
+
+	return float64(metrics.RecordsFailed) / float64(total)
+}
+
+// DeliveryFailure is a single failed upload to a destination
+type DeliveryFailure struct {
+	ConnectorID   int       `json:"connectorId"`
+	ConnectorType string    `json:"connectorType"`
+	ConnectorName string    `json:"connectorName"`
+	FailureTime   time.Time `json:"failureTime"`
+	StatusCode    int       `json:"statusCode,omitempty"`
+	ErrorCode     string    `json:"errorCode"`
+	Message       string    `json:"message"`
+	Records       int64     `json:"records"`
+}
+
+// GetDeliveryMetrics retrieves delivery metrics for each destination of a stream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#getdeliverymetrics
+// Endpoint: GET /datastream-config-api/v1/log/streams/{streamId}/delivery-metrics{?start,end,connectorId}
+func GetDeliveryMetrics(streamID int, queryArgs DeliveryQueryArgs) (*DeliveryMetrics, error) {
+	req, err := newDeliveryRequest(fmt.Sprintf("/datastream-config-api/v1/log/streams/%d/delivery-metrics", streamID), queryArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	metrics := &DeliveryMetrics{}
+	if err = client.BodyJSON(res, metrics); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+// GetDeliveryFailures retrieves the recent delivery failures of a stream, most recent first
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#getdeliveryfailures
+// Endpoint: GET /datastream-config-api/v1/log/streams/{streamId}/delivery-failures{?start,end,connectorId}
+func GetDeliveryFailures(streamID int, queryArgs DeliveryQueryArgs) ([]DeliveryFailure, error) {
+	req, err := newDeliveryRequest(fmt.Sprintf("/datastream-config-api/v1/log/streams/%d/delivery-failures", streamID), queryArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	response := struct {
+		Failures []DeliveryFailure `json:"failures"`
+	}{}
+	if err = client.BodyJSON(res, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Failures, nil
+}
+
+func newDeliveryRequest(path string, queryArgs DeliveryQueryArgs) (*http.Request, error) {
+	req, err := client.NewRequest(Config, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	end := queryArgs.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := queryArgs.Start
+	if start.IsZero() {
+		start = end.Add(-time.Hour)
+	}
+
+	q := req.URL.Query()
+	q.Add("start", start.UTC().Format(time.RFC3339))
+	q.Add("end", end.UTC().Format(time.RFC3339))
+	if queryArgs.ConnectorID != 0 {
+		q.Add("connectorId", fmt.Sprintf("%d", queryArgs.ConnectorID))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	edge.PrintHttpRequest(req, true)
+
+	return req, nil
+}
+
+// AlertReason is used to create an "enum" of possible DeliveryAlert.Reason values
+type AlertReason string
+
+const (
+	// AlertNoDeliveries the destination has not received logs within the allowed silence
+	AlertNoDeliveries AlertReason = "NO_DELIVERIES"
+	// AlertFailureRate the destination's failure rate is above the threshold
+	AlertFailureRate AlertReason = "FAILURE_RATE"
+)
+
+// AlertThresholds configures CheckDelivery
+type AlertThresholds struct {
+	// MaxSilence is the longest time a destination may go without a successful delivery
+	MaxSilence time.Duration
+	// MaxFailureRate is the highest acceptable fraction of failed records, 0 disables the check
+	MaxFailureRate float64
+}
+
+// DeliveryAlert flags a destination that looks like it stopped receiving logs
+type DeliveryAlert struct {
+	ConnectorID   int
+	ConnectorName string
+	ConnectorType string
+	Reason        AlertReason
+	Detail        string
+}
+
+// CheckDelivery evaluates stream metrics against thresholds at the given time
+//
+// Monitors typically call GetDeliveryMetrics on a schedule and page on any returned alert.
+func CheckDelivery(metrics *DeliveryMetrics, thresholds AlertThresholds, now time.Time) []DeliveryAlert {
+	var alerts []DeliveryAlert
+	for _, destination := range metrics.Destinations {
+		alert := DeliveryAlert{
+			ConnectorID:   destination.ConnectorID,
+			ConnectorName: destination.ConnectorName,
+			ConnectorType: destination.ConnectorType,
+		}
+
+		if thresholds.MaxSilence > 0 {
+			if destination.LastDeliveryTime == nil {
+				alert.Reason = AlertNoDeliveries
+				alert.Detail = "no successful delivery recorded"
+				alerts = append(alerts, alert)
+				continue
+			}
+			if silence := now.Sub(*destination.LastDeliveryTime); silence > thresholds.MaxSilence {
+				alert.Reason = AlertNoDeliveries
+				alert.Detail = fmt.Sprintf("last successful delivery %s ago", silence.Truncate(time.Second))
+				alerts = append(alerts, alert)
+				continue
+			}
+		}
+
+		if thresholds.MaxFailureRate > 0 {
+			if rate := destination.FailureRate(); rate > thresholds.MaxFailureRate {
+				alert.Reason = AlertFailureRate
+				alert.Detail = fmt.Sprintf("%.1f%% of records failed", rate*100)
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	return alerts
+}
@@ -0,0 +1,79 @@
+package datastream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestGetDeliveryMetrics(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/datastream-config-api/v1/log/streams/7050/delivery-metrics").
+		MatchParam("start", "2020-06-01T10:00:00Z").
+		MatchParam("end", "2020-06-01T11:00:00Z").
+		Reply(200).
+		JSON(`{
+			"streamId": 7050,
+			"start": "2020-06-01T10:00:00Z",
+			"end": "2020-06-01T11:00:00Z",
+			"destinations": [
+				{"connectorId": 1, "connectorType": "S3", "connectorName": "logs-bucket", "recordsDelivered": 9000, "recordsFailed": 1000, "lastDeliveryTime": "2020-06-01T10:59:00Z"},
+				{"connectorId": 2, "connectorType": "SPLUNK", "connectorName": "splunk-hec", "recordsDelivered": 100, "lastDeliveryTime": "2020-06-01T10:10:00Z"},
+				{"connectorId": 3, "connectorType": "SUMO_LOGIC", "connectorName": "sumo"}
+			]
+		}`)
+
+	Init(config)
+
+	end := time.Date(2020, 6, 1, 11, 0, 0, 0, time.UTC)
+	metrics, err := GetDeliveryMetrics(7050, DeliveryQueryArgs{Start: end.Add(-time.Hour), End: end})
+	require.NoError(t, err)
+	require.Len(t, metrics.Destinations, 3)
+	assert.InDelta(t, 0.1, metrics.Destinations[0].FailureRate(), 0.0001)
+
+	alerts := CheckDelivery(metrics, AlertThresholds{MaxSilence: 15 * time.Minute, MaxFailureRate: 0.05}, end)
+	require.Len(t, alerts, 3)
+	assert.Equal(t, AlertFailureRate, alerts[0].Reason)
+	assert.Equal(t, "logs-bucket", alerts[0].ConnectorName)
+	assert.Equal(t, AlertNoDeliveries, alerts[1].Reason)
+	assert.Equal(t, "last successful delivery 50m0s ago", alerts[1].Detail)
+	assert.Equal(t, AlertNoDeliveries, alerts[2].Reason)
+}
+
+func TestGetDeliveryFailures(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/datastream-config-api/v1/log/streams/7050/delivery-failures").
+		MatchParam("connectorId", "2").
+		Reply(200).
+		JSON(`{"failures": [
+			{"connectorId": 2, "connectorType": "SPLUNK", "failureTime": "2020-06-01T10:12:00Z", "statusCode": 403, "errorCode": "INVALID_TOKEN", "message": "Invalid HEC token", "records": 250}
+		]}`)
+
+	Init(config)
+
+	failures, err := GetDeliveryFailures(7050, DeliveryQueryArgs{ConnectorID: 2})
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, 403, failures[0].StatusCode)
+	assert.Equal(t, "INVALID_TOKEN", failures[0].ErrorCode)
+}
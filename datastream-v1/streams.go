@@ -0,0 +1,257 @@
+package datastream
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// LogFormat is used to create an "enum" of possible StreamConfig.Format values
+type LogFormat string
+
+const (
+	// FormatStructured StreamConfig.Format value STRUCTURED, delimited values
+	FormatStructured LogFormat = "STRUCTURED"
+	// FormatJSON StreamConfig.Format value JSON
+	FormatJSON LogFormat = "JSON"
+)
+
+// StreamType is used to create an "enum" of possible Stream.StreamType values
+type StreamType string
+
+const (
+	// StreamTypeRawLogs Stream.StreamType value RAW_LOGS
+	StreamTypeRawLogs StreamType = "RAW_LOGS"
+)
+
+// ActivationStatus is used to create an "enum" of possible StreamDetails.ActivationStatus values
+type ActivationStatus string
+
+const (
+	// ActivationStatusActivating StreamDetails.ActivationStatus value ACTIVATING
+	ActivationStatusActivating ActivationStatus = "ACTIVATING"
+	// ActivationStatusActivated StreamDetails.ActivationStatus value ACTIVATED
+	ActivationStatusActivated ActivationStatus = "ACTIVATED"
+	// ActivationStatusDeactivating StreamDetails.ActivationStatus value DEACTIVATING
+	ActivationStatusDeactivating ActivationStatus = "DEACTIVATING"
+	// ActivationStatusDeactivated StreamDetails.ActivationStatus value DEACTIVATED
+	ActivationStatusDeactivated ActivationStatus = "DEACTIVATED"
+	// ActivationStatusInactive StreamDetails.ActivationStatus value INACTIVE
+	ActivationStatusInactive ActivationStatus = "INACTIVE"
+)
+
+// Stream is the configuration of a stream, as sent by CreateStream and UpdateStream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#streamconfiguration
+type Stream struct {
+	StreamName      string       `json:"streamName"`
+	StreamType      StreamType   `json:"streamType"`
+	TemplateName    string       `json:"templateName"`
+	ContractID      string       `json:"contractId"`
+	GroupID         int          `json:"groupId,omitempty"`
+	PropertyIDs     []int        `json:"propertyIds"`
+	DatasetFieldIDs []int        `json:"datasetFieldIds"`
+	Config          StreamConfig `json:"config"`
+	Connectors      []Connector  `json:"connectors"`
+	// EmailIDs is a comma separated list of addresses notified of activations
+	EmailIDs string `json:"emailIds,omitempty"`
+}
+
+// StreamConfig is the format and upload frequency of a stream
+type StreamConfig struct {
+	Delimiter        string    `json:"delimiter,omitempty"`
+	Format           LogFormat `json:"format"`
+	Frequency        Frequency `json:"frequency"`
+	UploadFilePrefix string    `json:"uploadFilePrefix,omitempty"`
+	UploadFileSuffix string    `json:"uploadFileSuffix,omitempty"`
+}
+
+// Frequency is how often a stream uploads its logs, 30 or 60 seconds
+type Frequency struct {
+	TimeInSec int `json:"timeInSec"`
+}
+
+// StreamDetails is a stream as returned by GetStream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#detailedstreamversion
+type StreamDetails struct {
+	StreamID         int                `json:"streamId"`
+	StreamVersionID  int                `json:"streamVersionId"`
+	StreamName       string             `json:"streamName"`
+	StreamType       StreamType         `json:"streamType"`
+	TemplateName     string             `json:"templateName"`
+	ContractID       string             `json:"contractId"`
+	GroupID          int                `json:"groupId"`
+	GroupName        string             `json:"groupName,omitempty"`
+	ProductID        string             `json:"productId,omitempty"`
+	ActivationStatus ActivationStatus   `json:"activationStatus"`
+	Config           StreamConfig       `json:"config"`
+	Connectors       []ConnectorDetails `json:"connectors"`
+	Datasets         []DataSetGroup     `json:"datasets"`
+	Properties       []StreamProperty   `json:"properties"`
+	EmailIDs         string             `json:"emailIds,omitempty"`
+	CreatedBy        string             `json:"createdBy,omitempty"`
+	CreatedDate      string             `json:"createdDate,omitempty"`
+	ModifiedBy       string             `json:"modifiedBy,omitempty"`
+	ModifiedDate     string             `json:"modifiedDate,omitempty"`
+}
+
+// StreamProperty is a property a stream logs
+type StreamProperty struct {
+	PropertyID   int    `json:"propertyId"`
+	PropertyName string `json:"propertyName"`
+}
+
+// StreamSummary is a stream as listed by ListStreams
+type StreamSummary struct {
+	StreamID         int              `json:"streamId"`
+	StreamVersionID  int              `json:"streamVersionId"`
+	StreamName       string           `json:"streamName"`
+	StreamTypeName   string           `json:"streamTypeName,omitempty"`
+	ActivationStatus ActivationStatus `json:"activationStatus"`
+	GroupID          int              `json:"groupId"`
+	ContractID       string           `json:"contractId"`
+	Connectors       string           `json:"connectors,omitempty"`
+	Properties       []StreamProperty `json:"properties"`
+	CreatedBy        string           `json:"createdBy,omitempty"`
+	CreatedDate      string           `json:"createdDate,omitempty"`
+}
+
+// StreamVersionKey identifies a version of a stream
+type StreamVersionKey struct {
+	StreamID        int `json:"streamId"`
+	StreamVersionID int `json:"streamVersionId"`
+}
+
+// ActivationHistoryEntry is an activation or deactivation of a stream version
+type ActivationHistoryEntry struct {
+	StreamID        int    `json:"streamId"`
+	StreamVersionID int    `json:"streamVersionId"`
+	IsActive        bool   `json:"isActive"`
+	CreatedBy       string `json:"createdBy"`
+	CreatedDate     string `json:"createdDate"`
+}
+
+// ListStreams lists the streams, of groupID only when not 0
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#getstreams
+// Endpoint: GET /datastream-config-api/v1/log/streams{?groupId}
+func ListStreams(groupID int) ([]StreamSummary, error) {
+	path, err := client.PathWithQuery("/datastream-config-api/v1/log/streams", struct {
+		GroupID int `query:"groupId,omitempty"`
+	}{groupID})
+	if err != nil {
+		return nil, err
+	}
+
+	var streams []StreamSummary
+	if err := doJSON("GET", path, nil, &streams); err != nil {
+		return nil, err
+	}
+
+	return streams, nil
+}
+
+// GetStream retrieves the latest version of a stream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#getstream
+// Endpoint: GET /datastream-config-api/v1/log/streams/{streamId}
+func GetStream(streamID int) (*StreamDetails, error) {
+	stream := &StreamDetails{}
+	if err := doJSON("GET", streamPath(streamID), nil, stream); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// CreateStream creates a stream, and activates it when activate is true
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#poststreams
+// Endpoint: POST /datastream-config-api/v1/log/streams{?activate}
+func CreateStream(stream *Stream, activate bool) (*StreamVersionKey, error) {
+	return saveStream("POST", "/datastream-config-api/v1/log/streams", stream, activate)
+}
+
+// UpdateStream creates a new version of a stream, and activates it when activate is true
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#putstream
+// Endpoint: PUT /datastream-config-api/v1/log/streams/{streamId}{?activate}
+func UpdateStream(streamID int, stream *Stream, activate bool) (*StreamVersionKey, error) {
+	return saveStream("PUT", streamPath(streamID), stream, activate)
+}
+
+// DeleteStream deletes a deactivated stream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#deletestream
+// Endpoint: DELETE /datastream-config-api/v1/log/streams/{streamId}
+func DeleteStream(streamID int) error {
+	return doJSON("DELETE", streamPath(streamID), nil, nil)
+}
+
+// ActivateStream activates the latest version of a stream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#putactivate
+// Endpoint: PUT /datastream-config-api/v1/log/streams/{streamId}/activate
+func ActivateStream(streamID int) (*StreamVersionKey, error) {
+	return changeActivation(streamID, "activate")
+}
+
+// DeactivateStream deactivates a stream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#putdeactivate
+// Endpoint: PUT /datastream-config-api/v1/log/streams/{streamId}/deactivate
+func DeactivateStream(streamID int) (*StreamVersionKey, error) {
+	return changeActivation(streamID, "deactivate")
+}
+
+// GetActivationHistory retrieves the activations and deactivations of a stream
+//
+// API Docs: https://developer.akamai.com/api/web_performance/datastream2_config/v1.html#getactivationhistory
+// Endpoint: GET /datastream-config-api/v1/log/streams/{streamId}/activationHistory
+func GetActivationHistory(streamID int) ([]ActivationHistoryEntry, error) {
+	var history []ActivationHistoryEntry
+	if err := doJSON("GET", streamPath(streamID)+"/activationHistory", nil, &history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func streamPath(streamID int) string {
+	return "/datastream-config-api/v1/log/streams/" + strconv.Itoa(streamID)
+}
+
+func saveStream(method string, path string, stream *Stream, activate bool) (*StreamVersionKey, error) {
+	if len(stream.Connectors) != 1 {
+		return nil, fmt.Errorf("a stream needs exactly one connector, got %d", len(stream.Connectors))
+	}
+
+	path, err := client.PathWithQuery(path, struct {
+		Activate bool `query:"activate"`
+	}{activate})
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		StreamVersionKey StreamVersionKey `json:"streamVersionKey"`
+	}{}
+	if err := doJSON(method, path, stream, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.StreamVersionKey, nil
+}
+
+func changeActivation(streamID int, action string) (*StreamVersionKey, error) {
+	response := struct {
+		StreamVersionKey StreamVersionKey `json:"streamVersionKey"`
+	}{}
+	if err := doJSON("PUT", streamPath(streamID)+"/"+action, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.StreamVersionKey, nil
+}
@@ -0,0 +1,198 @@
+package datastream
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestConnectorsMarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		connector Connector
+		expected  string
+	}{
+		"s3": {
+			connector: &S3Connector{ConnectorName: "logs", Bucket: "bucket", Path: "logs/", Region: "us-east-1", AccessKey: "key", SecretAccessKey: "secret"},
+			expected:  `{"accessKey":"key","bucket":"bucket","connectorName":"logs","connectorType":"S3","path":"logs/","region":"us-east-1","secretAccessKey":"secret"}`,
+		},
+		"splunk": {
+			connector: &SplunkConnector{ConnectorName: "hec", URL: "https://splunk.example.com/services/collector/raw", EventCollectorToken: "token", CompressLogs: true},
+			expected:  `{"compressLogs":true,"connectorName":"hec","connectorType":"SPLUNK","eventCollectorToken":"token","url":"https://splunk.example.com/services/collector/raw"}`,
+		},
+		"datadog": {
+			connector: &DatadogConnector{ConnectorName: "dd", URL: "https://http-intake.logs.datadoghq.com/v1/input", AuthToken: "token", Service: "edge"},
+			expected:  `{"authToken":"token","compressLogs":false,"connectorName":"dd","connectorType":"DATADOG","service":"edge","url":"https://http-intake.logs.datadoghq.com/v1/input"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(test.connector)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(body))
+		})
+	}
+}
+
+func TestCreateStream(t *testing.T) {
+	defer gock.Off()
+
+	var sent map[string]interface{}
+	gock.New(baseURL).
+		Post("/datastream-config-api/v1/log/streams").
+		MatchParam("activate", "true").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return false, err
+			}
+			return true, json.Unmarshal(body, &sent)
+		}).
+		Reply(202).
+		JSON(`{"streamVersionKey": {"streamId": 7050, "streamVersionId": 1}}`)
+
+	Init(config)
+
+	key, err := CreateStream(&Stream{
+		StreamName:      "edge logs",
+		StreamType:      StreamTypeRawLogs,
+		TemplateName:    TemplateEdgeLogs,
+		ContractID:      "2-FGHIJ",
+		GroupID:         21484,
+		PropertyIDs:     []int{382631},
+		DatasetFieldIDs: []int{1000, 1002},
+		Config: StreamConfig{
+			Delimiter: "SPACE",
+			Format:    FormatStructured,
+			Frequency: Frequency{TimeInSec: 30},
+		},
+		Connectors: []Connector{&SplunkConnector{ConnectorName: "hec", URL: "https://splunk.example.com", EventCollectorToken: "token"}},
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, &StreamVersionKey{StreamID: 7050, StreamVersionID: 1}, key)
+	assert.True(t, gock.IsDone())
+
+	require.Len(t, sent["connectors"], 1)
+	connector := sent["connectors"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "SPLUNK", connector["connectorType"])
+	assert.Equal(t, "STRUCTURED", sent["config"].(map[string]interface{})["format"])
+}
+
+func TestCreateStreamConnectors(t *testing.T) {
+	_, err := CreateStream(&Stream{StreamName: "no destination"}, false)
+	assert.EqualError(t, err, "a stream needs exactly one connector, got 0")
+}
+
+func TestGetStream(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/datastream-config-api/v1/log/streams/7050").
+		Reply(200).
+		JSON(`{
+			"streamId": 7050,
+			"streamVersionId": 2,
+			"streamName": "edge logs",
+			"activationStatus": "ACTIVATED",
+			"config": {"format": "JSON", "frequency": {"timeInSec": 60}},
+			"connectors": [{"connectorId": 13174, "connectorType": "S3", "connectorName": "logs", "bucket": "bucket", "region": "us-east-1"}],
+			"properties": [{"propertyId": 382631, "propertyName": "example.com"}]
+		}`)
+
+	Init(config)
+
+	stream, err := GetStream(7050)
+	require.NoError(t, err)
+	assert.Equal(t, ActivationStatusActivated, stream.ActivationStatus)
+	assert.Equal(t, FormatJSON, stream.Config.Format)
+	require.Len(t, stream.Connectors, 1)
+	assert.Equal(t, ConnectorTypeS3, stream.Connectors[0].ConnectorType)
+	assert.Equal(t, "bucket", stream.Connectors[0].Bucket)
+}
+
+func TestListStreams(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/datastream-config-api/v1/log/streams").
+		MatchParam("groupId", "21484").
+		Reply(200).
+		JSON(`[{"streamId": 7050, "streamVersionId": 2, "streamName": "edge logs", "activationStatus": "DEACTIVATED", "groupId": 21484}]`)
+
+	Init(config)
+
+	streams, err := ListStreams(21484)
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+	assert.Equal(t, ActivationStatusDeactivated, streams[0].ActivationStatus)
+}
+
+func TestActivateStream(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/datastream-config-api/v1/log/streams/7050/activate").
+		Reply(202).
+		JSON(`{"streamVersionKey": {"streamId": 7050, "streamVersionId": 2}}`)
+	gock.New(baseURL).
+		Put("/datastream-config-api/v1/log/streams/7050/deactivate").
+		Reply(202).
+		JSON(`{"streamVersionKey": {"streamId": 7050, "streamVersionId": 2}}`)
+	gock.New(baseURL).
+		Get("/datastream-config-api/v1/log/streams/7050/activationHistory").
+		Reply(200).
+		JSON(`[
+			{"streamId": 7050, "streamVersionId": 2, "isActive": false, "createdBy": "user", "createdDate": "2020-06-02T10:00:00Z"},
+			{"streamId": 7050, "streamVersionId": 2, "isActive": true, "createdBy": "user", "createdDate": "2020-06-01T10:00:00Z"}
+		]`)
+
+	Init(config)
+
+	key, err := ActivateStream(7050)
+	require.NoError(t, err)
+	assert.Equal(t, 2, key.StreamVersionID)
+
+	_, err = DeactivateStream(7050)
+	require.NoError(t, err)
+
+	history, err := GetActivationHistory(7050)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.False(t, history[0].IsActive)
+	assert.True(t, gock.IsDone())
+}
+
+func TestListDataSets(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/datastream-config-api/v1/log/datasets/template/EDGE_LOGS").
+		Reply(200).
+		JSON(`[
+			{"datasetGroupName": "Log information", "datasetFields": [
+				{"datasetFieldId": 1000, "datasetFieldName": "CP code"},
+				{"datasetFieldId": 1002, "datasetFieldName": "Request ID"}
+			]},
+			{"datasetGroupName": "Message exchange data", "datasetFields": [
+				{"datasetFieldId": 1005, "datasetFieldName": "Bytes"}
+			]}
+		]`)
+
+	Init(config)
+
+	groups, err := ListDataSets(TemplateEdgeLogs)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	ids, err := FieldIDs(groups, "Bytes", "CP code")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1005, 1000}, ids)
+
+	_, err = FieldIDs(groups, "Unknown")
+	assert.EqualError(t, err, `data set field "Unknown" not found`)
+}
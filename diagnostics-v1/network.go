@@ -0,0 +1,213 @@
+package diagnostics
+
+import (
+	"errors"
+)
+
+// IPVersion is used to create an "enum" of possible IP versions of MTRRequest, CurlRequest and URLHealthCheckRequest
+type IPVersion string
+
+const (
+	// IPv4 IPVersion value IPV4
+	IPv4 IPVersion = "IPV4"
+	// IPv6 IPVersion value IPV6
+	IPv6 IPVersion = "IPV6"
+)
+
+// EdgeLocation is an Akamai edge location diagnostics can run from
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#edgelocation
+type EdgeLocation struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+// ListEdgeLocations lists the edge locations diagnostics can run from
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#getedgelocations
+// Endpoint: GET /edge-diagnostics/v1/edge-locations
+func ListEdgeLocations() ([]EdgeLocation, error) {
+	response := struct {
+		EdgeLocations []EdgeLocation `json:"edgeLocations"`
+	}{}
+	if err := doJSON("GET", "/edge-diagnostics/v1/edge-locations", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.EdgeLocations, nil
+}
+
+// ClientLocation is the edge location or edge server IP a diagnostic runs
+// from, an edge server is picked when both are empty
+type ClientLocation struct {
+	EdgeLocationID string `json:"edgeLocationId,omitempty"`
+	EdgeServerIP   string `json:"edgeServerIp,omitempty"`
+}
+
+// DigRequest is a DNS lookup Dig runs from an edge server
+type DigRequest struct {
+	Hostname       string          `json:"hostname"`
+	QueryType      string          `json:"queryType,omitempty"`
+	IsGTMHostname  bool            `json:"isGtmHostname,omitempty"`
+	ClientLocation *ClientLocation `json:"clientLocation,omitempty"`
+}
+
+// DigResult is the outcome of Dig
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#dig
+type DigResult struct {
+	RequestID       int       `json:"requestId"`
+	CreatedBy       string    `json:"createdBy"`
+	CreatedTime     string    `json:"createdTime"`
+	ExecutionStatus string    `json:"executionStatus"`
+	EdgeIP          string    `json:"edgeIp"`
+	Result          DigOutput `json:"result"`
+}
+
+// DigOutput are the records found by a DNS lookup
+type DigOutput struct {
+	AnswerSection    []DNSRecord `json:"answerSection"`
+	AuthoritySection []DNSRecord `json:"authoritySection"`
+	// Result is the raw dig output
+	Result string `json:"result"`
+}
+
+// DNSRecord is a record of a DigResult section
+type DNSRecord struct {
+	Domain           string `json:"domain"`
+	TTL              int    `json:"ttl"`
+	RecordClass      string `json:"recordClass"`
+	RecordType       string `json:"recordType"`
+	PreferenceValues string `json:"preferenceValues,omitempty"`
+	Value            string `json:"value"`
+}
+
+// Dig looks up a hostname from an edge server
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#postdig
+// Endpoint: POST /edge-diagnostics/v1/dig
+func Dig(request DigRequest) (*DigResult, error) {
+	if request.Hostname == "" {
+		return nil, errors.New("dig needs a hostname")
+	}
+	if request.QueryType == "" {
+		request.QueryType = "A"
+	}
+
+	result := &DigResult{}
+	if err := doJSON("POST", "/edge-diagnostics/v1/dig", request, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MTRRequest is a network path trace MTR runs from an edge server
+type MTRRequest struct {
+	Destination     string          `json:"destination"`
+	DestinationType string          `json:"destinationType,omitempty"`
+	IPVersion       IPVersion       `json:"ipVersion,omitempty"`
+	PacketType      string          `json:"packetType,omitempty"`
+	Port            int             `json:"port,omitempty"`
+	ResolveDNS      bool            `json:"resolveDns"`
+	ShowIPs         bool            `json:"showIps"`
+	ClientLocation  *ClientLocation `json:"clientLocation,omitempty"`
+}
+
+// MTRHop is a hop of an MTRResult
+type MTRHop struct {
+	Number int     `json:"number"`
+	Host   string  `json:"host"`
+	Loss   float64 `json:"loss"`
+	Sent   int     `json:"sent"`
+	Last   float64 `json:"last"`
+	Avg    float64 `json:"avg"`
+	Best   float64 `json:"best"`
+	Worst  float64 `json:"worst"`
+	StDev  float64 `json:"stDev"`
+}
+
+// MTRResult is the outcome of MTR
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#mtr
+type MTRResult struct {
+	RequestID       int       `json:"requestId"`
+	CreatedBy       string    `json:"createdBy"`
+	CreatedTime     string    `json:"createdTime"`
+	ExecutionStatus string    `json:"executionStatus"`
+	EdgeIP          string    `json:"edgeIp"`
+	Result          MTROutput `json:"result"`
+}
+
+// MTROutput is the network path found by a trace
+type MTROutput struct {
+	Source      string   `json:"source"`
+	Destination string   `json:"destination"`
+	Hops        []MTRHop `json:"hops"`
+	// Result is the raw mtr output
+	Result string `json:"result"`
+}
+
+// MTR traces the network path from an edge server to a destination
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#postmtr
+// Endpoint: POST /edge-diagnostics/v1/mtr
+func MTR(request MTRRequest) (*MTRResult, error) {
+	if request.Destination == "" {
+		return nil, errors.New("mtr needs a destination")
+	}
+
+	result := &MTRResult{}
+	if err := doJSON("POST", "/edge-diagnostics/v1/mtr", request, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CurlRequest is an HTTP request Curl sends from an edge server
+type CurlRequest struct {
+	URL            string          `json:"url"`
+	IPVersion      IPVersion       `json:"ipVersion,omitempty"`
+	RequestHeaders []string        `json:"requestHeaders,omitempty"`
+	SpoofEdgeIP    string          `json:"spoofEdgeIp,omitempty"`
+	ClientLocation *ClientLocation `json:"clientLocation,omitempty"`
+}
+
+// CurlResult is the outcome of Curl
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#curl
+type CurlResult struct {
+	RequestID       int        `json:"requestId"`
+	CreatedBy       string     `json:"createdBy"`
+	CreatedTime     string     `json:"createdTime"`
+	ExecutionStatus string     `json:"executionStatus"`
+	EdgeIP          string     `json:"edgeIp"`
+	Result          CurlOutput `json:"result"`
+}
+
+// CurlOutput is the response to an HTTP request
+type CurlOutput struct {
+	HTTPStatusCode  int               `json:"httpStatusCode"`
+	ResponseHeaders map[string]string `json:"responseHeaders"`
+	ResponseBody    string            `json:"responseBody"`
+	Timing          map[string]string `json:"timing,omitempty"`
+}
+
+// Curl sends an HTTP request from an edge server, RequestHeaders are
+// "Name: value" lines
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#postcurl
+// Endpoint: POST /edge-diagnostics/v1/curl
+func Curl(request CurlRequest) (*CurlResult, error) {
+	if request.URL == "" {
+		return nil, errors.New("curl needs a URL")
+	}
+
+	result := &CurlResult{}
+	if err := doJSON("POST", "/edge-diagnostics/v1/curl", request, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestDig(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/edge-diagnostics/v1/dig").
+		JSON(map[string]interface{}{"hostname": "www.example.com", "queryType": "A", "clientLocation": map[string]string{"edgeLocationId": "sydney-australia"}}).
+		Reply(200).
+		JSON(`{"requestId": 1, "executionStatus": "SUCCESS", "edgeIp": "192.0.2.10", "result": {
+			"answerSection": [{"domain": "www.example.com.", "ttl": 300, "recordClass": "IN", "recordType": "CNAME", "value": "www.example.com.edgekey.net."}],
+			"result": "; <<>> DiG"}}`)
+
+	Init(config)
+
+	result, err := Dig(DigRequest{Hostname: "www.example.com", ClientLocation: &ClientLocation{EdgeLocationID: "sydney-australia"}})
+	require.NoError(t, err)
+	require.Len(t, result.Result.AnswerSection, 1)
+	assert.Equal(t, "CNAME", result.Result.AnswerSection[0].RecordType)
+	assert.True(t, gock.IsDone())
+
+	_, err = Dig(DigRequest{})
+	assert.Error(t, err)
+}
+
+func TestCurl(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/edge-diagnostics/v1/curl").
+		JSON(map[string]interface{}{"url": "https://www.example.com/", "requestHeaders": []string{"Pragma: akamai-x-cache-on"}}).
+		Reply(200).
+		JSON(`{"requestId": 2, "executionStatus": "SUCCESS", "result": {"httpStatusCode": 200, "responseHeaders": {"X-Cache": "TCP_HIT"}}}`)
+	gock.New(baseURL).
+		Get("/edge-diagnostics/v1/edge-locations").
+		Reply(200).
+		JSON(`{"edgeLocations": [{"id": "sydney-australia", "value": "Sydney, Australia"}]}`)
+
+	Init(config)
+
+	result, err := Curl(CurlRequest{URL: "https://www.example.com/", RequestHeaders: []string{"Pragma: akamai-x-cache-on"}})
+	require.NoError(t, err)
+	assert.Equal(t, 200, result.Result.HTTPStatusCode)
+	assert.Equal(t, "TCP_HIT", result.Result.ResponseHeaders["X-Cache"])
+
+	locations, err := ListEdgeLocations()
+	require.NoError(t, err)
+	assert.Equal(t, []EdgeLocation{{ID: "sydney-australia", Value: "Sydney, Australia"}}, locations)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,281 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ExecutionStatus is used to create an "enum" of possible AsyncResult.ExecutionStatus values
+type ExecutionStatus string
+
+const (
+	// StatusInProgress AsyncResult.ExecutionStatus value IN_PROGRESS
+	StatusInProgress ExecutionStatus = "IN_PROGRESS"
+	// StatusSuccess AsyncResult.ExecutionStatus value SUCCESS
+	StatusSuccess ExecutionStatus = "SUCCESS"
+	// StatusFailure AsyncResult.ExecutionStatus value FAILURE
+	StatusFailure ExecutionStatus = "FAILURE"
+)
+
+// DefaultPollInterval is the interval Wait polls at by default, when the
+// request has no RetryAfter
+const DefaultPollInterval = 10 * time.Second
+
+// AsyncRequest is a diagnostic that runs in the background, see Wait
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#asyncresponse
+type AsyncRequest struct {
+	RequestID string `json:"requestId"`
+	// Link is the path of the result
+	Link string `json:"link"`
+	// RetryAfter is the number of seconds to wait before polling the result
+	RetryAfter int `json:"retryAfter,omitempty"`
+}
+
+// AsyncResult is the status of an AsyncRequest, embedded in its result
+type AsyncResult struct {
+	RequestID       string          `json:"requestId"`
+	ExecutionStatus ExecutionStatus `json:"executionStatus"`
+	CreatedBy       string          `json:"createdBy,omitempty"`
+	CreatedTime     string          `json:"createdTime,omitempty"`
+	CompletedTime   string          `json:"completedTime,omitempty"`
+}
+
+// Status returns the execution status of the request
+func (result *AsyncResult) Status() ExecutionStatus {
+	return result.ExecutionStatus
+}
+
+// Result is the result of an AsyncRequest: *URLHealthCheckResult,
+// *ErrorTranslation or *GrepResult
+type Result interface {
+	Status() ExecutionStatus
+}
+
+// URLHealthCheckRequest is a URL URLHealthCheck checks from an edge server:
+// its hostname is looked up, the path to the edge server traced and the URL
+// requested
+type URLHealthCheckRequest struct {
+	URL            string          `json:"url"`
+	IPVersion      IPVersion       `json:"ipVersion,omitempty"`
+	PacketType     string          `json:"packetType,omitempty"`
+	Port           int             `json:"port,omitempty"`
+	RequestHeaders []string        `json:"requestHeaders,omitempty"`
+	SpoofEdgeIP    string          `json:"spoofEdgeIp,omitempty"`
+	ClientLocation *ClientLocation `json:"clientLocation,omitempty"`
+}
+
+// URLHealthCheckResult is the result of URLHealthCheck
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#urlhealthcheck
+type URLHealthCheckResult struct {
+	AsyncResult
+	Result struct {
+		Dig  *DigOutput  `json:"dig,omitempty"`
+		MTR  *MTROutput  `json:"mtr,omitempty"`
+		Curl *CurlOutput `json:"curl,omitempty"`
+	} `json:"result"`
+}
+
+// URLHealthCheck starts checking a URL
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#posturlhealthcheck
+// Endpoint: POST /edge-diagnostics/v1/url-health-check
+func URLHealthCheck(request URLHealthCheckRequest) (*AsyncRequest, error) {
+	if request.URL == "" {
+		return nil, errors.New("a URL health check needs a URL")
+	}
+
+	return startAsync("/edge-diagnostics/v1/url-health-check", request)
+}
+
+// GetURLHealthCheck retrieves the result of URLHealthCheck
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#geturlhealthcheck
+// Endpoint: GET /edge-diagnostics/v1/url-health-check/requests/{requestId}
+func GetURLHealthCheck(requestID string) (*URLHealthCheckResult, error) {
+	result := &URLHealthCheckResult{}
+	if err := doJSON("GET", "/edge-diagnostics/v1/url-health-check/requests/"+url.PathEscape(requestID), nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ErrorTranslation is the request an error reference code, e.g.
+// 9.6f64d440.1318965461.2f2b078, was returned to
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#errortranslator
+type ErrorTranslation struct {
+	AsyncResult
+	Result struct {
+		ErrorCode        string   `json:"errorCode"`
+		ReasonForFailure string   `json:"reasonForFailure"`
+		URL              string   `json:"url"`
+		RequestMethod    string   `json:"requestMethod"`
+		HTTPResponseCode int      `json:"httpResponseCode"`
+		ServerIP         string   `json:"serverIp"`
+		ClientIP         string   `json:"clientIp"`
+		ConnectingIP     string   `json:"connectingIp"`
+		OriginHostname   string   `json:"originHostname"`
+		OriginIP         string   `json:"originIp"`
+		UserAgent        string   `json:"userAgent"`
+		EpochTime        int64    `json:"epochTime"`
+		Logs             []string `json:"logs,omitempty"`
+	} `json:"result"`
+}
+
+// TranslateError starts translating an error reference code, traceForwardLogs
+// includes the logs of the forward servers
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#posterrortranslator
+// Endpoint: POST /edge-diagnostics/v1/error-translator
+func TranslateError(errorCode string, traceForwardLogs bool) (*AsyncRequest, error) {
+	if errorCode == "" {
+		return nil, errors.New("an error translation needs an error code")
+	}
+
+	body := struct {
+		ErrorCode        string `json:"errorCode"`
+		TraceForwardLogs bool   `json:"traceForwardLogs"`
+	}{errorCode, traceForwardLogs}
+
+	return startAsync("/edge-diagnostics/v1/error-translator", body)
+}
+
+// GetErrorTranslation retrieves the result of TranslateError
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#geterrortranslator
+// Endpoint: GET /edge-diagnostics/v1/error-translator/requests/{requestId}
+func GetErrorTranslation(requestID string) (*ErrorTranslation, error) {
+	result := &ErrorTranslation{}
+	if err := doJSON("GET", "/edge-diagnostics/v1/error-translator/requests/"+url.PathEscape(requestID), nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LogType is used to create an "enum" of possible GrepRequest.LogType values
+type LogType string
+
+const (
+	// LogTypeClientRequests GrepRequest.LogType value R, the requests of clients
+	LogTypeClientRequests LogType = "R"
+	// LogTypeForwardRequests GrepRequest.LogType value F, the requests to
+	// parents and origins
+	LogTypeForwardRequests LogType = "F"
+)
+
+// GrepRequest selects the log lines of an edge server Grep retrieves, at most
+// the last 48 hours and a window of 3 hours
+type GrepRequest struct {
+	EdgeIP          string    `json:"edgeIp"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	LogType         LogType   `json:"logType"`
+	CPCodes         []int     `json:"cpCodes,omitempty"`
+	Hostnames       []string  `json:"hostnames,omitempty"`
+	ClientIPs       []string  `json:"clientIps,omitempty"`
+	UserAgents      []string  `json:"userAgents,omitempty"`
+	HTTPStatusCodes []string  `json:"httpStatusCodes,omitempty"`
+	ARLs            []string  `json:"arls,omitempty"`
+}
+
+// GrepResult is the result of Grep
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#grep
+type GrepResult struct {
+	AsyncResult
+	Result struct {
+		LogLines []string `json:"logLines"`
+	} `json:"result"`
+}
+
+// Grep starts retrieving log lines of an edge server
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#postgrep
+// Endpoint: POST /edge-diagnostics/v1/grep
+func Grep(request GrepRequest) (*AsyncRequest, error) {
+	if request.EdgeIP == "" {
+		return nil, errors.New("grep needs an edge server IP")
+	}
+	if !request.End.After(request.Start) {
+		return nil, fmt.Errorf("grep end %s is not after start %s", request.End.Format(time.RFC3339), request.Start.Format(time.RFC3339))
+	}
+	if request.End.Sub(request.Start) > 3*time.Hour {
+		return nil, errors.New("grep covers at most 3 hours")
+	}
+	if len(request.CPCodes) == 0 && len(request.Hostnames) == 0 {
+		return nil, errors.New("grep needs CP codes or hostnames")
+	}
+	if request.LogType == "" {
+		request.LogType = LogTypeClientRequests
+	}
+	request.Start = request.Start.UTC()
+	request.End = request.End.UTC()
+
+	return startAsync("/edge-diagnostics/v1/grep", request)
+}
+
+// GetGrep retrieves the result of Grep
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_diagnostics/v1.html#getgrep
+// Endpoint: GET /edge-diagnostics/v1/grep/requests/{requestId}
+func GetGrep(requestID string) (*GrepResult, error) {
+	result := &GrepResult{}
+	if err := doJSON("GET", "/edge-diagnostics/v1/grep/requests/"+url.PathEscape(requestID), nil, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Wait polls the result of request into result until it is no longer
+// IN_PROGRESS, and returns an error if it did not succeed
+//
+// pollInterval defaults to request.RetryAfter, then DefaultPollInterval.
+// ctx.Err() is returned when ctx is done first, result holds the last poll.
+func Wait(ctx context.Context, request *AsyncRequest, result Result, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = time.Duration(request.RetryAfter) * time.Second
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	for {
+		if err := doJSON("GET", request.Link, nil, result); err != nil {
+			return err
+		}
+		switch result.Status() {
+		case StatusSuccess:
+			return nil
+		case StatusFailure:
+			return fmt.Errorf("diagnostic request %s failed", request.RequestID)
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("diagnostic request %s still %s: %w", request.RequestID, result.Status(), ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func startAsync(path string, body interface{}) (*AsyncRequest, error) {
+	request := &AsyncRequest{}
+	if err := doJSON("POST", path, body, request); err != nil {
+		return nil, err
+	}
+	if request.Link == "" {
+		return nil, fmt.Errorf("diagnostic request %s has no result link", request.RequestID)
+	}
+
+	return request, nil
+}
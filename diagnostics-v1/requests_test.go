@@ -0,0 +1,89 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestTranslateError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/edge-diagnostics/v1/error-translator").
+		JSON(map[string]interface{}{"errorCode": "9.6f64d440.1318965461.2f2b078", "traceForwardLogs": false}).
+		Reply(202).
+		JSON(`{"requestId": "10", "link": "/edge-diagnostics/v1/error-translator/requests/10", "retryAfter": 5}`)
+	gock.New(baseURL).
+		Get("/edge-diagnostics/v1/error-translator/requests/10").
+		Reply(200).
+		JSON(`{"requestId": "10", "executionStatus": "IN_PROGRESS"}`)
+	gock.New(baseURL).
+		Get("/edge-diagnostics/v1/error-translator/requests/10").
+		Reply(200).
+		JSON(`{"requestId": "10", "executionStatus": "SUCCESS", "result": {"errorCode": "9.6f64d440.1318965461.2f2b078", "reasonForFailure": "Origin timeout", "httpResponseCode": 504}}`)
+
+	Init(config)
+
+	request, err := TranslateError("9.6f64d440.1318965461.2f2b078", false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, request.RetryAfter)
+
+	translation := &ErrorTranslation{}
+	require.NoError(t, Wait(context.Background(), request, translation, time.Millisecond))
+	assert.Equal(t, "Origin timeout", translation.Result.ReasonForFailure)
+	assert.Equal(t, 504, translation.Result.HTTPResponseCode)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWait_Failure(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/edge-diagnostics/v1/url-health-check/requests/11").
+		Reply(200).
+		JSON(`{"requestId": "11", "executionStatus": "FAILURE"}`)
+
+	Init(config)
+
+	request := &AsyncRequest{RequestID: "11", Link: "/edge-diagnostics/v1/url-health-check/requests/11"}
+	err := Wait(context.Background(), request, &URLHealthCheckResult{}, time.Millisecond)
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGrep(t *testing.T) {
+	defer gock.Off()
+
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	gock.New(baseURL).
+		Post("/edge-diagnostics/v1/grep").
+		JSON(map[string]interface{}{"edgeIp": "192.0.2.10", "start": "2020-06-01T12:00:00Z", "end": "2020-06-01T13:00:00Z", "logType": "R", "cpCodes": []int{12345}}).
+		Reply(202).
+		JSON(`{"requestId": "12", "link": "/edge-diagnostics/v1/grep/requests/12"}`)
+	gock.New(baseURL).
+		Get("/edge-diagnostics/v1/grep/requests/12").
+		Reply(200).
+		JSON(`{"requestId": "12", "executionStatus": "SUCCESS", "result": {"logLines": ["r 1591012800.123 192.0.2.10 GET /index.html 200"]}}`)
+
+	Init(config)
+
+	request, err := Grep(GrepRequest{EdgeIP: "192.0.2.10", Start: start, End: start.Add(time.Hour), CPCodes: []int{12345}})
+	require.NoError(t, err)
+
+	result, err := GetGrep(request.RequestID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuccess, result.Status())
+	assert.Len(t, result.Result.LogLines, 1)
+	assert.True(t, gock.IsDone())
+
+	_, err = Grep(GrepRequest{EdgeIP: "192.0.2.10", Start: start, End: start.Add(4 * time.Hour), CPCodes: []int{12345}})
+	assert.Error(t, err)
+	_, err = Grep(GrepRequest{EdgeIP: "192.0.2.10", Start: start, End: start.Add(time.Hour)})
+	assert.Error(t, err)
+}
@@ -118,7 +118,8 @@ func InitEdgeRc(filepath string, section string) (Config, error) {
 // InitEnv initializes using the Environment (ENV)
 //
 // By default, it uses AKAMAI_HOST, AKAMAI_CLIENT_TOKEN, AKAMAI_CLIENT_SECRET,
-// AKAMAI_ACCESS_TOKEN, and AKAMAI_MAX_BODY variables.
+// AKAMAI_ACCESS_TOKEN, and AKAMAI_MAX_BODY variables, as well as the optional
+// AKAMAI_ACCOUNT_KEY account switch key.
 //
 // You can define multiple configurations by prefixing with the section name specified, e.g.
 // passing "ccu" will cause it to look for AKAMAI_CCU_HOST, etc.
@@ -178,5 +179,9 @@ func InitEnv(section string) (Config, error) {
 		c.MaxBody = 131072
 	}
 
+	if val, ok := os.LookupEnv(prefix + "ACCOUNT_KEY"); ok {
+		c.AccountKey = val
+	}
+
 	return c, nil
 }
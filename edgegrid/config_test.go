@@ -127,6 +127,23 @@ func TestInitEnv_MaxBody(t *testing.T) {
 	assert.Equal(t, c.HeaderToSign, []string(nil))
 }
 
+func TestInitEnv_AccountKey(t *testing.T) {
+	os.Clearenv()
+	for key, value := range map[string]string{
+		"AKAMAI_HOST":          "env-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net/",
+		"AKAMAI_CLIENT_TOKEN":  "env-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+		"AKAMAI_CLIENT_SECRET": "envxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		"AKAMAI_ACCESS_TOKEN":  "env-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx",
+		"AKAMAI_ACCOUNT_KEY":   "1-ABCDE",
+	} {
+		assert.NoError(t, os.Setenv(key, value))
+	}
+
+	c, err := InitEnv("")
+	assert.NoError(t, err)
+	assert.Equal(t, "1-ABCDE", c.AccountKey)
+}
+
 func TestInit_WithEnv(t *testing.T) {
 	os.Clearenv()
 	err := os.Setenv("AKAMAI_HOST", "env-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net/")
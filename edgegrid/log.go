@@ -23,6 +23,7 @@ import (
 	"net/http/httputil"
 	"os"
 	"strings"
+	"sync"
 
 	logstd "log"
 
@@ -33,7 +34,17 @@ var logBuffer *bufio.Writer
 var LogFile *os.File
 var EdgegridLog *log.Logger
 
+// logSetup serializes the setup of EdgegridLog, requests may be signed concurrently
+var logSetup sync.Mutex
+
 func SetupLogging() {
+	logSetup.Lock()
+	defer logSetup.Unlock()
+
+	setupLogging()
+}
+
+func setupLogging() {
 
 	if EdgegridLog != nil {
 		return // already configured
@@ -21,18 +21,44 @@ import (
 
 const defaultSection = "DEFAULT"
 
+var (
+	// Clock returns the current time used to timestamp signed requests.
+	// Replace it (e.g. with FixedClock) to produce reproducible signatures in tests.
+	Clock = time.Now
+
+	// NonceSource returns the nonce used for each signed request, a random UUID by default.
+	// Replace it (e.g. with FixedNonce) to produce reproducible signatures in tests.
+	NonceSource = createNonce
+)
+
+// FixedClock returns a Clock that always reports t
+func FixedClock(t time.Time) func() time.Time {
+	return func() time.Time {
+		return t
+	}
+}
+
+// FixedNonce returns a NonceSource that always returns nonce
+func FixedNonce(nonce string) func() string {
+	return func() string {
+		return nonce
+	}
+}
+
 // AddRequestHeader sets the Authorization header to use Akamai Open API
 func AddRequestHeader(config Config, req *http.Request) *http.Request {
 
+	logSetup.Lock()
 	if EdgegridLog == nil {
-		SetupLogging()
+		setupLogging()
 		if config.Debug {
 			EdgegridLog.SetLevel(logrus.DebugLevel)
 		}
 	}
+	logSetup.Unlock()
 	timestamp := makeEdgeTimeStamp()
 	EdgegridLog.Debugf("Timestamp: '%s'", timestamp)
-	nonce := createNonce()
+	nonce := NonceSource()
 	EdgegridLog.Debugf("Nonce: '%s'", nonce)
 
 	if req.Header.Get("Content-Type") == "" {
@@ -67,7 +93,7 @@ func AddRequestHeader(config Config, req *http.Request) *http.Request {
 // Format of “yyyyMMddTHH:mm:ss+0000”
 func makeEdgeTimeStamp() string {
 	local := time.FixedZone("GMT", 0)
-	t := time.Now().In(local)
+	t := Clock().In(local)
 	return fmt.Sprintf("%d%02d%02dT%02d:%02d:%02d+0000",
 		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
 }
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
 	"github.com/stretchr/testify/assert"
@@ -97,3 +98,25 @@ func TestCreateAuthHeader(t *testing.T) {
 
 	}
 }
+
+func TestAddRequestHeader_Deterministic(t *testing.T) {
+	defer func(clock func() time.Time, nonceSource func() string) {
+		Clock = clock
+		NonceSource = nonceSource
+	}(Clock, NonceSource)
+
+	Clock = FixedClock(time.Date(2014, 3, 21, 19, 34, 21, 0, time.UTC))
+	NonceSource = FixedNonce(nonce)
+
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("POST", "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/testapi/v1/t4", bytes.NewBufferString("datadatadatadatadatadatadatadata"))
+		return req
+	}
+
+	SetupLogging()
+	first := AddRequestHeader(config, newRequest()).Header.Get("Authorization")
+	second := AddRequestHeader(config, newRequest()).Header.Get("Authorization")
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, createAuthHeader(config, newRequest(), timestamp, nonce), first)
+}
@@ -0,0 +1,112 @@
+package edgekv
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BackupItem is a line of a namespace backup
+type BackupItem struct {
+	Group string `json:"group"`
+	Key   string `json:"key"`
+	// Value is the item value as stored, JSON values are kept as their JSON
+	// text; it is base64 encoded in the backup so binary values round-trip
+	Value []byte `json:"value"`
+}
+
+// ExportOptions configures ExportNamespace
+type ExportOptions struct {
+	// Groups limits the export to these groups, all groups when empty
+	Groups []string
+	// MaxItems is passed to ListItems for each group, MaxListItems when 0,
+	// see ExportResult.Truncated
+	MaxItems int
+}
+
+// ExportResult summarizes an ExportNamespace run
+type ExportResult struct {
+	Items int
+	// Truncated are the groups that listed MaxItems items, which may have more
+	// items the API did not list
+	Truncated []string
+}
+
+// ExportNamespace writes every item of a namespace to w as JSON lines of
+// BackupItem, group by group
+//
+// EdgeKV listings have no offset, a group listing returning MaxItems (or
+// MaxListItems) keys is reported in ExportResult.Truncated. Requests rejected with 429 Too Many
+// Requests are retried, see MaxRetries.
+func ExportNamespace(network NetworkValue, namespaceID string, w io.Writer, options ExportOptions) (*ExportResult, error) {
+	groups := options.Groups
+	if len(groups) == 0 {
+		var err error
+		if groups, err = ListGroups(network, namespaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	maxItems := options.MaxItems
+	if maxItems <= 0 || maxItems > MaxListItems {
+		maxItems = MaxListItems
+	}
+
+	result := &ExportResult{}
+	encoder := json.NewEncoder(w)
+	for _, group := range groups {
+		keys, err := ListItems(network, namespaceID, group, maxItems)
+		if err != nil {
+			return result, fmt.Errorf("group %s: %w", group, err)
+		}
+		if len(keys) >= maxItems {
+			result.Truncated = append(result.Truncated, group)
+		}
+
+		for _, key := range keys {
+			value, err := GetItem(network, namespaceID, group, key)
+			if err != nil {
+				return result, fmt.Errorf("item %s/%s: %w", group, key, err)
+			}
+			if err := encoder.Encode(BackupItem{Group: group, Key: key, Value: value}); err != nil {
+				return result, err
+			}
+			result.Items++
+		}
+	}
+
+	return result, nil
+}
+
+// RestoreNamespace upserts the items of a backup written by ExportNamespace
+// into a namespace, returning the number of items restored
+func RestoreNamespace(network NetworkValue, namespaceID string, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	restored := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var item BackupItem
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			return restored, fmt.Errorf("line %d: %w", line, err)
+		}
+		if item.Group == "" || item.Key == "" {
+			return restored, fmt.Errorf("line %d: group and key are required", line)
+		}
+		if err := UpsertItem(network, namespaceID, item.Group, item.Key, item.Value); err != nil {
+			return restored, fmt.Errorf("item %s/%s: %w", item.Group, item.Key, err)
+		}
+		restored++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return restored, err
+	}
+
+	return restored, nil
+}
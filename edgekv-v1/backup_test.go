@@ -0,0 +1,134 @@
+package edgekv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestExportNamespace(t *testing.T) {
+	defer gock.Off()
+	var slept []time.Duration
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleep = time.Sleep }()
+	limiter := client.Limiter
+	client.Limiter = nil
+	defer func() { client.Limiter = limiter }()
+
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups").
+		Reply(200).
+		JSON([]string{"countries", "banners"})
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries").
+		MatchParam("maxItems", "2").
+		Reply(200).
+		JSON([]string{"US", "DE"})
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/US").
+		Reply(429).
+		SetHeader("Retry-After", "3")
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/US").
+		Reply(200).
+		BodyString(`{"currency":"USD"}`)
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/DE").
+		Reply(200).
+		BodyString(`{"currency":"EUR"}`)
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/banners").
+		Reply(200).
+		JSON([]string{"summer"})
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/banners/items/summer").
+		Reply(200).
+		BodyString("Summer sale")
+
+	Init(config)
+
+	var backup bytes.Buffer
+	result, err := ExportNamespace(NetworkStaging, "marketing", &backup, ExportOptions{MaxItems: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Items)
+	assert.Equal(t, []string{"countries"}, result.Truncated)
+	assert.Equal(t, []time.Duration{3 * time.Second}, slept)
+	assert.True(t, gock.IsDone())
+
+	lines := strings.Split(strings.TrimSpace(backup.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"group":"countries","key":"US","value":"eyJjdXJyZW5jeSI6IlVTRCJ9"}`, lines[0])
+	assert.Equal(t, `{"group":"banners","key":"summer","value":"U3VtbWVyIHNhbGU="}`, lines[2])
+}
+
+func TestExportNamespace_MaxListItems(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/logos").
+		MatchParam("maxItems", "10000").
+		Reply(200).
+		JSON([]string{"png"})
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/logos/items/png").
+		Reply(200).
+		BodyString("\x89PNG\x00\xff")
+
+	Init(config)
+
+	var backup bytes.Buffer
+	result, err := ExportNamespace(NetworkStaging, "marketing", &backup, ExportOptions{Groups: []string{"logos"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Items)
+	assert.Empty(t, result.Truncated)
+	assert.Equal(t, `{"group":"logos","key":"png","value":"iVBORwD/"}`, strings.TrimSpace(backup.String()))
+	assert.True(t, gock.IsDone())
+}
+
+func TestRestoreNamespace(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/edgekv/v1/networks/production/namespaces/marketing/groups/countries/items/US").
+		MatchHeader("Content-Type", "application/json").
+		BodyString(`{"currency":"USD"}`).
+		Reply(200)
+	gock.New(baseURL).
+		Put("/edgekv/v1/networks/production/namespaces/marketing/groups/banners/items/summer").
+		MatchHeader("Content-Type", "text/plain").
+		BodyString("Summer sale").
+		Reply(200)
+
+	Init(config)
+
+	backup := `{"group":"countries","key":"US","value":"eyJjdXJyZW5jeSI6IlVTRCJ9"}
+
+{"group":"banners","key":"summer","value":"U3VtbWVyIHNhbGU="}
+`
+	restored, err := RestoreNamespace(NetworkProduction, "marketing", strings.NewReader(backup))
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+	assert.True(t, gock.IsDone())
+
+	_, err = RestoreNamespace(NetworkProduction, "marketing", strings.NewReader(`{"group":"banners"}`))
+	assert.EqualError(t, err, "line 1: group and key are required")
+}
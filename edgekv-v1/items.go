@@ -0,0 +1,230 @@
+package edgekv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// NetworkValue is used to create an "enum" of possible EdgeKV network values
+type NetworkValue string
+
+const (
+	// NetworkStaging EdgeKV network value staging
+	NetworkStaging NetworkValue = "staging"
+	// NetworkProduction EdgeKV network value production
+	NetworkProduction NetworkValue = "production"
+)
+
+var (
+	// MaxRetries is the number of times a request client.Retries classifies as
+	// retryable, e.g. rejected with 429 Too Many Requests, is retried
+	MaxRetries = 5
+	// RetryWait is the wait before retrying a request when the response has no
+	// Retry-After header, doubled on each retry. Requests rejected with 429 Too
+	// Many Requests are only held back by client.Limiter when it is enabled.
+	RetryWait = time.Second
+
+	sleep = time.Sleep
+)
+
+// MaxListItems is the largest maxItems ListItems accepts
+const MaxListItems = 10000
+
+// ListGroups lists the groups of a namespace that have items
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getgroups
+// Endpoint: GET /edgekv/v1/networks/{network}/namespaces/{namespaceId}/groups
+func ListGroups(network NetworkValue, namespaceID string) ([]string, error) {
+	var groups []string
+	res, err := doRequest("GET", fmt.Sprintf("/edgekv/v1/networks/%s/namespaces/%s/groups", network, namespaceID), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = client.BodyJSON(res, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// ListItems lists the keys of up to maxItems items of a group, all items
+// (up to the API limit) when maxItems is 0
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getgroupitems
+// Endpoint: GET /edgekv/v1/networks/{network}/namespaces/{namespaceId}/groups/{groupId}{?maxItems}
+func ListItems(network NetworkValue, namespaceID string, groupID string, maxItems int) ([]string, error) {
+	path := fmt.Sprintf("/edgekv/v1/networks/%s/namespaces/%s/groups/%s", network, namespaceID, groupID)
+	if maxItems > 0 {
+		path += "?maxItems=" + strconv.Itoa(maxItems)
+	}
+
+	var items []string
+	res, err := doRequest("GET", path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = client.BodyJSON(res, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetItem reads the value of an item, as stored (JSON or text)
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getitem
+// Endpoint: GET /edgekv/v1/networks/{network}/namespaces/{namespaceId}/groups/{groupId}/items/{itemId}
+func GetItem(network NetworkValue, namespaceID string, groupID string, itemID string) ([]byte, error) {
+	res, err := doRequest("GET", itemPath(network, namespaceID, groupID, itemID), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ReadBody(res)
+}
+
+// UpsertItem creates or replaces the value of an item, values that are valid
+// JSON are sent as JSON, others as text
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#putitem
+// Endpoint: PUT /edgekv/v1/networks/{network}/namespaces/{namespaceId}/groups/{groupId}/items/{itemId}
+func UpsertItem(network NetworkValue, namespaceID string, groupID string, itemID string, value []byte) error {
+	contentType := "text/plain"
+	if json.Valid(value) {
+		contentType = "application/json"
+	}
+
+	res, err := doRequest("PUT", itemPath(network, namespaceID, groupID, itemID), value, contentType)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// DeleteItem deletes an item
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#deleteitem
+// Endpoint: DELETE /edgekv/v1/networks/{network}/namespaces/{namespaceId}/groups/{groupId}/items/{itemId}
+func DeleteItem(network NetworkValue, namespaceID string, groupID string, itemID string) error {
+	res, err := doRequest("DELETE", itemPath(network, namespaceID, groupID, itemID), nil, "")
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// GetItemJSON reads the JSON value of an item into out
+func GetItemJSON(network NetworkValue, namespaceID string, groupID string, itemID string, out interface{}) error {
+	return doJSON("GET", itemPath(network, namespaceID, groupID, itemID), nil, out)
+}
+
+// UpsertItemJSON creates or replaces the value of an item with value encoded as JSON
+func UpsertItemJSON(network NetworkValue, namespaceID string, groupID string, itemID string, value interface{}) error {
+	return doJSON("PUT", itemPath(network, namespaceID, groupID, itemID), value, nil)
+}
+
+// UpsertItemText creates or replaces the value of an item with text, also
+// when it happens to be valid JSON
+func UpsertItemText(network NetworkValue, namespaceID string, groupID string, itemID string, value string) error {
+	res, err := doRequest("PUT", itemPath(network, namespaceID, groupID, itemID), []byte(value), "text/plain")
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func itemPath(network NetworkValue, namespaceID string, groupID string, itemID string) string {
+	return fmt.Sprintf("/edgekv/v1/networks/%s/namespaces/%s/groups/%s/items/%s", network, namespaceID, groupID, itemID)
+}
+
+// doJSON sends body (if not nil) as JSON and decodes the response into out (if not nil)
+func doJSON(method string, path string, body interface{}, out interface{}) error {
+	var data []byte
+	contentType := ""
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+
+	res, err := doRequest(method, path, data, contentType)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		res.Body.Close()
+		return nil
+	}
+
+	return client.BodyJSON(res, out)
+}
+
+// doRequest sends a request, retrying it up to MaxRetries times while
+// client.Retries classifies its failure as retryable, and returns the
+// successful response
+func doRequest(method string, path string, body []byte, contentType string) (*http.Response, error) {
+	wait := RetryWait
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := client.NewRequest(Config, method, path, reader)
+		if err != nil {
+			return nil, err
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		edge.PrintHttpRequest(req, true)
+
+		res, err := client.Do(Config, req)
+		if err != nil {
+			if attempt < MaxRetries && client.Retries.Retryable(req, nil, err) {
+				sleep(wait)
+				wait *= 2
+				continue
+			}
+			return nil, err
+		}
+
+		edge.PrintHttpResponse(res, true)
+
+		if attempt < MaxRetries && client.Retries.Retryable(req, res, nil) {
+			res.Body.Close()
+			// client.Limiter already holds a 429 back until Retry-After
+			if res.StatusCode != http.StatusTooManyRequests || client.Limiter == nil {
+				delay := wait
+				if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+					delay = time.Duration(seconds) * time.Second
+				}
+				sleep(delay)
+				wait *= 2
+			}
+			continue
+		}
+
+		if client.IsError(res) {
+			return nil, client.NewAPIError(res)
+		}
+
+		return res, nil
+	}
+}
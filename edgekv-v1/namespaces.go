@@ -0,0 +1,118 @@
+package edgekv
+
+import (
+	"fmt"
+)
+
+// InitializationStatus is the EdgeKV status of the account
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#initialization
+type InitializationStatus struct {
+	AccountStatus    string `json:"accountStatus"`
+	CPCode           string `json:"cpcode,omitempty"`
+	ProductionStatus string `json:"productionStatus"`
+	StagingStatus    string `json:"stagingStatus"`
+}
+
+// Initialized reports whether EdgeKV is ready on both networks
+func (status *InitializationStatus) Initialized() bool {
+	return status.AccountStatus == "INITIALIZED" && status.StagingStatus == "INITIALIZED" && status.ProductionStatus == "INITIALIZED"
+}
+
+// Namespace is an EdgeKV namespace
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#namespace
+type Namespace struct {
+	Name string `json:"namespace"`
+	// GeoLocation is where the namespace data is stored, US, EU or JP
+	GeoLocation string `json:"geoLocation,omitempty"`
+	// Retention of the items in seconds, 0 keeps them forever
+	RetentionInSeconds int `json:"retentionInSeconds"`
+	GroupID            int `json:"groupId,omitempty"`
+}
+
+// GetInitializationStatus retrieves the EdgeKV status of the account
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getinitialize
+// Endpoint: GET /edgekv/v1/initialize
+func GetInitializationStatus() (*InitializationStatus, error) {
+	status := &InitializationStatus{}
+	if err := doJSON("GET", "/edgekv/v1/initialize", nil, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Initialize initializes EdgeKV for the account, which creates the default
+// namespace. Initialization takes a few minutes, see GetInitializationStatus.
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#putinitialize
+// Endpoint: PUT /edgekv/v1/initialize
+func Initialize() (*InitializationStatus, error) {
+	status := &InitializationStatus{}
+	if err := doJSON("PUT", "/edgekv/v1/initialize", nil, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// ListNamespaces lists the namespaces of a network with their details
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getnamespaces
+// Endpoint: GET /edgekv/v1/networks/{network}/namespaces{?details}
+func ListNamespaces(network NetworkValue) ([]Namespace, error) {
+	response := struct {
+		Namespaces []Namespace `json:"namespaces"`
+	}{}
+	if err := doJSON("GET", fmt.Sprintf("/edgekv/v1/networks/%s/namespaces?details=on", network), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Namespaces, nil
+}
+
+// GetNamespace retrieves a namespace
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#getnamespace
+// Endpoint: GET /edgekv/v1/networks/{network}/namespaces/{namespaceId}
+func GetNamespace(network NetworkValue, namespaceID string) (*Namespace, error) {
+	namespace := &Namespace{}
+	if err := doJSON("GET", namespacePath(network, namespaceID), nil, namespace); err != nil {
+		return nil, err
+	}
+
+	return namespace, nil
+}
+
+// CreateNamespace creates a namespace on a network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#postnamespace
+// Endpoint: POST /edgekv/v1/networks/{network}/namespaces
+func CreateNamespace(network NetworkValue, namespace *Namespace) (*Namespace, error) {
+	created := &Namespace{}
+	if err := doJSON("POST", fmt.Sprintf("/edgekv/v1/networks/%s/namespaces", network), namespace, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateNamespace changes the retention of a namespace
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#putnamespace
+// Endpoint: PUT /edgekv/v1/networks/{network}/namespaces/{namespaceId}
+func UpdateNamespace(network NetworkValue, namespace *Namespace) (*Namespace, error) {
+	updated := &Namespace{}
+	body := &Namespace{Name: namespace.Name, RetentionInSeconds: namespace.RetentionInSeconds, GroupID: namespace.GroupID}
+	if err := doJSON("PUT", namespacePath(network, namespace.Name), body, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func namespacePath(network NetworkValue, namespaceID string) string {
+	return fmt.Sprintf("/edgekv/v1/networks/%s/namespaces/%s", network, namespaceID)
+}
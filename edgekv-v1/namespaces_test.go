@@ -0,0 +1,134 @@
+package edgekv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestInitializeAndCreateNamespace(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/edgekv/v1/initialize").
+		Reply(201).
+		JSON(`{"accountStatus": "INITIALIZED", "cpcode": "123456", "productionStatus": "PENDING", "stagingStatus": "INITIALIZED"}`)
+	gock.New(baseURL).
+		Post("/edgekv/v1/networks/staging/namespaces").
+		JSON(map[string]interface{}{"namespace": "marketing", "geoLocation": "EU", "retentionInSeconds": 0, "groupId": 1234}).
+		Reply(200).
+		JSON(`{"namespace": "marketing", "geoLocation": "EU", "retentionInSeconds": 0, "groupId": 1234}`)
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces").
+		MatchParam("details", "on").
+		Reply(200).
+		JSON(`{"namespaces": [{"namespace": "default", "retentionInSeconds": 0}, {"namespace": "marketing", "geoLocation": "EU"}]}`)
+
+	Init(config)
+
+	status, err := Initialize()
+	require.NoError(t, err)
+	assert.False(t, status.Initialized())
+
+	namespace, err := CreateNamespace(NetworkStaging, &Namespace{Name: "marketing", GeoLocation: "EU", GroupID: 1234})
+	require.NoError(t, err)
+	assert.Equal(t, "marketing", namespace.Name)
+
+	namespaces, err := ListNamespaces(NetworkStaging)
+	require.NoError(t, err)
+	assert.Len(t, namespaces, 2)
+	assert.True(t, gock.IsDone())
+}
+
+func TestItemJSON(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/fr").
+		MatchType("json").
+		JSON(map[string]interface{}{"currency": "EUR"}).
+		Reply(200)
+	gock.New(baseURL).
+		Put("/edgekv/v1/networks/staging/namespaces/marketing/groups/banners/items/home").
+		MatchType("text").
+		BodyString("true").
+		Reply(200)
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/fr").
+		Reply(200).
+		JSON(`{"currency": "EUR"}`)
+
+	Init(config)
+
+	require.NoError(t, UpsertItemJSON(NetworkStaging, "marketing", "countries", "fr", map[string]string{"currency": "EUR"}))
+	require.NoError(t, UpsertItemText(NetworkStaging, "marketing", "banners", "home", "true"))
+
+	value := struct {
+		Currency string `json:"currency"`
+	}{}
+	require.NoError(t, GetItemJSON(NetworkStaging, "marketing", "countries", "fr", &value))
+	assert.Equal(t, "EUR", value.Currency)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGetItem_Retry(t *testing.T) {
+	defer gock.Off()
+	var slept []time.Duration
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleep = time.Sleep }()
+
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/fr").
+		Reply(503)
+	gock.New(baseURL).
+		Get("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/fr").
+		Reply(200).
+		BodyString("EUR")
+	gock.New(baseURL).
+		Delete("/edgekv/v1/networks/staging/namespaces/marketing/groups/countries/items/fr").
+		Reply(501)
+
+	Init(config)
+
+	value, err := GetItem(NetworkStaging, "marketing", "countries", "fr")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", string(value))
+	assert.Equal(t, []time.Duration{RetryWait}, slept)
+
+	assert.Error(t, DeleteItem(NetworkStaging, "marketing", "countries", "fr"))
+	assert.Len(t, slept, 1)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreateToken(t *testing.T) {
+	defer gock.Off()
+	now = func() time.Time { return time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	gock.New(baseURL).
+		Post("/edgekv/v1/tokens").
+		JSON(map[string]interface{}{
+			"name":                 "marketing-rw",
+			"allowOnProduction":    true,
+			"allowOnStaging":       true,
+			"expiry":               "2021-03-31",
+			"namespacePermissions": map[string][]string{"marketing": {"r", "w"}},
+		}).
+		Reply(200).
+		JSON(`{"name": "marketing-rw", "expiry": "2021-03-31", "uuid": "4c5d", "value": "eyJ0eXAi"}`)
+
+	Init(config)
+
+	request, err := NewTokenRequest("marketing-rw", 30*24*time.Hour, []string{"marketing"}, PermissionRead, PermissionWrite)
+	require.NoError(t, err)
+	token, err := CreateToken(request)
+	require.NoError(t, err)
+	assert.Equal(t, "eyJ0eXAi", token.Value)
+	assert.True(t, gock.IsDone())
+
+	_, err = NewTokenRequest("forever", 365*24*time.Hour, []string{"marketing"}, PermissionRead)
+	assert.Error(t, err)
+}
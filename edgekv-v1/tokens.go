@@ -0,0 +1,120 @@
+package edgekv
+
+import (
+	"fmt"
+	"time"
+)
+
+// Permission is used to create an "enum" of possible namespace permissions of a Token
+type Permission string
+
+const (
+	// PermissionRead namespace permission r
+	PermissionRead Permission = "r"
+	// PermissionWrite namespace permission w
+	PermissionWrite Permission = "w"
+	// PermissionDelete namespace permission d
+	PermissionDelete Permission = "d"
+)
+
+// MaxTokenLifetime is the longest an EdgeKV access token can be valid
+const MaxTokenLifetime = 6 * 30 * 24 * time.Hour
+
+var now = time.Now
+
+// TokenRequest describes an EdgeKV access token to create
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#tokenrequest
+type TokenRequest struct {
+	Name              string `json:"name"`
+	AllowOnProduction bool   `json:"allowOnProduction"`
+	AllowOnStaging    bool   `json:"allowOnStaging"`
+	// Expiry is the date, as YYYY-MM-DD, the token expires on
+	Expiry               string                  `json:"expiry"`
+	NamespacePermissions map[string][]Permission `json:"namespacePermissions"`
+}
+
+// Token is an EdgeKV access token, to be bundled with the EdgeWorkers that
+// access the namespaces
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#token
+type Token struct {
+	Name   string `json:"name"`
+	Expiry string `json:"expiry"`
+	// UUID and Value are only returned by CreateToken and GetToken
+	UUID  string `json:"uuid,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// NewTokenRequest creates a TokenRequest for name valid on both networks for
+// lifetime, granting permissions on each namespace of namespaces
+func NewTokenRequest(name string, lifetime time.Duration, namespaces []string, permissions ...Permission) (*TokenRequest, error) {
+	if lifetime <= 0 || lifetime > MaxTokenLifetime {
+		return nil, fmt.Errorf("token lifetime must be between 0 and %s, got %s", MaxTokenLifetime, lifetime)
+	}
+	if len(namespaces) == 0 || len(permissions) == 0 {
+		return nil, fmt.Errorf("token %s has no namespace permissions", name)
+	}
+
+	request := &TokenRequest{
+		Name:                 name,
+		AllowOnProduction:    true,
+		AllowOnStaging:       true,
+		Expiry:               now().Add(lifetime).UTC().Format("2006-01-02"),
+		NamespacePermissions: map[string][]Permission{},
+	}
+	for _, namespace := range namespaces {
+		request.NamespacePermissions[namespace] = permissions
+	}
+
+	return request, nil
+}
+
+// CreateToken creates an EdgeKV access token
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#posttokens
+// Endpoint: POST /edgekv/v1/tokens
+func CreateToken(request *TokenRequest) (*Token, error) {
+	token := &Token{}
+	if err := doJSON("POST", "/edgekv/v1/tokens", request, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ListTokens lists the EdgeKV access tokens, expired ones only when includeExpired is true
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#gettokens
+// Endpoint: GET /edgekv/v1/tokens{?includeExpired}
+func ListTokens(includeExpired bool) ([]Token, error) {
+	response := struct {
+		Tokens []Token `json:"tokens"`
+	}{}
+	if err := doJSON("GET", fmt.Sprintf("/edgekv/v1/tokens?includeExpired=%t", includeExpired), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Tokens, nil
+}
+
+// GetToken retrieves an EdgeKV access token
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#gettoken
+// Endpoint: GET /edgekv/v1/tokens/{tokenName}
+func GetToken(name string) (*Token, error) {
+	token := &Token{}
+	if err := doJSON("GET", "/edgekv/v1/tokens/"+name, nil, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// RevokeToken revokes an EdgeKV access token
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgekv/v1.html#deletetoken
+// Endpoint: DELETE /edgekv/v1/tokens/{tokenName}
+func RevokeToken(name string) error {
+	return doJSON("DELETE", "/edgekv/v1/tokens/"+name, nil, nil)
+}
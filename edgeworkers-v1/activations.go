@@ -0,0 +1,136 @@
+package edgeworkers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// Network is used to create an "enum" of possible Activation.Network values
+type Network string
+
+// ActivationStatus is used to create an "enum" of possible Activation.Status values
+type ActivationStatus string
+
+const (
+	// NetworkStaging the staging network
+	NetworkStaging Network = "STAGING"
+	// NetworkProduction the production network
+	NetworkProduction Network = "PRODUCTION"
+
+	// StatusPresubmit Activation.Status value PRESUBMIT
+	StatusPresubmit ActivationStatus = "PRESUBMIT"
+	// StatusPending Activation.Status value PENDING
+	StatusPending ActivationStatus = "PENDING"
+	// StatusInProgress Activation.Status value IN_PROGRESS
+	StatusInProgress ActivationStatus = "IN_PROGRESS"
+	// StatusComplete Activation.Status value COMPLETE
+	StatusComplete ActivationStatus = "COMPLETE"
+	// StatusError Activation.Status value ERROR
+	StatusError ActivationStatus = "ERROR"
+	// StatusCanceled Activation.Status value CANCELED
+	StatusCanceled ActivationStatus = "CANCELED"
+)
+
+// Activation is an EdgeWorker version activation on a network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#activation
+type Activation struct {
+	AccountID        string           `json:"accountId,omitempty"`
+	ActivationID     int              `json:"activationId,omitempty"`
+	EdgeWorkerID     int              `json:"edgeWorkerId"`
+	Version          string           `json:"version"`
+	Network          Network          `json:"network"`
+	Status           ActivationStatus `json:"status,omitempty"`
+	CreatedBy        string           `json:"createdBy,omitempty"`
+	CreatedTime      string           `json:"createdTime,omitempty"`
+	LastModifiedTime string           `json:"lastModifiedTime,omitempty"`
+}
+
+// Done reports whether the activation reached a final status
+func (activation *Activation) Done() bool {
+	switch activation.Status {
+	case StatusComplete, StatusError, StatusCanceled:
+		return true
+	}
+
+	return false
+}
+
+// ListActivations lists the activations of an EdgeWorker, optionally only those of version
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getactivations
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}/activations{?version}
+func ListActivations(edgeWorkerID int, version string) ([]Activation, error) {
+	path := fmt.Sprintf("/edgeworkers/v1/ids/%d/activations", edgeWorkerID)
+	if version != "" {
+		path += "?version=" + url.QueryEscape(version)
+	}
+
+	req, err := client.NewRequest(Config, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Activations []Activation `json:"activations"`
+	}{}
+	if err = doJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Activations, nil
+}
+
+// GetActivation retrieves an activation
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getactivation
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}/activations/{activationId}
+func GetActivation(edgeWorkerID, activationID int) (*Activation, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/edgeworkers/v1/ids/%d/activations/%d", edgeWorkerID, activationID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activation := &Activation{}
+	if err = doJSON(req, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// ActivateVersion activates an EdgeWorker version on a network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#postactivations
+// Endpoint: POST /edgeworkers/v1/ids/{edgeWorkerId}/activations
+func ActivateVersion(edgeWorkerID int, network Network, version string) (*Activation, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/edgeworkers/v1/ids/%d/activations", edgeWorkerID),
+		map[string]string{"network": string(network), "version": version},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activation := &Activation{}
+	if err = doJSON(req, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// doJSON sends req and decodes the JSON response into out
+func doJSON(req *http.Request, out interface{}) error {
+	return client.DoJSONRequest(Config, req, out)
+}
@@ -0,0 +1,184 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// FileChangeType is used to create an "enum" of possible FileChange.Change values
+type FileChangeType string
+
+const (
+	// FileAdded FileChange.Change value added
+	FileAdded FileChangeType = "added"
+	// FileRemoved FileChange.Change value removed
+	FileRemoved FileChangeType = "removed"
+	// FileChanged FileChange.Change value changed
+	FileChanged FileChangeType = "changed"
+)
+
+// BundleFile is a file of a code bundle
+type BundleFile struct {
+	Name string
+	Size int64
+	// SHA256 is the hex encoded SHA-256 of the content
+	SHA256 string
+}
+
+// FileChange is a file added, removed or changed between two code bundles,
+// From is nil for added files and To for removed ones
+type FileChange struct {
+	Name   string
+	Change FileChangeType
+	From   *BundleFile
+	To     *BundleFile
+}
+
+// BundleDiff is the file-level difference between the code bundles of two
+// versions of an EdgeWorker
+type BundleDiff struct {
+	EdgeWorkerID int
+	From         string
+	To           string
+	// Changes are sorted by file name
+	Changes []FileChange
+}
+
+// ReadBundle lists the regular files of a .tgz code bundle by name, without
+// a leading ./
+func ReadBundle(bundle io.Reader) (map[string]BundleFile, error) {
+	gz, err := gzip.NewReader(bundle)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	files := map[string]BundleFile{}
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+
+		hash := sha256.New()
+		size, err := io.Copy(hash, archive)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", header.Name, err)
+		}
+		name := strings.TrimPrefix(path.Clean(header.Name), "./")
+		files[name] = BundleFile{Name: name, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}
+	}
+}
+
+// DiffBundles returns the files added, removed and changed from one code
+// bundle to another, sorted by name
+func DiffBundles(from, to map[string]BundleFile) []FileChange {
+	var changes []FileChange
+	for name, fromFile := range from {
+		fromFile := fromFile
+		toFile, ok := to[name]
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{Name: name, Change: FileRemoved, From: &fromFile})
+		case toFile.SHA256 != fromFile.SHA256:
+			changes = append(changes, FileChange{Name: name, Change: FileChanged, From: &fromFile, To: &toFile})
+		}
+	}
+	for name, toFile := range to {
+		toFile := toFile
+		if _, ok := from[name]; !ok {
+			changes = append(changes, FileChange{Name: name, Change: FileAdded, To: &toFile})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+
+	return changes
+}
+
+// DiffVersions downloads the code bundles of two versions of an EdgeWorker and
+// returns their file-level difference
+//
+// Bundles with the same checksum are not downloaded, there is no change.
+func DiffVersions(edgeWorkerID int, from, to string) (*BundleDiff, error) {
+	diff := &BundleDiff{EdgeWorkerID: edgeWorkerID, From: from, To: to}
+
+	fromVersion, err := GetVersion(edgeWorkerID, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := GetVersion(edgeWorkerID, to)
+	if err != nil {
+		return nil, err
+	}
+	if fromVersion.Checksum != "" && fromVersion.Checksum == toVersion.Checksum {
+		return diff, nil
+	}
+
+	fromFiles, err := downloadBundleFiles(edgeWorkerID, from)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := downloadBundleFiles(edgeWorkerID, to)
+	if err != nil {
+		return nil, err
+	}
+	diff.Changes = DiffBundles(fromFiles, toFiles)
+
+	return diff, nil
+}
+
+// String describes the diff, one changed file per line
+func (diff *BundleDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "EdgeWorker %d version %s -> %s: %d files changed\n", diff.EdgeWorkerID, diff.From, diff.To, len(diff.Changes))
+	for _, change := range diff.Changes {
+		switch change.Change {
+		case FileAdded:
+			fmt.Fprintf(&b, "+ %s %s (%d bytes)\n", change.Name, shortHash(change.To.SHA256), change.To.Size)
+		case FileRemoved:
+			fmt.Fprintf(&b, "- %s %s (%d bytes)\n", change.Name, shortHash(change.From.SHA256), change.From.Size)
+		default:
+			fmt.Fprintf(&b, "~ %s %s -> %s (%d -> %d bytes)\n", change.Name, shortHash(change.From.SHA256), shortHash(change.To.SHA256), change.From.Size, change.To.Size)
+		}
+	}
+
+	return b.String()
+}
+
+func downloadBundleFiles(edgeWorkerID int, version string) (map[string]BundleFile, error) {
+	var bundle bytes.Buffer
+	if _, err := DownloadBundle(edgeWorkerID, version, &bundle); err != nil {
+		return nil, err
+	}
+
+	files, err := ReadBundle(&bundle)
+	if err != nil {
+		return nil, fmt.Errorf("version %s bundle: %w", version, err)
+	}
+
+	return files, nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+
+	return hash
+}
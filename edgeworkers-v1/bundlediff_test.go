@@ -0,0 +1,97 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func testBundle(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	require.NoError(t, archive.WriteHeader(&tar.Header{Name: "./lib/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for name, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func TestDiffVersions(t *testing.T) {
+	defer gock.Off()
+
+	from := testBundle(t, map[string]string{
+		"./main.js":     "export function onClientRequest(request) {}",
+		"./bundle.json": `{"edgeworker-version": "1.1"}`,
+		"./lib/old.js":  "old",
+	})
+	to := testBundle(t, map[string]string{
+		"main.js":      "export function onClientRequest(request) {}",
+		"bundle.json":  `{"edgeworker-version": "1.2"}`,
+		"lib/utils.js": "export const x = 1",
+	})
+
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.1").
+		Reply(200).
+		JSON(`{"edgeWorkerId": 42, "version": "1.1", "checksum": "aaa"}`)
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.2").
+		Reply(200).
+		JSON(`{"edgeWorkerId": 42, "version": "1.2", "checksum": "bbb"}`)
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.1/content").
+		Reply(200).
+		Body(bytes.NewReader(from))
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.2/content").
+		Reply(200).
+		Body(bytes.NewReader(to))
+
+	Init(config)
+
+	diff, err := DiffVersions(42, "1.1", "1.2")
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	require.Len(t, diff.Changes, 3)
+	assert.Equal(t, "bundle.json", diff.Changes[0].Name)
+	assert.Equal(t, FileChanged, diff.Changes[0].Change)
+	assert.Equal(t, "lib/old.js", diff.Changes[1].Name)
+	assert.Equal(t, FileRemoved, diff.Changes[1].Change)
+	assert.Nil(t, diff.Changes[1].To)
+	assert.Equal(t, "lib/utils.js", diff.Changes[2].Name)
+	assert.Equal(t, FileAdded, diff.Changes[2].Change)
+	assert.Equal(t, int64(18), diff.Changes[2].To.Size)
+	assert.Contains(t, diff.String(), "EdgeWorker 42 version 1.1 -> 1.2: 3 files changed\n~ bundle.json ")
+}
+
+func TestDiffVersions_SameChecksum(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.1").
+		Reply(200).
+		JSON(`{"edgeWorkerId": 42, "version": "1.1", "checksum": "aaa"}`)
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.1-hotfix").
+		Reply(200).
+		JSON(`{"edgeWorkerId": 42, "version": "1.1-hotfix", "checksum": "aaa"}`)
+
+	Init(config)
+
+	diff, err := DiffVersions(42, "1.1", "1.1-hotfix")
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+	assert.True(t, gock.IsDone())
+}
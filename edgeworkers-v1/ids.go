@@ -0,0 +1,114 @@
+package edgeworkers
+
+import (
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// EdgeWorkerID is an EdgeWorker, the versions of its code bundle are uploaded with UploadBundle
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#edgeworkerid
+type EdgeWorkerID struct {
+	EdgeWorkerID     int    `json:"edgeWorkerId,omitempty"`
+	Name             string `json:"name"`
+	AccountID        string `json:"accountId,omitempty"`
+	GroupID          int    `json:"groupId"`
+	ResourceTierID   int    `json:"resourceTierId,omitempty"`
+	CreatedBy        string `json:"createdBy,omitempty"`
+	CreatedTime      string `json:"createdTime,omitempty"`
+	LastModifiedBy   string `json:"lastModifiedBy,omitempty"`
+	LastModifiedTime string `json:"lastModifiedTime,omitempty"`
+}
+
+// ListEdgeWorkerIDs lists the EdgeWorkers, of groupID and resourceTierID only when not 0
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getids
+// Endpoint: GET /edgeworkers/v1/ids{?groupId,resourceTierId}
+func ListEdgeWorkerIDs(groupID, resourceTierID int) ([]EdgeWorkerID, error) {
+	path, err := client.PathWithQuery("/edgeworkers/v1/ids", struct {
+		GroupID        int `query:"groupId,omitempty"`
+		ResourceTierID int `query:"resourceTierId,omitempty"`
+	}{groupID, resourceTierID})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(Config, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		EdgeWorkerIDs []EdgeWorkerID `json:"edgeWorkerIds"`
+	}{}
+	if err = doJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	return response.EdgeWorkerIDs, nil
+}
+
+// GetEdgeWorkerID retrieves an EdgeWorker
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getid
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}
+func GetEdgeWorkerID(edgeWorkerID int) (*EdgeWorkerID, error) {
+	req, err := client.NewRequest(Config, "GET", fmt.Sprintf("/edgeworkers/v1/ids/%d", edgeWorkerID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &EdgeWorkerID{}
+	if err = doJSON(req, id); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// CreateEdgeWorkerID creates an EdgeWorker in groupID on resourceTierID, see ListResourceTiers
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#postids
+// Endpoint: POST /edgeworkers/v1/ids
+func CreateEdgeWorkerID(groupID int, name string, resourceTierID int) (*EdgeWorkerID, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		"/edgeworkers/v1/ids",
+		&EdgeWorkerID{GroupID: groupID, Name: name, ResourceTierID: resourceTierID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &EdgeWorkerID{}
+	if err = doJSON(req, id); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// UpdateEdgeWorkerID renames an EdgeWorker or moves it to another group
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#putid
+// Endpoint: PUT /edgeworkers/v1/ids/{edgeWorkerId}
+func UpdateEdgeWorkerID(id *EdgeWorkerID) (*EdgeWorkerID, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/edgeworkers/v1/ids/%d", id.EdgeWorkerID),
+		&EdgeWorkerID{GroupID: id.GroupID, Name: id.Name, ResourceTierID: id.ResourceTierID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &EdgeWorkerID{}
+	if err = doJSON(req, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
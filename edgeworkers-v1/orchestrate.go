@@ -0,0 +1,204 @@
+package edgeworkers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNewerVersionActive is returned by ActivateOnBothNetworks when a newer version is already active
+	ErrNewerVersionActive = errors.New("a newer EdgeWorker version is already active")
+	// ErrActivationTimeout is returned by ActivateOnBothNetworks when an activation does not finish in time
+	ErrActivationTimeout = errors.New("timed out waiting for EdgeWorker activation")
+)
+
+// BothNetworksOptions configures ActivateOnBothNetworks
+type BothNetworksOptions struct {
+	// PollInterval defaults to 30 seconds
+	PollInterval time.Duration
+	// Timeout per network, zero waits forever
+	Timeout time.Duration
+	// VerifyStaging is called once staging is COMPLETE; an error stops before production
+	VerifyStaging func(activation *Activation) error
+	// AllowOlderVersion activates even if a newer version is active on a network
+	AllowOlderVersion bool
+}
+
+// NetworkResult is the outcome of ActivateOnBothNetworks on one network
+type NetworkResult struct {
+	Network Network
+	// Activation is the activation that was created or, if Skipped, the one already active
+	Activation *Activation
+	// Skipped is true when the version was already active on the network
+	Skipped bool
+	Err     error
+}
+
+// BothNetworksStatus is the combined status of ActivateOnBothNetworks
+type BothNetworksStatus struct {
+	EdgeWorkerID int
+	Version      string
+	Staging      NetworkResult
+	Production   NetworkResult
+}
+
+// Complete reports whether the version is active on both networks
+func (status *BothNetworksStatus) Complete() bool {
+	return status.Staging.Err == nil && status.Production.Err == nil &&
+		status.Staging.Activation != nil && status.Staging.Activation.Status == StatusComplete &&
+		status.Production.Activation != nil && status.Production.Activation.Status == StatusComplete
+}
+
+// ActivateOnBothNetworks activates version on staging, waits for it to complete and
+// be verified, then activates it on production
+//
+// A network where version is already active is skipped. Unless
+// options.AllowOlderVersion is set, it refuses to activate if a newer version is
+// active on either network, returning ErrNewerVersionActive before any change.
+// The returned status is filled in as far as the sequence got, also on error.
+func ActivateOnBothNetworks(edgeWorkerID int, version string, options BothNetworksOptions) (*BothNetworksStatus, error) {
+	status := &BothNetworksStatus{
+		EdgeWorkerID: edgeWorkerID,
+		Version:      version,
+		Staging:      NetworkResult{Network: NetworkStaging},
+		Production:   NetworkResult{Network: NetworkProduction},
+	}
+
+	activations, err := ListActivations(edgeWorkerID, "")
+	if err != nil {
+		return status, err
+	}
+
+	for _, result := range []*NetworkResult{&status.Staging, &status.Production} {
+		active := latestCompleteActivation(activations, result.Network)
+		if active == nil {
+			continue
+		}
+		if active.Version == version {
+			result.Activation = active
+			result.Skipped = true
+			continue
+		}
+		if !options.AllowOlderVersion && CompareVersions(active.Version, version) > 0 {
+			result.Err = fmt.Errorf("%w: %s on %s", ErrNewerVersionActive, active.Version, result.Network)
+			return status, result.Err
+		}
+	}
+
+	if !status.Staging.Skipped {
+		if err := activateAndWait(edgeWorkerID, version, &status.Staging, options); err != nil {
+			return status, err
+		}
+	}
+
+	if options.VerifyStaging != nil {
+		if err := options.VerifyStaging(status.Staging.Activation); err != nil {
+			status.Staging.Err = err
+			return status, err
+		}
+	}
+
+	if !status.Production.Skipped {
+		if err := activateAndWait(edgeWorkerID, version, &status.Production, options); err != nil {
+			return status, err
+		}
+	}
+
+	return status, nil
+}
+
+func activateAndWait(edgeWorkerID int, version string, result *NetworkResult, options BothNetworksOptions) error {
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	deadline := time.Now().Add(options.Timeout)
+
+	activation, err := ActivateVersion(edgeWorkerID, result.Network, version)
+	if err != nil {
+		result.Err = err
+		return err
+	}
+	result.Activation = activation
+
+	for !activation.Done() {
+		if options.Timeout > 0 && time.Now().After(deadline) {
+			result.Err = ErrActivationTimeout
+			return result.Err
+		}
+		time.Sleep(interval)
+
+		if activation, err = GetActivation(edgeWorkerID, activation.ActivationID); err != nil {
+			result.Err = err
+			return err
+		}
+		result.Activation = activation
+	}
+
+	if activation.Status != StatusComplete {
+		result.Err = fmt.Errorf("activation %d on %s finished with status %s", activation.ActivationID, result.Network, activation.Status)
+		return result.Err
+	}
+
+	return nil
+}
+
+// latestCompleteActivation returns the most recent COMPLETE activation on network
+func latestCompleteActivation(activations []Activation, network Network) *Activation {
+	var latest *Activation
+	for i, activation := range activations {
+		if activation.Network != network || activation.Status != StatusComplete {
+			continue
+		}
+		if latest == nil || activation.ActivationID > latest.ActivationID {
+			latest = &activations[i]
+		}
+	}
+
+	return latest
+}
+
+// CompareVersions compares dotted EdgeWorker versions numerically, e.g. "1.10" > "1.9",
+// returning -1, 0 or 1. Non numeric parts are compared as strings.
+func CompareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart string
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		if aPart == "" {
+			aNum, aErr = 0, nil
+		}
+		if bPart == "" {
+			bNum, bErr = 0, nil
+		}
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aPart != bPart:
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
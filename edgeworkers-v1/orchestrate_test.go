@@ -0,0 +1,85 @@
+package edgeworkers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestActivateOnBothNetworks(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/activations").
+		Reply(200).
+		JSON(`{"activations": [
+			{"edgeWorkerId": 42, "activationId": 1, "version": "1.2", "network": "PRODUCTION", "status": "COMPLETE"},
+			{"edgeWorkerId": 42, "activationId": 2, "version": "1.3", "network": "STAGING", "status": "COMPLETE"}
+		]}`)
+	gock.New(baseURL).
+		Post("/edgeworkers/v1/ids/42/activations").
+		JSON(map[string]string{"network": "PRODUCTION", "version": "1.3"}).
+		Reply(201).
+		JSON(`{"edgeWorkerId": 42, "activationId": 3, "version": "1.3", "network": "PRODUCTION", "status": "PENDING"}`)
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/activations/3").
+		Reply(200).
+		JSON(`{"edgeWorkerId": 42, "activationId": 3, "version": "1.3", "network": "PRODUCTION", "status": "COMPLETE"}`)
+
+	Init(config)
+
+	verified := false
+	status, err := ActivateOnBothNetworks(42, "1.3", BothNetworksOptions{
+		PollInterval: time.Millisecond,
+		VerifyStaging: func(activation *Activation) error {
+			verified = activation.ActivationID == 2
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.True(t, status.Staging.Skipped)
+	assert.False(t, status.Production.Skipped)
+	assert.Equal(t, 3, status.Production.Activation.ActivationID)
+	assert.True(t, status.Complete())
+	assert.True(t, gock.IsDone())
+}
+
+func TestActivateOnBothNetworks_NewerVersionActive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/activations").
+		Reply(200).
+		JSON(`{"activations": [{"edgeWorkerId": 42, "activationId": 1, "version": "1.10", "network": "PRODUCTION", "status": "COMPLETE"}]}`)
+
+	Init(config)
+
+	status, err := ActivateOnBothNetworks(42, "1.9", BothNetworksOptions{})
+	assert.True(t, errors.Is(err, ErrNewerVersionActive))
+	assert.Nil(t, status.Staging.Activation)
+	assert.Error(t, status.Production.Err)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 1, CompareVersions("1.10", "1.9"))
+	assert.Equal(t, 0, CompareVersions("1.0", "1"))
+	assert.Equal(t, -1, CompareVersions("1.0-beta", "1.0-rc"))
+}
@@ -0,0 +1,62 @@
+package edgeworkers
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// ResourceTier is a set of execution limits an EdgeWorker runs with
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#resourcetier
+type ResourceTier struct {
+	ResourceTierID   int               `json:"resourceTierId"`
+	ResourceTierName string            `json:"resourceTierName"`
+	EdgeWorkerLimits []EdgeWorkerLimit `json:"edgeWorkerLimits"`
+}
+
+// EdgeWorkerLimit is a limit of a ResourceTier, e.g. the maximum CPU time of an event handler
+type EdgeWorkerLimit struct {
+	LimitName  string `json:"limitName"`
+	LimitValue int64  `json:"limitValue"`
+	LimitUnit  string `json:"limitUnit"`
+}
+
+// ListResourceTiers lists the resource tiers available to a contract
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getresourcetiers
+// Endpoint: GET /edgeworkers/v1/resource-tiers{?contractId}
+func ListResourceTiers(contractID string) ([]ResourceTier, error) {
+	req, err := client.NewRequest(Config, "GET", "/edgeworkers/v1/resource-tiers?contractId="+url.QueryEscape(contractID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		ResourceTiers []ResourceTier `json:"resourceTiers"`
+	}{}
+	if err = doJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	return response.ResourceTiers, nil
+}
+
+// GetEdgeWorkerResourceTier retrieves the resource tier of an EdgeWorker
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getidresourcetier
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}/resource-tier
+func GetEdgeWorkerResourceTier(edgeWorkerID int) (*ResourceTier, error) {
+	req, err := client.NewRequest(Config, "GET", fmt.Sprintf("/edgeworkers/v1/ids/%d/resource-tier", edgeWorkerID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := &ResourceTier{}
+	if err = doJSON(req, tier); err != nil {
+		return nil, err
+	}
+
+	return tier, nil
+}
@@ -0,0 +1,141 @@
+package edgeworkers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// BundleContentType is the content type of EdgeWorker code bundles, gzipped
+// tar archives with a main.js and a bundle.json manifest
+const BundleContentType = "application/gzip"
+
+// Version is an uploaded code bundle version of an EdgeWorker
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#version
+type Version struct {
+	EdgeWorkerID   int    `json:"edgeWorkerId"`
+	Version        string `json:"version"`
+	AccountID      string `json:"accountId,omitempty"`
+	Checksum       string `json:"checksum,omitempty"`
+	SequenceNumber int    `json:"sequenceNumber,omitempty"`
+	CreatedBy      string `json:"createdBy,omitempty"`
+	CreatedTime    string `json:"createdTime,omitempty"`
+}
+
+// ListVersions lists the versions of an EdgeWorker
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getversions
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}/versions
+func ListVersions(edgeWorkerID int) ([]Version, error) {
+	req, err := client.NewRequest(Config, "GET", fmt.Sprintf("/edgeworkers/v1/ids/%d/versions", edgeWorkerID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Versions []Version `json:"versions"`
+	}{}
+	if err = doJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Versions, nil
+}
+
+// GetVersion retrieves a version of an EdgeWorker
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getversion
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}/versions/{version}
+func GetVersion(edgeWorkerID int, version string) (*Version, error) {
+	req, err := client.NewRequest(Config, "GET", versionPath(edgeWorkerID, version), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Version{}
+	if err = doJSON(req, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// UploadBundle uploads a .tgz code bundle as a new version of an EdgeWorker,
+// the version is the one of the bundle.json manifest
+//
+// The bundle is read into memory so that the request can be signed and retried.
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#postversions
+// Endpoint: POST /edgeworkers/v1/ids/{edgeWorkerId}/versions
+func UploadBundle(edgeWorkerID int, bundle io.Reader) (*Version, error) {
+	body, err := ioutil.ReadAll(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(Config, "POST", fmt.Sprintf("/edgeworkers/v1/ids/%d/versions", edgeWorkerID), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", BundleContentType)
+
+	// The bundle is binary, only the headers are logged
+	edge.PrintHttpRequest(req, false)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	v := &Version{}
+	if err = client.BodyJSON(res, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DownloadBundle writes the .tgz code bundle of a version of an EdgeWorker to w
+//
+// API Docs: https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#getversioncontent
+// Endpoint: GET /edgeworkers/v1/ids/{edgeWorkerId}/versions/{version}/content
+func DownloadBundle(edgeWorkerID int, version string, w io.Writer) (int64, error) {
+	req, err := client.NewRequest(Config, "GET", versionPath(edgeWorkerID, version)+"/content", nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", BundleContentType)
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// The bundle is binary, only the headers are logged
+	edge.PrintHttpResponse(res, false)
+
+	if client.IsError(res) {
+		return 0, client.NewAPIError(res)
+	}
+
+	return io.Copy(w, res.Body)
+}
+
+func versionPath(edgeWorkerID int, version string) string {
+	return fmt.Sprintf("/edgeworkers/v1/ids/%d/versions/%s", edgeWorkerID, url.PathEscape(version))
+}
@@ -0,0 +1,82 @@
+package edgeworkers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestUploadBundle(t *testing.T) {
+	defer gock.Off()
+
+	bundle := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff, 0x00}
+	gock.New(baseURL).
+		Post("/edgeworkers/v1/ids/42/versions").
+		MatchType("application/gzip").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			return bytes.Equal(body, bundle), err
+		}).
+		Reply(201).
+		JSON(`{"edgeWorkerId": 42, "version": "1.2", "checksum": "5f1e", "sequenceNumber": 3}`)
+
+	Init(config)
+
+	version, err := UploadBundle(42, bytes.NewReader(bundle))
+	require.NoError(t, err)
+	assert.Equal(t, "1.2", version.Version)
+	assert.Equal(t, 3, version.SequenceNumber)
+	assert.True(t, gock.IsDone())
+}
+
+func TestDownloadBundle(t *testing.T) {
+	defer gock.Off()
+
+	bundle := []byte{0x1f, 0x8b, 0x08, 0x00, 0x00, 0xfe}
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/ids/42/versions/1.2/content").
+		MatchHeader("Accept", "application/gzip").
+		Reply(200).
+		SetHeader("Content-Type", "application/gzip").
+		Body(bytes.NewReader(bundle))
+
+	Init(config)
+
+	var w bytes.Buffer
+	n, err := DownloadBundle(42, "1.2", &w)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(bundle)), n)
+	assert.Equal(t, bundle, w.Bytes())
+}
+
+func TestCreateEdgeWorkerID(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/edgeworkers/v1/resource-tiers").
+		MatchParam("contractId", "1-ABC").
+		Reply(200).
+		JSON(`{"resourceTiers": [{"resourceTierId": 200, "resourceTierName": "Dynamic Compute", "edgeWorkerLimits": [{"limitName": "Maximum CPU time during initialization", "limitValue": 30, "limitUnit": "MILLISECOND"}]}]}`)
+	gock.New(baseURL).
+		Post("/edgeworkers/v1/ids").
+		JSON(map[string]interface{}{"name": "geo-redirect", "groupId": 1234, "resourceTierId": 200}).
+		Reply(201).
+		JSON(`{"edgeWorkerId": 42, "name": "geo-redirect", "groupId": 1234, "resourceTierId": 200}`)
+
+	Init(config)
+
+	tiers, err := ListResourceTiers("1-ABC")
+	require.NoError(t, err)
+	require.Len(t, tiers, 1)
+	assert.Equal(t, int64(30), tiers[0].EdgeWorkerLimits[0].LimitValue)
+
+	id, err := CreateEdgeWorkerID(1234, "geo-redirect", tiers[0].ResourceTierID)
+	require.NoError(t, err)
+	assert.Equal(t, 42, id.EdgeWorkerID)
+	assert.True(t, gock.IsDone())
+}
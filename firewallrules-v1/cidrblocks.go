@@ -0,0 +1,121 @@
+package firewallrules
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// LastAction is used to create an "enum" of possible CIDRBlock.LastAction values
+type LastAction string
+
+const (
+	// LastActionAdd CIDRBlock.LastAction value add
+	LastActionAdd LastAction = "add"
+	// LastActionUpdate CIDRBlock.LastAction value update
+	LastActionUpdate LastAction = "update"
+	// LastActionDelete CIDRBlock.LastAction value delete, the block is being
+	// withdrawn from its service as of its EffectiveDate
+	LastActionDelete LastAction = "delete"
+)
+
+// CIDRBlock is a CIDR block (and port) of a service
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#cidrblock
+type CIDRBlock struct {
+	CIDRID      int    `json:"cidrId"`
+	ServiceID   int    `json:"serviceId"`
+	ServiceName string `json:"serviceName"`
+	CIDR        string `json:"cidr"`
+	// CIDRMask is the prefix length with a leading slash, e.g. "/24"
+	CIDRMask      string     `json:"cidrMask"`
+	Port          string     `json:"port"`
+	CreationDate  string     `json:"creationDate"`
+	EffectiveDate string     `json:"effectiveDate"`
+	ChangeDate    string     `json:"changeDate,omitempty"`
+	MinIP         string     `json:"minIp"`
+	MaxIP         string     `json:"maxIp"`
+	LastAction    LastAction `json:"lastAction"`
+}
+
+// InEffect reports whether the LastAction of the block is in effect at now,
+// i.e. its EffectiveDate (YYYY-MM-DD, UTC) is not after now
+func (block *CIDRBlock) InEffect(now time.Time) bool {
+	if len(block.EffectiveDate) < len("2006-01-02") {
+		return true
+	}
+	effective, err := time.Parse("2006-01-02", block.EffectiveDate[:len("2006-01-02")])
+	if err != nil {
+		return true
+	}
+
+	return !effective.After(now.UTC())
+}
+
+// Prefix returns the block in CIDR notation, e.g. 192.0.2.0/24
+func (block *CIDRBlock) Prefix() string {
+	if block.CIDRMask == "" {
+		return block.CIDR
+	}
+
+	return block.CIDR + "/" + strings.TrimPrefix(block.CIDRMask, "/")
+}
+
+// ListCIDRBlocksOptions filters ListCIDRBlocks, the dates are YYYY-MM-DD
+type ListCIDRBlocksOptions struct {
+	LastAction LastAction `query:"lastAction,omitempty"`
+	// EffectiveDateGt lists the blocks changed after a date, e.g. the last sync
+	EffectiveDateGt string `query:"effectiveDateGt,omitempty"`
+	EffectiveDateLt string `query:"effectiveDateLt,omitempty"`
+}
+
+// ListCIDRBlocks lists the CIDR blocks of the services the account is subscribed to
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#getcidrblocks
+// Endpoint: GET /firewall-rules-manager/v1/cidr-blocks{?lastAction,effectiveDateGt,effectiveDateLt}
+func ListCIDRBlocks(options ListCIDRBlocksOptions) ([]CIDRBlock, error) {
+	path, err := client.PathWithQuery("/firewall-rules-manager/v1/cidr-blocks", options)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []CIDRBlock
+	if err := doJSON("GET", path, nil, &blocks); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// AllowedPrefixes returns the sorted, unique prefixes of blocks to allow in
+// firewall rules at now, those of the services serviceIDs (all when none)
+// that are not deleted: a block deleted as of a later date is still allowed,
+// a block added or updated as of a later date is not yet
+func AllowedPrefixes(blocks []CIDRBlock, now time.Time, serviceIDs ...int) []string {
+	services := map[int]bool{}
+	for _, id := range serviceIDs {
+		services[id] = true
+	}
+
+	found := map[string]bool{}
+	for i := range blocks {
+		deleted := blocks[i].LastAction == LastActionDelete
+		if deleted == blocks[i].InEffect(now) {
+			continue
+		}
+		if len(services) > 0 && !services[blocks[i].ServiceID] {
+			continue
+		}
+		found[blocks[i].Prefix()] = true
+	}
+
+	prefixes := make([]string, 0, len(found))
+	for prefix := range found {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	return prefixes
+}
@@ -0,0 +1,81 @@
+package firewallrules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestListCIDRBlocks(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/firewall-rules-manager/v1/cidr-blocks").
+		MatchParam("effectiveDateGt", "2020-06-01").
+		Reply(200).
+		JSON(`[
+			{"cidrId": 1, "serviceId": 3, "serviceName": "SITE_SHIELD", "cidr": "192.0.2.0", "cidrMask": "/24", "port": "80,443", "effectiveDate": "2020-06-02", "lastAction": "add"},
+			{"cidrId": 2, "serviceId": 3, "serviceName": "SITE_SHIELD", "cidr": "198.51.100.0", "cidrMask": "/24", "port": "80,443", "effectiveDate": "2020-06-02", "lastAction": "delete"},
+			{"cidrId": 3, "serviceId": 7, "serviceName": "NETSTORAGE", "cidr": "203.0.113.0", "cidrMask": "/25", "port": "21", "lastAction": "update"},
+			{"cidrId": 4, "serviceId": 3, "serviceName": "SITE_SHIELD", "cidr": "192.0.2.0", "cidrMask": "/24", "port": "8080", "lastAction": "add"},
+			{"cidrId": 5, "serviceId": 3, "serviceName": "SITE_SHIELD", "cidr": "192.0.2.128", "cidrMask": "/25", "port": "80,443", "effectiveDate": "2020-07-01", "lastAction": "add"},
+			{"cidrId": 6, "serviceId": 7, "serviceName": "NETSTORAGE", "cidr": "198.51.100.128", "cidrMask": "/25", "port": "21", "effectiveDate": "2020-07-01", "lastAction": "delete"}
+		]`)
+
+	Init(config)
+
+	blocks, err := ListCIDRBlocks(ListCIDRBlocksOptions{EffectiveDateGt: "2020-06-01"})
+	require.NoError(t, err)
+	require.Len(t, blocks, 6)
+	assert.Equal(t, "192.0.2.0/24", blocks[0].Prefix())
+
+	now := time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"192.0.2.0/24", "198.51.100.128/25", "203.0.113.0/25"}, AllowedPrefixes(blocks, now))
+	assert.Equal(t, []string{"192.0.2.0/24"}, AllowedPrefixes(blocks, now, 3))
+
+	later := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"192.0.2.0/24", "192.0.2.128/25", "203.0.113.0/25"}, AllowedPrefixes(blocks, later))
+	assert.True(t, gock.IsDone())
+}
+
+func TestUpdateSubscriptions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/firewall-rules-manager/v1/services").
+		Reply(200).
+		JSON(`[{"serviceId": 3, "serviceName": "SITE_SHIELD", "description": "Site Shield"}]`)
+	gock.New(baseURL).
+		Put("/firewall-rules-manager/v1/subscriptions").
+		JSON(map[string]interface{}{"subscriptions": []map[string]interface{}{{"serviceId": 3, "email": "jsmith@example.com"}}}).
+		Reply(200).
+		JSON(`{"subscriptions": [{"serviceId": 3, "serviceName": "SITE_SHIELD", "email": "jsmith@example.com", "signupDate": "2020-06-01"}]}`)
+
+	Init(config)
+
+	services, err := ListServices()
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+
+	subscriptions, err := UpdateSubscriptions([]Subscription{{ServiceID: services[0].ServiceID, Email: "jsmith@example.com"}})
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, "2020-06-01", subscriptions[0].SignupDate)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,76 @@
+package firewallrules
+
+// Service is an Akamai service whose edge or origin IP ranges firewall rules
+// need, e.g. Site Shield or NetStorage
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#service
+type Service struct {
+	ServiceID   int    `json:"serviceId"`
+	ServiceName string `json:"serviceName"`
+	Description string `json:"description,omitempty"`
+}
+
+// Subscription is an email address notified of the CIDR block changes of a service
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#subscription
+type Subscription struct {
+	ServiceID   int    `json:"serviceId"`
+	ServiceName string `json:"serviceName,omitempty"`
+	Email       string `json:"email"`
+	SignupDate  string `json:"signupDate,omitempty"`
+}
+
+// ListServices lists the services with firewall rules notifications
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#getservices
+// Endpoint: GET /firewall-rules-manager/v1/services
+func ListServices() ([]Service, error) {
+	var services []Service
+	if err := doJSON("GET", "/firewall-rules-manager/v1/services", nil, &services); err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+// ListSubscriptions lists the subscriptions of the account
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#getsubscriptions
+// Endpoint: GET /firewall-rules-manager/v1/subscriptions
+func ListSubscriptions() ([]Subscription, error) {
+	response := struct {
+		Subscriptions []Subscription `json:"subscriptions"`
+	}{}
+	if err := doJSON("GET", "/firewall-rules-manager/v1/subscriptions", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Subscriptions, nil
+}
+
+// UpdateSubscriptions replaces the subscriptions of the account with
+// subscriptions, those not listed are removed, and returns the new ones
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/firewall_rules_notification/v1.html#putsubscriptions
+// Endpoint: PUT /firewall-rules-manager/v1/subscriptions
+func UpdateSubscriptions(subscriptions []Subscription) ([]Subscription, error) {
+	type subscription struct {
+		ServiceID int    `json:"serviceId"`
+		Email     string `json:"email"`
+	}
+	body := struct {
+		Subscriptions []subscription `json:"subscriptions"`
+	}{Subscriptions: make([]subscription, 0, len(subscriptions))}
+	for _, s := range subscriptions {
+		body.Subscriptions = append(body.Subscriptions, subscription{s.ServiceID, s.Email})
+	}
+
+	response := struct {
+		Subscriptions []Subscription `json:"subscriptions"`
+	}{}
+	if err := doJSON("PUT", "/firewall-rules-manager/v1/subscriptions", body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Subscriptions, nil
+}
@@ -0,0 +1,169 @@
+package hapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// Certificate is the certificate deployed to an edge hostname's slot
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#certificate
+type Certificate struct {
+	CertificateID    string    `json:"certificateId"`
+	CommonName       string    `json:"commonName"`
+	SerialNumber     string    `json:"serialNumber"`
+	SlotNumber       int       `json:"slotNumber"`
+	ExpirationDate   time.Time `json:"expirationDate"`
+	CertificateType  string    `json:"certificateType"`
+	ValidationType   string    `json:"validationType"`
+	Status           string    `json:"status"`
+	AvailableDomains []string  `json:"availableDomains"`
+}
+
+// GetCertificate retrieves the certificate bound to an edge hostname
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getcertificate
+// Endpoint: GET /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}/certificate
+func GetCertificate(recordName, dnsZone string) (*Certificate, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s/certificate", dnsZone, recordName),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	certificate := &Certificate{}
+	if err = client.BodyJSON(res, certificate); err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+// Covers reports whether hostname matches the certificate's common name or
+// one of its available domains, including single level wildcards
+func (certificate *Certificate) Covers(hostname string) bool {
+	if matchesDomain(certificate.CommonName, hostname) {
+		return true
+	}
+	for _, domain := range certificate.AvailableDomains {
+		if matchesDomain(domain, hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesDomain(pattern, hostname string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	if pattern == hostname {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		i := strings.Index(hostname, ".")
+		return i > 0 && hostname[i+1:] == pattern[2:]
+	}
+
+	return false
+}
+
+// TLSServingState joins HAPI and CPS data describing how TLS is served for an edge hostname
+type TLSServingState struct {
+	EdgeHostname *EdgeHostname
+	// Certificate is nil for non-secure (non enhanced TLS) edge hostnames
+	Certificate *Certificate
+	// Enrollment is the CPS enrollment whose CSR matches the certificate, nil if none was found
+	Enrollment *cps.Enrollment
+}
+
+// DaysUntilExpiry returns the number of whole days until the certificate expires, or -1 without a certificate
+func (state *TLSServingState) DaysUntilExpiry(now time.Time) int {
+	if state.Certificate == nil {
+		return -1
+	}
+
+	return int(state.Certificate.ExpirationDate.Sub(now).Hours() / 24)
+}
+
+// GetTLSServingState retrieves the edge hostname, its bound certificate and, if
+// contractID is not empty, the CPS enrollment the certificate was issued from
+//
+// CPS must be initialized (cps.Init) with credentials that can list the contract's enrollments.
+func GetTLSServingState(recordName, dnsZone, contractID string) (*TLSServingState, error) {
+	edgeHostname, err := GetEdgeHostname(recordName, dnsZone)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &TLSServingState{EdgeHostname: edgeHostname}
+	if edgeHostname.SecurityType == "" || strings.EqualFold(edgeHostname.SecurityType, "STANDARD-TLS") {
+		return state, nil
+	}
+
+	state.Certificate, err = GetCertificate(recordName, dnsZone)
+	if err != nil {
+		return nil, err
+	}
+
+	if contractID == "" {
+		return state, nil
+	}
+
+	enrollments, err := cps.ListEnrollments(cps.ListEnrollmentsQueryParams{ContractID: contractID})
+	if err != nil {
+		return nil, err
+	}
+	state.Enrollment = findEnrollment(enrollments, state.Certificate)
+
+	return state, nil
+}
+
+func findEnrollment(enrollments []cps.Enrollment, certificate *Certificate) *cps.Enrollment {
+	for i, enrollment := range enrollments {
+		csr := enrollment.CertificateSigningRequest
+		if csr == nil {
+			continue
+		}
+		if strings.EqualFold(csr.CommonName, certificate.CommonName) {
+			return &enrollments[i]
+		}
+	}
+
+	// fall back to enrollments listing the certificate's common name as a SAN
+	for i, enrollment := range enrollments {
+		csr := enrollment.CertificateSigningRequest
+		if csr == nil || csr.AlternativeNames == nil {
+			continue
+		}
+		for _, san := range *csr.AlternativeNames {
+			if strings.EqualFold(san, certificate.CommonName) {
+				return &enrollments[i]
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package hapi
+
+import (
+	"testing"
+	"time"
+
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestGetTLSServingState(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/hapi/v1/dns-zones/edgekey.net/edge-hostnames/www.example.com/certificate").
+		Reply(200).
+		JSON(`{
+			"certificateId": "1234",
+			"commonName": "www.example.com",
+			"serialNumber": "12:34:56",
+			"slotNumber": 5678,
+			"expirationDate": "2030-01-02T15:04:05Z",
+			"certificateType": "SAN",
+			"validationType": "DV",
+			"status": "DEPLOYED",
+			"availableDomains": ["www.example.com", "*.api.example.com"]
+		}`)
+	gock.New(baseURL).
+		Get("/hapi/v1/dns-zones/edgekey.net/edge-hostnames/www.example.com").
+		Reply(200).
+		JSON(`{
+			"edgeHostnameId": 42,
+			"recordName": "www.example.com",
+			"dnsZone": "edgekey.net",
+			"securityType": "ENHANCED-TLS",
+			"useDefaultTtl": true,
+			"useDefaultMap": true,
+			"ttl": 21600,
+			"map": "e1.a.akamaiedge.net",
+			"slotNumber": 5678,
+			"ipVersionBehavior": "IPV6_IPV4_DUALSTACK"
+		}`)
+	gock.New(baseURL).
+		Get("/cps/v2/enrollments").
+		MatchParam("contractId", "ctr_1-1TJZFW").
+		Reply(200).
+		JSON(`{"enrollments": [
+			{"location": "/cps/v2/enrollments/1", "csr": {"cn": "other.example.com"}},
+			{"location": "/cps/v2/enrollments/2", "csr": {"cn": "example.com", "sans": ["example.com", "www.example.com"]}}
+		]}`)
+
+	Init(config)
+	cps.Init(config)
+
+	state, err := GetTLSServingState("www.example.com", "edgekey.net", "ctr_1-1TJZFW")
+	require.NoError(t, err)
+
+	assert.Equal(t, "www.example.com.edgekey.net", state.EdgeHostname.Hostname())
+	assert.Equal(t, "e1.a.akamaiedge.net", state.EdgeHostname.Map)
+	require.NotNil(t, state.Certificate)
+	assert.Equal(t, 5678, state.Certificate.SlotNumber)
+	assert.True(t, state.Certificate.Covers("v1.api.example.com"))
+	assert.False(t, state.Certificate.Covers("api.example.com"))
+	require.NotNil(t, state.Enrollment)
+	assert.Equal(t, "/cps/v2/enrollments/2", *state.Enrollment.Location)
+	assert.Equal(t, 2, state.DaysUntilExpiry(time.Date(2029, 12, 31, 15, 4, 5, 0, time.UTC)))
+}
+
+func TestGetTLSServingState_StandardTLS(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/www.example.com").
+		Reply(200).
+		JSON(`{"recordName": "www.example.com", "dnsZone": "edgesuite.net", "securityType": "STANDARD-TLS"}`)
+
+	Init(config)
+
+	state, err := GetTLSServingState("www.example.com", "edgesuite.net", "ctr_1-1TJZFW")
+	require.NoError(t, err)
+	assert.Nil(t, state.Certificate)
+	assert.Nil(t, state.Enrollment)
+	assert.Equal(t, -1, state.DaysUntilExpiry(time.Now()))
+}
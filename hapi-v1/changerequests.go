@@ -0,0 +1,108 @@
+package hapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ChangeStatus is used to create an "enum" of possible ChangeRequest.Status values
+type ChangeStatus string
+
+const (
+	// ChangeStatusPending ChangeRequest.Status value PENDING
+	ChangeStatusPending ChangeStatus = "PENDING"
+	// ChangeStatusSucceeded ChangeRequest.Status value SUCCEEDED
+	ChangeStatusSucceeded ChangeStatus = "SUCCEEDED"
+	// ChangeStatusFailed ChangeRequest.Status value FAILED
+	ChangeStatusFailed ChangeStatus = "FAILED"
+)
+
+// DefaultChangeRequestPollInterval is the interval WaitForChangeRequest polls at by default
+const DefaultChangeRequestPollInterval = 30 * time.Second
+
+// ChangeRequest is an asynchronous change of an edge hostname
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#changerequest
+type ChangeRequest struct {
+	ChangeID         int              `json:"changeId"`
+	Action           string           `json:"action"`
+	Status           ChangeStatus     `json:"status"`
+	StatusMessage    string           `json:"statusMessage,omitempty"`
+	StatusUpdateDate string           `json:"statusUpdateDate,omitempty"`
+	SubmitDate       string           `json:"submitDate,omitempty"`
+	Submitter        string           `json:"submitter,omitempty"`
+	TicketID         int              `json:"ticketId,omitempty"`
+	EdgeHostnames    []EdgeHostname   `json:"edgeHostnames,omitempty"`
+	PatchOperations  []PatchOperation `json:"patchOperations,omitempty"`
+}
+
+// Done reports whether the change request reached a final status
+func (change *ChangeRequest) Done() bool {
+	return change.Status != "" && change.Status != ChangeStatusPending
+}
+
+// GetChangeRequest retrieves a change request
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getchangerequest
+// Endpoint: GET /hapi/v1/change-requests/{changeId}
+func GetChangeRequest(changeID int) (*ChangeRequest, error) {
+	change := &ChangeRequest{}
+	if err := doJSON("GET", fmt.Sprintf("/hapi/v1/change-requests/%d", changeID), "", nil, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// ListChangeRequests lists the change requests of an edge hostname, of status only when not empty
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getchangerequests
+// Endpoint: GET /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}/change-requests{?status}
+func ListChangeRequests(recordName, dnsZone string, status ChangeStatus) ([]ChangeRequest, error) {
+	path := edgeHostnamePath(recordName, dnsZone) + "/change-requests"
+	if status != "" {
+		path += "?status=" + string(status)
+	}
+
+	response := struct {
+		ChangeRequests []ChangeRequest `json:"changeRequests"`
+	}{}
+	if err := doJSON("GET", path, "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.ChangeRequests, nil
+}
+
+// WaitForChangeRequest polls a change request until it is no longer PENDING,
+// and returns an error if it did not succeed
+//
+// pollInterval defaults to DefaultChangeRequestPollInterval. The last polled
+// change request is returned along with ctx.Err() when ctx is done first.
+func WaitForChangeRequest(ctx context.Context, changeID int, pollInterval time.Duration) (*ChangeRequest, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultChangeRequestPollInterval
+	}
+
+	for {
+		change, err := GetChangeRequest(changeID)
+		if err != nil {
+			return nil, err
+		}
+		if change.Done() {
+			if change.Status != ChangeStatusSucceeded {
+				return change, fmt.Errorf("change request %d %s: %s", changeID, change.Status, change.StatusMessage)
+			}
+			return change, nil
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return change, fmt.Errorf("change request %d still %s: %w", changeID, change.Status, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
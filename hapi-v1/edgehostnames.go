@@ -0,0 +1,199 @@
+package hapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// EdgeHostname represents an edge hostname as seen by HAPI
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#edgehostname
+type EdgeHostname struct {
+	EdgeHostnameID         int      `json:"edgeHostnameId,omitempty"`
+	RecordName             string   `json:"recordName"`
+	DNSZone                string   `json:"dnsZone"`
+	SecurityType           string   `json:"securityType,omitempty"`
+	UseDefaultTTL          bool     `json:"useDefaultTtl"`
+	UseDefaultMap          bool     `json:"useDefaultMap"`
+	TTL                    int      `json:"ttl,omitempty"`
+	Map                    string   `json:"map,omitempty"`
+	SlotNumber             int      `json:"slotNumber,omitempty"`
+	IPVersionBehavior      string   `json:"ipVersionBehavior,omitempty"`
+	Comments               string   `json:"comments,omitempty"`
+	SerialNumber           int      `json:"serialNumber,omitempty"`
+	CustomTarget           string   `json:"customTarget,omitempty"`
+	ProductID              string   `json:"productId,omitempty"`
+	IsEdgeIPBindingEnabled bool     `json:"isEdgeIPBindingEnabled,omitempty"`
+	ChinaCdn               ChinaCdn `json:"chinaCdn"`
+}
+
+// ChinaCdn describes China CDN settings of an edge hostname
+type ChinaCdn struct {
+	IsChinaCdn bool `json:"isChinaCdn"`
+}
+
+// Hostname returns the fully qualified edge hostname
+func (edgeHostname *EdgeHostname) Hostname() string {
+	return fmt.Sprintf("%s.%s", edgeHostname.RecordName, edgeHostname.DNSZone)
+}
+
+// GetEdgeHostname retrieves an edge hostname by record name and DNS zone
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostnamebyname
+// Endpoint: GET /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}
+func GetEdgeHostname(recordName, dnsZone string) (*EdgeHostname, error) {
+	return getEdgeHostname(edgeHostnamePath(recordName, dnsZone))
+}
+
+// GetEdgeHostnameByID retrieves an edge hostname by ID
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostname
+// Endpoint: GET /hapi/v1/edge-hostnames/{edgeHostnameId}
+func GetEdgeHostnameByID(edgeHostnameID int) (*EdgeHostname, error) {
+	return getEdgeHostname(fmt.Sprintf("/hapi/v1/edge-hostnames/%d", edgeHostnameID))
+}
+
+func getEdgeHostname(path string) (*EdgeHostname, error) {
+	req, err := client.NewRequest(Config, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	edgeHostname := &EdgeHostname{}
+	if err = client.BodyJSON(res, edgeHostname); err != nil {
+		return nil, err
+	}
+
+	return edgeHostname, nil
+}
+
+// IPVersionBehavior is used to create an "enum" of possible EdgeHostname.IPVersionBehavior values
+type IPVersionBehavior string
+
+const (
+	// IPVersionV4 EdgeHostname.IPVersionBehavior value IPV4
+	IPVersionV4 IPVersionBehavior = "IPV4"
+	// IPVersionV6Performance EdgeHostname.IPVersionBehavior value IPV6_PERFORMANCE
+	IPVersionV6Performance IPVersionBehavior = "IPV6_PERFORMANCE"
+	// IPVersionDualStack EdgeHostname.IPVersionBehavior value IPV6_IPV4_DUALSTACK
+	IPVersionDualStack IPVersionBehavior = "IPV6_IPV4_DUALSTACK"
+)
+
+// ListEdgeHostnamesOptions filters ListEdgeHostnames
+type ListEdgeHostnamesOptions struct {
+	RecordNameSubstring string `query:"recordNameSubstring,omitempty"`
+	DNSZone             string `query:"dnsZone,omitempty"`
+}
+
+// ChangeOptions are the optional query parameters of UpdateEdgeHostname and DeleteEdgeHostname
+type ChangeOptions struct {
+	Comments string `query:"comments,omitempty"`
+	// StatusUpdateEmail is notified when the change request completes
+	StatusUpdateEmail string `query:"statusUpdateEmail,omitempty"`
+}
+
+// EdgeHostnameUpdate is the change of an edge hostname sent by
+// UpdateEdgeHostname, the fields left empty are not changed
+type EdgeHostnameUpdate struct {
+	TTL               int
+	IPVersionBehavior IPVersionBehavior
+}
+
+// PatchOperation is an RFC 6902 operation of an edge hostname change request
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// ListEdgeHostnames lists the edge hostnames, optionally filtered by record name and DNS zone
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#getedgehostnames
+// Endpoint: GET /hapi/v1/edge-hostnames{?recordNameSubstring,dnsZone}
+func ListEdgeHostnames(options ListEdgeHostnamesOptions) ([]EdgeHostname, error) {
+	path, err := client.PathWithQuery("/hapi/v1/edge-hostnames", options)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		EdgeHostnames []EdgeHostname `json:"edgeHostnames"`
+	}{}
+	if err := doJSON("GET", path, "", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.EdgeHostnames, nil
+}
+
+// UpdateEdgeHostname changes the TTL and/or IP version behavior of an edge
+// hostname, the change is applied asynchronously, see WaitForChangeRequest
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#patchedgehostname
+// Endpoint: PATCH /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}{?comments,statusUpdateEmail}
+func UpdateEdgeHostname(recordName, dnsZone string, update EdgeHostnameUpdate, options ChangeOptions) (*ChangeRequest, error) {
+	var patches []PatchOperation
+	if update.TTL != 0 {
+		if update.TTL < 60 || update.TTL > 86400 {
+			return nil, fmt.Errorf("TTL %d must be between 60 and 86400 seconds", update.TTL)
+		}
+		patches = append(patches, PatchOperation{Op: "replace", Path: "/ttl", Value: strconv.Itoa(update.TTL)})
+	}
+	if update.IPVersionBehavior != "" {
+		patches = append(patches, PatchOperation{Op: "replace", Path: "/ipVersionBehavior", Value: string(update.IPVersionBehavior)})
+	}
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("edge hostname %s.%s: nothing to update", recordName, dnsZone)
+	}
+
+	path, err := client.PathWithQuery(edgeHostnamePath(recordName, dnsZone), options)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &ChangeRequest{}
+	if err := doJSON("PATCH", path, "application/json-patch+json", patches, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// DeleteEdgeHostname deletes an edge hostname no property uses, the deletion
+// is applied asynchronously, see WaitForChangeRequest
+//
+// API Docs: https://developer.akamai.com/api/core_features/edge_hostnames/v1.html#deleteedgehostname
+// Endpoint: DELETE /hapi/v1/dns-zones/{dnsZone}/edge-hostnames/{recordName}{?comments,statusUpdateEmail}
+func DeleteEdgeHostname(recordName, dnsZone string, options ChangeOptions) (*ChangeRequest, error) {
+	path, err := client.PathWithQuery(edgeHostnamePath(recordName, dnsZone), options)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &ChangeRequest{}
+	if err := doJSON("DELETE", path, "", nil, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func edgeHostnamePath(recordName, dnsZone string) string {
+	return fmt.Sprintf("/hapi/v1/dns-zones/%s/edge-hostnames/%s", dnsZone, recordName)
+}
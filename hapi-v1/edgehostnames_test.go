@@ -0,0 +1,143 @@
+package hapi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestListEdgeHostnames(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/hapi/v1/edge-hostnames").
+		MatchParam("recordNameSubstring", "example").
+		MatchParam("dnsZone", "edgekey.net").
+		Reply(200).
+		JSON(`{"edgeHostnames": [
+			{"edgeHostnameId": 42, "recordName": "www.example.com", "dnsZone": "edgekey.net", "ttl": 21600},
+			{"edgeHostnameId": 43, "recordName": "api.example.com", "dnsZone": "edgekey.net", "ttl": 300}
+		]}`)
+
+	Init(config)
+
+	edgeHostnames, err := ListEdgeHostnames(ListEdgeHostnamesOptions{RecordNameSubstring: "example", DNSZone: "edgekey.net"})
+	require.NoError(t, err)
+	require.Len(t, edgeHostnames, 2)
+	assert.Equal(t, "api.example.com.edgekey.net", edgeHostnames[1].Hostname())
+}
+
+func TestUpdateEdgeHostname(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Patch("/hapi/v1/dns-zones/edgekey.net/edge-hostnames/www.example.com").
+		MatchParam("comments", "lower TTL").
+		MatchHeader("Content-Type", "application/json-patch\\+json").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			return string(body) == `[{"op":"replace","path":"/ttl","value":"300"},{"op":"replace","path":"/ipVersionBehavior","value":"IPV6_IPV4_DUALSTACK"}]`, err
+		}).
+		Reply(202).
+		JSON(`{"changeId": 1234, "action": "EDIT", "status": "PENDING", "submitter": "jsmith"}`)
+
+	Init(config)
+
+	change, err := UpdateEdgeHostname("www.example.com", "edgekey.net",
+		EdgeHostnameUpdate{TTL: 300, IPVersionBehavior: IPVersionDualStack},
+		ChangeOptions{Comments: "lower TTL"})
+	require.NoError(t, err)
+	assert.Equal(t, 1234, change.ChangeID)
+	assert.False(t, change.Done())
+	assert.True(t, gock.IsDone())
+
+	_, err = UpdateEdgeHostname("www.example.com", "edgekey.net", EdgeHostnameUpdate{}, ChangeOptions{})
+	assert.EqualError(t, err, "edge hostname www.example.com.edgekey.net: nothing to update")
+	_, err = UpdateEdgeHostname("www.example.com", "edgekey.net", EdgeHostnameUpdate{TTL: 10}, ChangeOptions{})
+	assert.EqualError(t, err, "TTL 10 must be between 60 and 86400 seconds")
+}
+
+func TestDeleteEdgeHostnameAndWait(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Delete("/hapi/v1/dns-zones/edgesuite.net/edge-hostnames/old.example.com").
+		Reply(202).
+		JSON(`{"changeId": 1235, "action": "DELETE", "status": "PENDING"}`)
+	gock.New(baseURL).
+		Get("/hapi/v1/change-requests/1235").
+		Reply(200).
+		JSON(`{"changeId": 1235, "action": "DELETE", "status": "PENDING"}`)
+	gock.New(baseURL).
+		Get("/hapi/v1/change-requests/1235").
+		Reply(200).
+		JSON(`{"changeId": 1235, "action": "DELETE", "status": "SUCCEEDED", "statusMessage": "File successfully deployed to Akamai's network"}`)
+
+	Init(config)
+
+	change, err := DeleteEdgeHostname("old.example.com", "edgesuite.net", ChangeOptions{})
+	require.NoError(t, err)
+
+	change, err = WaitForChangeRequest(context.Background(), change.ChangeID, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, ChangeStatusSucceeded, change.Status)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForChangeRequest_Failed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/hapi/v1/change-requests/1236").
+		Reply(200).
+		JSON(`{"changeId": 1236, "status": "FAILED", "statusMessage": "edge hostname is in use"}`)
+
+	Init(config)
+
+	change, err := WaitForChangeRequest(context.Background(), 1236, time.Millisecond)
+	assert.EqualError(t, err, "change request 1236 FAILED: edge hostname is in use")
+	assert.Equal(t, ChangeStatusFailed, change.Status)
+}
+
+func TestWaitForChangeRequest_Cancelled(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/hapi/v1/change-requests/1237").
+		Persist().
+		Reply(200).
+		JSON(`{"changeId": 1237, "status": "PENDING"}`)
+
+	Init(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	change, err := WaitForChangeRequest(ctx, 1237, 5*time.Millisecond)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, ChangeStatusPending, change.Status)
+}
+
+func TestListChangeRequests(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/hapi/v1/dns-zones/edgekey.net/edge-hostnames/www.example.com/change-requests").
+		MatchParam("status", "PENDING").
+		Reply(200).
+		JSON(`{"changeRequests": [{"changeId": 1234, "action": "EDIT", "status": "PENDING", "patchOperations": [{"op": "replace", "path": "/ttl", "value": "300"}]}]}`)
+
+	Init(config)
+
+	changes, err := ListChangeRequests("www.example.com", "edgekey.net", ChangeStatusPending)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "/ttl", changes[0].PatchOperations[0].Path)
+}
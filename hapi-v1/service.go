@@ -0,0 +1,32 @@
+package hapi
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+var (
+	// Config contains the Akamai OPEN Edgegrid API credentials
+	// for automatic signing of requests
+	Config edgegrid.Config
+)
+
+// Init sets the HAPI edgegrid Config
+func Init(config edgegrid.Config) {
+	Config = config
+	edgegrid.SetupLogging()
+}
+
+// doJSON sends body (if not nil) as contentType (application/json when empty)
+// to path and decodes the response into out (if not nil)
+func doJSON(method, path, contentType string, body, out interface{}) error {
+	req, err := client.NewJSONRequest(Config, method, path, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return client.DoJSONRequest(Config, req, out)
+}
@@ -0,0 +1,262 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// CIDRBlock is an entry of the account IP allowlist (IP ACL)
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#cidrblock
+type CIDRBlock struct {
+	CIDRBlockID  int    `json:"cidrBlockId,omitempty"`
+	CIDRBlock    string `json:"cidrBlock"`
+	Comments     string `json:"comments,omitempty"`
+	Enabled      bool   `json:"enabled"`
+	CreatedBy    string `json:"createdBy,omitempty"`
+	CreatedDate  string `json:"createdDate,omitempty"`
+	ModifiedBy   string `json:"modifiedBy,omitempty"`
+	ModifiedDate string `json:"modifiedDate,omitempty"`
+}
+
+// Contains reports whether ip is within the CIDR block; single addresses
+// without a prefix length are accepted too
+func (block *CIDRBlock) Contains(ip net.IP) bool {
+	cidr := block.CIDRBlock
+	if !strings.Contains(cidr, "/") {
+		return net.ParseIP(cidr).Equal(ip)
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+
+	return network.Contains(ip)
+}
+
+// ErrSelfLockout is returned, before calling the API, when an allowlist change
+// would block the IP address the requests are sent from
+//
+// The API doesn't report that address: callers pass their egress IP, which
+// may differ from the local address behind NAT or a proxy.
+var ErrSelfLockout = errors.New("allowlist change would lock out the current IP address")
+
+// CheckAllowlist returns ErrSelfLockout when none of the enabled blocks contains ip
+func CheckAllowlist(blocks []CIDRBlock, ip net.IP) error {
+	if ip == nil {
+		return errors.New("the IP address requests are sent from is required to check the allowlist")
+	}
+
+	for _, block := range blocks {
+		if block.Enabled && block.Contains(ip) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSelfLockout, ip)
+}
+
+// ListCIDRBlocks retrieves the allowlist entries
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getcidrblocks
+// Endpoint: GET /identity-management/v3/user-admin/ip-acl/allowlist
+func ListCIDRBlocks() ([]CIDRBlock, error) {
+	req, err := client.NewRequest(Config, "GET", "/identity-management/v3/user-admin/ip-acl/allowlist", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []CIDRBlock{}
+	if err = doJSON(req, &blocks); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// GetCIDRBlock retrieves an allowlist entry
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getcidrblock
+// Endpoint: GET /identity-management/v3/user-admin/ip-acl/allowlist/{cidrBlockId}
+func GetCIDRBlock(cidrBlockID int) (*CIDRBlock, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/identity-management/v3/user-admin/ip-acl/allowlist/%d", cidrBlockID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &CIDRBlock{}
+	if err = doJSON(req, block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// CreateCIDRBlock adds an entry to the allowlist
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postcidrblock
+// Endpoint: POST /identity-management/v3/user-admin/ip-acl/allowlist
+func CreateCIDRBlock(block CIDRBlock) (*CIDRBlock, error) {
+	req, err := client.NewJSONRequest(Config, "POST", "/identity-management/v3/user-admin/ip-acl/allowlist", block)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &CIDRBlock{}
+	if err = doJSON(req, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateCIDRBlock updates an allowlist entry
+//
+// When the allowlist is enabled, ErrSelfLockout is returned if the updated
+// allowlist would no longer contain currentIP.
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#putcidrblock
+// Endpoint: PUT /identity-management/v3/user-admin/ip-acl/allowlist/{cidrBlockId}
+func UpdateCIDRBlock(block CIDRBlock, currentIP net.IP) (*CIDRBlock, error) {
+	if err := guardAllowlist(currentIP, func(blocks []CIDRBlock) []CIDRBlock {
+		for i := range blocks {
+			if blocks[i].CIDRBlockID == block.CIDRBlockID {
+				blocks[i] = block
+			}
+		}
+		return blocks
+	}); err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/identity-management/v3/user-admin/ip-acl/allowlist/%d", block.CIDRBlockID),
+		block,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &CIDRBlock{}
+	if err = doJSON(req, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteCIDRBlock removes an entry from the allowlist
+//
+// When the allowlist is enabled, ErrSelfLockout is returned if the remaining
+// allowlist would no longer contain currentIP.
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#deletecidrblock
+// Endpoint: DELETE /identity-management/v3/user-admin/ip-acl/allowlist/{cidrBlockId}
+func DeleteCIDRBlock(cidrBlockID int, currentIP net.IP) error {
+	if err := guardAllowlist(currentIP, func(blocks []CIDRBlock) []CIDRBlock {
+		remaining := []CIDRBlock{}
+		for _, block := range blocks {
+			if block.CIDRBlockID != cidrBlockID {
+				remaining = append(remaining, block)
+			}
+		}
+		return remaining
+	}); err != nil {
+		return err
+	}
+
+	req, err := client.NewRequest(
+		Config,
+		"DELETE",
+		fmt.Sprintf("/identity-management/v3/user-admin/ip-acl/allowlist/%d", cidrBlockID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// GetAllowlistStatus reports whether the allowlist is enforced
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getipaclallowliststatus
+// Endpoint: GET /identity-management/v3/user-admin/ip-acl/allowlist/status
+func GetAllowlistStatus() (bool, error) {
+	req, err := client.NewRequest(Config, "GET", "/identity-management/v3/user-admin/ip-acl/allowlist/status", nil)
+	if err != nil {
+		return false, err
+	}
+
+	var status struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err = doJSON(req, &status); err != nil {
+		return false, err
+	}
+
+	return status.Enabled, nil
+}
+
+// EnableAllowlist enforces the allowlist, or returns ErrSelfLockout if it
+// doesn't contain currentIP
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postipaclallowlistenable
+// Endpoint: POST /identity-management/v3/user-admin/ip-acl/allowlist/enable
+func EnableAllowlist(currentIP net.IP) error {
+	blocks, err := ListCIDRBlocks()
+	if err != nil {
+		return err
+	}
+	if err = CheckAllowlist(blocks, currentIP); err != nil {
+		return err
+	}
+
+	req, err := client.NewRequest(Config, "POST", "/identity-management/v3/user-admin/ip-acl/allowlist/enable", nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// DisableAllowlist stops enforcing the allowlist
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postipaclallowlistdisable
+// Endpoint: POST /identity-management/v3/user-admin/ip-acl/allowlist/disable
+func DisableAllowlist() error {
+	req, err := client.NewRequest(Config, "POST", "/identity-management/v3/user-admin/ip-acl/allowlist/disable", nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// guardAllowlist checks the allowlist returned by change against currentIP,
+// when the allowlist is enabled
+func guardAllowlist(currentIP net.IP, change func([]CIDRBlock) []CIDRBlock) error {
+	enabled, err := GetAllowlistStatus()
+	if err != nil || !enabled {
+		return err
+	}
+
+	blocks, err := ListCIDRBlocks()
+	if err != nil {
+		return err
+	}
+
+	return CheckAllowlist(change(blocks), currentIP)
+}
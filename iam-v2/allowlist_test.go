@@ -0,0 +1,69 @@
+package iam
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const allowlistBody = `[
+	{"cidrBlockId": 1, "cidrBlock": "198.51.100.0/24", "enabled": true},
+	{"cidrBlockId": 2, "cidrBlock": "203.0.113.7", "enabled": true}
+]`
+
+func TestCheckAllowlist(t *testing.T) {
+	blocks := []CIDRBlock{
+		{CIDRBlock: "198.51.100.0/24", Enabled: true},
+		{CIDRBlock: "203.0.113.7", Enabled: false},
+	}
+
+	assert.NoError(t, CheckAllowlist(blocks, net.ParseIP("198.51.100.20")))
+	assert.True(t, errors.Is(CheckAllowlist(blocks, net.ParseIP("203.0.113.7")), ErrSelfLockout))
+	assert.Error(t, CheckAllowlist(blocks, nil))
+}
+
+func TestDeleteCIDRBlock_SelfLockout(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v3/user-admin/ip-acl/allowlist/status").
+		Reply(200).
+		JSON(map[string]bool{"enabled": true})
+	gock.New(baseURL).
+		Get("/identity-management/v3/user-admin/ip-acl/allowlist").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(allowlistBody)
+
+	Init(config)
+
+	err := DeleteCIDRBlock(2, net.ParseIP("203.0.113.7"))
+	assert.True(t, errors.Is(err, ErrSelfLockout))
+	assert.True(t, gock.IsDone())
+}
+
+func TestDeleteCIDRBlock(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v3/user-admin/ip-acl/allowlist/status").
+		Reply(200).
+		JSON(map[string]bool{"enabled": true})
+	gock.New(baseURL).
+		Get("/identity-management/v3/user-admin/ip-acl/allowlist").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(allowlistBody)
+	gock.New(baseURL).
+		Delete("/identity-management/v3/user-admin/ip-acl/allowlist/2").
+		Reply(204)
+
+	Init(config)
+
+	require.NoError(t, DeleteCIDRBlock(2, net.ParseIP("198.51.100.20")))
+	assert.True(t, gock.IsDone())
+}
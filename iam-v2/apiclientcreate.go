@@ -0,0 +1,189 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// APIClientType is used to create an "enum" of possible CreateAPIClientRequest.ClientType values
+type APIClientType string
+
+const (
+	// ClientTypeClient CreateAPIClientRequest.ClientType value CLIENT, a client
+	// managed by its authorized users
+	ClientTypeClient APIClientType = "CLIENT"
+	// ClientTypeUserClient CreateAPIClientRequest.ClientType value USER_CLIENT,
+	// a client acting as its single authorized user
+	ClientTypeUserClient APIClientType = "USER_CLIENT"
+	// ClientTypeServiceAccount CreateAPIClientRequest.ClientType value
+	// SERVICE_ACCOUNT, a client of the account rather than of a user
+	ClientTypeServiceAccount APIClientType = "SERVICE_ACCOUNT"
+)
+
+// CreateAPIClientRequest is the API client CreateAPIClient creates
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postapiclients
+type CreateAPIClientRequest struct {
+	ClientName        string        `json:"clientName"`
+	ClientDescription string        `json:"clientDescription,omitempty"`
+	ClientType        APIClientType `json:"clientType"`
+	AuthorizedUsers   []string      `json:"authorizedUsers"`
+	// CanAutoCreateCredential creates a first credential with the client, its
+	// secret is only returned by CreateAPIClient
+	CanAutoCreateCredential bool        `json:"canAutoCreateCredential"`
+	NotificationEmails      []string    `json:"notificationEmails,omitempty"`
+	AllowAccountSwitch      bool        `json:"allowAccountSwitch"`
+	APIAccess               APIAccess   `json:"apiAccess"`
+	GroupAccess             GroupAccess `json:"groupAccess"`
+}
+
+// AllowedAPI is an API a user can grant to the API clients they create
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#allowedapi
+type AllowedAPI struct {
+	APIID            int           `json:"apiId"`
+	APIName          string        `json:"apiName"`
+	Description      string        `json:"description,omitempty"`
+	Endpoint         string        `json:"endPoint"`
+	DocumentationURL string        `json:"documentationUrl,omitempty"`
+	AccessLevels     []AccessLevel `json:"accessLevels"`
+	HasAccess        bool          `json:"hasAccess"`
+}
+
+// APIGrants returns the grants of the APIs named names (API names, e.g.
+// "Property Manager (PAPI)", or endpoints, e.g. "/papi") at level, checking
+// the level is allowed for each of them
+func APIGrants(allowed []AllowedAPI, level AccessLevel, names ...string) ([]APIGrant, error) {
+	grants := make([]APIGrant, 0, len(names))
+	for _, name := range names {
+		var api *AllowedAPI
+		for i := range allowed {
+			if strings.EqualFold(allowed[i].APIName, name) || strings.EqualFold(allowed[i].Endpoint, name) {
+				api = &allowed[i]
+				break
+			}
+		}
+		if api == nil {
+			return nil, fmt.Errorf("API %q cannot be granted", name)
+		}
+
+		levelAllowed := false
+		for _, accessLevel := range api.AccessLevels {
+			if accessLevel == level {
+				levelAllowed = true
+			}
+		}
+		if !levelAllowed {
+			return nil, fmt.Errorf("API %q cannot be granted %s", name, level)
+		}
+
+		grants = append(grants, APIGrant{APIID: api.APIID, APIName: api.APIName, Endpoint: api.Endpoint, AccessLevel: level})
+	}
+
+	return grants, nil
+}
+
+// ListAllowedAPIs lists the APIs userName can grant to an API client of clientType
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getallowedapis
+// Endpoint: GET /identity-management/v3/users/{userName}/allowed-apis{?clientType,allowAccountSwitch}
+func ListAllowedAPIs(userName string, clientType APIClientType, allowAccountSwitch bool) ([]AllowedAPI, error) {
+	path, err := client.PathWithQuery(
+		fmt.Sprintf("/identity-management/v3/users/%s/allowed-apis", url.PathEscape(userName)),
+		struct {
+			ClientType         APIClientType `query:"clientType,omitempty"`
+			AllowAccountSwitch bool          `query:"allowAccountSwitch,omitempty"`
+		}{clientType, allowAccountSwitch},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(Config, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apis []AllowedAPI
+	if err = doJSON(req, &apis); err != nil {
+		return nil, err
+	}
+
+	return apis, nil
+}
+
+// CreateAPIClient creates an API client with the APIs and groups of request
+//
+// With request.CanAutoCreateCredential the returned client has a credential
+// whose ClientSecret is not returned again: keep it, e.g. with EdgegridConfig.
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postapiclients
+// Endpoint: POST /identity-management/v3/api-clients
+func CreateAPIClient(request CreateAPIClientRequest) (*APIClient, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewJSONRequest(Config, "POST", "/identity-management/v3/api-clients", request)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := &APIClient{}
+	if err = doJSON(req, apiClient); err != nil {
+		return nil, err
+	}
+
+	return apiClient, nil
+}
+
+// EdgegridConfig returns the configuration to sign requests with a credential
+// of the API client, as returned by CreateAPIClient or CreateCredential
+func (apiClient *APIClient) EdgegridConfig(credential Credential) (edgegrid.Config, error) {
+	if apiClient.AccessToken == "" || apiClient.BaseURL == "" {
+		return edgegrid.Config{}, fmt.Errorf("API client %s has no access token or base URL", apiClient.ClientID)
+	}
+	if credential.ClientSecret == "" {
+		return edgegrid.Config{}, fmt.Errorf("credential %d has no client secret, it is only returned when created", credential.CredentialID)
+	}
+
+	host := strings.TrimSuffix(strings.TrimPrefix(apiClient.BaseURL, "https://"), "/")
+
+	return edgegrid.Config{
+		Host:         host,
+		ClientToken:  credential.ClientToken,
+		ClientSecret: credential.ClientSecret,
+		AccessToken:  apiClient.AccessToken,
+		MaxBody:      131072,
+	}, nil
+}
+
+func (request *CreateAPIClientRequest) validate() error {
+	if request.ClientName == "" {
+		return errors.New("an API client needs a name")
+	}
+	if len(request.AuthorizedUsers) == 0 {
+		return errors.New("an API client needs at least one authorized user")
+	}
+	if request.ClientType == ClientTypeUserClient && len(request.AuthorizedUsers) != 1 {
+		return fmt.Errorf("a %s API client has exactly one authorized user", ClientTypeUserClient)
+	}
+	if !request.APIAccess.AllAccessibleAPIs && len(request.APIAccess.APIs) == 0 {
+		return errors.New("an API client needs APIs, or APIAccess.AllAccessibleAPIs")
+	}
+	if !request.GroupAccess.CloneAuthorizedUserGroups && len(request.GroupAccess.Groups) == 0 {
+		return errors.New("an API client needs groups, or GroupAccess.CloneAuthorizedUserGroups")
+	}
+	for _, group := range request.GroupAccess.Groups {
+		if group.RoleID == nil {
+			return fmt.Errorf("group %d has no role", group.GroupID)
+		}
+	}
+
+	return nil
+}
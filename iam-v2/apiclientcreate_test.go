@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateAPIClient(t *testing.T) {
+	defer gock.Off()
+
+	allowedAPIs := []AllowedAPI{
+		{APIID: 1, APIName: "Property Manager (PAPI)", Endpoint: "/papi", AccessLevels: []AccessLevel{AccessReadOnly, AccessReadWrite}},
+		{APIID: 2, APIName: "Reporting API", Endpoint: "/reporting-api", AccessLevels: []AccessLevel{AccessReadOnly}},
+	}
+	grants, err := APIGrants(allowedAPIs, AccessReadOnly, "/papi", "reporting api")
+	require.NoError(t, err)
+	_, err = APIGrants(allowedAPIs, AccessReadWrite, "/reporting-api")
+	assert.Error(t, err)
+	_, err = APIGrants(allowedAPIs, AccessReadOnly, "/unknown")
+	assert.Error(t, err)
+
+	roleID := 14
+	request := CreateAPIClientRequest{
+		ClientName:              "team-a",
+		ClientType:              ClientTypeClient,
+		AuthorizedUsers:         []string{"jdoe"},
+		CanAutoCreateCredential: true,
+		APIAccess:               APIAccess{APIs: grants},
+		GroupAccess:             GroupAccess{Groups: []AuthGrant{{GroupID: 12345, RoleID: &roleID}}},
+	}
+
+	gock.New(baseURL).
+		Post("/identity-management/v3/api-clients").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return false, err
+			}
+			var sent CreateAPIClientRequest
+			if err := json.Unmarshal(body, &sent); err != nil {
+				return false, err
+			}
+			return sent.ClientName == "team-a" && len(sent.APIAccess.APIs) == 2 && sent.APIAccess.APIs[1].APIID == 2, nil
+		}).
+		Reply(201).
+		JSON(`{"clientId": "1abcd", "clientName": "team-a", "accessToken": "akab-access", "baseURL": "https://akab-host.luna.akamaiapis.net/",
+			"credentials": [{"credentialId": 1001, "clientToken": "akab-client", "clientSecret": "secret", "status": "ACTIVE"}]}`)
+
+	Init(config)
+
+	apiClient, err := CreateAPIClient(request)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	require.Len(t, apiClient.Credentials, 1)
+
+	edgegridConfig, err := apiClient.EdgegridConfig(apiClient.Credentials[0])
+	require.NoError(t, err)
+	assert.Equal(t, "akab-host.luna.akamaiapis.net", edgegridConfig.Host)
+	assert.Equal(t, "akab-access", edgegridConfig.AccessToken)
+	assert.Equal(t, "akab-client", edgegridConfig.ClientToken)
+	assert.Equal(t, "secret", edgegridConfig.ClientSecret)
+
+	_, err = apiClient.EdgegridConfig(Credential{CredentialID: 1001, ClientToken: "akab-client"})
+	assert.Error(t, err)
+}
+
+func TestCreateAPIClient_Validate(t *testing.T) {
+	valid := CreateAPIClientRequest{
+		ClientName:      "team-a",
+		ClientType:      ClientTypeClient,
+		AuthorizedUsers: []string{"jdoe"},
+		APIAccess:       APIAccess{AllAccessibleAPIs: true},
+		GroupAccess:     GroupAccess{CloneAuthorizedUserGroups: true},
+	}
+	assert.NoError(t, valid.validate())
+
+	noName := valid
+	noName.ClientName = ""
+	noUsers := valid
+	noUsers.AuthorizedUsers = nil
+	userClient := valid
+	userClient.ClientType = ClientTypeUserClient
+	userClient.AuthorizedUsers = []string{"jdoe", "asmith"}
+	noAPIs := valid
+	noAPIs.APIAccess = APIAccess{}
+	noGroups := valid
+	noGroups.GroupAccess = GroupAccess{}
+	noRole := valid
+	noRole.GroupAccess = GroupAccess{Groups: []AuthGrant{{GroupID: 12345}}}
+
+	for _, request := range []CreateAPIClientRequest{noName, noUsers, userClient, noAPIs, noGroups, noRole} {
+		_, err := CreateAPIClient(request)
+		assert.Error(t, err)
+	}
+}
+
+func TestListAllowedAPIs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v3/users/jdoe/allowed-apis").
+		MatchParam("clientType", "CLIENT").
+		Reply(200).
+		JSON(`[{"apiId": 1, "apiName": "Property Manager (PAPI)", "endPoint": "/papi", "accessLevels": ["READ-ONLY", "READ-WRITE"], "hasAccess": true}]`)
+
+	Init(config)
+
+	apis, err := ListAllowedAPIs("jdoe", ClientTypeClient, false)
+	require.NoError(t, err)
+	require.Len(t, apis, 1)
+	assert.Equal(t, "/papi", apis[0].Endpoint)
+	assert.Equal(t, []AccessLevel{AccessReadOnly, AccessReadWrite}, apis[0].AccessLevels)
+	assert.True(t, gock.IsDone())
+}
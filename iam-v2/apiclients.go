@@ -0,0 +1,240 @@
+package iam
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// CredentialStatus is used to create an "enum" of possible Credential.Status values
+type CredentialStatus string
+
+const (
+	// CredentialActive Credential.Status value ACTIVE
+	CredentialActive CredentialStatus = "ACTIVE"
+	// CredentialInactive Credential.Status value INACTIVE
+	CredentialInactive CredentialStatus = "INACTIVE"
+	// CredentialDeleted Credential.Status value DELETED
+	CredentialDeleted CredentialStatus = "DELETED"
+)
+
+// SelfClientID is the client ID of the API client whose credentials are in Config
+const SelfClientID = "self"
+
+// Credential is a client token and secret of an API client, ClientSecret is
+// only returned once, when the credential is created
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#credential
+type Credential struct {
+	CredentialID int              `json:"credentialId"`
+	ClientToken  string           `json:"clientToken"`
+	ClientSecret string           `json:"clientSecret,omitempty"`
+	Status       CredentialStatus `json:"status"`
+	Description  string           `json:"description,omitempty"`
+	CreatedOn    string           `json:"createdOn,omitempty"`
+	ExpiresOn    string           `json:"expiresOn,omitempty"`
+}
+
+// CredentialUpdate are the fields of a credential UpdateCredential changes
+type CredentialUpdate struct {
+	Status      CredentialStatus `json:"status"`
+	ExpiresOn   string           `json:"expiresOn"`
+	Description string           `json:"description,omitempty"`
+}
+
+// ListAPIClients retrieves the API clients the credential in Config can manage
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getapiclients
+// Endpoint: GET /identity-management/v3/api-clients
+func ListAPIClients() ([]*APIClient, error) {
+	req, err := client.NewRequest(Config, "GET", "/identity-management/v3/api-clients", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiClients []*APIClient
+	if err = doJSON(req, &apiClients); err != nil {
+		return nil, err
+	}
+
+	return apiClients, nil
+}
+
+// GetAPIClient retrieves an API client with its access and credentials, use SelfClientID for the current one
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getapiclient
+// Endpoint: GET /identity-management/v3/api-clients/{clientId}{?apiAccess,credentials,groupAccess}
+func GetAPIClient(clientID string) (*APIClient, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/identity-management/v3/api-clients/%s?apiAccess=true&credentials=true&groupAccess=true", clientID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := &APIClient{}
+	if err = doJSON(req, apiClient); err != nil {
+		return nil, err
+	}
+
+	return apiClient, nil
+}
+
+// LockAPIClient locks an API client, its credentials can no longer be used
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#putlockapiclient
+// Endpoint: PUT /identity-management/v3/api-clients/{clientId}/lock
+func LockAPIClient(clientID string) error {
+	return apiClientRequest("PUT", fmt.Sprintf("/identity-management/v3/api-clients/%s/lock", clientID), nil)
+}
+
+// UnlockAPIClient unlocks an API client
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#putunlockapiclient
+// Endpoint: PUT /identity-management/v3/api-clients/{clientId}/unlock
+func UnlockAPIClient(clientID string) error {
+	return apiClientRequest("PUT", fmt.Sprintf("/identity-management/v3/api-clients/%s/unlock", clientID), nil)
+}
+
+// RemoveAPIClient deletes an API client and its credentials
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#deleteapiclient
+// Endpoint: DELETE /identity-management/v3/api-clients/{clientId}
+func RemoveAPIClient(clientID string) error {
+	return apiClientRequest("DELETE", fmt.Sprintf("/identity-management/v3/api-clients/%s", clientID), nil)
+}
+
+// ListCredentials retrieves the credentials of an API client, without their secrets
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getcredentials
+// Endpoint: GET /identity-management/v3/api-clients/{clientId}/credentials
+func ListCredentials(clientID string) ([]Credential, error) {
+	req, err := client.NewRequest(Config, "GET", credentialsPath(clientID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []Credential
+	if err = doJSON(req, &credentials); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+// CreateCredential creates a new credential for an API client, the returned
+// ClientSecret can't be retrieved again
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postcredentials
+// Endpoint: POST /identity-management/v3/api-clients/{clientId}/credentials
+func CreateCredential(clientID string) (*Credential, error) {
+	req, err := client.NewRequest(Config, "POST", credentialsPath(clientID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &Credential{}
+	if err = doJSON(req, credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// UpdateCredential changes the status, expiry and description of a credential
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#putcredential
+// Endpoint: PUT /identity-management/v3/api-clients/{clientId}/credentials/{credentialId}
+func UpdateCredential(clientID string, credentialID int, update CredentialUpdate) (*Credential, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("%s/%d", credentialsPath(clientID), credentialID),
+		update,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &Credential{}
+	if err = doJSON(req, credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// DeactivateCredential deactivates a credential, it can be activated again with UpdateCredential
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#postdeactivatecredential
+// Endpoint: POST /identity-management/v3/api-clients/{clientId}/credentials/{credentialId}/deactivate
+func DeactivateCredential(clientID string, credentialID int) error {
+	return apiClientRequest("POST", fmt.Sprintf("%s/%d/deactivate", credentialsPath(clientID), credentialID), nil)
+}
+
+// RemoveCredential deletes a credential, it must be inactive
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#deletecredential
+// Endpoint: DELETE /identity-management/v3/api-clients/{clientId}/credentials/{credentialId}
+func RemoveCredential(clientID string, credentialID int) error {
+	return apiClientRequest("DELETE", fmt.Sprintf("%s/%d", credentialsPath(clientID), credentialID), nil)
+}
+
+// RotateCredential creates a new credential for an API client and makes the
+// credential oldCredentialID expire after grace, giving the users of the old
+// credential time to switch. A grace of 0 deactivates the old credential at once.
+func RotateCredential(clientID string, oldCredentialID int, grace time.Duration) (*Credential, error) {
+	credentials, err := ListCredentials(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	var old *Credential
+	for i := range credentials {
+		if credentials[i].CredentialID == oldCredentialID {
+			old = &credentials[i]
+		}
+	}
+	if old == nil {
+		return nil, fmt.Errorf("credential %d not found for API client %s", oldCredentialID, clientID)
+	}
+
+	created, err := CreateCredential(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if grace <= 0 {
+		err = DeactivateCredential(clientID, oldCredentialID)
+	} else {
+		_, err = UpdateCredential(clientID, oldCredentialID, CredentialUpdate{
+			Status:      old.Status,
+			ExpiresOn:   now().Add(grace).UTC().Format(time.RFC3339),
+			Description: old.Description,
+		})
+	}
+	if err != nil {
+		return created, fmt.Errorf("credential %d created, but credential %d was not retired: %w", created.CredentialID, oldCredentialID, err)
+	}
+
+	return created, nil
+}
+
+var now = time.Now
+
+func credentialsPath(clientID string) string {
+	return fmt.Sprintf("/identity-management/v3/api-clients/%s/credentials", clientID)
+}
+
+func apiClientRequest(method, path string, body interface{}) error {
+	req, err := client.NewJSONRequest(Config, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
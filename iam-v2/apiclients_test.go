@@ -0,0 +1,64 @@
+package iam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestRotateCredential(t *testing.T) {
+	defer gock.Off()
+	fixed := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	gock.New(baseURL).
+		Get("/identity-management/v3/api-clients/self/credentials").
+		Reply(200).
+		JSON(`[{"credentialId": 1001, "clientToken": "akab-old", "status": "ACTIVE", "description": "ci", "expiresOn": "2021-01-01T00:00:00.000Z"}]`)
+	gock.New(baseURL).
+		Post("/identity-management/v3/api-clients/self/credentials").
+		Reply(201).
+		JSON(`{"credentialId": 1002, "clientToken": "akab-new", "clientSecret": "secret", "status": "ACTIVE"}`)
+	gock.New(baseURL).
+		Put("/identity-management/v3/api-clients/self/credentials/1001").
+		JSON(map[string]string{"status": "ACTIVE", "expiresOn": "2020-06-02T12:00:00Z", "description": "ci"}).
+		Reply(200).
+		JSON(`{"credentialId": 1001, "clientToken": "akab-old", "status": "ACTIVE", "expiresOn": "2020-06-02T12:00:00.000Z"}`)
+
+	Init(config)
+
+	credential, err := RotateCredential(SelfClientID, 1001, 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1002, credential.CredentialID)
+	assert.Equal(t, "secret", credential.ClientSecret)
+	assert.True(t, gock.IsDone())
+}
+
+func TestRotateCredential_Deactivate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v3/api-clients/1abcd/credentials").
+		Reply(200).
+		JSON(`[{"credentialId": 1001, "clientToken": "akab-old", "status": "ACTIVE"}]`)
+	gock.New(baseURL).
+		Post("/identity-management/v3/api-clients/1abcd/credentials").
+		Reply(201).
+		JSON(`{"credentialId": 1002, "clientToken": "akab-new", "clientSecret": "secret", "status": "ACTIVE"}`)
+	gock.New(baseURL).
+		Post("/identity-management/v3/api-clients/1abcd/credentials/1001/deactivate").
+		Reply(204)
+
+	Init(config)
+
+	_, err := RotateCredential("1abcd", 1001, 0)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	_, err = RotateCredential("1abcd", 1003, 0)
+	assert.Error(t, err)
+}
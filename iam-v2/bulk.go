@@ -0,0 +1,338 @@
+package iam
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RosterState is the desired state of a user in a Roster
+type RosterState string
+
+const (
+	// RosterStateActive the user should exist and be able to log in
+	RosterStateActive RosterState = "active"
+	// RosterStateInactive the user should be locked, if it exists
+	RosterStateInactive RosterState = "inactive"
+)
+
+// RosterEntry is the desired state of a single user
+//
+// Empty fields keep the current value of an existing user, and so do empty
+// AuthGrants: a roster without (or with an empty) authGrants column does not
+// change the roles of existing users.
+type RosterEntry struct {
+	Email      string
+	FirstName  string
+	LastName   string
+	Phone      string
+	Country    string
+	State      RosterState
+	AuthGrants []AuthGrant
+}
+
+// Roster is a declarative list of users, typically exported from an HR system
+type Roster []RosterEntry
+
+// rosterColumns are the CSV columns understood by ParseRosterCSV
+var rosterColumns = []string{"email", "firstName", "lastName", "phone", "country", "state", "authGrants"}
+
+// ParseRosterCSV reads a Roster from CSV
+//
+// The first row must be a header naming the columns email, firstName, lastName,
+// phone, country, state and authGrants (in any order; only email is required).
+// authGrants is a "|" separated list of groupId:roleId pairs, e.g. "12345:67|23456:89".
+// An empty state is treated as active.
+func ParseRosterCSV(r io.Reader) (Roster, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("roster header: %s", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("roster header is missing the \"email\" column, expected %s", strings.Join(rosterColumns, ","))
+	}
+
+	var roster Roster
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		entry := RosterEntry{
+			Email:     value("email"),
+			FirstName: value("firstName"),
+			LastName:  value("lastName"),
+			Phone:     value("phone"),
+			Country:   value("country"),
+			State:     RosterState(strings.ToLower(value("state"))),
+		}
+		if entry.Email == "" {
+			return nil, fmt.Errorf("roster line %d: email is required", line)
+		}
+		if entry.State == "" {
+			entry.State = RosterStateActive
+		}
+		if entry.State != RosterStateActive && entry.State != RosterStateInactive {
+			return nil, fmt.Errorf("roster line %d: invalid state \"%s\"", line, entry.State)
+		}
+
+		entry.AuthGrants, err = parseAuthGrants(value("authGrants"))
+		if err != nil {
+			return nil, fmt.Errorf("roster line %d: %s", line, err)
+		}
+
+		roster = append(roster, entry)
+	}
+
+	return roster, nil
+}
+
+func parseAuthGrants(value string) ([]AuthGrant, error) {
+	var grants []AuthGrant
+	if value == "" {
+		return grants, nil
+	}
+
+	for _, pair := range strings.Split(value, "|") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid auth grant \"%s\", expected groupId:roleId", pair)
+		}
+
+		groupID, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid group ID \"%s\"", parts[0])
+		}
+		roleID, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid role ID \"%s\"", parts[1])
+		}
+
+		grants = append(grants, AuthGrant{GroupID: groupID, RoleID: &roleID})
+	}
+
+	return grants, nil
+}
+
+// ProvisionAction describes what BulkProvision did (or would do) for a user
+type ProvisionAction string
+
+const (
+	// ProvisionCreated the user was created
+	ProvisionCreated ProvisionAction = "CREATED"
+	// ProvisionUpdated the user's basic info, auth grants or lock state changed
+	ProvisionUpdated ProvisionAction = "UPDATED"
+	// ProvisionDeactivated the user was locked
+	ProvisionDeactivated ProvisionAction = "DEACTIVATED"
+	// ProvisionUnchanged the user already matched the roster
+	ProvisionUnchanged ProvisionAction = "UNCHANGED"
+	// ProvisionFailed an API call failed, see ProvisionResult.Err
+	ProvisionFailed ProvisionAction = "FAILED"
+)
+
+// ProvisionOptions controls BulkProvision
+type ProvisionOptions struct {
+	// SendEmail sends the welcome email to newly created users
+	SendEmail bool
+	// DryRun computes the report without making any changes
+	DryRun bool
+	// DeactivateMissing locks existing users who are not in the roster
+	DeactivateMissing bool
+}
+
+// ProvisionResult is the outcome for a single user
+type ProvisionResult struct {
+	Email        string
+	UIIdentityID string
+	Action       ProvisionAction
+	Err          error
+}
+
+// BulkProvision reconciles the account's users with a Roster
+//
+// Users are matched by email (case-insensitive). Missing active users are
+// created, existing users have their basic info and auth grants updated, and
+// inactive users are locked. A failure for one user is recorded in its
+// ProvisionResult and does not stop the remaining users from being processed;
+// the returned error is only set when the current users cannot be listed.
+func BulkProvision(roster Roster, options ProvisionOptions) ([]ProvisionResult, error) {
+	users, err := ListUsers(ListUsersQueryArgs{AuthGrants: true})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*User, len(users))
+	for _, user := range users {
+		existing[strings.ToLower(user.Email)] = user
+	}
+
+	results := make([]ProvisionResult, 0, len(roster))
+	seen := make(map[string]bool, len(roster))
+	for _, entry := range roster {
+		key := strings.ToLower(entry.Email)
+		seen[key] = true
+		results = append(results, provisionEntry(entry, existing[key], options))
+	}
+
+	if options.DeactivateMissing {
+		for _, user := range users {
+			if seen[strings.ToLower(user.Email)] || user.IsLocked {
+				continue
+			}
+			result := ProvisionResult{Email: user.Email, UIIdentityID: user.UIIdentityID, Action: ProvisionDeactivated}
+			if !options.DryRun {
+				if err := LockUser(user.UIIdentityID); err != nil {
+					result.Action, result.Err = ProvisionFailed, err
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func provisionEntry(entry RosterEntry, user *User, options ProvisionOptions) ProvisionResult {
+	result := ProvisionResult{Email: entry.Email, Action: ProvisionUnchanged}
+
+	failed := func(err error) ProvisionResult {
+		result.Action, result.Err = ProvisionFailed, err
+		return result
+	}
+
+	if user == nil {
+		if entry.State == RosterStateInactive {
+			return result
+		}
+
+		result.Action = ProvisionCreated
+		if options.DryRun {
+			return result
+		}
+
+		created, err := CreateUser(&User{
+			FirstName:  entry.FirstName,
+			LastName:   entry.LastName,
+			Email:      entry.Email,
+			Phone:      entry.Phone,
+			Country:    entry.Country,
+			AuthGrants: entry.AuthGrants,
+		}, options.SendEmail)
+		if err != nil {
+			return failed(err)
+		}
+		result.UIIdentityID = created.UIIdentityID
+
+		return result
+	}
+
+	result.UIIdentityID = user.UIIdentityID
+
+	if entry.State == RosterStateInactive {
+		if user.IsLocked {
+			return result
+		}
+
+		result.Action = ProvisionDeactivated
+		if !options.DryRun {
+			if err := LockUser(user.UIIdentityID); err != nil {
+				return failed(err)
+			}
+		}
+
+		return result
+	}
+
+	info := user.BasicInfo()
+	wanted := info
+	if entry.FirstName != "" {
+		wanted.FirstName = entry.FirstName
+	}
+	if entry.LastName != "" {
+		wanted.LastName = entry.LastName
+	}
+	if entry.Phone != "" {
+		wanted.Phone = entry.Phone
+	}
+	if entry.Country != "" {
+		wanted.Country = entry.Country
+	}
+
+	updateInfo := wanted != info
+	updateGrants := len(entry.AuthGrants) > 0 && !sameAuthGrants(user.AuthGrants, entry.AuthGrants)
+	if updateInfo || updateGrants || user.IsLocked {
+		result.Action = ProvisionUpdated
+	}
+	if options.DryRun {
+		return result
+	}
+
+	if updateInfo {
+		if err := UpdateUserInfo(user.UIIdentityID, wanted); err != nil {
+			return failed(err)
+		}
+	}
+	if updateGrants {
+		if err := UpdateUserAuthGrants(user.UIIdentityID, entry.AuthGrants); err != nil {
+			return failed(err)
+		}
+	}
+	if user.IsLocked {
+		if err := UnlockUser(user.UIIdentityID); err != nil {
+			return failed(err)
+		}
+	}
+
+	return result
+}
+
+// sameAuthGrants compares the top level group/role assignments, ignoring order
+func sameAuthGrants(a, b []AuthGrant) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	key := func(grants []AuthGrant) []string {
+		keys := make([]string, 0, len(grants))
+		for _, grant := range grants {
+			role := 0
+			if grant.RoleID != nil {
+				role = *grant.RoleID
+			}
+			keys = append(keys, fmt.Sprintf("%d:%d", grant.GroupID, role))
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	ka, kb := key(a), key(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+
+	return true
+}
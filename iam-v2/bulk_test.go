@@ -0,0 +1,146 @@
+package iam
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestParseRosterCSV(t *testing.T) {
+	roster, err := ParseRosterCSV(strings.NewReader(`email,firstName,lastName,state,authGrants
+jdoe@example.com,John,Doe,,12345:67|23456:89
+asmith@example.com,Alice,Smith,INACTIVE,
+`))
+	require.NoError(t, err)
+	require.Len(t, roster, 2)
+
+	assert.Equal(t, "jdoe@example.com", roster[0].Email)
+	assert.Equal(t, RosterStateActive, roster[0].State)
+	require.Len(t, roster[0].AuthGrants, 2)
+	assert.Equal(t, 23456, roster[0].AuthGrants[1].GroupID)
+	assert.Equal(t, 89, *roster[0].AuthGrants[1].RoleID)
+
+	assert.Equal(t, RosterStateInactive, roster[1].State)
+	assert.Empty(t, roster[1].AuthGrants)
+}
+
+func TestParseRosterCSV_Invalid(t *testing.T) {
+	_, err := ParseRosterCSV(strings.NewReader("firstName,lastName\nJohn,Doe\n"))
+	assert.Error(t, err)
+
+	_, err = ParseRosterCSV(strings.NewReader("email,authGrants\njdoe@example.com,12345\n"))
+	assert.Error(t, err)
+
+	_, err = ParseRosterCSV(strings.NewReader("email,state\njdoe@example.com,deleted\n"))
+	assert.Error(t, err)
+}
+
+func TestBulkProvision(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v2/user-admin/ui-identities").
+		MatchParam("authGrants", "true").
+		HeaderPresent("Authorization").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`[
+			{"uiIdentityId": "A-B-111", "firstName": "John", "lastName": "Doe", "email": "JDoe@example.com",
+			 "authGrants": [{"groupId": 12345, "roleId": 67}]},
+			{"uiIdentityId": "A-B-222", "firstName": "Alice", "lastName": "Smith", "email": "asmith@example.com",
+			 "authGrants": [{"groupId": 12345, "roleId": 67}]},
+			{"uiIdentityId": "A-B-333", "firstName": "Bob", "lastName": "Stale", "email": "bstale@example.com"}
+		]`)
+	gock.New(baseURL).
+		Put("/identity-management/v2/user-admin/ui-identities/A-B-111/auth-grants").
+		Reply(200)
+	gock.New(baseURL).
+		Post("/identity-management/v2/user-admin/ui-identities/A-B-222/lock").
+		Reply(204)
+	gock.New(baseURL).
+		Post("/identity-management/v2/user-admin/ui-identities").
+		MatchParam("sendEmail", "false").
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"uiIdentityId": "A-B-444", "firstName": "New", "lastName": "Hire", "email": "nhire@example.com"}`)
+	gock.New(baseURL).
+		Post("/identity-management/v2/user-admin/ui-identities/A-B-333/lock").
+		Reply(204)
+
+	Init(config)
+
+	roleID := 89
+	results, err := BulkProvision(Roster{
+		{Email: "jdoe@example.com", FirstName: "John", LastName: "Doe", State: RosterStateActive,
+			AuthGrants: []AuthGrant{{GroupID: 23456, RoleID: &roleID}}},
+		{Email: "asmith@example.com", FirstName: "Alice", LastName: "Smith", State: RosterStateInactive},
+		{Email: "nhire@example.com", FirstName: "New", LastName: "Hire", State: RosterStateActive},
+	}, ProvisionOptions{DeactivateMissing: true})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, ProvisionUpdated, results[0].Action)
+	assert.Equal(t, ProvisionDeactivated, results[1].Action)
+	assert.Equal(t, ProvisionCreated, results[2].Action)
+	assert.Equal(t, "A-B-444", results[2].UIIdentityID)
+	assert.Equal(t, ProvisionDeactivated, results[3].Action)
+	assert.Equal(t, "bstale@example.com", results[3].Email)
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+	assert.True(t, gock.IsDone())
+}
+
+func TestBulkProvision_KeepsMissingColumns(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v2/user-admin/ui-identities").
+		MatchParam("authGrants", "true").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`[
+			{"uiIdentityId": "A-B-111", "firstName": "John", "lastName": "Doe", "email": "jdoe@example.com",
+			 "authGrants": [{"groupId": 12345, "roleId": 67}]},
+			{"uiIdentityId": "A-B-222", "firstName": "Alice", "lastName": "Smith", "email": "asmith@example.com",
+			 "authGrants": [{"groupId": 12345, "roleId": 67}]}
+		]`)
+	gock.New(baseURL).
+		Put("/identity-management/v2/user-admin/ui-identities/A-B-111/basic-info").
+		JSON(map[string]string{"firstName": "John", "lastName": "Doe", "phone": "+1 617 555 0100"}).
+		Reply(204)
+
+	Init(config)
+
+	withoutGrants, err := ParseRosterCSV(strings.NewReader("email,phone\njdoe@example.com,+1 617 555 0100\n"))
+	require.NoError(t, err)
+	emptyGrants, err := ParseRosterCSV(strings.NewReader("email,firstName,authGrants\nasmith@example.com,Alice,\n"))
+	require.NoError(t, err)
+
+	results, err := BulkProvision(append(withoutGrants, emptyGrants...), ProvisionOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, ProvisionUpdated, results[0].Action)
+	assert.Equal(t, ProvisionUnchanged, results[1].Action)
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+	assert.True(t, gock.IsDone())
+}
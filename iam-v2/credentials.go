@@ -0,0 +1,165 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// AccessLevel is used to create an "enum" of possible APIAccess.AccessLevel values
+type AccessLevel string
+
+const (
+	// AccessReadOnly APIAccess.AccessLevel value READ-ONLY
+	AccessReadOnly AccessLevel = "READ-ONLY"
+	// AccessReadWrite APIAccess.AccessLevel value READ-WRITE
+	AccessReadWrite AccessLevel = "READ-WRITE"
+)
+
+// APIClient is an API client (credential) and the access granted to it,
+// AccessToken and BaseURL complete the client token and secret of its
+// credentials into an edgegrid.Config, see EdgegridConfig()
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#apiclient
+type APIClient struct {
+	ClientID          string       `json:"clientId"`
+	ClientName        string       `json:"clientName"`
+	ClientDescription string       `json:"clientDescription,omitempty"`
+	ClientType        string       `json:"clientType,omitempty"`
+	AuthorizedUsers   []string     `json:"authorizedUsers,omitempty"`
+	IsLocked          bool         `json:"isLocked,omitempty"`
+	CreatedDate       string       `json:"createdDate,omitempty"`
+	CreatedBy         string       `json:"createdBy,omitempty"`
+	AccessToken       string       `json:"accessToken,omitempty"`
+	BaseURL           string       `json:"baseURL,omitempty"`
+	APIAccess         APIAccess    `json:"apiAccess"`
+	GroupAccess       GroupAccess  `json:"groupAccess"`
+	Credentials       []Credential `json:"credentials,omitempty"`
+}
+
+// APIAccess lists the APIs an APIClient may call
+type APIAccess struct {
+	AllAccessibleAPIs bool       `json:"allAccessibleApis"`
+	APIs              []APIGrant `json:"apis"`
+}
+
+// APIGrant is a single API granted to an APIClient
+type APIGrant struct {
+	APIID       int         `json:"apiId"`
+	APIName     string      `json:"apiName"`
+	Endpoint    string      `json:"endPoint"`
+	AccessLevel AccessLevel `json:"accessLevel"`
+}
+
+// GroupAccess lists the groups and roles an APIClient acts with
+type GroupAccess struct {
+	CloneAuthorizedUserGroups bool        `json:"cloneAuthorizedUserGroups"`
+	Groups                    []AuthGrant `json:"groups"`
+}
+
+// Role is an IAM role
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#role
+type Role struct {
+	RoleID          int           `json:"roleId"`
+	RoleName        string        `json:"roleName"`
+	RoleDescription string        `json:"roleDescription,omitempty"`
+	RoleType        string        `json:"type,omitempty"`
+	GrantedRoles    []GrantedRole `json:"grantedRoles,omitempty"`
+}
+
+// GrantedRole is a permission bundle included in a Role
+type GrantedRole struct {
+	GrantedRoleID          int    `json:"grantedRoleId"`
+	GrantedRoleName        string `json:"grantedRoleName"`
+	GrantedRoleDescription string `json:"grantedRoleDescription,omitempty"`
+}
+
+// RoleSnapshot is a point in time copy of the roles used by a credential
+type RoleSnapshot struct {
+	TakenAt time.Time
+	Roles   map[int]*Role
+}
+
+// GetSelfAPIClient retrieves the API client whose credentials are in Config
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management/v3.html#getselfapiclient
+// Endpoint: GET /identity-management/v3/api-clients/self{?apiAccess,groupAccess}
+func GetSelfAPIClient() (*APIClient, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		"/identity-management/v3/api-clients/self?apiAccess=true&groupAccess=true",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := &APIClient{}
+	if err = doJSON(req, apiClient); err != nil {
+		return nil, err
+	}
+
+	return apiClient, nil
+}
+
+// GetRole retrieves a role and the roles granted by it
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getrole
+// Endpoint: GET /identity-management/v2/user-admin/roles/{roleId}{?grantedRoles}
+func GetRole(roleID int) (*Role, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/identity-management/v2/user-admin/roles/%d?grantedRoles=true", roleID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &Role{}
+	if err = doJSON(req, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// TakeRoleSnapshot retrieves every role referenced by the group grants of apiClient
+func TakeRoleSnapshot(apiClient *APIClient) (*RoleSnapshot, error) {
+	snapshot := &RoleSnapshot{TakenAt: time.Now(), Roles: map[int]*Role{}}
+
+	var walk func(grants []AuthGrant) error
+	walk = func(grants []AuthGrant) error {
+		for _, grant := range grants {
+			if grant.RoleID != nil {
+				if _, ok := snapshot.Roles[*grant.RoleID]; !ok {
+					role, err := GetRole(*grant.RoleID)
+					if err != nil {
+						return err
+					}
+					snapshot.Roles[*grant.RoleID] = role
+				}
+			}
+			if err := walk(grant.SubGroups); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(apiClient.GroupAccess.Groups); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// doJSON sends req and decodes the JSON response into out
+func doJSON(req *http.Request, out interface{}) error {
+	return client.DoJSONRequest(Config, req, out)
+}
@@ -0,0 +1,170 @@
+package iam
+
+import (
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// Group is a group of the account group hierarchy
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#group
+type Group struct {
+	GroupID       int     `json:"groupId"`
+	GroupName     string  `json:"groupName"`
+	ParentGroupID int     `json:"parentGroupId,omitempty"`
+	CreatedDate   string  `json:"createdDate,omitempty"`
+	CreatedBy     string  `json:"createdBy,omitempty"`
+	ModifiedDate  string  `json:"modifiedDate,omitempty"`
+	ModifiedBy    string  `json:"modifiedBy,omitempty"`
+	SubGroups     []Group `json:"subGroups,omitempty"`
+}
+
+// Find returns the group or subgroup with groupID, if any
+func (group *Group) Find(groupID int) (*Group, bool) {
+	if group.GroupID == groupID {
+		return group, true
+	}
+	for i := range group.SubGroups {
+		if found, ok := group.SubGroups[i].Find(groupID); ok {
+			return found, true
+		}
+	}
+
+	return nil, false
+}
+
+// ListGroups retrieves the group hierarchy, top level groups with their subgroups
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getgroups
+// Endpoint: GET /identity-management/v2/user-admin/groups{?actions}
+func ListGroups() ([]Group, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		"/identity-management/v2/user-admin/groups",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []Group
+	if err = doJSON(req, &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetGroup retrieves a group and its subgroups
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getgroup
+// Endpoint: GET /identity-management/v2/user-admin/groups/{groupId}
+func GetGroup(groupID int) (*Group, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/identity-management/v2/user-admin/groups/%d", groupID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &Group{}
+	if err = doJSON(req, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// CreateGroup creates a subgroup of parentGroupID
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#postgroup
+// Endpoint: POST /identity-management/v2/user-admin/groups/{parentGroupId}
+func CreateGroup(parentGroupID int, groupName string) (*Group, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/identity-management/v2/user-admin/groups/%d", parentGroupID),
+		struct {
+			GroupName string `json:"groupName"`
+		}{groupName},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &Group{}
+	if err = doJSON(req, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// RenameGroup changes the name of a group
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#putgroup
+// Endpoint: PUT /identity-management/v2/user-admin/groups/{groupId}
+func RenameGroup(groupID int, groupName string) (*Group, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/identity-management/v2/user-admin/groups/%d", groupID),
+		struct {
+			GroupName string `json:"groupName"`
+		}{groupName},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &Group{}
+	if err = doJSON(req, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// MoveGroup moves a group, with its subgroups, under destinationGroupID
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#postmovegroup
+// Endpoint: POST /identity-management/v2/user-admin/groups/move
+func MoveGroup(sourceGroupID int, destinationGroupID int) error {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		"/identity-management/v2/user-admin/groups/move",
+		struct {
+			SourceGroupID      int `json:"sourceGroupId"`
+			DestinationGroupID int `json:"destinationGroupId"`
+		}{sourceGroupID, destinationGroupID},
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// RemoveGroup deletes a group, it must have no subgroups, properties or users
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#deletegroup
+// Endpoint: DELETE /identity-management/v2/user-admin/groups/{groupId}
+func RemoveGroup(groupID int) error {
+	req, err := client.NewRequest(
+		Config,
+		"DELETE",
+		fmt.Sprintf("/identity-management/v2/user-admin/groups/%d", groupID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
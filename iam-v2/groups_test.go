@@ -0,0 +1,50 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGroupsAndRoles(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v2/user-admin/groups").
+		Reply(200).
+		JSON(`[{"groupId": 10, "groupName": "Top", "subGroups": [{"groupId": 11, "groupName": "Web", "parentGroupId": 10, "subGroups": [{"groupId": 12, "groupName": "Shop", "parentGroupId": 11}]}]}]`)
+	gock.New(baseURL).
+		Post("/identity-management/v2/user-admin/groups/12").
+		JSON(map[string]string{"groupName": "Checkout"}).
+		Reply(201).
+		JSON(`{"groupId": 13, "groupName": "Checkout", "parentGroupId": 12}`)
+	gock.New(baseURL).
+		Post("/identity-management/v2/user-admin/roles").
+		JSON(map[string]interface{}{"roleName": "Purge only", "roleDescription": "Fast purge", "grantedRoles": []map[string]int{{"grantedRoleId": 7}}}).
+		Reply(201).
+		JSON(`{"roleId": 500, "roleName": "Purge only", "type": "custom", "grantedRoles": [{"grantedRoleId": 7, "grantedRoleName": "CCU"}]}`)
+
+	Init(config)
+
+	groups, err := ListGroups()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	shop, ok := groups[0].Find(12)
+	require.True(t, ok)
+	assert.Equal(t, "Shop", shop.GroupName)
+
+	group, err := CreateGroup(shop.GroupID, "Checkout")
+	require.NoError(t, err)
+	assert.Equal(t, 13, group.GroupID)
+
+	role, err := CreateRole(&Role{
+		RoleName:        "Purge only",
+		RoleDescription: "Fast purge",
+		GrantedRoles:    []GrantedRole{{GrantedRoleID: 7, GrantedRoleName: "CCU"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 500, role.RoleID)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,159 @@
+package iam
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Call is an endpoint called through a CallTracker
+type Call struct {
+	Method string
+	Path   string
+	Count  int
+}
+
+// CallTracker is an http.RoundTripper middleware recording the endpoints called
+//
+// Install it on the session client to track every API call made by the library:
+//
+//	tracker := iam.NewCallTracker(client.Client.Transport)
+//	client.Client = &http.Client{Transport: tracker}
+type CallTracker struct {
+	Next http.RoundTripper
+
+	mu    sync.Mutex
+	calls map[Call]int
+}
+
+// NewCallTracker creates a CallTracker sending requests with next, or
+// http.DefaultTransport when next is nil
+func NewCallTracker(next http.RoundTripper) *CallTracker {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &CallTracker{Next: next, calls: map[Call]int{}}
+}
+
+// RoundTrip records req and sends it with the next RoundTripper
+func (tracker *CallTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	tracker.mu.Lock()
+	tracker.calls[Call{Method: req.Method, Path: req.URL.Path}]++
+	tracker.mu.Unlock()
+
+	return tracker.Next.RoundTrip(req)
+}
+
+// Calls returns the endpoints called so far, sorted by path and method
+func (tracker *CallTracker) Calls() []Call {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	calls := make([]Call, 0, len(tracker.calls))
+	for call, count := range tracker.calls {
+		call.Count = count
+		calls = append(calls, call)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].Path != calls[j].Path {
+			return calls[i].Path < calls[j].Path
+		}
+		return calls[i].Method < calls[j].Method
+	})
+
+	return calls
+}
+
+// Reset forgets all recorded calls
+func (tracker *CallTracker) Reset() {
+	tracker.mu.Lock()
+	tracker.calls = map[Call]int{}
+	tracker.mu.Unlock()
+}
+
+// PrivilegeReport lists the privileges of a credential that were not needed
+type PrivilegeReport struct {
+	ClientID string
+	// UnusedAPIs were granted but never called
+	UnusedAPIs []APIGrant
+	// ReadOnlyCandidates have READ-WRITE access but were only read from
+	ReadOnlyCandidates []APIGrant
+	// UngrantedCalls match no granted API, e.g. because all APIs are accessible
+	UngrantedCalls []Call
+	// Roles is the snapshot of the roles the credential acts with
+	Roles *RoleSnapshot
+}
+
+// AnalyzePrivileges compares the APIs granted to apiClient with the calls made
+func AnalyzePrivileges(apiClient *APIClient, roles *RoleSnapshot, calls []Call) *PrivilegeReport {
+	report := &PrivilegeReport{ClientID: apiClient.ClientID, Roles: roles}
+
+	used := make([]bool, len(apiClient.APIAccess.APIs))
+	written := make([]bool, len(apiClient.APIAccess.APIs))
+	for _, call := range calls {
+		granted := false
+		for i, api := range apiClient.APIAccess.APIs {
+			if !grantCovers(api, call.Path) {
+				continue
+			}
+			granted = true
+			used[i] = true
+			if !isReadMethod(call.Method) {
+				written[i] = true
+			}
+		}
+		if !granted {
+			report.UngrantedCalls = append(report.UngrantedCalls, call)
+		}
+	}
+
+	for i, api := range apiClient.APIAccess.APIs {
+		switch {
+		case !used[i]:
+			report.UnusedAPIs = append(report.UnusedAPIs, api)
+		case !written[i] && api.AccessLevel == AccessReadWrite:
+			report.ReadOnlyCandidates = append(report.ReadOnlyCandidates, api)
+		}
+	}
+
+	return report
+}
+
+// AnalyzeSelfPrivileges analyzes the credentials in Config against the calls
+// recorded by tracker so far
+func AnalyzeSelfPrivileges(tracker *CallTracker) (*PrivilegeReport, error) {
+	calls := tracker.Calls()
+
+	apiClient, err := GetSelfAPIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := TakeRoleSnapshot(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return AnalyzePrivileges(apiClient, roles, calls), nil
+}
+
+// grantCovers reports whether path belongs to the API of grant, e.g. "/papi" covers "/papi/v1/groups"
+func grantCovers(grant APIGrant, path string) bool {
+	endpoint := "/" + strings.Trim(grant.Endpoint, "/")
+	if endpoint == "/" {
+		return false
+	}
+
+	return path == endpoint || strings.HasPrefix(path, endpoint+"/")
+}
+
+func isReadMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+
+	return false
+}
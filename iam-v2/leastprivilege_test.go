@@ -0,0 +1,88 @@
+package iam
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type stubTransport struct{}
+
+func (stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: 200, Request: req}, nil
+}
+
+func TestCallTracker(t *testing.T) {
+	tracker := NewCallTracker(stubTransport{})
+	httpClient := &http.Client{Transport: tracker}
+
+	for _, call := range []struct{ method, url string }{
+		{"GET", "https://example.net/papi/v1/groups?accountSwitchKey=x"},
+		{"GET", "https://example.net/papi/v1/groups"},
+		{"POST", "https://example.net/ccu/v3/invalidate/url/staging"},
+	} {
+		req, _ := http.NewRequest(call.method, call.url, nil)
+		_, err := httpClient.Do(req)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []Call{
+		{Method: "POST", Path: "/ccu/v3/invalidate/url/staging", Count: 1},
+		{Method: "GET", Path: "/papi/v1/groups", Count: 2},
+	}, tracker.Calls())
+
+	tracker.Reset()
+	assert.Empty(t, tracker.Calls())
+}
+
+func TestAnalyzeSelfPrivileges(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/identity-management/v3/api-clients/self").
+		Reply(200).
+		JSON(`{
+			"clientId": "abcd1234",
+			"apiAccess": {"apis": [
+				{"apiId": 1, "apiName": "Property Manager (PAPI)", "endPoint": "/papi", "accessLevel": "READ-WRITE"},
+				{"apiId": 2, "apiName": "CCU APIs", "endPoint": "/ccu", "accessLevel": "READ-WRITE"},
+				{"apiId": 3, "apiName": "Edge DNS", "endPoint": "/config-dns", "accessLevel": "READ-ONLY"}
+			]},
+			"groupAccess": {"groups": [{"groupId": 10, "roleId": 5, "subGroups": [{"groupId": 11, "roleId": 5}]}]}
+		}`)
+	gock.New(baseURL).
+		Get("/identity-management/v2/user-admin/roles/5").
+		Reply(200).
+		JSON(`{"roleId": 5, "roleName": "Editor", "grantedRoles": [{"grantedRoleId": 1, "grantedRoleName": "Property Manager - Edit"}]}`)
+
+	Init(config)
+
+	tracker := NewCallTracker(stubTransport{})
+	for _, call := range []struct{ method, url string }{
+		{"GET", "https://example.net/papi/v1/groups"},
+		{"POST", "https://example.net/ccu/v3/invalidate/url/staging"},
+		{"GET", "https://example.net/siem/v1/configs/1"},
+	} {
+		req, _ := http.NewRequest(call.method, call.url, nil)
+		_, err := tracker.RoundTrip(req)
+		require.NoError(t, err)
+	}
+
+	report, err := AnalyzeSelfPrivileges(tracker)
+	require.NoError(t, err)
+	assert.Equal(t, "abcd1234", report.ClientID)
+
+	require.Len(t, report.UnusedAPIs, 1)
+	assert.Equal(t, "/config-dns", report.UnusedAPIs[0].Endpoint)
+	require.Len(t, report.ReadOnlyCandidates, 1)
+	assert.Equal(t, "/papi", report.ReadOnlyCandidates[0].Endpoint)
+	require.Len(t, report.UngrantedCalls, 1)
+	assert.Equal(t, "/siem/v1/configs/1", report.UngrantedCalls[0].Path)
+
+	require.Len(t, report.Roles.Roles, 1)
+	assert.Equal(t, "Editor", report.Roles.Roles[5].RoleName)
+	assert.True(t, gock.IsDone())
+}
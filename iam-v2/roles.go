@@ -0,0 +1,153 @@
+package iam
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// ListRolesQueryArgs are the optional filters for ListRoles
+type ListRolesQueryArgs struct {
+	// GroupID lists the roles granted in a group
+	GroupID      int
+	GrantedRoles bool
+	// IgnoreContext lists all roles, not only those the credential can grant
+	IgnoreContext bool
+}
+
+// ListRoles retrieves the roles
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getroles
+// Endpoint: GET /identity-management/v2/user-admin/roles{?groupId,grantedRoles,ignoreContext}
+func ListRoles(queryArgs ListRolesQueryArgs) ([]*Role, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		"/identity-management/v2/user-admin/roles",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("grantedRoles", strconv.FormatBool(queryArgs.GrantedRoles))
+	q.Add("ignoreContext", strconv.FormatBool(queryArgs.IgnoreContext))
+	if queryArgs.GroupID != 0 {
+		q.Add("groupId", strconv.Itoa(queryArgs.GroupID))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var roles []*Role
+	if err = doJSON(req, &roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// ListGrantableRoles retrieves the granted roles (permission bundles) a role can be created with
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getgrantableroles
+// Endpoint: GET /identity-management/v2/user-admin/roles/grantable-roles
+func ListGrantableRoles() ([]GrantedRole, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		"/identity-management/v2/user-admin/roles/grantable-roles",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var grantedRoles []GrantedRole
+	if err = doJSON(req, &grantedRoles); err != nil {
+		return nil, err
+	}
+
+	return grantedRoles, nil
+}
+
+// CreateRole creates a custom role from role.GrantedRoles
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#postroles
+// Endpoint: POST /identity-management/v2/user-admin/roles
+func CreateRole(role *Role) (*Role, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		"/identity-management/v2/user-admin/roles",
+		roleRequest(role),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &Role{}
+	if err = doJSON(req, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateRole updates the name, description and granted roles of a custom role
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#putrole
+// Endpoint: PUT /identity-management/v2/user-admin/roles/{roleId}
+func UpdateRole(role *Role) (*Role, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/identity-management/v2/user-admin/roles/%d", role.RoleID),
+		roleRequest(role),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &Role{}
+	if err = doJSON(req, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveRole deletes a custom role, it must not be assigned to any user
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#deleterole
+// Endpoint: DELETE /identity-management/v2/user-admin/roles/{roleId}
+func RemoveRole(roleID int) error {
+	req, err := client.NewRequest(
+		Config,
+		"DELETE",
+		fmt.Sprintf("/identity-management/v2/user-admin/roles/%d", roleID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// roleRequest is the body of role create and update requests, granted roles are sent by ID only
+func roleRequest(role *Role) interface{} {
+	type grantedRoleID struct {
+		GrantedRoleID int `json:"grantedRoleId"`
+	}
+
+	body := struct {
+		RoleName        string          `json:"roleName"`
+		RoleDescription string          `json:"roleDescription"`
+		GrantedRoles    []grantedRoleID `json:"grantedRoles"`
+	}{RoleName: role.RoleName, RoleDescription: role.RoleDescription}
+	for _, grantedRole := range role.GrantedRoles {
+		body.GrantedRoles = append(body.GrantedRoles, grantedRoleID{grantedRole.GrantedRoleID})
+	}
+
+	return body
+}
@@ -0,0 +1,284 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// User represents a Control Center user (ui-identity)
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#user
+type User struct {
+	UIIdentityID      string      `json:"uiIdentityId,omitempty"`
+	UIUserName        string      `json:"uiUserName,omitempty"`
+	FirstName         string      `json:"firstName"`
+	LastName          string      `json:"lastName"`
+	Email             string      `json:"email"`
+	Phone             string      `json:"phone,omitempty"`
+	Country           string      `json:"country,omitempty"`
+	TimeZone          string      `json:"timeZone,omitempty"`
+	ContactType       string      `json:"contactType,omitempty"`
+	PreferredLanguage string      `json:"preferredLanguage,omitempty"`
+	SessionTimeOut    *int        `json:"sessionTimeOut,omitempty"`
+	IsLocked          bool        `json:"isLocked,omitempty"`
+	LastLoginDate     string      `json:"lastLoginDate,omitempty"`
+	TFAEnabled        bool        `json:"tfaEnabled,omitempty"`
+	TFAConfigured     bool        `json:"tfaConfigured,omitempty"`
+	AuthGrants        []AuthGrant `json:"authGrants,omitempty"`
+}
+
+// AuthGrant assigns a role to a user within a group
+type AuthGrant struct {
+	GroupID   int         `json:"groupId"`
+	GroupName string      `json:"groupName,omitempty"`
+	IsBlocked bool        `json:"isBlocked,omitempty"`
+	RoleID    *int        `json:"roleId,omitempty"`
+	RoleName  string      `json:"roleName,omitempty"`
+	SubGroups []AuthGrant `json:"subGroups,omitempty"`
+}
+
+// UserBasicInfo is the subset of User fields accepted by UpdateUserInfo
+type UserBasicInfo struct {
+	FirstName         string `json:"firstName"`
+	LastName          string `json:"lastName"`
+	Phone             string `json:"phone,omitempty"`
+	Country           string `json:"country,omitempty"`
+	TimeZone          string `json:"timeZone,omitempty"`
+	ContactType       string `json:"contactType,omitempty"`
+	PreferredLanguage string `json:"preferredLanguage,omitempty"`
+	SessionTimeOut    *int   `json:"sessionTimeOut,omitempty"`
+}
+
+// ListUsersQueryArgs are the optional filters for ListUsers
+type ListUsersQueryArgs struct {
+	GroupID    int
+	AuthGrants bool
+}
+
+// BasicInfo returns the updatable subset of the user
+func (user *User) BasicInfo() UserBasicInfo {
+	return UserBasicInfo{
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		Phone:             user.Phone,
+		Country:           user.Country,
+		TimeZone:          user.TimeZone,
+		ContactType:       user.ContactType,
+		PreferredLanguage: user.PreferredLanguage,
+		SessionTimeOut:    user.SessionTimeOut,
+	}
+}
+
+// ListUsers retrieves all users, optionally including their auth grants
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getuiidentities
+// Endpoint: GET /identity-management/v2/user-admin/ui-identities{?authGrants,groupId}
+func ListUsers(queryArgs ListUsersQueryArgs) ([]*User, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		"/identity-management/v2/user-admin/ui-identities",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("authGrants", strconv.FormatBool(queryArgs.AuthGrants))
+	if queryArgs.GroupID != 0 {
+		q.Add("groupId", strconv.Itoa(queryArgs.GroupID))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	var users []*User
+	if err = client.BodyJSON(res, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetUser retrieves a single user
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#getuiidentity
+// Endpoint: GET /identity-management/v2/user-admin/ui-identities/{uiIdentityId}{?authGrants}
+func GetUser(uiIdentityID string) (*User, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities/%s?authGrants=true", uiIdentityID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	user := &User{}
+	if err = client.BodyJSON(res, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CreateUser creates a new user, optionally sending the welcome email
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#postuiidentity
+// Endpoint: POST /identity-management/v2/user-admin/ui-identities{?sendEmail}
+func CreateUser(user *User, sendEmail bool) (*User, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities?sendEmail=%t", sendEmail),
+		user,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	created := &User{}
+	if err = client.BodyJSON(res, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateUserInfo updates a user's basic information
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#putuiidentitybasicinfo
+// Endpoint: PUT /identity-management/v2/user-admin/ui-identities/{uiIdentityId}/basic-info
+func UpdateUserInfo(uiIdentityID string, info UserBasicInfo) error {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities/%s/basic-info", uiIdentityID),
+		info,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// UpdateUserAuthGrants replaces a user's role assignments
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#putuiidentityauthgrants
+// Endpoint: PUT /identity-management/v2/user-admin/ui-identities/{uiIdentityId}/auth-grants
+func UpdateUserAuthGrants(uiIdentityID string, authGrants []AuthGrant) error {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities/%s/auth-grants", uiIdentityID),
+		authGrants,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// LockUser locks a user's account, preventing them from logging in
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#postlockuser
+// Endpoint: POST /identity-management/v2/user-admin/ui-identities/{uiIdentityId}/lock
+func LockUser(uiIdentityID string) error {
+	req, err := client.NewRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities/%s/lock", uiIdentityID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// UnlockUser releases the lock on a user's account
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#postunlockuser
+// Endpoint: POST /identity-management/v2/user-admin/ui-identities/{uiIdentityId}/unlock
+func UnlockUser(uiIdentityID string) error {
+	req, err := client.NewRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities/%s/unlock", uiIdentityID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// RemoveUser deletes a user
+//
+// API Docs: https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#deleteuiidentity
+// Endpoint: DELETE /identity-management/v2/user-admin/ui-identities/{uiIdentityId}
+func RemoveUser(uiIdentityID string) error {
+	req, err := client.NewRequest(
+		Config,
+		"DELETE",
+		fmt.Sprintf("/identity-management/v2/user-admin/ui-identities/%s", uiIdentityID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(req)
+}
+
+// doNoContent sends a request whose response body is not needed, closing it
+func doNoContent(req *http.Request) error {
+	return client.DoJSONRequest(Config, req, nil)
+}
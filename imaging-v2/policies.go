@@ -0,0 +1,208 @@
+package imaging
+
+import (
+	"fmt"
+)
+
+// NetworkValue is used to create an "enum" of possible policy networks
+type NetworkValue string
+
+const (
+	// NetworkStaging policy network value staging
+	NetworkStaging NetworkValue = "staging"
+	// NetworkProduction policy network value production
+	NetworkProduction NetworkValue = "production"
+)
+
+// DefaultPolicyID is the ID of the policy applied to the requests that name no policy
+const DefaultPolicyID = ".auto"
+
+// OutputFormat is used to create an "enum" of possible OutputImage format values
+type OutputFormat string
+
+const (
+	// OutputFormatAVIF OutputImage format value avif
+	OutputFormatAVIF OutputFormat = "avif"
+	// OutputFormatGIF OutputImage format value gif
+	OutputFormatGIF OutputFormat = "gif"
+	// OutputFormatJP2 OutputImage format value jp2
+	OutputFormatJP2 OutputFormat = "jp2"
+	// OutputFormatJPEG OutputImage format value jpeg
+	OutputFormatJPEG OutputFormat = "jpeg"
+	// OutputFormatJXR OutputImage format value jxr
+	OutputFormatJXR OutputFormat = "jxr"
+	// OutputFormatPNG OutputImage format value png
+	OutputFormatPNG OutputFormat = "png"
+	// OutputFormatWebP OutputImage format value webp
+	OutputFormatWebP OutputFormat = "webp"
+)
+
+// PerceptualQuality is used to create an "enum" of possible OutputImage.PerceptualQuality values
+type PerceptualQuality string
+
+const (
+	// PerceptualQualityHigh OutputImage.PerceptualQuality value high
+	PerceptualQualityHigh PerceptualQuality = "high"
+	// PerceptualQualityMediumHigh OutputImage.PerceptualQuality value mediumHigh
+	PerceptualQualityMediumHigh PerceptualQuality = "mediumHigh"
+	// PerceptualQualityMedium OutputImage.PerceptualQuality value medium
+	PerceptualQualityMedium PerceptualQuality = "medium"
+	// PerceptualQualityMediumLow OutputImage.PerceptualQuality value mediumLow
+	PerceptualQualityMediumLow PerceptualQuality = "mediumLow"
+	// PerceptualQualityLow OutputImage.PerceptualQuality value low
+	PerceptualQualityLow PerceptualQuality = "low"
+)
+
+// Policy is an image policy: the transformations applied to the images, the
+// widths they are resized to and the formats they are delivered in
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#policyoutputimage
+type Policy struct {
+	ID              string       `json:"id,omitempty"`
+	Version         int          `json:"version,omitempty"`
+	PreviousVersion int          `json:"previousVersion,omitempty"`
+	DateCreated     string       `json:"dateCreated,omitempty"`
+	User            string       `json:"user,omitempty"`
+	RolloutInfo     *RolloutInfo `json:"rolloutInfo,omitempty"`
+	// RolloutDuration is the number of seconds, 3600 to 604800, a new version
+	// takes to replace the previous one
+	RolloutDuration int             `json:"rolloutDuration,omitempty"`
+	Breakpoints     *Breakpoints    `json:"breakpoints,omitempty"`
+	Output          *OutputImage    `json:"output,omitempty"`
+	Hosts           []string        `json:"hosts,omitempty"`
+	Variables       []Variable      `json:"variables,omitempty"`
+	Transformations Transformations `json:"transformations,omitempty"`
+	// PostBreakpointTransformations are applied after the image is resized to a breakpoint width
+	PostBreakpointTransformations Transformations `json:"postBreakpointTransformations,omitempty"`
+}
+
+// RolloutInfo is the progress of the rollout of a policy version, Unix timestamps
+type RolloutInfo struct {
+	StartTime       int64 `json:"startTime"`
+	EndTime         int64 `json:"endTime"`
+	RolloutDuration int   `json:"rolloutDuration"`
+}
+
+// Breakpoints are the widths images are resized to, depending on the device
+type Breakpoints struct {
+	Widths []int `json:"widths,omitempty"`
+}
+
+// OutputImage are the quality and formats of the images delivered
+type OutputImage struct {
+	PerceptualQuality PerceptualQuality `json:"perceptualQuality,omitempty"`
+	// Quality is 1 to 100, used instead of PerceptualQuality
+	Quality         *Number        `json:"quality,omitempty"`
+	AdaptiveQuality int            `json:"adaptiveQuality,omitempty"`
+	AllowedFormats  []OutputFormat `json:"allowedFormats,omitempty"`
+	ForcedFormats   []OutputFormat `json:"forcedFormats,omitempty"`
+}
+
+// Variable is a policy variable, whose value a request can override with a
+// query parameter and that Number values can reference
+type Variable struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	DefaultValue string `json:"defaultValue"`
+}
+
+// PolicyResponse is the outcome of UpsertPolicy and DeletePolicy
+type PolicyResponse struct {
+	ID                 string `json:"id"`
+	Description        string `json:"description"`
+	OperationPerformed string `json:"operationPerformed"`
+}
+
+// ListPolicies lists the policies of a policy set on network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#getpolicies
+// Endpoint: GET /imaging/v2/network/{network}/policies
+func ListPolicies(network NetworkValue, contractID, policySetID string) ([]Policy, error) {
+	response := struct {
+		Items []Policy `json:"items"`
+	}{}
+	path := fmt.Sprintf("/imaging/v2/network/%s/policies", network)
+	if err := doJSON("GET", path, policyHeaders(contractID, policySetID), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Items, nil
+}
+
+// GetPolicy retrieves the latest version of a policy on network
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#getpolicy
+// Endpoint: GET /imaging/v2/network/{network}/policies/{policyId}
+func GetPolicy(network NetworkValue, contractID, policySetID, policyID string) (*Policy, error) {
+	policy := &Policy{}
+	if err := doJSON("GET", policyPath(network, policyID), policyHeaders(contractID, policySetID), nil, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// UpsertPolicy creates or updates the policy policy.ID on network, the new
+// version rolls out over policy.RolloutDuration
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#putpolicy
+// Endpoint: PUT /imaging/v2/network/{network}/policies/{policyId}
+func UpsertPolicy(network NetworkValue, contractID, policySetID string, policy *Policy) (*PolicyResponse, error) {
+	if policy.ID == "" {
+		return nil, fmt.Errorf("policy has no ID")
+	}
+
+	response := &PolicyResponse{}
+	if err := doJSON("PUT", policyPath(network, policy.ID), policyHeaders(contractID, policySetID), policy.body(), response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeletePolicy deletes a policy on network, the default policy cannot be deleted
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#deletepolicy
+// Endpoint: DELETE /imaging/v2/network/{network}/policies/{policyId}
+func DeletePolicy(network NetworkValue, contractID, policySetID, policyID string) (*PolicyResponse, error) {
+	response := &PolicyResponse{}
+	if err := doJSON("DELETE", policyPath(network, policyID), policyHeaders(contractID, policySetID), nil, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// PublishPolicy copies the latest staging version of a policy to production
+func PublishPolicy(contractID, policySetID, policyID string) (*PolicyResponse, error) {
+	policy, err := GetPolicy(NetworkStaging, contractID, policySetID, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("staging policy %s: %w", policyID, err)
+	}
+
+	return UpsertPolicy(NetworkProduction, contractID, policySetID, policy)
+}
+
+func policyPath(network NetworkValue, policyID string) string {
+	return fmt.Sprintf("/imaging/v2/network/%s/policies/%s", network, policyID)
+}
+
+func policyHeaders(contractID, policySetID string) map[string]string {
+	headers := contractHeader(contractID)
+	headers["Policy-Set"] = policySetID
+
+	return headers
+}
+
+// body is policy without the read-only fields
+func (policy *Policy) body() *Policy {
+	return &Policy{
+		RolloutDuration:               policy.RolloutDuration,
+		Breakpoints:                   policy.Breakpoints,
+		Output:                        policy.Output,
+		Hosts:                         policy.Hosts,
+		Variables:                     policy.Variables,
+		Transformations:               policy.Transformations,
+		PostBreakpointTransformations: policy.PostBreakpointTransformations,
+	}
+}
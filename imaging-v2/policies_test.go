@@ -0,0 +1,149 @@
+package imaging
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const policyJSON = `{
+	"id": "thumbnail",
+	"version": 3,
+	"previousVersion": 2,
+	"user": "jsmith",
+	"dateCreated": "2021-01-11 10:21:33+0000",
+	"rolloutInfo": {"startTime": 1610360493, "endTime": 1610360893, "rolloutDuration": 400},
+	"breakpoints": {"widths": [320, 640, 1024]},
+	"output": {"perceptualQuality": "mediumHigh", "allowedFormats": ["webp", "jpeg"]},
+	"variables": [{"name": "width", "type": "number", "defaultValue": "200"}],
+	"transformations": [
+		{"transformation": "Resize", "width": {"var": "width"}, "height": 200, "aspect": "fit"},
+		{"transformation": "Crop", "width": 150, "height": 150, "gravity": "Center"},
+		{"transformation": "Composite", "image": {"url": "https://example.com/logo.png"}, "placement": "Over"}
+	],
+	"postBreakpointTransformations": [{"transformation": "Grayscale", "type": "Rec709"}]
+}`
+
+func TestPolicyJSON(t *testing.T) {
+	policy := &Policy{}
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), policy))
+
+	require.Len(t, policy.Transformations, 3)
+	resize, ok := policy.Transformations[0].(*Resize)
+	require.True(t, ok)
+	assert.Equal(t, &Number{Var: "width"}, resize.Width)
+	assert.Equal(t, &Number{Value: 200}, resize.Height)
+	crop, ok := policy.Transformations[1].(*Crop)
+	require.True(t, ok)
+	assert.Equal(t, "Center", crop.Gravity)
+	unknown, ok := policy.Transformations[2].(*UnknownTransformation)
+	require.True(t, ok)
+	assert.Equal(t, "Composite", unknown.Type)
+	assert.IsType(t, &Grayscale{}, policy.PostBreakpointTransformations[0])
+	assert.Equal(t, []OutputFormat{OutputFormatWebP, OutputFormatJPEG}, policy.Output.AllowedFormats)
+
+	body, err := json.Marshal(policy.body())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"breakpoints": {"widths": [320, 640, 1024]},
+		"output": {"perceptualQuality": "mediumHigh", "allowedFormats": ["webp", "jpeg"]},
+		"variables": [{"name": "width", "type": "number", "defaultValue": "200"}],
+		"transformations": [
+			{"transformation": "Resize", "width": {"var": "width"}, "height": 200, "aspect": "fit"},
+			{"transformation": "Crop", "width": 150, "height": 150, "gravity": "Center"},
+			{"transformation": "Composite", "image": {"url": "https://example.com/logo.png"}, "placement": "Over"}
+		],
+		"postBreakpointTransformations": [{"transformation": "Grayscale", "type": "Rec709"}]
+	}`, string(body))
+}
+
+func TestTransformationsMarshalJSON(t *testing.T) {
+	body, err := json.Marshal(Transformations{
+		&Resize{Width: NumberValue(300)},
+		&Rotate{Degrees: NumberValue(90)},
+		&Blur{Sigma: NumberVar("blur")},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"transformation": "Resize", "width": 300},
+		{"transformation": "Rotate", "degrees": 90},
+		{"transformation": "Blur", "sigma": {"var": "blur"}}
+	]`, string(body))
+
+	var transformations Transformations
+	err = json.Unmarshal([]byte(`[{"width": 300}]`), &transformations)
+	assert.EqualError(t, err, "transformation 0: no transformation type")
+}
+
+func TestUpsertPolicy(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/imaging/v2/network/staging/policies/thumbnail").
+		MatchHeader("Contract", "^3-WNKXX1$").
+		MatchHeader("Policy-Set", "^570f9090$").
+		JSON(map[string]interface{}{
+			"rolloutDuration": 3600,
+			"breakpoints":     map[string]interface{}{"widths": []int{320}},
+		}).
+		Reply(200).
+		JSON(`{"id": "thumbnail", "description": "Policy thumbnail updated.", "operationPerformed": "UPDATED"}`)
+
+	Init(config)
+
+	response, err := UpsertPolicy(NetworkStaging, "3-WNKXX1", "570f9090", &Policy{
+		ID:              "thumbnail",
+		Version:         2,
+		RolloutDuration: 3600,
+		Breakpoints:     &Breakpoints{Widths: []int{320}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATED", response.OperationPerformed)
+	assert.True(t, gock.IsDone())
+
+	_, err = UpsertPolicy(NetworkStaging, "3-WNKXX1", "570f9090", &Policy{})
+	assert.EqualError(t, err, "policy has no ID")
+}
+
+func TestPublishPolicy(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/imaging/v2/network/staging/policies/thumbnail").
+		MatchHeader("Policy-Set", "^570f9090$").
+		Reply(200).
+		JSON(policyJSON)
+	gock.New(baseURL).
+		Put("/imaging/v2/network/production/policies/thumbnail").
+		MatchHeader("Policy-Set", "^570f9090$").
+		Reply(200).
+		JSON(`{"id": "thumbnail", "description": "Policy thumbnail updated.", "operationPerformed": "UPDATED"}`)
+
+	Init(config)
+
+	response, err := PublishPolicy("3-WNKXX1", "570f9090", "thumbnail")
+	require.NoError(t, err)
+	assert.Equal(t, "thumbnail", response.ID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestListPolicies(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/imaging/v2/network/production/policies").
+		MatchHeader("Policy-Set", "^570f9090$").
+		Reply(200).
+		JSON(`{"itemKind": "POLICY", "items": [{"id": ".auto", "version": 1}, ` + policyJSON + `], "totalItems": 2}`)
+
+	Init(config)
+
+	policies, err := ListPolicies(NetworkProduction, "3-WNKXX1", "570f9090")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, DefaultPolicyID, policies[0].ID)
+	assert.Equal(t, 3, policies[1].Version)
+}
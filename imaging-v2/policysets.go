@@ -0,0 +1,128 @@
+package imaging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Region is used to create an "enum" of possible PolicySet.Region values
+type Region string
+
+const (
+	// RegionUS PolicySet.Region value US
+	RegionUS Region = "US"
+	// RegionEMEA PolicySet.Region value EMEA
+	RegionEMEA Region = "EMEA"
+	// RegionAsia PolicySet.Region value ASIA
+	RegionAsia Region = "ASIA"
+	// RegionAustralia PolicySet.Region value AUSTRALIA
+	RegionAustralia Region = "AUSTRALIA"
+	// RegionJapan PolicySet.Region value JAPAN
+	RegionJapan Region = "JAPAN"
+	// RegionChina PolicySet.Region value CHINA
+	RegionChina Region = "CHINA"
+)
+
+// MediaType is used to create an "enum" of possible PolicySet.Type values
+type MediaType string
+
+const (
+	// TypeImage PolicySet.Type value IMAGE
+	TypeImage MediaType = "IMAGE"
+	// TypeVideo PolicySet.Type value VIDEO
+	TypeVideo MediaType = "VIDEO"
+)
+
+// PolicySet is a set of image or video policies, used by the properties
+// whose Image & Video Manager behavior references it
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#policyset
+type PolicySet struct {
+	ID           string    `json:"id,omitempty"`
+	Name         string    `json:"name"`
+	Region       Region    `json:"region"`
+	Type         MediaType `json:"type"`
+	User         string    `json:"user,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Properties   []string  `json:"properties,omitempty"`
+}
+
+// ListPolicySets lists the policy sets of a contract
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#getpolicysets
+// Endpoint: GET /imaging/v2/policysets
+func ListPolicySets(contractID string) ([]PolicySet, error) {
+	var sets []PolicySet
+	if err := doJSON("GET", "/imaging/v2/policysets", contractHeader(contractID), nil, &sets); err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+// GetPolicySet retrieves a policy set
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#getpolicyset
+// Endpoint: GET /imaging/v2/policysets/{policySetId}
+func GetPolicySet(contractID, policySetID string) (*PolicySet, error) {
+	set := &PolicySet{}
+	if err := doJSON("GET", policySetPath(policySetID), contractHeader(contractID), nil, set); err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+// CreatePolicySet creates a policy set, with a default policy on both networks
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#postpolicysets
+// Endpoint: POST /imaging/v2/policysets
+func CreatePolicySet(contractID string, set *PolicySet) (*PolicySet, error) {
+	body := struct {
+		Name   string    `json:"name"`
+		Region Region    `json:"region"`
+		Type   MediaType `json:"type"`
+	}{set.Name, set.Region, set.Type}
+
+	created := &PolicySet{}
+	if err := doJSON("POST", "/imaging/v2/policysets", contractHeader(contractID), body, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdatePolicySet updates the name and region of a policy set
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#putpolicyset
+// Endpoint: PUT /imaging/v2/policysets/{policySetId}
+func UpdatePolicySet(contractID string, set *PolicySet) (*PolicySet, error) {
+	body := struct {
+		Name   string `json:"name"`
+		Region Region `json:"region"`
+	}{set.Name, set.Region}
+
+	updated := &PolicySet{}
+	if err := doJSON("PUT", policySetPath(set.ID), contractHeader(contractID), body, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeletePolicySet deletes a policy set no property uses
+//
+// API Docs: https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#deletepolicyset
+// Endpoint: DELETE /imaging/v2/policysets/{policySetId}
+func DeletePolicySet(contractID, policySetID string) error {
+	return doJSON("DELETE", policySetPath(policySetID), contractHeader(contractID), nil, nil)
+}
+
+func policySetPath(policySetID string) string {
+	return fmt.Sprintf("/imaging/v2/policysets/%s", policySetID)
+}
+
+// contractHeader is the Contract header all requests need, without the ctr_ prefix
+func contractHeader(contractID string) map[string]string {
+	return map[string]string{"Contract": strings.TrimPrefix(contractID, "ctr_")}
+}
@@ -0,0 +1,74 @@
+package imaging
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestListPolicySets(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/imaging/v2/policysets").
+		MatchHeader("Contract", "^3-WNKXX1$").
+		Reply(200).
+		JSON(`[{"id": "570f9090-5dbe-11ec-8a0a-71665789c1d8", "name": "images", "region": "US", "type": "IMAGE", "properties": ["prp_202"]}]`)
+
+	Init(config)
+
+	sets, err := ListPolicySets("ctr_3-WNKXX1")
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+	assert.Equal(t, RegionUS, sets[0].Region)
+	assert.Equal(t, TypeImage, sets[0].Type)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreatePolicySet(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/imaging/v2/policysets").
+		MatchHeader("Contract", "^3-WNKXX1$").
+		JSON(map[string]string{"name": "images", "region": "EMEA", "type": "IMAGE"}).
+		Reply(200).
+		JSON(`{"id": "570f9090-5dbe-11ec-8a0a-71665789c1d8", "name": "images", "region": "EMEA", "type": "IMAGE", "user": "jsmith"}`)
+
+	Init(config)
+
+	set, err := CreatePolicySet("3-WNKXX1", &PolicySet{Name: "images", Region: RegionEMEA, Type: TypeImage})
+	require.NoError(t, err)
+	assert.Equal(t, "570f9090-5dbe-11ec-8a0a-71665789c1d8", set.ID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestDeletePolicySet(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Delete("/imaging/v2/policysets/570f9090").
+		Reply(403).
+		JSON(`{"type": "https://problems.luna.akamaiapis.net/image-policy-manager/IVM_1004", "title": "Forbidden", "detail": "policy set is in use"}`)
+
+	Init(config)
+
+	err := DeletePolicySet("3-WNKXX1", "570f9090")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "policy set is in use")
+}
@@ -0,0 +1,32 @@
+package imaging
+
+import (
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+var (
+	// Config contains the Akamai OPEN Edgegrid API credentials
+	// for automatic signing of requests
+	Config edgegrid.Config
+)
+
+// Init sets the Image & Video Manager edgegrid Config
+func Init(config edgegrid.Config) {
+	Config = config
+	edgegrid.SetupLogging()
+}
+
+// doJSON sends body (if not nil) as JSON to path with the given headers and
+// decodes the response into out (if not nil)
+func doJSON(method, path string, headers map[string]string, body, out interface{}) error {
+	req, err := client.NewJSONRequest(Config, method, path, body)
+	if err != nil {
+		return err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	return client.DoJSONRequest(Config, req, out)
+}
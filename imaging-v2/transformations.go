@@ -0,0 +1,233 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Transformation types, as used by the "transformation" field of a Transformation
+const (
+	TransformationTypeBlur      = "Blur"
+	TransformationTypeCrop      = "Crop"
+	TransformationTypeGrayscale = "Grayscale"
+	TransformationTypeResize    = "Resize"
+	TransformationTypeRotate    = "Rotate"
+	TransformationTypeTrim      = "Trim"
+)
+
+// Number is a number of a policy, either a value or a reference to a policy
+// variable
+type Number struct {
+	Value float64
+	// Var is the name of the variable when not empty
+	Var string
+}
+
+// NumberValue returns a Number of value
+func NumberValue(value float64) *Number {
+	return &Number{Value: value}
+}
+
+// NumberVar returns a Number referencing the variable name
+func NumberVar(name string) *Number {
+	return &Number{Var: name}
+}
+
+// MarshalJSON encodes the number as a JSON number, or as {"var": name}
+func (number Number) MarshalJSON() ([]byte, error) {
+	if number.Var != "" {
+		return json.Marshal(struct {
+			Var string `json:"var"`
+		}{number.Var})
+	}
+
+	return json.Marshal(number.Value)
+}
+
+// UnmarshalJSON decodes a JSON number or a {"var": name} reference
+func (number *Number) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		reference := struct {
+			Var string `json:"var"`
+		}{}
+		if err := json.Unmarshal(data, &reference); err != nil {
+			return err
+		}
+		*number = Number{Var: reference.Var}
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*number = Number{Value: value}
+
+	return nil
+}
+
+// Transformation is an image transformation of a policy, one of *Blur,
+// *Crop, *Grayscale, *Resize, *Rotate, *Trim or *UnknownTransformation
+type Transformation interface {
+	transformationType() string
+}
+
+// Transformations are the transformations of a policy, applied in order
+type Transformations []Transformation
+
+// Blur blurs the image
+type Blur struct {
+	Transformation string `json:"transformation"`
+	// Sigma is the radius of the blur, 0 to 200
+	Sigma *Number `json:"sigma,omitempty"`
+}
+
+// Crop crops the image to Width by Height pixels, positioned by the X and Y
+// positions or by Gravity
+type Crop struct {
+	Transformation string  `json:"transformation"`
+	Width          *Number `json:"width"`
+	Height         *Number `json:"height"`
+	XPosition      *Number `json:"xPosition,omitempty"`
+	YPosition      *Number `json:"yPosition,omitempty"`
+	// Gravity is one of North, NorthEast, NorthWest, South, SouthEast,
+	// SouthWest, East, West or Center
+	Gravity        string `json:"gravity,omitempty"`
+	AllowExpansion bool   `json:"allowExpansion,omitempty"`
+}
+
+// Grayscale removes the colors of the image
+type Grayscale struct {
+	Transformation string `json:"transformation"`
+	// Type is one of Rec601, Rec709, Brightness or Lightness
+	Type string `json:"type,omitempty"`
+}
+
+// Resize resizes the image to Width and/or Height pixels
+type Resize struct {
+	Transformation string  `json:"transformation"`
+	Width          *Number `json:"width,omitempty"`
+	Height         *Number `json:"height,omitempty"`
+	// Aspect is fit, ignore or fill
+	Aspect string `json:"aspect,omitempty"`
+	// Type is normal (the default), upsize or downsize
+	Type string `json:"type,omitempty"`
+}
+
+// Rotate rotates the image clockwise
+type Rotate struct {
+	Transformation string  `json:"transformation"`
+	Degrees        *Number `json:"degrees"`
+}
+
+// Trim removes the uniform border of the image
+type Trim struct {
+	Transformation string  `json:"transformation"`
+	Fuzz           *Number `json:"fuzz,omitempty"`
+	Padding        *Number `json:"padding,omitempty"`
+}
+
+// UnknownTransformation keeps a transformation of a type this package has no
+// struct for, so that it is sent back unchanged
+type UnknownTransformation struct {
+	Type string
+	JSON json.RawMessage
+}
+
+func (*Blur) transformationType() string      { return TransformationTypeBlur }
+func (*Crop) transformationType() string      { return TransformationTypeCrop }
+func (*Grayscale) transformationType() string { return TransformationTypeGrayscale }
+func (*Resize) transformationType() string    { return TransformationTypeResize }
+func (*Rotate) transformationType() string    { return TransformationTypeRotate }
+func (*Trim) transformationType() string      { return TransformationTypeTrim }
+
+func (transformation *UnknownTransformation) transformationType() string {
+	return transformation.Type
+}
+
+// MarshalJSON encodes the transformations, setting their "transformation" type
+func (transformations Transformations) MarshalJSON() ([]byte, error) {
+	encoded := make([]interface{}, len(transformations))
+	for i, transformation := range transformations {
+		switch t := transformation.(type) {
+		case *Blur:
+			typed := *t
+			typed.Transformation = t.transformationType()
+			encoded[i] = typed
+		case *Crop:
+			typed := *t
+			typed.Transformation = t.transformationType()
+			encoded[i] = typed
+		case *Grayscale:
+			typed := *t
+			typed.Transformation = t.transformationType()
+			encoded[i] = typed
+		case *Resize:
+			typed := *t
+			typed.Transformation = t.transformationType()
+			encoded[i] = typed
+		case *Rotate:
+			typed := *t
+			typed.Transformation = t.transformationType()
+			encoded[i] = typed
+		case *Trim:
+			typed := *t
+			typed.Transformation = t.transformationType()
+			encoded[i] = typed
+		case *UnknownTransformation:
+			encoded[i] = t.JSON
+		default:
+			return nil, fmt.Errorf("transformation %d: unsupported type %T", i, transformation)
+		}
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes each transformation to the type of its "transformation"
+// field, an UnknownTransformation for the types this package has no struct for
+func (transformations *Transformations) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	decoded := make(Transformations, 0, len(raw))
+	for i, item := range raw {
+		header := struct {
+			Transformation string `json:"transformation"`
+		}{}
+		if err := json.Unmarshal(item, &header); err != nil {
+			return fmt.Errorf("transformation %d: %w", i, err)
+		}
+
+		var transformation Transformation
+		switch header.Transformation {
+		case TransformationTypeBlur:
+			transformation = &Blur{}
+		case TransformationTypeCrop:
+			transformation = &Crop{}
+		case TransformationTypeGrayscale:
+			transformation = &Grayscale{}
+		case TransformationTypeResize:
+			transformation = &Resize{}
+		case TransformationTypeRotate:
+			transformation = &Rotate{}
+		case TransformationTypeTrim:
+			transformation = &Trim{}
+		case "":
+			return fmt.Errorf("transformation %d: no transformation type", i)
+		default:
+			decoded = append(decoded, &UnknownTransformation{Type: header.Transformation, JSON: item})
+			continue
+		}
+		if err := json.Unmarshal(item, transformation); err != nil {
+			return fmt.Errorf("transformation %d: %w", i, err)
+		}
+		decoded = append(decoded, transformation)
+	}
+	*transformations = decoded
+
+	return nil
+}
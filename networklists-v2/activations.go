@@ -0,0 +1,208 @@
+package networklists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// Environment is used to create an "enum" of possible activation environments
+type Environment string
+
+// ActivationStatus is used to create an "enum" of possible Activation.ActivationStatus values
+type ActivationStatus string
+
+const (
+	// EnvironmentStaging the staging network
+	EnvironmentStaging Environment = "STAGING"
+	// EnvironmentProduction the production network
+	EnvironmentProduction Environment = "PRODUCTION"
+
+	// StatusInactive Activation.ActivationStatus value INACTIVE
+	StatusInactive ActivationStatus = "INACTIVE"
+	// StatusScheduled Activation.ActivationStatus value SCHEDULED
+	StatusScheduled ActivationStatus = "SCHEDULED"
+	// StatusPendingActivation Activation.ActivationStatus value PENDING_ACTIVATION
+	StatusPendingActivation ActivationStatus = "PENDING_ACTIVATION"
+	// StatusActive Activation.ActivationStatus value ACTIVE
+	StatusActive ActivationStatus = "ACTIVE"
+	// StatusModified Activation.ActivationStatus value MODIFIED
+	StatusModified ActivationStatus = "MODIFIED"
+	// StatusPendingDeactivation Activation.ActivationStatus value PENDING_DEACTIVATION
+	StatusPendingDeactivation ActivationStatus = "PENDING_DEACTIVATION"
+	// StatusFailed Activation.ActivationStatus value FAILED
+	StatusFailed ActivationStatus = "FAILED"
+)
+
+var (
+	// MinScheduleLead is how far in the future a scheduled activation must be at least
+	MinScheduleLead = 5 * time.Minute
+	// MaxScheduleLead is how far in the future a scheduled activation may be at most
+	MaxScheduleLead = 7 * 24 * time.Hour
+
+	// ErrScheduleTooSoon is returned for activations scheduled less than MinScheduleLead ahead
+	ErrScheduleTooSoon = errors.New("scheduled activation time is too soon")
+	// ErrScheduleTooLate is returned for activations scheduled more than MaxScheduleLead ahead
+	ErrScheduleTooLate = errors.New("scheduled activation time is too far in the future")
+
+	// ErrActivationFailed is returned by WaitForActivation for FAILED activations
+	ErrActivationFailed = errors.New("network list activation failed")
+
+	// DefaultActivationInterval is the WaitForActivation polling interval used when none is given
+	DefaultActivationInterval = 30 * time.Second
+
+	now = time.Now
+)
+
+// ActivationRequest are the parameters of a network list activation
+type ActivationRequest struct {
+	Comments               string   `json:"comments,omitempty"`
+	NotificationRecipients []string `json:"notificationRecipients,omitempty"`
+	SiebelTicketID         string   `json:"siebelTicketId,omitempty"`
+	// ScheduledActivationTime activates the list at a future time instead of immediately
+	ScheduledActivationTime *time.Time `json:"scheduledActivationTime,omitempty"`
+}
+
+// Validate checks the scheduled activation time is within the allowed window
+func (request *ActivationRequest) Validate() error {
+	if request.ScheduledActivationTime == nil {
+		return nil
+	}
+
+	lead := request.ScheduledActivationTime.Sub(now())
+	switch {
+	case lead < MinScheduleLead:
+		return fmt.Errorf("%w: must be at least %s ahead", ErrScheduleTooSoon, MinScheduleLead)
+	case lead > MaxScheduleLead:
+		return fmt.Errorf("%w: must be at most %s ahead", ErrScheduleTooLate, MaxScheduleLead)
+	}
+
+	return nil
+}
+
+// Activation is the status of a network list activation
+type Activation struct {
+	ActivationID            int              `json:"activationId"`
+	ActivationComments      string           `json:"activationComments,omitempty"`
+	ActivationStatus        ActivationStatus `json:"activationStatus"`
+	SyncPoint               int              `json:"syncPoint"`
+	UniqueID                string           `json:"uniqueId"`
+	Fast                    bool             `json:"fast"`
+	DispatchCount           int              `json:"dispatchCount"`
+	ScheduledActivationTime *time.Time       `json:"scheduledActivationTime,omitempty"`
+}
+
+// ActivateNetworkList activates a network list on environment, now or at request.ScheduledActivationTime
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#postactivate
+// Endpoint: POST /network-list/v2/network-lists/{networkListId}/environments/{environment}/activate
+func ActivateNetworkList(networkListID string, environment Environment, request ActivationRequest) (*Activation, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/network-list/v2/network-lists/%s/environments/%s/activate", networkListID, environment),
+		request,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activation := &Activation{}
+	if err = doJSON(req, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// GetActivation retrieves the status of an activation
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getactivation
+// Endpoint: GET /network-list/v2/network-lists/activations/{activationId}
+func GetActivation(activationID int) (*Activation, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/network-list/v2/network-lists/activations/%d", activationID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activation := &Activation{}
+	if err = doJSON(req, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// GetActivationStatus retrieves the activation status of a network list on environment
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getactivationstatus
+// Endpoint: GET /network-list/v2/network-lists/{networkListId}/environments/{environment}/status
+func GetActivationStatus(networkListID string, environment Environment) (*Activation, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/network-list/v2/network-lists/%s/environments/%s/status", networkListID, environment),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	activation := &Activation{}
+	if err = doJSON(req, activation); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// WaitForActivation polls the status of an activation every interval until it
+// is ACTIVE or FAILED, or ctx is done. A FAILED activation returns ErrActivationFailed.
+func WaitForActivation(ctx context.Context, activationID int, interval time.Duration) (*Activation, error) {
+	if interval <= 0 {
+		interval = DefaultActivationInterval
+	}
+
+	for {
+		activation, err := GetActivation(activationID)
+		if err != nil {
+			return nil, err
+		}
+		switch activation.ActivationStatus {
+		case StatusActive:
+			return activation, nil
+		case StatusFailed:
+			return activation, fmt.Errorf("%w: activation %d of %s", ErrActivationFailed, activation.ActivationID, activation.UniqueID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return activation, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// doJSON sends req and decodes the JSON response into out (if not nil)
+func doJSON(req *http.Request, out interface{}) error {
+	err := client.DoJSONRequest(Config, req, out)
+	var apiError client.APIError
+	if errors.As(err, &apiError) {
+		return newAPIError(apiError)
+	}
+
+	return err
+}
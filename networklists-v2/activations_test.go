@@ -0,0 +1,63 @@
+package networklists
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+	config  = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+)
+
+func TestActivateNetworkList_Scheduled(t *testing.T) {
+	defer gock.Off()
+	fixed := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	gock.New(baseURL).
+		Post("/network-list/v2/network-lists/25614_GENERALLIST/environments/PRODUCTION/activate").
+		BodyString(`{"comments": "emergency block", "scheduledActivationTime": "2020-06-01T22:00:00Z"}`).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activationId": 12345, "activationStatus": "SCHEDULED", "uniqueId": "25614_GENERALLIST", "scheduledActivationTime": "2020-06-01T22:00:00Z"}`)
+
+	Init(config)
+
+	at := fixed.Add(10 * time.Hour)
+	activation, err := ActivateNetworkList("25614_GENERALLIST", EnvironmentProduction, ActivationRequest{
+		Comments:                "emergency block",
+		ScheduledActivationTime: &at,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusScheduled, activation.ActivationStatus)
+	assert.True(t, at.Equal(*activation.ScheduledActivationTime))
+	assert.True(t, gock.IsDone())
+}
+
+func TestActivationRequest_Validate(t *testing.T) {
+	fixed := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	soon := fixed.Add(time.Minute)
+	late := fixed.Add(8 * 24 * time.Hour)
+
+	assert.NoError(t, (&ActivationRequest{}).Validate())
+	assert.True(t, errors.Is((&ActivationRequest{ScheduledActivationTime: &soon}).Validate(), ErrScheduleTooSoon))
+	assert.True(t, errors.Is((&ActivationRequest{ScheduledActivationTime: &late}).Validate(), ErrScheduleTooLate))
+}
@@ -0,0 +1,77 @@
+package networklists
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+var (
+	// ErrNotFound is matched by errors.Is() for responses to requests on unknown network lists or activations
+	ErrNotFound = errors.New("network list not found")
+	// ErrSyncPointConflict is matched by errors.Is() when a network list was
+	// modified since the sync point of the update was read
+	ErrSyncPointConflict = errors.New("network list was modified since its sync point was read")
+	// ErrForbidden is matched by errors.Is() when access to the network list is forbidden
+	ErrForbidden = errors.New("access to the network list is forbidden")
+)
+
+// Error is a Network Lists error response
+//
+// Use errors.Is() with ErrNotFound, ErrSyncPointConflict or ErrForbidden to
+// check for common statuses, and errors.As() to get the client.APIError of the
+// response.
+type Error struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+	Status   int    `json:"status"`
+	// APIError is the generic error of the response
+	APIError client.APIError `json:"-"`
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("Network Lists error %d: %s", e.Status, e.Title)
+	if e.Detail != "" {
+		message = fmt.Sprintf("%s: %s", message, e.Detail)
+	}
+
+	return message
+}
+
+// Is reports whether the status of e is the one of target
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrSyncPointConflict:
+		return e.Status == http.StatusConflict
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	}
+
+	return false
+}
+
+// Unwrap allows errors.As() with a client.APIError target
+func (e *Error) Unwrap() error {
+	return e.APIError
+}
+
+// newAPIError creates the *Error of the generic error of a response
+func newAPIError(apiError client.APIError) error {
+	res := apiError.Response
+	e := &Error{APIError: apiError}
+	if err := json.Unmarshal([]byte(apiError.RawBody), e); err != nil || e.Status == 0 {
+		e.Status = res.StatusCode
+	}
+	if e.Title == "" {
+		e.Title = http.StatusText(res.StatusCode)
+	}
+
+	return e
+}
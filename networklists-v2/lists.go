@@ -0,0 +1,247 @@
+package networklists
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// ListType is used to create an "enum" of possible NetworkList.Type values
+type ListType string
+
+const (
+	// ListTypeIP NetworkList.Type value IP, a list of IP addresses and CIDR blocks
+	ListTypeIP ListType = "IP"
+	// ListTypeGeo NetworkList.Type value GEO, a list of ISO 3166 country codes
+	ListTypeGeo ListType = "GEO"
+)
+
+// MaxSyncPointRetries is the number of times ModifyNetworkList retries an
+// update rejected because the list was modified meanwhile
+var MaxSyncPointRetries = 3
+
+// NetworkList is a list of IP addresses or country codes
+//
+// SyncPoint is the version of the list it was read at, UpdateNetworkList is
+// rejected with ErrSyncPointConflict when the list changed since.
+type NetworkList struct {
+	UniqueID                   string           `json:"uniqueId,omitempty"`
+	Name                       string           `json:"name"`
+	Type                       ListType         `json:"type"`
+	Description                string           `json:"description,omitempty"`
+	List                       []string         `json:"list"`
+	ElementCount               int              `json:"elementCount,omitempty"`
+	SyncPoint                  int              `json:"syncPoint"`
+	ReadOnly                   bool             `json:"readOnly,omitempty"`
+	Shared                     bool             `json:"shared,omitempty"`
+	ContractID                 string           `json:"contractId,omitempty"`
+	GroupID                    int              `json:"groupId,omitempty"`
+	CreateDate                 string           `json:"createDate,omitempty"`
+	UpdateDate                 string           `json:"updateDate,omitempty"`
+	StagingActivationStatus    ActivationStatus `json:"stagingActivationStatus,omitempty"`
+	ProductionActivationStatus ActivationStatus `json:"productionActivationStatus,omitempty"`
+}
+
+// ListOptions filters ListNetworkLists
+type ListOptions struct {
+	// Search matches list names and elements
+	Search          string
+	ListType        ListType
+	IncludeElements bool
+	// Extended includes the activation status, dates and owners of the lists
+	Extended bool
+}
+
+// ListNetworkLists lists the network lists
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getlists
+// Endpoint: GET /network-list/v2/network-lists{?search,listType,includeElements,extended}
+func ListNetworkLists(options ListOptions) ([]NetworkList, error) {
+	q := url.Values{}
+	if options.Search != "" {
+		q.Set("search", options.Search)
+	}
+	if options.ListType != "" {
+		q.Set("listType", string(options.ListType))
+	}
+	q.Set("includeElements", strconv.FormatBool(options.IncludeElements))
+	q.Set("extended", strconv.FormatBool(options.Extended))
+
+	req, err := client.NewRequest(Config, "GET", "/network-list/v2/network-lists?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		NetworkLists []NetworkList `json:"networkLists"`
+	}{}
+	if err = doJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	return response.NetworkLists, nil
+}
+
+// GetNetworkList retrieves a network list, along with its elements when includeElements is set
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getlist
+// Endpoint: GET /network-list/v2/network-lists/{networkListId}{?includeElements,extended}
+func GetNetworkList(networkListID string, includeElements bool) (*NetworkList, error) {
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/network-list/v2/network-lists/%s?includeElements=%t&extended=true", networkListID, includeElements),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &NetworkList{}
+	if err = doJSON(req, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// CreateNetworkList creates a network list
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#postlists
+// Endpoint: POST /network-list/v2/network-lists
+func CreateNetworkList(list *NetworkList) (*NetworkList, error) {
+	req, err := client.NewJSONRequest(Config, "POST", "/network-list/v2/network-lists", list)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &NetworkList{}
+	if err = doJSON(req, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateNetworkList replaces the name, description and elements of a network
+// list, the update is rejected with ErrSyncPointConflict when the list was
+// modified since list.SyncPoint
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#putlist
+// Endpoint: PUT /network-list/v2/network-lists/{networkListId}
+func UpdateNetworkList(list *NetworkList) (*NetworkList, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"PUT",
+		fmt.Sprintf("/network-list/v2/network-lists/%s", list.UniqueID),
+		list,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &NetworkList{}
+	if err = doJSON(req, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ModifyNetworkList reads a network list with its elements, applies modify
+// to it and saves it. When the list was modified meanwhile, it is read and
+// modified again, up to MaxSyncPointRetries times.
+func ModifyNetworkList(networkListID string, modify func(list *NetworkList) error) (*NetworkList, error) {
+	for attempt := 0; ; attempt++ {
+		list, err := GetNetworkList(networkListID, true)
+		if err != nil {
+			return nil, err
+		}
+		if err = modify(list); err != nil {
+			return nil, err
+		}
+
+		updated, err := UpdateNetworkList(list)
+		if errors.Is(err, ErrSyncPointConflict) && attempt < MaxSyncPointRetries {
+			continue
+		}
+
+		return updated, err
+	}
+}
+
+// AppendElements adds elements to a network list, elements already in the list are ignored
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#postappend
+// Endpoint: POST /network-list/v2/network-lists/{networkListId}/append
+func AppendElements(networkListID string, elements []string) (*NetworkList, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/network-list/v2/network-lists/%s/append", networkListID),
+		struct {
+			List []string `json:"list"`
+		}{elements},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &NetworkList{}
+	if err = doJSON(req, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// AddElement adds an element to a network list
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#putelement
+// Endpoint: PUT /network-list/v2/network-lists/{networkListId}/elements{?element}
+func AddElement(networkListID string, element string) (*NetworkList, error) {
+	return modifyElement("PUT", networkListID, element)
+}
+
+// RemoveElement removes an element from a network list
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#deleteelement
+// Endpoint: DELETE /network-list/v2/network-lists/{networkListId}/elements{?element}
+func RemoveElement(networkListID string, element string) (*NetworkList, error) {
+	return modifyElement("DELETE", networkListID, element)
+}
+
+// DeleteNetworkList deletes a network list, it must not be active or used by a security configuration
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/network_lists/v2.html#deletelist
+// Endpoint: DELETE /network-list/v2/network-lists/{networkListId}
+func DeleteNetworkList(networkListID string) error {
+	req, err := client.NewRequest(Config, "DELETE", fmt.Sprintf("/network-list/v2/network-lists/%s", networkListID), nil)
+	if err != nil {
+		return err
+	}
+
+	return doJSON(req, nil)
+}
+
+func modifyElement(method string, networkListID string, element string) (*NetworkList, error) {
+	req, err := client.NewRequest(
+		Config,
+		method,
+		fmt.Sprintf("/network-list/v2/network-lists/%s/elements?element=%s", networkListID, url.QueryEscape(element)),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &NetworkList{}
+	if err = doJSON(req, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
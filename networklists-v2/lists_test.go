@@ -0,0 +1,109 @@
+package networklists
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestModifyNetworkList_SyncPointConflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/network-list/v2/network-lists/25614_GENERALLIST").
+		MatchParam("includeElements", "true").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"uniqueId": "25614_GENERALLIST", "name": "General List", "type": "IP", "list": ["192.0.2.1"], "syncPoint": 4}`)
+	gock.New(baseURL).
+		Put("/network-list/v2/network-lists/25614_GENERALLIST").
+		BodyString(`{"uniqueId": "25614_GENERALLIST", "name": "General List", "type": "IP", "list": ["192.0.2.1", "198.51.100.0/24"], "syncPoint": 4}`).
+		Reply(409).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"type": "/network-list/error-types/conflict", "title": "Conflict", "status": 409, "detail": "syncPoint 4 is stale"}`)
+	gock.New(baseURL).
+		Get("/network-list/v2/network-lists/25614_GENERALLIST").
+		MatchParam("includeElements", "true").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"uniqueId": "25614_GENERALLIST", "name": "General List", "type": "IP", "list": ["192.0.2.1", "192.0.2.2"], "syncPoint": 5}`)
+	gock.New(baseURL).
+		Put("/network-list/v2/network-lists/25614_GENERALLIST").
+		BodyString(`{"uniqueId": "25614_GENERALLIST", "name": "General List", "type": "IP", "list": ["192.0.2.1", "192.0.2.2", "198.51.100.0/24"], "syncPoint": 5}`).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"uniqueId": "25614_GENERALLIST", "name": "General List", "type": "IP", "list": ["192.0.2.1", "192.0.2.2", "198.51.100.0/24"], "syncPoint": 6}`)
+
+	Init(config)
+
+	list, err := ModifyNetworkList("25614_GENERALLIST", func(list *NetworkList) error {
+		list.List = append(list.List, "198.51.100.0/24")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 6, list.SyncPoint)
+	assert.Len(t, list.List, 3)
+	assert.True(t, gock.IsDone())
+}
+
+func TestUpdateNetworkList_Conflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Put("/network-list/v2/network-lists/25614_GENERALLIST").
+		Reply(409).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"title": "Conflict", "status": 409}`)
+
+	Init(config)
+
+	_, err := UpdateNetworkList(&NetworkList{UniqueID: "25614_GENERALLIST", Name: "General List", Type: ListTypeIP, SyncPoint: 4})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSyncPointConflict))
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
+
+func TestAppendElementsAndWaitForActivation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/network-list/v2/network-lists/25614_GENERALLIST/append").
+		BodyString(`{"list": ["203.0.113.7"]}`).
+		Reply(202).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"uniqueId": "25614_GENERALLIST", "name": "General List", "type": "IP", "elementCount": 4, "syncPoint": 7}`)
+	gock.New(baseURL).
+		Post("/network-list/v2/network-lists/25614_GENERALLIST/environments/STAGING/activate").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activationId": 12346, "activationStatus": "PENDING_ACTIVATION", "uniqueId": "25614_GENERALLIST", "syncPoint": 7}`)
+	gock.New(baseURL).
+		Get("/network-list/v2/network-lists/activations/12346").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activationId": 12346, "activationStatus": "PENDING_ACTIVATION", "uniqueId": "25614_GENERALLIST", "syncPoint": 7}`)
+	gock.New(baseURL).
+		Get("/network-list/v2/network-lists/activations/12346").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activationId": 12346, "activationStatus": "ACTIVE", "uniqueId": "25614_GENERALLIST", "syncPoint": 7}`)
+
+	Init(config)
+
+	list, err := AppendElements("25614_GENERALLIST", []string{"203.0.113.7"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, list.SyncPoint)
+
+	activation, err := ActivateNetworkList(list.UniqueID, EnvironmentStaging, ActivationRequest{})
+	require.NoError(t, err)
+
+	activation, err = WaitForActivation(context.Background(), activation.ActivationID, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, activation.ActivationStatus)
+	assert.True(t, gock.IsDone())
+}
@@ -1,9 +1,11 @@
 package papi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
@@ -61,7 +63,7 @@ func (activations *Activations) GetActivations(property *Property) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, activations); err != nil {
@@ -133,6 +135,7 @@ type Activation struct {
 	Note                string                      `json:"note,omitempty"`
 	NotifyEmails        []string                    `json:"notifyEmails"`
 	StatusChange        chan bool                   `json:"-"`
+	etag                string
 }
 
 type ActivationComplianceRecord struct {
@@ -154,6 +157,9 @@ func (activation *Activation) Init() {
 
 // GetActivation populates the Activation resource
 //
+// Repeated calls send the ETag of the previous response in If-None-Match, and
+// leave the Activation untouched when PAPI replies 304 Not Modified.
+//
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#getanactivation
 // Endpoint: GET /papi/v1/properties/{propertyId}/activations/{activationId}{?contractId,groupId}
 func (activation *Activation) GetActivation(property *Property) (time.Duration, error) {
@@ -174,6 +180,10 @@ func (activation *Activation) GetActivation(property *Property) (time.Duration,
 		return 0, err
 	}
 
+	if activation.etag != "" {
+		req.Header.Set("If-None-Match", activation.etag)
+	}
+
 	edge.PrintHttpRequest(req, true)
 
 	res, err := client.Do(Config, req)
@@ -183,10 +193,18 @@ func (activation *Activation) GetActivation(property *Property) (time.Duration,
 
 	edge.PrintHttpResponse(res, true)
 
+	// Unchanged since the last poll, keep the current state
+	if res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		return time.Duration(30 * time.Second), nil
+	}
+
 	if client.IsError(res) {
-		return 0, client.NewAPIError(res)
+		return 0, newAPIError(res)
 	}
 
+	activation.etag = res.Header.Get("ETag")
+
 	activations := NewActivations()
 	if err := client.BodyJSON(res, activations); err != nil {
 		return 0, err
@@ -216,6 +234,9 @@ func (activation *Activation) GetActivation(property *Property) (time.Duration,
 
 // Save activates a given property
 //
+// NotifyEmails are validated and deduplicated, the addresses set by
+// SetDefaultNotifyEmails() are notified when it is empty.
+//
 // If acknowledgeWarnings is true and warnings are returned on the first attempt,
 // a second attempt is made, acknowledging the warnings.
 //
@@ -223,6 +244,12 @@ func (activation *Activation) GetActivation(property *Property) (time.Duration,
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#activateaproperty
 // Endpoint: POST /papi/v1/properties/{propertyId}/activations/{?contractId,groupId}
 func (activation *Activation) Save(property *Property, acknowledgeWarnings bool) error {
+	notifyEmails, err := activationNotifyEmails(activation.NotifyEmails)
+	if err != nil {
+		return err
+	}
+	activation.NotifyEmails = notifyEmails
+
 	if activation.ComplianceRecord == nil {
 		activation.ComplianceRecord = &ActivationComplianceRecord{
 			NoncomplianceReason: "NO_PRODUCTION_TRAFFIC",
@@ -256,7 +283,7 @@ func (activation *Activation) Save(property *Property, acknowledgeWarnings bool)
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) && (!acknowledgeWarnings || (acknowledgeWarnings && res.StatusCode != 400)) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if res.StatusCode == 400 && acknowledgeWarnings {
@@ -284,7 +311,7 @@ func (activation *Activation) Save(property *Property, acknowledgeWarnings bool)
 				return err
 			}
 
-			return client.NewAPIErrorFromBody(res, body)
+			return newAPIErrorFromBody(res, body)
 		}
 
 		for _, warning := range warnings.Warnings {
@@ -399,6 +426,55 @@ func (activation *Activation) PollStatus(property *Property) bool {
 	return true
 }
 
+// Done reports whether the activation reached a final status
+func (activation *Activation) Done() bool {
+	switch activation.Status {
+	case StatusActive, StatusFailed, StatusAborted, StatusDeactivated, StatusInactive:
+		return true
+	}
+
+	return false
+}
+
+// WaitForActivation polls activation until it is ACTIVE, or returns
+// ErrorMap[ErrActivationFailed] when it ends in any other final status, e.g.
+// FAILED or ABORTED. Deactivations are done once DEACTIVATED.
+//
+// pollInterval defaults to the interval suggested by GetActivation. The wait
+// is abandoned with an *ErrWaitCancelled when ctx is done.
+func WaitForActivation(ctx context.Context, property *Property, activation *Activation, pollInterval time.Duration) error {
+	start := time.Now()
+	for !activation.Done() {
+		retry, err := activation.GetActivation(property)
+		if err != nil {
+			return err
+		}
+		if activation.Done() {
+			break
+		}
+
+		if pollInterval > 0 {
+			retry = pollInterval
+		}
+
+		timer := time.NewTimer(retry)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &ErrWaitCancelled{LastStatus: string(activation.Status), Elapsed: time.Since(start), Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+
+	switch {
+	case activation.Status == StatusActive && activation.ActivationType != ActivationTypeDeactivate,
+		activation.Status == StatusDeactivated && activation.ActivationType == ActivationTypeDeactivate:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrorMap[ErrActivationFailed], activation.Status)
+}
+
 // Cancel an activation in progress
 //
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#cancelapendingactivation
@@ -408,8 +484,9 @@ func (activation *Activation) Cancel(property *Property) error {
 		Config,
 		"DELETE",
 		fmt.Sprintf(
-			"/papi/v1/properties/%s/activations?contractId=%s&groupId=%s",
+			"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
 			property.PropertyID,
+			activation.ActivationID,
 			property.Contract.ContractID,
 			property.Group.GroupID,
 		),
@@ -431,7 +508,7 @@ func (activation *Activation) Cancel(property *Property) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newActivations := NewActivations()
@@ -0,0 +1,166 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestActivation_GetActivation_NotModified(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/activations/atv_67037").
+		MatchHeader("If-None-Match", `"a1b2c3"`).
+		Reply(304)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/activations/atv_67037").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("ETag", `"a1b2c3"`).
+		BodyString(`{
+			"activations": {
+				"items": [
+					{
+						"activationId": "atv_67037",
+						"propertyName": "example.com",
+						"propertyId": "prp_173136",
+						"propertyVersion": 1,
+						"network": "STAGING",
+						"activationType": "ACTIVATE",
+						"status": "PENDING",
+						"submitDate": "2014-03-02T02:22:12Z",
+						"updateDate": "2014-03-01T21:12:57Z"
+					}
+				]
+			}
+		}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+	activation := NewActivation(NewActivations())
+	activation.ActivationID = "atv_67037"
+
+	_, err := activation.GetActivation(property)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, activation.Status)
+	assert.Equal(t, `"a1b2c3"`, activation.etag)
+
+	_, err = activation.GetActivation(property)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, activation.Status)
+	assert.Equal(t, "example.com", activation.PropertyName)
+	assert.True(t, gock.IsDone())
+}
+
+func activationBody(status StatusValue) string {
+	return fmt.Sprintf(`{"activations": {"items": [{
+		"activationId": "atv_67037",
+		"propertyId": "prp_173136",
+		"propertyVersion": 1,
+		"network": "STAGING",
+		"activationType": "ACTIVATE",
+		"status": "%s"
+	}]}}`, status)
+}
+
+func TestWaitForActivation(t *testing.T) {
+	defer gock.Off()
+
+	for _, status := range []StatusValue{StatusPending, StatusZone1, StatusActive} {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Get("/papi/v1/properties/prp_173136/activations/atv_67037").
+			Reply(200).
+			SetHeader("Content-Type", "application/json").
+			BodyString(activationBody(status))
+	}
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+	activation := NewActivation(NewActivations())
+	activation.ActivationID = "atv_67037"
+
+	require.NoError(t, WaitForActivation(context.Background(), property, activation, time.Millisecond))
+	assert.Equal(t, StatusActive, activation.Status)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForActivation_Failed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/activations/atv_67037").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(activationBody(StatusFailed))
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	activation, err := GetActivation(property, "atv_67037")
+	require.NoError(t, err)
+
+	err = WaitForActivation(context.Background(), property, activation, time.Millisecond)
+	assert.True(t, errors.Is(err, ErrorMap[ErrActivationFailed]))
+}
+
+func TestWaitForActivation_Cancelled(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/activations/atv_67037").
+		Persist().
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(activationBody(StatusPending))
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+	activation := NewActivation(NewActivations())
+	activation.ActivationID = "atv_67037"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForActivation(ctx, property, activation, 5*time.Millisecond)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var cancelled *ErrWaitCancelled
+	require.True(t, errors.As(err, &cancelled))
+	assert.Equal(t, string(StatusPending), cancelled.LastStatus)
+	assert.True(t, cancelled.Elapsed >= 20*time.Millisecond)
+}
+
+func TestCancelActivation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Delete("/papi/v1/properties/prp_173136/activations/atv_67037").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(activationBody(StatusAborted))
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	activation, err := CancelActivation(property, "atv_67037")
+	require.NoError(t, err)
+	assert.Equal(t, StatusAborted, activation.Status)
+	assert.True(t, gock.IsDone())
+}
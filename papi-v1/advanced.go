@@ -0,0 +1,213 @@
+package papi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// RuleFindingKind is used to create an "enum" of possible RuleFinding.Kind values
+type RuleFindingKind string
+
+const (
+	// FindingAdvancedBehavior RuleFinding.Kind value ADVANCED_BEHAVIOR, an "advanced" (XML metadata) behavior
+	FindingAdvancedBehavior RuleFindingKind = "ADVANCED_BEHAVIOR"
+	// FindingAdvancedCriteria RuleFinding.Kind value ADVANCED_CRITERIA, a "matchAdvanced" (XML metadata) criteria
+	FindingAdvancedCriteria RuleFindingKind = "ADVANCED_CRITERIA"
+	// FindingAdvancedOverride RuleFinding.Kind value ADVANCED_OVERRIDE, the XML metadata of Rule.AdvancedOverride
+	FindingAdvancedOverride RuleFindingKind = "ADVANCED_OVERRIDE"
+	// FindingCustomOverride RuleFinding.Kind value CUSTOM_OVERRIDE, a Rule.CustomOverride
+	FindingCustomOverride RuleFindingKind = "CUSTOM_OVERRIDE"
+	// FindingCustomBehavior RuleFinding.Kind value CUSTOM_BEHAVIOR, a "customBehavior" behavior
+	FindingCustomBehavior RuleFindingKind = "CUSTOM_BEHAVIOR"
+	// FindingLockedBehavior RuleFinding.Kind value LOCKED_BEHAVIOR, a behavior with Locked set
+	FindingLockedBehavior RuleFindingKind = "LOCKED_BEHAVIOR"
+	// FindingLockedCriteria RuleFinding.Kind value LOCKED_CRITERIA, a criteria with Locked set
+	FindingLockedCriteria RuleFindingKind = "LOCKED_CRITERIA"
+	// FindingLockedRuleCriteria RuleFinding.Kind value LOCKED_RULE_CRITERIA, a rule with CriteriaLocked set
+	FindingLockedRuleCriteria RuleFindingKind = "LOCKED_RULE_CRITERIA"
+)
+
+// RuleFinding is an advanced or locked item of a rule tree, these can only be
+// changed by Akamai and must be sent back unchanged on rule tree updates
+type RuleFinding struct {
+	// Path is the names of the rule and its parents, e.g. "/default/Performance"
+	Path string          `json:"path"`
+	Kind RuleFindingKind `json:"kind"`
+	// Name is the behavior or criteria name, empty for rule level findings
+	Name string `json:"name,omitempty"`
+	// Value is the options of the behavior or criteria, the advanced override
+	// XML, or the custom override
+	Value interface{} `json:"value,omitempty"`
+}
+
+// key identifies the finding in a rule tree, ignoring its value
+func (finding RuleFinding) key() string {
+	return fmt.Sprintf("%s|%s|%s", finding.Path, finding.Kind, finding.Name)
+}
+
+func (finding RuleFinding) String() string {
+	if finding.Name == "" {
+		return fmt.Sprintf("%s %s", finding.Kind, finding.Path)
+	}
+
+	return fmt.Sprintf("%s %s/%s", finding.Kind, finding.Path, finding.Name)
+}
+
+// FindAdvancedAndLocked lists the advanced and locked items of the rule tree, in rule tree order
+func (rules *Rules) FindAdvancedAndLocked() []RuleFinding {
+	findings := []RuleFinding{}
+	if rules.Rule == nil {
+		return findings
+	}
+
+	var walk func(path string, rule *Rule)
+	walk = func(path string, rule *Rule) {
+		path += "/" + rule.Name
+
+		if rule.AdvancedOverride != "" {
+			findings = append(findings, RuleFinding{Path: path, Kind: FindingAdvancedOverride, Value: rule.AdvancedOverride})
+		}
+		if rule.CustomOverride != nil {
+			findings = append(findings, RuleFinding{Path: path, Kind: FindingCustomOverride, Value: rule.CustomOverride.OverrideID})
+		}
+		if rule.CriteriaLocked {
+			findings = append(findings, RuleFinding{Path: path, Kind: FindingLockedRuleCriteria, Value: criteriaOptions(rule.Criteria)})
+		}
+
+		seen := map[string]int{}
+		for _, criteria := range rule.Criteria {
+			name := fmt.Sprintf("%s#%d", criteria.Name, seen[criteria.Name])
+			seen[criteria.Name]++
+			if criteria.Name == "matchAdvanced" {
+				findings = append(findings, RuleFinding{Path: path, Kind: FindingAdvancedCriteria, Name: name, Value: criteria.Options})
+			}
+			if criteria.Locked {
+				findings = append(findings, RuleFinding{Path: path, Kind: FindingLockedCriteria, Name: name, Value: criteria.Options})
+			}
+		}
+
+		seen = map[string]int{}
+		for _, behavior := range rule.Behaviors {
+			name := fmt.Sprintf("%s#%d", behavior.Name, seen[behavior.Name])
+			seen[behavior.Name]++
+			switch behavior.Name {
+			case "advanced":
+				findings = append(findings, RuleFinding{Path: path, Kind: FindingAdvancedBehavior, Name: name, Value: behavior.Options})
+			case "customBehavior":
+				findings = append(findings, RuleFinding{Path: path, Kind: FindingCustomBehavior, Name: name, Value: behavior.Options})
+			}
+			if behavior.Locked {
+				findings = append(findings, RuleFinding{Path: path, Kind: FindingLockedBehavior, Name: name, Value: behavior.Options})
+			}
+		}
+
+		for _, child := range rule.Children {
+			walk(path, child)
+		}
+	}
+	walk("", rules.Rule)
+
+	return findings
+}
+
+// HasAdvancedOrLocked reports whether the rule tree has advanced or locked items
+func (rules *Rules) HasAdvancedOrLocked() bool {
+	return len(rules.FindAdvancedAndLocked()) > 0
+}
+
+// UpdateSafetyValue is used to create an "enum" of possible RuleTreeUpdateSafety.Safety values
+type UpdateSafetyValue string
+
+const (
+	// UpdateSafe RuleTreeUpdateSafety.Safety value SAFE, the rule tree has no advanced or locked items
+	UpdateSafe UpdateSafetyValue = "SAFE"
+	// UpdateReview RuleTreeUpdateSafety.Safety value REVIEW, the advanced and
+	// locked items are kept unchanged, but a rule tree with advanced metadata
+	// should still be reviewed before it is saved
+	UpdateReview UpdateSafetyValue = "REVIEW"
+	// UpdateUnsafe RuleTreeUpdateSafety.Safety value UNSAFE, advanced or locked
+	// items are added, changed or removed
+	UpdateUnsafe UpdateSafetyValue = "UNSAFE"
+)
+
+// RuleTreeUpdateSafety classifies an automated rule tree update, see ClassifyRuleTreeUpdate()
+type RuleTreeUpdateSafety struct {
+	Safety UpdateSafetyValue `json:"safety"`
+	// Findings are the advanced and locked items of the current rule tree
+	Findings []RuleFinding `json:"findings"`
+	// Added, Changed and Removed are the advanced and locked items the update adds, changes or removes
+	Added   []RuleFinding `json:"added,omitempty"`
+	Changed []RuleFinding `json:"changed,omitempty"`
+	Removed []RuleFinding `json:"removed,omitempty"`
+}
+
+// Safe reports whether the update can be saved without review
+func (safety *RuleTreeUpdateSafety) Safe() bool {
+	return safety.Safety == UpdateSafe
+}
+
+// Reasons describes the advanced and locked items that make the update unsafe
+func (safety *RuleTreeUpdateSafety) Reasons() []string {
+	var reasons []string
+	for _, finding := range safety.Added {
+		reasons = append(reasons, "added "+finding.String())
+	}
+	for _, finding := range safety.Changed {
+		reasons = append(reasons, "changed "+finding.String())
+	}
+	for _, finding := range safety.Removed {
+		reasons = append(reasons, "removed "+finding.String())
+	}
+
+	return reasons
+}
+
+// ClassifyRuleTreeUpdate classifies replacing the current rule tree by next
+//
+// The update is UpdateUnsafe when next adds, changes or removes an advanced or
+// locked item of current (PAPI rejects these or breaks the configuration),
+// UpdateReview when current has advanced or locked items that next keeps
+// unchanged, and UpdateSafe otherwise.
+func ClassifyRuleTreeUpdate(current *Rules, next *Rules) *RuleTreeUpdateSafety {
+	safety := &RuleTreeUpdateSafety{Findings: current.FindAdvancedAndLocked()}
+
+	currentFindings := map[string]RuleFinding{}
+	for _, finding := range safety.Findings {
+		currentFindings[finding.key()] = finding
+	}
+	nextFindings := map[string]RuleFinding{}
+	for _, finding := range next.FindAdvancedAndLocked() {
+		nextFindings[finding.key()] = finding
+		previous, ok := currentFindings[finding.key()]
+		switch {
+		case !ok:
+			safety.Added = append(safety.Added, finding)
+		case !reflect.DeepEqual(previous.Value, finding.Value):
+			safety.Changed = append(safety.Changed, finding)
+		}
+	}
+	for _, finding := range safety.Findings {
+		if _, ok := nextFindings[finding.key()]; !ok {
+			safety.Removed = append(safety.Removed, finding)
+		}
+	}
+	sortFindings(safety.Added)
+	sortFindings(safety.Changed)
+	sortFindings(safety.Removed)
+
+	switch {
+	case len(safety.Added)+len(safety.Changed)+len(safety.Removed) > 0:
+		safety.Safety = UpdateUnsafe
+	case len(safety.Findings) > 0:
+		safety.Safety = UpdateReview
+	default:
+		safety.Safety = UpdateSafe
+	}
+
+	return safety
+}
+
+func sortFindings(findings []RuleFinding) {
+	sort.Slice(findings, func(i, j int) bool { return findings[i].key() < findings[j].key() })
+}
@@ -0,0 +1,63 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func advancedRules(xml string) *Rules {
+	rules := NewRules()
+	rules.Rule.Behaviors = []*Behavior{
+		{Name: "origin", Options: OptionValue{"hostname": "origin.example.com"}},
+		{Name: "cpCode", Options: OptionValue{"value": OptionValue{"id": 12345}}, Locked: true},
+	}
+
+	legacy := NewRule()
+	legacy.Name = "Legacy"
+	legacy.Criteria = []*Criteria{{Name: "matchAdvanced", Options: OptionValue{"openXml": "<match:request.type value=\"CLIENT_REQ\">"}}}
+	legacy.Behaviors = []*Behavior{{Name: "advanced", Options: OptionValue{"xml": xml}}}
+	rules.Rule.Children = []*Rule{legacy}
+
+	return rules
+}
+
+func TestRules_FindAdvancedAndLocked(t *testing.T) {
+	rules := advancedRules("<edgeservices:modify-outgoing-request.path-rewrite/>")
+	rules.Rule.Children[0].AdvancedOverride = "<comment/>"
+
+	findings := rules.FindAdvancedAndLocked()
+	require.Len(t, findings, 4)
+	assert.Equal(t, RuleFinding{Path: "/default", Kind: FindingLockedBehavior, Name: "cpCode#0", Value: OptionValue{"value": OptionValue{"id": 12345}}}, findings[0])
+	assert.Equal(t, FindingAdvancedOverride, findings[1].Kind)
+	assert.Equal(t, FindingAdvancedCriteria, findings[2].Kind)
+	assert.Equal(t, "ADVANCED_BEHAVIOR /default/Legacy/advanced#0", findings[3].String())
+	assert.True(t, rules.HasAdvancedOrLocked())
+
+	assert.False(t, NewRules().HasAdvancedOrLocked())
+}
+
+func TestClassifyRuleTreeUpdate(t *testing.T) {
+	current := advancedRules("<edgeservices:modify-outgoing-request.path-rewrite/>")
+
+	next := advancedRules("<edgeservices:modify-outgoing-request.path-rewrite/>")
+	next.Rule.Behaviors[0].Options["hostname"] = "new-origin.example.com"
+	safety := ClassifyRuleTreeUpdate(current, next)
+	assert.Equal(t, UpdateReview, safety.Safety)
+	assert.False(t, safety.Safe())
+	assert.Empty(t, safety.Reasons())
+
+	next = advancedRules("<edgeservices:modify-outgoing-request.path-rewrite></edgeservices:modify-outgoing-request.path-rewrite>")
+	next.Rule.Behaviors[1].Locked = false
+	safety = ClassifyRuleTreeUpdate(current, next)
+	assert.Equal(t, UpdateUnsafe, safety.Safety)
+	assert.Equal(t, []string{
+		"changed ADVANCED_BEHAVIOR /default/Legacy/advanced#0",
+		"removed LOCKED_BEHAVIOR /default/cpCode#0",
+	}, safety.Reasons())
+
+	plain := NewRules()
+	plain.Rule.Behaviors = []*Behavior{{Name: "origin", Options: OptionValue{"hostname": "origin.example.com"}}}
+	assert.True(t, ClassifyRuleTreeUpdate(plain, plain).Safe())
+}
@@ -63,7 +63,7 @@ func (availableCriteria *AvailableCriteria) GetAvailableCriteria(property *Prope
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, availableCriteria); err != nil {
@@ -140,7 +140,7 @@ func (availableBehaviors *AvailableBehaviors) GetAvailableBehaviors(property *Pr
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, availableBehaviors); err != nil {
@@ -0,0 +1,278 @@
+package papi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// BulkSearchStatusValue is used to create an "enum" of possible BulkSearch.SearchTargetStatus values
+type BulkSearchStatusValue string
+
+// BulkStatusValue is the status of any BulkRequest, its values are the
+// BulkSearchStatusValue ones
+type BulkStatusValue = BulkSearchStatusValue
+
+// BulkSearchSyntaxValue is used to create an "enum" of possible BulkSearchQuery.Syntax values
+type BulkSearchSyntaxValue string
+
+const (
+	// BulkSearchStatusPending BulkSearch.SearchTargetStatus value PENDING
+	BulkSearchStatusPending BulkSearchStatusValue = "PENDING"
+	// BulkSearchStatusInProgress BulkSearch.SearchTargetStatus value IN_PROGRESS
+	BulkSearchStatusInProgress BulkSearchStatusValue = "IN_PROGRESS"
+	// BulkSearchStatusComplete BulkSearch.SearchTargetStatus value COMPLETE
+	BulkSearchStatusComplete BulkSearchStatusValue = "COMPLETE"
+	// BulkSearchStatusError BulkSearch.SearchTargetStatus value ERROR
+	BulkSearchStatusError BulkSearchStatusValue = "ERROR"
+
+	// BulkStatusPending bulk request status value PENDING
+	BulkStatusPending = BulkSearchStatusPending
+	// BulkStatusInProgress bulk request status value IN_PROGRESS
+	BulkStatusInProgress = BulkSearchStatusInProgress
+	// BulkStatusComplete bulk request status value COMPLETE
+	BulkStatusComplete = BulkSearchStatusComplete
+	// BulkStatusError bulk request status value ERROR
+	BulkStatusError = BulkSearchStatusError
+
+	// BulkSearchSyntaxJSONPath BulkSearchQuery.Syntax value JSONPATH
+	BulkSearchSyntaxJSONPath BulkSearchSyntaxValue = "JSONPATH"
+)
+
+// BulkSearchQuery is a JSONPath expression matched against the rule trees of property versions
+type BulkSearchQuery struct {
+	Syntax BulkSearchSyntaxValue `json:"syntax"`
+	Match  string                `json:"match"`
+	// BulkSearchQualifiers are further JSONPath expressions each match must satisfy
+	BulkSearchQualifiers []string `json:"bulkSearchQualifiers,omitempty"`
+}
+
+// BehaviorSearchQuery matches rule trees using the behavior name
+func BehaviorSearchQuery(name string) BulkSearchQuery {
+	return BulkSearchQuery{
+		Syntax: BulkSearchSyntaxJSONPath,
+		Match:  fmt.Sprintf("$..behaviors[?(@.name == '%s')]", name),
+	}
+}
+
+// HostnameSearchQuery matches rule trees with a hostname criteria on hostname
+func HostnameSearchQuery(hostname string) BulkSearchQuery {
+	return BulkSearchQuery{
+		Syntax: BulkSearchSyntaxJSONPath,
+		Match:  "$..criteria[?(@.name == 'hostname')].options.values[*]",
+		BulkSearchQualifiers: []string{
+			fmt.Sprintf("$.[?(@ == '%s')]", hostname),
+		},
+	}
+}
+
+// BulkSearch represents a PAPI bulk rules search request
+type BulkSearch struct {
+	client.Resource
+	BulkSearchID       int                   `json:"bulkSearchId"`
+	SearchTargetStatus BulkSearchStatusValue `json:"searchTargetStatus"`
+	SearchSubmitDate   string                `json:"searchSubmitDate,omitempty"`
+	SearchUpdateDate   string                `json:"searchUpdateDate,omitempty"`
+	BulkSearchQuery    BulkSearchQuery       `json:"bulkSearchQuery"`
+	Results            []*BulkSearchMatch    `json:"results,omitempty"`
+}
+
+// BulkSearchMatch is a property version matching a BulkSearchQuery
+type BulkSearchMatch struct {
+	AccountID        string      `json:"accountId"`
+	PropertyID       string      `json:"propertyId"`
+	PropertyName     string      `json:"propertyName"`
+	PropertyVersion  int         `json:"propertyVersion"`
+	PropertyType     string      `json:"propertyType,omitempty"`
+	IsLatest         bool        `json:"isLatest"`
+	IsLocked         bool        `json:"isLocked"`
+	IsSecure         bool        `json:"isSecure"`
+	ProductionStatus StatusValue `json:"productionStatus"`
+	StagingStatus    StatusValue `json:"stagingStatus"`
+	LastModifiedTime string      `json:"lastModifiedTime,omitempty"`
+	// MatchLocations are JSON pointers to the matches in the rule tree, e.g. "/rules/children/0/behaviors/1"
+	MatchLocations []string `json:"matchLocations"`
+}
+
+// NewBulkSearch creates a new BulkSearch for query
+func NewBulkSearch(query BulkSearchQuery) *BulkSearch {
+	bulkSearch := &BulkSearch{BulkSearchQuery: query}
+	bulkSearch.Init()
+
+	return bulkSearch
+}
+
+// Done reports whether the search has finished, successfully or not
+func (bulkSearch *BulkSearch) Done() bool {
+	return bulkSearch.SearchTargetStatus == BulkSearchStatusComplete || bulkSearch.SearchTargetStatus == BulkSearchStatusError
+}
+
+// Status returns the SearchTargetStatus of the search
+func (bulkSearch *BulkSearch) Status() BulkStatusValue {
+	return bulkSearch.SearchTargetStatus
+}
+
+// Save submits the bulk search for the property versions of a contract and group
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulksearch
+// Endpoint: POST /papi/v1/bulk/rules-search-requests{?contractId,groupId}
+func (bulkSearch *BulkSearch) Save(contractID string, groupID string, correlationid string) error {
+	id, err := submitBulkRequest(
+		"/papi/v1/bulk/rules-search-requests",
+		contractID,
+		groupID,
+		struct {
+			BulkSearchQuery BulkSearchQuery `json:"bulkSearchQuery"`
+		}{bulkSearch.BulkSearchQuery},
+		"bulkSearchLink",
+		correlationid,
+	)
+	if err != nil {
+		return err
+	}
+
+	bulkSearch.BulkSearchID = id
+	bulkSearch.SearchTargetStatus = BulkSearchStatusPending
+
+	return nil
+}
+
+// GetBulkSearch populates BulkSearch with its status, and results once COMPLETE
+//
+// The returned duration is the time to wait before polling again.
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulksearch
+// Endpoint: GET /papi/v1/bulk/rules-search-requests/{bulkSearchId}
+func (bulkSearch *BulkSearch) GetBulkSearch(correlationid string) (time.Duration, error) {
+	return getBulkRequest(fmt.Sprintf("/papi/v1/bulk/rules-search-requests/%d", bulkSearch.BulkSearchID), bulkSearch, correlationid)
+}
+
+// Poll is GetBulkSearch, see BulkRequest
+func (bulkSearch *BulkSearch) Poll(correlationid string) (time.Duration, error) {
+	return bulkSearch.GetBulkSearch(correlationid)
+}
+
+// WaitForBulkSearch waits for bulkSearch to complete, see WaitForBulkRequest()
+func WaitForBulkSearch(ctx context.Context, bulkSearch *BulkSearch, pollInterval time.Duration) error {
+	return WaitForBulkRequest(ctx, bulkSearch, pollInterval)
+}
+
+// BulkRequest is an asynchronous PAPI bulk request: BulkSearch,
+// BulkVersionCreation, BulkPatch or BulkActivation
+type BulkRequest interface {
+	// Done reports whether the request has finished, successfully or not
+	Done() bool
+	// Status returns the status of the request
+	Status() BulkStatusValue
+	// Poll refreshes the request and returns the time to wait before polling again
+	Poll(correlationid string) (time.Duration, error)
+}
+
+// WaitForBulkRequest polls request until it is COMPLETE, or returns
+// ErrorMap[ErrBulkRequestFailed] when it ends in ERROR. Requests that
+// complete may still contain failed items.
+//
+// pollInterval defaults to the interval suggested by the API. The wait
+// is abandoned with an *ErrWaitCancelled when ctx is done.
+func WaitForBulkRequest(ctx context.Context, request BulkRequest, pollInterval time.Duration) error {
+	start := time.Now()
+	for !request.Done() {
+		retry, err := request.Poll("")
+		if err != nil {
+			return err
+		}
+		if request.Done() {
+			break
+		}
+
+		if pollInterval > 0 {
+			retry = pollInterval
+		}
+
+		timer := time.NewTimer(retry)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &ErrWaitCancelled{LastStatus: string(request.Status()), Elapsed: time.Since(start), Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+
+	if request.Status() != BulkStatusComplete {
+		return fmt.Errorf("%w: %s", ErrorMap[ErrBulkRequestFailed], request.Status())
+	}
+
+	return nil
+}
+
+// submitBulkRequest POSTs body to endpoint and returns the ID in the linkKey link of the response
+func submitBulkRequest(endpoint string, contractID string, groupID string, body interface{}, linkKey string, correlationid string) (int, error) {
+	if contractID != "" || groupID != "" {
+		endpoint = fmt.Sprintf("%s?contractId=%s&groupId=%s", endpoint, contractID, groupID)
+	}
+
+	req, err := client.NewJSONRequest(Config, "POST", endpoint, body)
+	if err != nil {
+		return 0, err
+	}
+
+	edge.PrintHttpRequestCorrelation(req, true, correlationid)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return 0, err
+	}
+
+	edge.PrintHttpResponseCorrelation(res, true, correlationid)
+
+	if client.IsError(res) {
+		return 0, newAPIError(res)
+	}
+
+	var location client.JSONBody
+	if err = client.BodyJSON(res, &location); err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.Atoi(linkID(location, linkKey))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected %s %v", linkKey, location[linkKey])
+	}
+
+	return id, nil
+}
+
+// getBulkRequest GETs endpoint into out and returns the Retry-After of the response
+func getBulkRequest(endpoint string, out interface{}, correlationid string) (time.Duration, error) {
+	req, err := client.NewRequest(Config, "GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	edge.PrintHttpRequestCorrelation(req, true, correlationid)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return 0, err
+	}
+
+	edge.PrintHttpResponseCorrelation(res, true, correlationid)
+
+	if client.IsError(res) {
+		return 0, newAPIError(res)
+	}
+
+	if err = client.BodyJSON(res, out); err != nil {
+		return 0, err
+	}
+
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return 5 * time.Second, nil
+}
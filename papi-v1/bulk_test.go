@@ -0,0 +1,80 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchRules(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/bulk/rules-search-requests").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		MatchParam("groupId", "grp_15225").
+		BodyString(`{"bulkSearchQuery": {"syntax": "JSONPATH", "match": "$..behaviors[?(@.name == 'origin')]"}}`).
+		Reply(202).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/bulk/rules-search-requests/5").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"bulkSearchId": 5, "searchTargetStatus": "IN_PROGRESS"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/bulk/rules-search-requests/5").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+			"bulkSearchId": 5,
+			"searchTargetStatus": "COMPLETE",
+			"results": [{
+				"propertyId": "prp_173136",
+				"propertyName": "example.com",
+				"propertyVersion": 3,
+				"isLatest": true,
+				"productionStatus": "ACTIVE",
+				"stagingStatus": "INACTIVE",
+				"matchLocations": ["/rules/behaviors/0"]
+			}]
+		}`)
+
+	Init(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	bulkSearch := NewBulkSearch(BehaviorSearchQuery("origin"))
+	require.NoError(t, bulkSearch.Save("ctr_1-1TJZH5", "grp_15225", ""))
+	assert.Equal(t, 5, bulkSearch.BulkSearchID)
+
+	require.NoError(t, WaitForBulkSearch(ctx, bulkSearch, time.Millisecond))
+	require.Len(t, bulkSearch.Results, 1)
+	assert.Equal(t, "prp_173136", bulkSearch.Results[0].PropertyID)
+	assert.Equal(t, StatusActive, bulkSearch.Results[0].ProductionStatus)
+	assert.Equal(t, []string{"/rules/behaviors/0"}, bulkSearch.Results[0].MatchLocations)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForBulkSearch_Error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/bulk/rules-search-requests/6").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"bulkSearchId": 6, "searchTargetStatus": "ERROR"}`)
+
+	Init(config)
+
+	bulkSearch := NewBulkSearch(HostnameSearchQuery("www.example.com"))
+	bulkSearch.BulkSearchID = 6
+	err := WaitForBulkSearch(context.Background(), bulkSearch, time.Millisecond)
+	assert.True(t, errors.Is(err, ErrorMap[ErrBulkSearchFailed]))
+}
@@ -0,0 +1,108 @@
+package papi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkEdgeHostnameConcurrency is the number of edge hostnames
+// CreateEdgeHostnamesInBulk creates at a time when no concurrency is given
+const DefaultBulkEdgeHostnameConcurrency = 4
+
+// EdgeHostnameUseCase maps a use case of the product to an edge hostname
+type EdgeHostnameUseCase struct {
+	Option  string `json:"option"`
+	Type    string `json:"type"`
+	UseCase string `json:"useCase"`
+}
+
+// ProductUseCase is a use case supported by a product, see GetProductUseCases()
+type ProductUseCase struct {
+	EdgeHostnameUseCase
+	Description string `json:"description"`
+}
+
+// GetProductUseCases lists the use cases edge hostnames of a product can be mapped for
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getproductusecases
+// Endpoint: GET /papi/v1/products/{productId}/mapping-use-cases{?contractId}
+func GetProductUseCases(contractID string, productID string) ([]ProductUseCase, error) {
+	useCases := []ProductUseCase{}
+	endpoint := fmt.Sprintf("/papi/v1/products/%s/mapping-use-cases?contractId=%s", productID, contractID)
+	if err := doIncludeRequest("GET", endpoint, nil, &useCases); err != nil {
+		return nil, err
+	}
+
+	return useCases, nil
+}
+
+// BulkEdgeHostnameResult is the outcome of creating the edge hostname of a hostname
+type BulkEdgeHostnameResult struct {
+	Hostname     string
+	EdgeHostname *EdgeHostname
+	Err          error
+}
+
+// CreateEdgeHostnamesInBulk creates an edge hostname for each of hostnames
+// with the settings of template (product, IP version behavior, secure network,
+// certificate enrollment and use cases), using the hostname as domain prefix.
+//
+// Up to concurrency (DefaultBulkEdgeHostnameConcurrency when 0) edge hostnames
+// are created at a time. A result is returned for every hostname, in order;
+// hostnames not started before ctx is done fail with ctx.Err().
+func CreateEdgeHostnamesInBulk(ctx context.Context, contractID string, groupID string, hostnames []string, template EdgeHostname, concurrency int) []BulkEdgeHostnameResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBulkEdgeHostnameConcurrency
+	}
+
+	results := make([]BulkEdgeHostnameResult, len(hostnames))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, hostname := range hostnames {
+		results[i].Hostname = hostname
+
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(result *BulkEdgeHostnameResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			edgeHostname := NewEdgeHostname(nil)
+			edgeHostname.ProductID = template.ProductID
+			edgeHostname.DomainPrefix = strings.ToLower(result.Hostname)
+			edgeHostname.DomainSuffix = template.DomainSuffix
+			edgeHostname.IPVersionBehavior = template.IPVersionBehavior
+			edgeHostname.SecureNetwork = template.SecureNetwork
+			edgeHostname.CertEnrollmentId = template.CertEnrollmentId
+			edgeHostname.SlotNumber = template.SlotNumber
+			edgeHostname.UseCases = template.UseCases
+
+			result.EdgeHostname, result.Err = CreateEdgeHostname(contractID, groupID, edgeHostname)
+		}(&results[i])
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// FailedEdgeHostnames returns the results that have an error
+func FailedEdgeHostnames(results []BulkEdgeHostnameResult) []BulkEdgeHostnameResult {
+	var failed []BulkEdgeHostnameResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
@@ -0,0 +1,101 @@
+package papi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateEdgeHostnamesInBulk(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/edgehostnames/").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		BodyString(`{"productId":"prd_Dynamic_Site_Del","domainPrefix":"www.example.com","domainSuffix":"edgesuite.net","ipVersionBehavior":"IPV6_COMPLIANCE","useCases":[{"option":"BACKGROUND","type":"GLOBAL","useCase":"Download_Mode"}]}`).
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"edgeHostnameLink": "/papi/v1/edgehostnames/ehn_1132709?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/edgehostnames/").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		BodyString(`"domainPrefix":"shop.example.com"`).
+		Reply(400).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"type": "https://problems.luna.akamaiapis.net/papi/v0/edgehostname/already-exists", "title": "Edge hostname already exists", "status": 400}`)
+
+	Init(config)
+
+	template := EdgeHostname{
+		ProductID:         "prd_Dynamic_Site_Del",
+		IPVersionBehavior: IPVersionIPv6Compliance,
+		UseCases:          []EdgeHostnameUseCase{{Option: "BACKGROUND", Type: "GLOBAL", UseCase: "Download_Mode"}},
+	}
+	results := CreateEdgeHostnamesInBulk(context.Background(), "ctr_1-1TJZH5", "grp_15225", []string{"www.example.com", "Shop.example.com"}, template, 2)
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "ehn_1132709", results[0].EdgeHostname.EdgeHostnameID)
+
+	failed := FailedEdgeHostnames(results)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "Shop.example.com", failed[0].Hostname)
+	assert.Contains(t, failed[0].Err.Error(), "Edge hostname already exists")
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreateEdgeHostnamesInBulk_Concurrent(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mutex sync.Mutex
+	bothStarted := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		mutex.Lock()
+		if current > maxInFlight {
+			maxInFlight = current
+		}
+		if current == 2 {
+			close(bothStarted)
+		}
+		mutex.Unlock()
+
+		// hold the first requests until two of them are in flight
+		select {
+		case <-bothStarted:
+		case <-time.After(2 * time.Second):
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"edgeHostnameLink": "/papi/v1/edgehostnames/ehn_1132709?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`))
+	}))
+	defer server.Close()
+
+	defaultClient := client.Client
+	client.Client = server.Client()
+	defer func() { client.Client = defaultClient }()
+
+	serverConfig := config
+	serverConfig.Host = strings.TrimPrefix(server.URL, "https://")
+	Init(serverConfig)
+	defer Init(config)
+
+	hostnames := []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"}
+	results := CreateEdgeHostnamesInBulk(context.Background(), "ctr_1-1TJZH5", "grp_15225", hostnames, EdgeHostname{ProductID: "prd_Dynamic_Site_Del"}, 2)
+
+	require.Len(t, results, len(hostnames))
+	assert.Empty(t, FailedEdgeHostnames(results))
+	assert.Equal(t, int32(2), maxInFlight)
+}
@@ -0,0 +1,328 @@
+package papi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// BulkItemStatusValue is used to create an "enum" of possible status values of bulk request items
+type BulkItemStatusValue string
+
+const (
+	// BulkItemStatusSubmitted bulk item status value SUBMITTED
+	BulkItemStatusSubmitted BulkItemStatusValue = "SUBMITTED"
+	// BulkItemStatusInProgress bulk item status value IN_PROGRESS
+	BulkItemStatusInProgress BulkItemStatusValue = "IN_PROGRESS"
+	// BulkItemStatusComplete bulk item status value COMPLETE
+	BulkItemStatusComplete BulkItemStatusValue = "COMPLETE"
+	// BulkItemStatusFailed bulk item status value FAILED
+	BulkItemStatusFailed BulkItemStatusValue = "FAILED"
+)
+
+// BulkVersionCreation represents a PAPI bulk property version creation request
+type BulkVersionCreation struct {
+	client.Resource
+	BulkCreateVersionID      int                `json:"bulkCreateVersionId,omitempty"`
+	BulkCreateVersionsStatus BulkStatusValue    `json:"bulkCreateVersionsStatus,omitempty"`
+	CreatePropertyVersions   []*BulkVersionItem `json:"createPropertyVersions"`
+}
+
+// BulkVersionItem is a property version to create from CreateFromVersion
+type BulkVersionItem struct {
+	PropertyID            string `json:"propertyId"`
+	CreateFromVersion     int    `json:"createFromVersion"`
+	CreateFromVersionEtag string `json:"createFromVersionEtag,omitempty"`
+	// PropertyVersion is the created version, once Status is COMPLETE
+	PropertyVersion int                 `json:"propertyVersion,omitempty"`
+	Status          BulkItemStatusValue `json:"status,omitempty"`
+	FatalError      string              `json:"fatalError,omitempty"`
+}
+
+// NewBulkVersionCreation creates a new BulkVersionCreation
+func NewBulkVersionCreation() *BulkVersionCreation {
+	bulkVersionCreation := &BulkVersionCreation{}
+	bulkVersionCreation.Init()
+
+	return bulkVersionCreation
+}
+
+// AddProperty adds a version of property created from createFromVersion
+func (bulkVersionCreation *BulkVersionCreation) AddProperty(propertyID string, createFromVersion int) *BulkVersionItem {
+	item := &BulkVersionItem{PropertyID: propertyID, CreateFromVersion: createFromVersion}
+	bulkVersionCreation.CreatePropertyVersions = append(bulkVersionCreation.CreatePropertyVersions, item)
+
+	return item
+}
+
+// Done reports whether the version creation has finished
+func (bulkVersionCreation *BulkVersionCreation) Done() bool {
+	return bulkVersionCreation.BulkCreateVersionsStatus == BulkStatusComplete || bulkVersionCreation.BulkCreateVersionsStatus == BulkStatusError
+}
+
+// Status returns the BulkCreateVersionsStatus of the version creation
+func (bulkVersionCreation *BulkVersionCreation) Status() BulkStatusValue {
+	return bulkVersionCreation.BulkCreateVersionsStatus
+}
+
+// Save submits the version creation
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulkversioning
+// Endpoint: POST /papi/v1/bulk/property-version-creations{?contractId,groupId}
+func (bulkVersionCreation *BulkVersionCreation) Save(contractID string, groupID string, correlationid string) error {
+	id, err := submitBulkRequest(
+		"/papi/v1/bulk/property-version-creations",
+		contractID,
+		groupID,
+		struct {
+			CreatePropertyVersions []*BulkVersionItem `json:"createPropertyVersions"`
+		}{bulkVersionCreation.CreatePropertyVersions},
+		"bulkCreateVersionLink",
+		correlationid,
+	)
+	if err != nil {
+		return err
+	}
+
+	bulkVersionCreation.BulkCreateVersionID = id
+	bulkVersionCreation.BulkCreateVersionsStatus = BulkStatusPending
+
+	return nil
+}
+
+// Poll populates BulkVersionCreation with its status and created versions, see BulkRequest
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulkversioning
+// Endpoint: GET /papi/v1/bulk/property-version-creations/{bulkCreateId}
+func (bulkVersionCreation *BulkVersionCreation) Poll(correlationid string) (time.Duration, error) {
+	return getBulkRequest(fmt.Sprintf("/papi/v1/bulk/property-version-creations/%d", bulkVersionCreation.BulkCreateVersionID), bulkVersionCreation, correlationid)
+}
+
+// Failed returns the versions that could not be created
+func (bulkVersionCreation *BulkVersionCreation) Failed() []*BulkVersionItem {
+	var failed []*BulkVersionItem
+	for _, item := range bulkVersionCreation.CreatePropertyVersions {
+		if item.Status == BulkItemStatusFailed {
+			failed = append(failed, item)
+		}
+	}
+
+	return failed
+}
+
+// JSONPatchOperation is an RFC 6902 operation on a rule tree
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// BulkPatch represents a PAPI bulk rule tree patch request
+type BulkPatch struct {
+	client.Resource
+	BulkPatchID           int              `json:"bulkPatchId,omitempty"`
+	BulkPatchStatus       BulkStatusValue  `json:"bulkPatchStatus,omitempty"`
+	PatchPropertyVersions []*BulkPatchItem `json:"patchPropertyVersions"`
+}
+
+// BulkPatchItem are the patches applied to the rule tree of a property version
+type BulkPatchItem struct {
+	PropertyID      string               `json:"propertyId"`
+	PropertyVersion int                  `json:"propertyVersion"`
+	Etag            string               `json:"etag,omitempty"`
+	Patches         []JSONPatchOperation `json:"patches"`
+	Status          BulkItemStatusValue  `json:"status,omitempty"`
+	FatalError      string               `json:"fatalError,omitempty"`
+}
+
+// NewBulkPatch creates a new BulkPatch
+func NewBulkPatch() *BulkPatch {
+	bulkPatch := &BulkPatch{}
+	bulkPatch.Init()
+
+	return bulkPatch
+}
+
+// AddPatches adds patches of the rule tree of a property version
+func (bulkPatch *BulkPatch) AddPatches(propertyID string, propertyVersion int, patches ...JSONPatchOperation) *BulkPatchItem {
+	item := &BulkPatchItem{PropertyID: propertyID, PropertyVersion: propertyVersion, Patches: patches}
+	bulkPatch.PatchPropertyVersions = append(bulkPatch.PatchPropertyVersions, item)
+
+	return item
+}
+
+// Done reports whether the patch has finished
+func (bulkPatch *BulkPatch) Done() bool {
+	return bulkPatch.BulkPatchStatus == BulkStatusComplete || bulkPatch.BulkPatchStatus == BulkStatusError
+}
+
+// Status returns the BulkPatchStatus of the patch
+func (bulkPatch *BulkPatch) Status() BulkStatusValue {
+	return bulkPatch.BulkPatchStatus
+}
+
+// Save submits the patch
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulkpatch
+// Endpoint: POST /papi/v1/bulk/rules-patch-requests{?contractId,groupId}
+func (bulkPatch *BulkPatch) Save(contractID string, groupID string, correlationid string) error {
+	id, err := submitBulkRequest(
+		"/papi/v1/bulk/rules-patch-requests",
+		contractID,
+		groupID,
+		struct {
+			PatchPropertyVersions []*BulkPatchItem `json:"patchPropertyVersions"`
+		}{bulkPatch.PatchPropertyVersions},
+		"bulkPatchLink",
+		correlationid,
+	)
+	if err != nil {
+		return err
+	}
+
+	bulkPatch.BulkPatchID = id
+	bulkPatch.BulkPatchStatus = BulkStatusPending
+
+	return nil
+}
+
+// Poll populates BulkPatch with its status, see BulkRequest
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulkpatch
+// Endpoint: GET /papi/v1/bulk/rules-patch-requests/{bulkPatchId}
+func (bulkPatch *BulkPatch) Poll(correlationid string) (time.Duration, error) {
+	return getBulkRequest(fmt.Sprintf("/papi/v1/bulk/rules-patch-requests/%d", bulkPatch.BulkPatchID), bulkPatch, correlationid)
+}
+
+// Failed returns the property versions that could not be patched
+func (bulkPatch *BulkPatch) Failed() []*BulkPatchItem {
+	var failed []*BulkPatchItem
+	for _, item := range bulkPatch.PatchPropertyVersions {
+		if item.Status == BulkItemStatusFailed {
+			failed = append(failed, item)
+		}
+	}
+
+	return failed
+}
+
+// BulkActivationSettings are the defaults of all activations of a BulkActivation
+type BulkActivationSettings struct {
+	AcknowledgeAllWarnings bool     `json:"acknowledgeAllWarnings"`
+	NotifyEmails           []string `json:"notifyEmails,omitempty"`
+	UseFastFallback        bool     `json:"useFastFallback"`
+	FastPush               bool     `json:"fastPush"`
+}
+
+// BulkActivation represents a PAPI bulk activation request
+type BulkActivation struct {
+	client.Resource
+	BulkActivationID          int                     `json:"bulkActivationId,omitempty"`
+	BulkActivationStatus      BulkStatusValue         `json:"bulkActivationStatus,omitempty"`
+	DefaultActivationSettings *BulkActivationSettings `json:"defaultActivationSettings,omitempty"`
+	ActivatePropertyVersions  []*BulkActivationItem   `json:"activatePropertyVersions"`
+}
+
+// BulkActivationItem is a property version to activate on a network
+type BulkActivationItem struct {
+	PropertyID             string       `json:"propertyId"`
+	PropertyVersion        int          `json:"propertyVersion"`
+	Network                NetworkValue `json:"network"`
+	Note                   string       `json:"note,omitempty"`
+	NotifyEmails           []string     `json:"notifyEmails,omitempty"`
+	AcknowledgeAllWarnings bool         `json:"acknowledgeAllWarnings,omitempty"`
+	// ActivationID is the activation of the property, once submitted
+	ActivationID string              `json:"activationId,omitempty"`
+	TaskStatus   BulkItemStatusValue `json:"taskStatus,omitempty"`
+	FatalError   string              `json:"fatalError,omitempty"`
+}
+
+// NewBulkActivation creates a new BulkActivation with settings for all its activations
+func NewBulkActivation(settings *BulkActivationSettings) *BulkActivation {
+	bulkActivation := &BulkActivation{DefaultActivationSettings: settings}
+	bulkActivation.Init()
+
+	return bulkActivation
+}
+
+// AddProperty adds the activation of a property version on network
+func (bulkActivation *BulkActivation) AddProperty(propertyID string, propertyVersion int, network NetworkValue) *BulkActivationItem {
+	item := &BulkActivationItem{PropertyID: propertyID, PropertyVersion: propertyVersion, Network: network}
+	bulkActivation.ActivatePropertyVersions = append(bulkActivation.ActivatePropertyVersions, item)
+
+	return item
+}
+
+// Done reports whether the activations have finished
+func (bulkActivation *BulkActivation) Done() bool {
+	return bulkActivation.BulkActivationStatus == BulkStatusComplete || bulkActivation.BulkActivationStatus == BulkStatusError
+}
+
+// Status returns the BulkActivationStatus of the activation
+func (bulkActivation *BulkActivation) Status() BulkStatusValue {
+	return bulkActivation.BulkActivationStatus
+}
+
+// Save submits the activations
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postbulkactivations
+// Endpoint: POST /papi/v1/bulk/activations{?contractId,groupId}
+func (bulkActivation *BulkActivation) Save(contractID string, groupID string, correlationid string) error {
+	var err error
+	if settings := bulkActivation.DefaultActivationSettings; settings != nil {
+		if len(settings.NotifyEmails) == 0 {
+			settings.NotifyEmails = defaultNotifyEmails
+		}
+		if settings.NotifyEmails, err = NormalizeNotifyEmails(settings.NotifyEmails); err != nil {
+			return err
+		}
+	} else if len(defaultNotifyEmails) > 0 {
+		bulkActivation.DefaultActivationSettings = &BulkActivationSettings{NotifyEmails: DefaultNotifyEmails()}
+	}
+	for _, item := range bulkActivation.ActivatePropertyVersions {
+		if item.NotifyEmails, err = NormalizeNotifyEmails(item.NotifyEmails); err != nil {
+			return fmt.Errorf("%s version %d: %w", item.PropertyID, item.PropertyVersion, err)
+		}
+	}
+
+	id, err := submitBulkRequest(
+		"/papi/v1/bulk/activations",
+		contractID,
+		groupID,
+		struct {
+			DefaultActivationSettings *BulkActivationSettings `json:"defaultActivationSettings,omitempty"`
+			ActivatePropertyVersions  []*BulkActivationItem   `json:"activatePropertyVersions"`
+		}{bulkActivation.DefaultActivationSettings, bulkActivation.ActivatePropertyVersions},
+		"bulkActivationLink",
+		correlationid,
+	)
+	if err != nil {
+		return err
+	}
+
+	bulkActivation.BulkActivationID = id
+	bulkActivation.BulkActivationStatus = BulkStatusPending
+
+	return nil
+}
+
+// Poll populates BulkActivation with its status and activations, see BulkRequest
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getbulkactivation
+// Endpoint: GET /papi/v1/bulk/activations/{bulkActivationId}
+func (bulkActivation *BulkActivation) Poll(correlationid string) (time.Duration, error) {
+	return getBulkRequest(fmt.Sprintf("/papi/v1/bulk/activations/%d", bulkActivation.BulkActivationID), bulkActivation, correlationid)
+}
+
+// Failed returns the activations that could not be submitted or failed
+func (bulkActivation *BulkActivation) Failed() []*BulkActivationItem {
+	var failed []*BulkActivationItem
+	for _, item := range bulkActivation.ActivatePropertyVersions {
+		if item.TaskStatus == BulkItemStatusFailed {
+			failed = append(failed, item)
+		}
+	}
+
+	return failed
+}
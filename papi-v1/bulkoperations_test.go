@@ -0,0 +1,94 @@
+package papi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestBulkActivation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/bulk/activations").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		BodyString(`{
+			"defaultActivationSettings": {"acknowledgeAllWarnings": true, "notifyEmails": ["you@example.com"], "useFastFallback": false, "fastPush": true},
+			"activatePropertyVersions": [
+				{"propertyId": "prp_1", "propertyVersion": 2, "network": "STAGING"},
+				{"propertyId": "prp_2", "propertyVersion": 5, "network": "STAGING"}
+			]
+		}`).
+		Reply(202).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"bulkActivationLink": "/papi/v1/bulk/activations/234?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/bulk/activations/234").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Retry-After", "1").
+		BodyString(`{"bulkActivationId": 234, "bulkActivationStatus": "IN_PROGRESS"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/bulk/activations/234").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+			"bulkActivationId": 234,
+			"bulkActivationStatus": "COMPLETE",
+			"activatePropertyVersions": [
+				{"propertyId": "prp_1", "propertyVersion": 2, "network": "STAGING", "activationId": "atv_1", "taskStatus": "COMPLETE"},
+				{"propertyId": "prp_2", "propertyVersion": 5, "network": "STAGING", "taskStatus": "FAILED", "fatalError": "property is locked"}
+			]
+		}`)
+
+	Init(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	bulkActivation := NewBulkActivation(&BulkActivationSettings{
+		AcknowledgeAllWarnings: true,
+		NotifyEmails:           []string{"you@example.com"},
+		FastPush:               true,
+	})
+	bulkActivation.AddProperty("prp_1", 2, NetworkStaging)
+	bulkActivation.AddProperty("prp_2", 5, NetworkStaging)
+
+	require.NoError(t, bulkActivation.Save("ctr_1-1TJZH5", "grp_15225", ""))
+	assert.Equal(t, 234, bulkActivation.BulkActivationID)
+
+	require.NoError(t, WaitForBulkRequest(ctx, bulkActivation, time.Millisecond))
+	assert.Equal(t, "atv_1", bulkActivation.ActivatePropertyVersions[0].ActivationID)
+	failed := bulkActivation.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "prp_2", failed[0].PropertyID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreateVersionsInBulk(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/bulk/property-version-creations").
+		BodyString(`{"createPropertyVersions": [{"propertyId": "prp_1", "createFromVersion": 2}]}`).
+		Reply(202).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"bulkCreateVersionLink": "/papi/v1/bulk/property-version-creations/9"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/bulk/property-version-creations/9").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"bulkCreateVersionId": 9, "bulkCreateVersionsStatus": "COMPLETE", "createPropertyVersions": [{"propertyId": "prp_1", "createFromVersion": 2, "propertyVersion": 3, "status": "COMPLETE"}]}`)
+
+	Init(config)
+
+	bulkVersionCreation, err := CreateVersionsInBulk(context.Background(), "ctr_1-1TJZH5", "grp_15225", map[string]int{"prp_1": 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, bulkVersionCreation.CreatePropertyVersions[0].PropertyVersion)
+	assert.Empty(t, bulkVersionCreation.Failed())
+	assert.True(t, gock.IsDone())
+}
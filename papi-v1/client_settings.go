@@ -1,6 +1,10 @@
 package papi
 
 import (
+	"fmt"
+	"net/http"
+	"sync"
+
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 )
@@ -8,9 +12,18 @@ import (
 // ClientSettings represents the PAPI client settings resource
 type ClientSettings struct {
 	client.Resource
-	RuleFormat string `json:"ruleFormat"`
+	RuleFormat  string `json:"ruleFormat"`
+	UsePrefixes bool   `json:"usePrefixes"`
 }
 
+var (
+	// defaultClientSettings are the settings last fetched or saved, rule tree
+	// requests use their rule format when Rules.RuleFormat is not set
+	defaultClientSettings *ClientSettings
+	// defaultClientSettingsMu guards defaultClientSettings
+	defaultClientSettingsMu sync.RWMutex
+)
+
 // NewClientSettings creates a new ClientSettings
 func NewClientSettings() *ClientSettings {
 	clientSettings := &ClientSettings{}
@@ -39,13 +52,15 @@ func (clientSettings *ClientSettings) GetClientSettings() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err := client.BodyJSON(res, clientSettings); err != nil {
 		return err
 	}
 
+	clientSettings.setDefault()
+
 	return nil
 }
 
@@ -74,7 +89,7 @@ func (clientSettings *ClientSettings) Save() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newClientSettings := NewClientSettings()
@@ -83,6 +98,43 @@ func (clientSettings *ClientSettings) Save() error {
 	}
 
 	clientSettings.RuleFormat = newClientSettings.RuleFormat
+	clientSettings.UsePrefixes = newClientSettings.UsePrefixes
+	clientSettings.setDefault()
 
 	return nil
 }
+
+func (clientSettings *ClientSettings) setDefault() {
+	settings := &ClientSettings{
+		RuleFormat:  clientSettings.RuleFormat,
+		UsePrefixes: clientSettings.UsePrefixes,
+	}
+
+	defaultClientSettingsMu.Lock()
+	defer defaultClientSettingsMu.Unlock()
+	defaultClientSettings = settings
+}
+
+// getDefaultClientSettings returns the settings last fetched or saved, nil if none
+func getDefaultClientSettings() *ClientSettings {
+	defaultClientSettingsMu.RLock()
+	defer defaultClientSettingsMu.RUnlock()
+
+	return defaultClientSettings
+}
+
+// setRuleFormatHeader sets header (Accept or Content-Type) to the rule tree
+// media type of format, falling back to the default client settings
+//
+// "latest" and an empty format leave the header untouched.
+func setRuleFormatHeader(req *http.Request, header string, format string) {
+	if settings := getDefaultClientSettings(); format == "" && settings != nil {
+		format = settings.RuleFormat
+	}
+
+	if format == "" || format == "latest" {
+		return
+	}
+
+	req.Header.Set(header, fmt.Sprintf("application/vnd.akamai.papirules.%s+json", format))
+}
@@ -0,0 +1,85 @@
+package papi
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClientSettings_DefaultRuleFormat(t *testing.T) {
+	defer gock.Off()
+	defer func() { defaultClientSettings = nil }()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/client-settings").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"ruleFormat": "v2020-03-04", "usePrefixes": true}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/3/rules").
+		MatchHeader("Accept", "application/vnd.akamai.papirules.v2020-03-04\\+json").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "propertyVersion": 3, "ruleFormat": "v2020-03-04", "rules": {"name": "default"}}`)
+
+	Init(config)
+
+	clientSettings, err := GetClientSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "v2020-03-04", clientSettings.RuleFormat)
+	assert.True(t, clientSettings.UsePrefixes)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+	property.LatestVersion = 3
+	rules := NewRules()
+	require.NoError(t, rules.GetRules(property, ""))
+	assert.Equal(t, "v2020-03-04", rules.RuleFormat)
+	assert.True(t, gock.IsDone())
+}
+
+func TestUpdateClientSettings(t *testing.T) {
+	defer gock.Off()
+	defer func() { defaultClientSettings = nil }()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put("/papi/v1/client-settings").
+		BodyString(`{"ruleFormat": "latest", "usePrefixes": false}`).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"ruleFormat": "latest", "usePrefixes": false}`)
+
+	Init(config)
+
+	clientSettings, err := UpdateClientSettings("latest", false)
+	require.NoError(t, err)
+	assert.Equal(t, "latest", clientSettings.RuleFormat)
+	assert.False(t, clientSettings.UsePrefixes)
+	assert.Equal(t, "latest", getDefaultClientSettings().RuleFormat)
+	assert.True(t, gock.IsDone())
+}
+
+func TestClientSettings_ConcurrentDefault(t *testing.T) {
+	defer func() { defaultClientSettings = nil }()
+
+	var wg sync.WaitGroup
+	for _, format := range []string{"v2020-03-04", "v2021-01-01"} {
+		wg.Add(2)
+		go func(format string) {
+			defer wg.Done()
+			(&ClientSettings{RuleFormat: format}).setDefault()
+		}(format)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", "/papi/v1/properties/prp_173136/versions/3/rules", nil)
+			setRuleFormatHeader(req, "Accept", "")
+		}()
+	}
+	wg.Wait()
+
+	assert.Contains(t, []string{"v2020-03-04", "v2021-01-01"}, getDefaultClientSettings().RuleFormat)
+}
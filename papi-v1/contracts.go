@@ -49,7 +49,7 @@ func (contracts *Contracts) PostUnmarshalJSON() error {
 // Endpoint: GET /papi/v1/contracts
 func (contracts *Contracts) GetContracts(correlationid string) error {
 
-	cachecontracts, found := Profilecache.Get("contracts")
+	cachecontracts, found := Profilecache.Get(CacheKeyContracts)
 	if found {
 		json.Unmarshal(cachecontracts.([]byte), contracts)
 		return nil
@@ -75,7 +75,7 @@ func (contracts *Contracts) GetContracts(correlationid string) error {
 		edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 		if client.IsError(res) {
-			return client.NewAPIError(res)
+			return newAPIError(res)
 		}
 
 		if err = client.BodyJSON(res, contracts); err != nil {
@@ -86,7 +86,7 @@ func (contracts *Contracts) GetContracts(correlationid string) error {
 			return err
 		}
 		byt, _ := json.Marshal(contracts)
-		Profilecache.Set("contracts", byt, cache.DefaultExpiration)
+		Profilecache.Set(CacheKeyContracts, byt, cache.DefaultExpiration)
 		return nil
 	}
 }
@@ -170,7 +170,7 @@ func (contract *Contract) GetProducts() (*Products, error) {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return nil, client.NewAPIError(res)
+		return nil, newAPIError(res)
 	}
 
 	products := NewProducts()
@@ -31,10 +31,18 @@ type CpCodes struct {
 
 // NewCpCodes creates a new *CpCodes
 func NewCpCodes(contract *Contract, group *Group) *CpCodes {
-	return &CpCodes{
+	cpcodes := &CpCodes{
 		Contract: contract,
 		Group:    group,
 	}
+	if contract != nil {
+		cpcodes.ContractID = contract.ContractID
+	}
+	if group != nil {
+		cpcodes.GroupID = group.GroupID
+	}
+
+	return cpcodes
 }
 
 // PostUnmarshalJSON is called after UnmarshalJSON to setup the
@@ -74,7 +82,7 @@ func (cpcodes *CpCodes) PostUnmarshalJSON() error {
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listcpcodes
 // Endpoint: GET /papi/v1/cpcodes/{?contractId,groupId}
 func (cpcodes *CpCodes) GetCpCodes(correlationid string) error {
-	cachecpcodes, found := Profilecache.Get("cpcodes")
+	cachecpcodes, found := Profilecache.Get(CacheKeyCpCodes)
 	if found {
 		json.Unmarshal(cachecpcodes.([]byte), cpcodes)
 		return nil
@@ -108,14 +116,14 @@ func (cpcodes *CpCodes) GetCpCodes(correlationid string) error {
 		edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 		if client.IsError(res) {
-			return client.NewAPIError(res)
+			return newAPIError(res)
 		}
 
 		if err = client.BodyJSON(res, cpcodes); err != nil {
 			return err
 		}
 		byt, _ := json.Marshal(cpcodes)
-		Profilecache.Set("cpcodes", byt, cache.DefaultExpiration)
+		Profilecache.Set(CacheKeyCpCodes, byt, cache.DefaultExpiration)
 		return nil
 	}
 }
@@ -211,7 +219,7 @@ func (cpcode *CpCode) GetCpCode() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newCpcodes := NewCpCodes(nil, nil)
@@ -275,7 +283,7 @@ func (cpcode *CpCode) Save(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	var location client.JSONBody
@@ -303,7 +311,7 @@ func (cpcode *CpCode) Save(correlationid string) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	cpcodes := NewCpCodes(nil, nil)
@@ -329,3 +337,99 @@ func (cpcode *CpCode) Save(correlationid string) error {
 
 	return nil
 }
+
+// GetCPCodes lists the CP codes of a contract and group, bypassing the cache
+//
+// API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listcpcodes
+// Endpoint: GET /papi/v1/cpcodes/{?contractId,groupId}
+func GetCPCodes(contractID string, groupID string) ([]*CpCode, error) {
+	return getCPCodes(fmt.Sprintf("/papi/v1/cpcodes?contractId=%s&groupId=%s", contractID, groupID))
+}
+
+// GetCPCode retrieves a CP code of a contract and group
+//
+// API Docs: https://developer.akamai.com/api/luna/papi/resources.html#getacpcode
+// Endpoint: GET /papi/v1/cpcodes/{cpcodeId}{?contractId,groupId}
+func GetCPCode(contractID string, groupID string, cpcodeID string) (*CpCode, error) {
+	cpcodes, err := getCPCodes(fmt.Sprintf("/papi/v1/cpcodes/%s?contractId=%s&groupId=%s", cpcodeID, contractID, groupID))
+	if err != nil {
+		return nil, err
+	}
+	if len(cpcodes) == 0 {
+		return nil, fmt.Errorf("CP Code \"%s\" not found", cpcodeID)
+	}
+
+	return cpcodes[0], nil
+}
+
+// CreateCPCode creates a CP code for productID in a contract and group, and
+// returns it with its ID and created date
+//
+// API Docs: https://developer.akamai.com/api/luna/papi/resources.html#createanewcpcode
+// Endpoint: POST /papi/v1/cpcodes/{?contractId,groupId}
+func CreateCPCode(contractID string, groupID string, name string, productID string) (*CpCode, error) {
+	req, err := client.NewJSONRequest(
+		Config,
+		"POST",
+		fmt.Sprintf("/papi/v1/cpcodes?contractId=%s&groupId=%s", contractID, groupID),
+		client.JSONBody{"productId": productID, "cpcodeName": name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, newAPIError(res)
+	}
+
+	var location client.JSONBody
+	if err = client.BodyJSON(res, &location); err != nil {
+		return nil, err
+	}
+
+	Profilecache.Delete(CacheKeyCpCodes)
+
+	return GetCPCode(contractID, groupID, linkID(location, "cpcodeLink"))
+}
+
+// getCPCodes retrieves the CP codes at endpoint without populating their
+// parent CpCodes (and its contract and group)
+func getCPCodes(endpoint string) ([]*CpCode, error) {
+	req, err := client.NewRequest(Config, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return nil, newAPIError(res)
+	}
+
+	response := struct {
+		CpCodes struct {
+			Items []*CpCode `json:"items"`
+		} `json:"cpcodes"`
+	}{}
+	if err = client.BodyJSON(res, &response); err != nil {
+		return nil, err
+	}
+
+	return response.CpCodes.Items, nil
+}
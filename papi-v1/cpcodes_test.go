@@ -0,0 +1,54 @@
+package papi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateCPCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/cpcodes").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		MatchParam("groupId", "grp_15225").
+		BodyString(`{"cpcodeName": "example.com", "productId": "prd_Site_Accel"}`).
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"cpcodeLink": "/papi/v1/cpcodes/cpc_33190?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/cpcodes/cpc_33190").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+			"accountId": "act_1-1TJZFB",
+			"contractId": "ctr_1-1TJZH5",
+			"groupId": "grp_15225",
+			"cpcodes": {"items": [{"cpcodeId": "cpc_33190", "cpcodeName": "example.com", "productIds": ["prd_Site_Accel"], "createdDate": "2020-06-01T12:30:00Z"}]}
+		}`)
+
+	Init(config)
+
+	cpcode, err := CreateCPCode("ctr_1-1TJZH5", "grp_15225", "example.com", "prd_Site_Accel")
+	require.NoError(t, err)
+	assert.Equal(t, 33190, cpcode.ID())
+	assert.Equal(t, []string{"prd_Site_Accel"}, cpcode.ProductIDs)
+	assert.Equal(t, time.Date(2020, 6, 1, 12, 30, 0, 0, time.UTC), cpcode.CreatedDate)
+	assert.True(t, gock.IsDone())
+}
+
+func TestNewCpCodes_IDs(t *testing.T) {
+	contract := NewContract(NewContracts())
+	contract.ContractID = "ctr_1-1TJZH5"
+	group := NewGroup(NewGroups())
+	group.GroupID = "grp_15225"
+
+	cpcodes := NewCpCodes(contract, group)
+	assert.Equal(t, "ctr_1-1TJZH5", cpcodes.ContractID)
+	assert.Equal(t, "grp_15225", cpcodes.GroupID)
+}
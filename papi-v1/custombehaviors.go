@@ -67,7 +67,7 @@ func (behaviors *CustomBehaviors) GetCustomBehaviors() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, behaviors); err != nil {
@@ -135,7 +135,7 @@ func (behavior *CustomBehavior) GetCustomBehavior() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newCustomBehaviors := NewCustomBehaviors()
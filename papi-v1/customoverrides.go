@@ -69,7 +69,7 @@ func (overrides *CustomOverrides) GetCustomOverrides() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, overrides); err != nil {
@@ -136,7 +136,7 @@ func (override *CustomOverride) GetCustomOverride() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newCustomOverrides := NewCustomOverrides()
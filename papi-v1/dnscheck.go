@@ -0,0 +1,99 @@
+package papi
+
+import (
+	"net"
+	"strings"
+)
+
+// CNAMEResolver returns the CNAME targets of hostname, in resolution order
+//
+// Use LiveCNAMEResolver to query public DNS, or wrap dnsv2.CNAMEChain to check
+// the records configured in Edge DNS before they are live:
+//
+//	resolver := func(hostname string) ([]string, error) {
+//		return dnsv2.CNAMEChain("example.com", hostname)
+//	}
+type CNAMEResolver func(hostname string) ([]string, error)
+
+// LiveCNAMEResolver resolves hostname with the system resolver
+//
+// The system resolver only reports the final canonical name, so the returned
+// chain has at most one element.
+func LiveCNAMEResolver(hostname string) ([]string, error) {
+	cname, err := net.LookupCNAME(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	cname = strings.TrimSuffix(cname, ".")
+	if strings.EqualFold(cname, strings.TrimSuffix(hostname, ".")) {
+		return nil, nil
+	}
+
+	return []string{cname}, nil
+}
+
+// DNSMismatch is a property hostname whose DNS does not point at its edge hostname
+type DNSMismatch struct {
+	Hostname             string
+	ExpectedEdgeHostname string
+	// Chain is the CNAME chain that was found
+	Chain []string
+	// Err is set when the hostname could not be resolved
+	Err error
+}
+
+// CheckHostnameDNS verifies that each property hostname's CNAME chain leads to its edge hostname
+//
+// A chain matches when it contains the edge hostname, or when it ends at the same
+// name the edge hostname itself resolves to (e.g. e1234.a.akamaiedge.net), which
+// covers resolvers that only report the final canonical name.
+func (hostnames *Hostnames) CheckHostnameDNS(resolve CNAMEResolver) []DNSMismatch {
+	var mismatches []DNSMismatch
+	edgeTargets := map[string][]string{}
+
+	for _, hostname := range hostnames.Hostnames.Items {
+		if hostname.CnameTo == "" {
+			continue
+		}
+
+		mismatch := DNSMismatch{Hostname: hostname.CnameFrom, ExpectedEdgeHostname: hostname.CnameTo}
+
+		chain, err := resolve(hostname.CnameFrom)
+		if err != nil {
+			mismatch.Err = err
+			mismatches = append(mismatches, mismatch)
+			continue
+		}
+		mismatch.Chain = chain
+
+		if containsName(chain, hostname.CnameTo) {
+			continue
+		}
+
+		edgeChain, ok := edgeTargets[hostname.CnameTo]
+		if !ok {
+			// an unresolvable edge hostname only means there is nothing more to compare
+			edgeChain, _ = resolve(hostname.CnameTo)
+			edgeTargets[hostname.CnameTo] = edgeChain
+		}
+		if len(chain) > 0 && len(edgeChain) > 0 && strings.EqualFold(chain[len(chain)-1], edgeChain[len(edgeChain)-1]) {
+			continue
+		}
+
+		mismatches = append(mismatches, mismatch)
+	}
+
+	return mismatches
+}
+
+func containsName(names []string, name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	for _, n := range names {
+		if strings.EqualFold(strings.TrimSuffix(n, "."), name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostnames_CheckHostnameDNS(t *testing.T) {
+	hostnames := NewHostnames()
+	for _, h := range [][2]string{
+		{"www.example.com", "www.example.com.edgekey.net"},
+		{"api.example.com", "api.example.com.edgesuite.net"},
+		{"img.example.com", "img.example.com.edgesuite.net"},
+		{"old.example.com", "old.example.com.edgesuite.net"},
+		{"gone.example.com", "gone.example.com.edgesuite.net"},
+	} {
+		hostname := NewHostname(hostnames)
+		hostname.CnameFrom = h[0]
+		hostname.CnameTo = h[1]
+		hostnames.Hostnames.Items = append(hostnames.Hostnames.Items, hostname)
+	}
+
+	records := map[string][]string{
+		"www.example.com": {"www.example.com.edgekey.net", "e1.a.akamaiedge.net"},
+		// only the final canonical name is known, and it matches the edge hostname's
+		"api.example.com":               {"a1.g.akamai.net"},
+		"api.example.com.edgesuite.net": {"a1.g.akamai.net"},
+		"img.example.com":               {"img.example.com.edgesuite.net."},
+		"old.example.com":               {"example.othercdn.net"},
+	}
+	resolver := func(hostname string) ([]string, error) {
+		chain, ok := records[hostname]
+		if !ok {
+			return nil, errors.New("no such host")
+		}
+		return chain, nil
+	}
+
+	mismatches := hostnames.CheckHostnameDNS(resolver)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "old.example.com", mismatches[0].Hostname)
+	assert.Equal(t, "old.example.com.edgesuite.net", mismatches[0].ExpectedEdgeHostname)
+	assert.Equal(t, []string{"example.othercdn.net"}, mismatches[0].Chain)
+	assert.NoError(t, mismatches[0].Err)
+	assert.Equal(t, "gone.example.com", mismatches[1].Hostname)
+	assert.Error(t, mismatches[1].Err)
+}
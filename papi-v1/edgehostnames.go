@@ -67,7 +67,7 @@ func (edgeHostnames *EdgeHostnames) GetEdgeHostnames(contract *Contract, group *
 		return errors.New("function requires at least \"group\" argument")
 	}
 
-	cacheedgehostnames, found := Profilecache.Get("edgehostnames")
+	cacheedgehostnames, found := Profilecache.Get(CacheKeyEdgeHostnames)
 	if found {
 		json.Unmarshal(cacheedgehostnames.([]byte), edgeHostnames)
 		return nil
@@ -107,7 +107,7 @@ func (edgeHostnames *EdgeHostnames) GetEdgeHostnames(contract *Contract, group *
 		edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 		if client.IsError(res) {
-			return client.NewAPIError(res)
+			return newAPIError(res)
 		}
 
 		if err = client.BodyJSON(res, edgeHostnames); err != nil {
@@ -115,7 +115,7 @@ func (edgeHostnames *EdgeHostnames) GetEdgeHostnames(contract *Contract, group *
 		}
 
 		byt, _ := json.Marshal(edgeHostnames)
-		Profilecache.Set("edgehostnames", byt, cache.DefaultExpiration)
+		Profilecache.Set(CacheKeyEdgeHostnames, byt, cache.DefaultExpiration)
 		return nil
 	}
 }
@@ -161,23 +161,47 @@ func (edgeHostnames *EdgeHostnames) AddEdgeHostname(edgeHostname *EdgeHostname)
 type EdgeHostname struct {
 	client.Resource
 	parent                 *EdgeHostnames
-	EdgeHostnameID         string      `json:"edgeHostnameId,omitempty"`
-	EdgeHostnameDomain     string      `json:"edgeHostnameDomain,omitempty"`
-	ProductID              string      `json:"productId"`
-	DomainPrefix           string      `json:"domainPrefix"`
-	DomainSuffix           string      `json:"domainSuffix"`
-	CertEnrollmentId       int         `json:"certEnrollmentId,omitempty"`
-	SlotNumber             int         `json:"slotNumber,omitempty"`
-	SecureNetwork          string      `json:"secureNetwork,omitempty"`
-	Status                 StatusValue `json:"status,omitempty"`
-	Secure                 bool        `json:"secure,omitempty"`
-	IPVersionBehavior      string      `json:"ipVersionBehavior,omitempty"`
-	MapDetailsSerialNumber int         `json:"mapDetails:serialNumber,omitempty"`
-	MapDetailsSlotNumber   int         `json:"mapDetails:slotNumber,omitempty"`
-	MapDetailsMapDomain    string      `json:"mapDetails:mapDomain,omitempty"`
-	StatusChange           chan bool   `json:"-"`
+	EdgeHostnameID         string             `json:"edgeHostnameId,omitempty"`
+	EdgeHostnameDomain     string             `json:"edgeHostnameDomain,omitempty"`
+	ProductID              string             `json:"productId"`
+	DomainPrefix           string             `json:"domainPrefix"`
+	DomainSuffix           string             `json:"domainSuffix"`
+	CertEnrollmentId       int                `json:"certEnrollmentId,omitempty"`
+	SlotNumber             int                `json:"slotNumber,omitempty"`
+	SecureNetwork          SecureNetworkValue `json:"secureNetwork,omitempty"`
+	Status                 StatusValue        `json:"status,omitempty"`
+	Secure                 bool               `json:"secure,omitempty"`
+	IPVersionBehavior      IPVersionValue     `json:"ipVersionBehavior,omitempty"`
+	MapDetailsSerialNumber int                `json:"mapDetails:serialNumber,omitempty"`
+	MapDetailsSlotNumber   int                `json:"mapDetails:slotNumber,omitempty"`
+	MapDetailsMapDomain    string             `json:"mapDetails:mapDomain,omitempty"`
+	// UseCases are only sent when creating the edge hostname, see GetProductUseCases()
+	UseCases     []EdgeHostnameUseCase `json:"useCases,omitempty"`
+	StatusChange chan bool             `json:"-"`
 }
 
+// IPVersionValue is used to create an "enum" of possible EdgeHostname.IPVersionBehavior values
+type IPVersionValue string
+
+// SecureNetworkValue is used to create an "enum" of possible EdgeHostname.SecureNetwork values
+type SecureNetworkValue string
+
+const (
+	// IPVersionIPv4 EdgeHostname.IPVersionBehavior value IPV4
+	IPVersionIPv4 IPVersionValue = "IPV4"
+	// IPVersionIPv6Compliance EdgeHostname.IPVersionBehavior value IPV6_COMPLIANCE (dual stack)
+	IPVersionIPv6Compliance IPVersionValue = "IPV6_COMPLIANCE"
+	// IPVersionIPv6Performance EdgeHostname.IPVersionBehavior value IPV6_PERFORMANCE
+	IPVersionIPv6Performance IPVersionValue = "IPV6_PERFORMANCE"
+
+	// SecureNetworkStandardTLS EdgeHostname.SecureNetwork value STANDARD_TLS
+	SecureNetworkStandardTLS SecureNetworkValue = "STANDARD_TLS"
+	// SecureNetworkEnhancedTLS EdgeHostname.SecureNetwork value ENHANCED_TLS
+	SecureNetworkEnhancedTLS SecureNetworkValue = "ENHANCED_TLS"
+	// SecureNetworkSharedCert EdgeHostname.SecureNetwork value SHARED_CERT
+	SecureNetworkSharedCert SecureNetworkValue = "SHARED_CERT"
+)
+
 // NewEdgeHostname creates a new EdgeHostname
 func NewEdgeHostname(edgeHostnames *EdgeHostnames) *EdgeHostname {
 	edgeHostname := &EdgeHostname{parent: edgeHostnames}
@@ -235,7 +259,7 @@ func (edgeHostname *EdgeHostname) GetEdgeHostname(options string, correlationid
 			edgeHostname.parent.GetEdgeHostnames(contract, group, "", correlationid)
 			newEdgeHostname, err := edgeHostname.parent.FindEdgeHostname(edgeHostname)
 			if err != nil || newEdgeHostname == nil {
-				return client.NewAPIError(res)
+				return newAPIError(res)
 			}
 
 			edgeHostname.EdgeHostnameID = newEdgeHostname.EdgeHostnameID
@@ -249,11 +273,14 @@ func (edgeHostname *EdgeHostname) GetEdgeHostname(options string, correlationid
 			edgeHostname.MapDetailsSerialNumber = newEdgeHostname.MapDetailsSerialNumber
 			edgeHostname.MapDetailsSlotNumber = newEdgeHostname.MapDetailsSlotNumber
 			edgeHostname.MapDetailsMapDomain = newEdgeHostname.MapDetailsMapDomain
+			edgeHostname.CertEnrollmentId = newEdgeHostname.CertEnrollmentId
+			edgeHostname.SlotNumber = newEdgeHostname.SlotNumber
+			edgeHostname.SecureNetwork = newEdgeHostname.SecureNetwork
 
 			return nil
 		}
 
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newEdgeHostnames := NewEdgeHostnames()
@@ -272,6 +299,9 @@ func (edgeHostname *EdgeHostname) GetEdgeHostname(options string, correlationid
 	edgeHostname.MapDetailsSerialNumber = newEdgeHostnames.EdgeHostnames.Items[0].MapDetailsSerialNumber
 	edgeHostname.MapDetailsSlotNumber = newEdgeHostnames.EdgeHostnames.Items[0].MapDetailsSlotNumber
 	edgeHostname.MapDetailsMapDomain = newEdgeHostnames.EdgeHostnames.Items[0].MapDetailsMapDomain
+	edgeHostname.CertEnrollmentId = newEdgeHostnames.EdgeHostnames.Items[0].CertEnrollmentId
+	edgeHostname.SlotNumber = newEdgeHostnames.EdgeHostnames.Items[0].SlotNumber
+	edgeHostname.SecureNetwork = newEdgeHostnames.EdgeHostnames.Items[0].SecureNetwork
 
 	return nil
 }
@@ -281,6 +311,10 @@ func (edgeHostname *EdgeHostname) GetEdgeHostname(options string, correlationid
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#createanewedgehostname
 // Endpoint: POST /papi/v1/edgehostnames/{?contractId,groupId,options}
 func (edgeHostname *EdgeHostname) Save(options string, correlationid string) error {
+	if edgeHostname.SecureNetwork == SecureNetworkEnhancedTLS && edgeHostname.CertEnrollmentId == 0 {
+		return ErrorMap[ErrCertEnrollmentRequired]
+	}
+
 	if options != "" {
 		options = "&options=" + options
 	}
@@ -309,7 +343,7 @@ func (edgeHostname *EdgeHostname) Save(options string, correlationid string) err
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	var location client.JSONBody
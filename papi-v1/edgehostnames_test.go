@@ -0,0 +1,59 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCreateEdgeHostname_EnhancedTLS(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/edgehostnames/").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		MatchParam("groupId", "grp_15225").
+		BodyString(`{"productId":"prd_Dynamic_Site_Del","domainPrefix":"www.example.com","domainSuffix":"edgekey.net","certEnrollmentId":12345,"secureNetwork":"ENHANCED_TLS","secure":true,"ipVersionBehavior":"IPV6_COMPLIANCE"}`).
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"edgeHostnameLink": "/papi/v1/edgehostnames/ehn_1132709?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/edgehostnames/ehn_1132709").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		MatchParam("groupId", "grp_15225").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"edgeHostnames": {"items": [{"edgeHostnameId": "ehn_1132709", "edgeHostnameDomain": "www.example.com.edgekey.net", "productId": "prd_Dynamic_Site_Del", "domainPrefix": "www.example.com", "domainSuffix": "edgekey.net", "certEnrollmentId": 12345, "secureNetwork": "ENHANCED_TLS", "secure": true, "ipVersionBehavior": "IPV6_COMPLIANCE", "status": "PENDING"}]}}`)
+
+	Init(config)
+
+	edgeHostname := NewEdgeHostname(nil)
+	edgeHostname.ProductID = "prd_Dynamic_Site_Del"
+	edgeHostname.DomainPrefix = "www.example.com"
+	edgeHostname.SecureNetwork = SecureNetworkEnhancedTLS
+	edgeHostname.CertEnrollmentId = 12345
+	edgeHostname.IPVersionBehavior = IPVersionIPv6Compliance
+
+	created, err := CreateEdgeHostname("ctr_1-1TJZH5", "grp_15225", edgeHostname)
+	require.NoError(t, err)
+	assert.Equal(t, "ehn_1132709", created.EdgeHostnameID)
+
+	fetched, err := GetEdgeHostname("ctr_1-1TJZH5", "grp_15225", created.EdgeHostnameID)
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com.edgekey.net", fetched.EdgeHostnameDomain)
+	assert.Equal(t, 12345, fetched.CertEnrollmentId)
+	assert.Equal(t, SecureNetworkEnhancedTLS, fetched.SecureNetwork)
+	assert.Equal(t, IPVersionIPv6Compliance, fetched.IPVersionBehavior)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCreateEdgeHostname_MissingCertEnrollment(t *testing.T) {
+	edgeHostname := NewEdgeHostname(nil)
+	edgeHostname.DomainPrefix = "www.example.com"
+	edgeHostname.SecureNetwork = SecureNetworkEnhancedTLS
+
+	_, err := CreateEdgeHostname("ctr_1-1TJZH5", "grp_15225", edgeHostname)
+	assert.Equal(t, ErrorMap[ErrCertEnrollmentRequired], err)
+}
@@ -1,6 +1,14 @@
 package papi
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
 
 // Error constants
 const (
@@ -10,15 +18,166 @@ const (
 	ErrVariableNotFound
 	ErrRuleNotFound
 	ErrInvalidRules
+	ErrLockTimeout
+	ErrActivationFailed
+	ErrCertEnrollmentRequired
+	ErrCertProvisioningConflict
+	ErrBulkSearchFailed
+	ErrConflict
+	ErrNotFound
+	ErrForbidden
+	ErrVersionNotFound
+	ErrInvalidNotifyEmails
 )
 
+// ErrBulkRequestFailed is the ErrorMap key of any BulkRequest that ends in
+// the ERROR status, bulk searches included
+const ErrBulkRequestFailed = ErrBulkSearchFailed
+
 var (
 	ErrorMap = map[int]error{
-		ErrInvalidPath:      errors.New("Invalid Path"),
-		ErrCriteriaNotFound: errors.New("Criteria not found"),
-		ErrBehaviorNotFound: errors.New("Behavior not found"),
-		ErrVariableNotFound: errors.New("Variable not found"),
-		ErrRuleNotFound:     errors.New("Rule not found"),
-		ErrInvalidRules:     errors.New("Rule validation failed. See papi.Rules.Errors for details"),
+		ErrInvalidPath:              errors.New("Invalid Path"),
+		ErrCriteriaNotFound:         errors.New("Criteria not found"),
+		ErrBehaviorNotFound:         errors.New("Behavior not found"),
+		ErrVariableNotFound:         errors.New("Variable not found"),
+		ErrRuleNotFound:             errors.New("Rule not found"),
+		ErrInvalidRules:             errors.New("Rule validation failed. See papi.Rules.Errors for details"),
+		ErrLockTimeout:              errors.New("Timed out waiting for property lock"),
+		ErrActivationFailed:         errors.New("Activation did not become active"),
+		ErrCertEnrollmentRequired:   errors.New("Enhanced TLS edge hostnames require a certificate enrollment ID"),
+		ErrCertProvisioningConflict: errors.New("Hostnames on the same edge hostname use different certificate provisioning types"),
+		ErrBulkSearchFailed:         errors.New("Bulk request did not complete"),
+		ErrConflict:                 errors.New("Resource was modified since its Etag was read"),
+		ErrNotFound:                 errors.New("Resource not found"),
+		ErrForbidden:                errors.New("Access to the resource is forbidden"),
+		ErrVersionNotFound:          errors.New("Property version not found"),
+		ErrInvalidNotifyEmails:      errors.New("Invalid activation notification emails"),
 	}
 )
+
+// ErrWaitCancelled is returned by the WaitFor* helpers when their context is
+// done before the request reached a final status. The waited for resource is
+// left as last polled, so the wait can be resumed by calling the helper again.
+type ErrWaitCancelled struct {
+	// LastStatus is the status last observed
+	LastStatus string
+	// Elapsed is the time spent waiting
+	Elapsed time.Duration
+	// Err is the error of the context
+	Err error
+}
+
+func (e *ErrWaitCancelled) Error() string {
+	return fmt.Sprintf("wait cancelled after %s with status %s: %s", e.Elapsed.Round(time.Second), e.LastStatus, e.Err)
+}
+
+// Unwrap allows errors.Is(err, context.Canceled) and errors.Is(err, context.DeadlineExceeded)
+func (e *ErrWaitCancelled) Unwrap() error {
+	return e.Err
+}
+
+// ErrEtagRetryFailed is returned by Version.Save when creating a version
+// failed again after retrying from the latest version and its Etag
+type ErrEtagRetryFailed struct {
+	// First is the Etag mismatch of the first attempt
+	First error
+	// Retry is the error of the retry, or of retrieving the latest version
+	Retry error
+}
+
+func (e *ErrEtagRetryFailed) Error() string {
+	return fmt.Sprintf("creating the version failed after retrying from the latest version: %s (first attempt: %s)", e.Retry, e.First)
+}
+
+// Unwrap returns the error of the retry
+func (e *ErrEtagRetryFailed) Unwrap() error {
+	return e.Retry
+}
+
+// Error is a PAPI error response, the RFC 7807 problem details PAPI returns
+// along with the location of each invalid rule tree item in Errors
+//
+// Use errors.Is() with ErrorMap[ErrNotFound], ErrorMap[ErrForbidden] or
+// ErrorMap[ErrConflict] to check for common statuses.
+//
+// PAPI calls used to return a client.APIError value, which err.(client.APIError)
+// type assertions no longer match. errors.As() is the migration path:
+//
+//	var apiError client.APIError
+//	if errors.As(err, &apiError) {
+//		// apiError.Response, apiError.RawBody, ...
+//	}
+type Error struct {
+	Type     string        `json:"type"`
+	Title    string        `json:"title"`
+	Detail   string        `json:"detail"`
+	Instance string        `json:"instance"`
+	Status   int           `json:"status"`
+	Errors   []*RuleErrors `json:"errors,omitempty"`
+	// APIError is the generic error of the response
+	APIError client.APIError `json:"-"`
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("PAPI error %d: %s", e.Status, e.Title)
+	if e.Detail != "" {
+		message = fmt.Sprintf("%s: %s", message, e.Detail)
+	}
+
+	for _, ruleError := range e.Errors {
+		location := ruleError.ErrorLocation
+		if location == "" {
+			location = ruleError.BehaviorName
+		}
+		message = fmt.Sprintf("%s\n %s %s: %s", message, location, ruleError.Title, ruleError.Detail)
+	}
+
+	return message
+}
+
+// ConflictError is the *Error of a 412 Precondition Failed response, the
+// Etag sent in If-Match (or as createFromVersionEtag) is no longer current
+//
+// Deprecated: every API error is now an *Error, use
+// errors.Is(err, ErrorMap[ErrConflict]) to check for conflicts.
+type ConflictError = Error
+
+// Is reports whether the status of e is the one of target
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrorMap[ErrNotFound]:
+		return e.Status == http.StatusNotFound
+	case ErrorMap[ErrForbidden]:
+		return e.Status == http.StatusForbidden
+	case ErrorMap[ErrConflict]:
+		return e.Status == http.StatusPreconditionFailed
+	}
+
+	return false
+}
+
+// Unwrap allows errors.As() with a client.APIError target
+func (e *Error) Unwrap() error {
+	return e.APIError
+}
+
+// newAPIError creates the *Error of res, reading and closing its body within
+// client.MaxResponseSize
+func newAPIError(res *http.Response) error {
+	body, _ := client.ReadBody(res)
+
+	return newAPIErrorFromBody(res, body)
+}
+
+// newAPIErrorFromBody creates the *Error of res when its body was already read
+func newAPIErrorFromBody(res *http.Response, body []byte) error {
+	e := &Error{APIError: client.NewAPIErrorFromBody(res, body)}
+	if err := json.Unmarshal(body, e); err != nil || e.Status == 0 {
+		e.Status = res.StatusCode
+	}
+	if e.Title == "" {
+		e.Title = http.StatusText(res.StatusCode)
+	}
+
+	return e
+}
@@ -0,0 +1,64 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestError_ProblemDetails(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put("/papi/v1/properties/prp_123/versions/2/rules").
+		Reply(400).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{
+			"type": "https://problems.luna.akamaiapis.net/papi/v0/json-schema-invalid",
+			"title": "Input does not match schema",
+			"detail": "Your input has 1 error.",
+			"instance": "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/papi/v1/properties/prp_123/versions/2/rules#c3b1",
+			"status": 400,
+			"errors": [{
+				"type": "https://problems.luna.akamaiapis.net/papi/v0/schema/invalid_type",
+				"title": "Invalid option type",
+				"detail": "The ttl option must be a string.",
+				"behaviorName": "caching",
+				"errorLocation": "#/rules/behaviors/0/options/ttl"
+			}]
+		}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_404").
+		Reply(404).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"type": "https://problems.luna.akamaiapis.net/papi/v0/http/not-found", "title": "Not Found", "status": 404}`)
+
+	Init(config)
+
+	rules := NewRules()
+	rules.PropertyID = "prp_123"
+	rules.PropertyVersion = 2
+	err := rules.Save("")
+
+	var papiError *Error
+	require.True(t, errors.As(err, &papiError))
+	assert.Equal(t, "Input does not match schema", papiError.Title)
+	require.Len(t, papiError.Errors, 1)
+	assert.Equal(t, "caching", papiError.Errors[0].BehaviorName)
+	assert.Contains(t, err.Error(), "#/rules/behaviors/0/options/ttl")
+	assert.False(t, errors.Is(err, ErrorMap[ErrNotFound]))
+
+	var apiError client.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, 400, apiError.Status)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_404"
+	err = property.GetProperty("")
+	assert.True(t, errors.Is(err, ErrorMap[ErrNotFound]))
+	assert.True(t, gock.IsDone())
+}
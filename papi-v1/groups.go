@@ -47,7 +47,7 @@ func (groups *Groups) PostUnmarshalJSON() error {
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listgroups
 // Endpoint: GET /papi/v1/groups/
 func (groups *Groups) GetGroups(correlationid string) error {
-	cachegroups, found := Profilecache.Get("groups")
+	cachegroups, found := Profilecache.Get(CacheKeyGroups)
 	if found {
 		json.Unmarshal(cachegroups.([]byte), groups)
 		return nil
@@ -72,14 +72,14 @@ func (groups *Groups) GetGroups(correlationid string) error {
 		edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 		if client.IsError(res) {
-			return client.NewAPIError(res)
+			return newAPIError(res)
 		}
 
 		if err = client.BodyJSON(res, groups); err != nil {
 			return err
 		}
 		byt, _ := json.Marshal(groups)
-		Profilecache.Set("groups", byt, cache.DefaultExpiration)
+		Profilecache.Set(CacheKeyGroups, byt, cache.DefaultExpiration)
 		return nil
 	}
 }
@@ -0,0 +1,211 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+)
+
+// DefaultBucketPatchSize is the number of hostnames RemoveBucketHostnames
+// removes per PATCH request when no batch size is given
+const DefaultBucketPatchSize = 500
+
+// bucketPageSize is the largest page of the hostname bucket listing
+const bucketPageSize = 999
+
+// BucketHostname is a hostname of the hostname bucket of a property
+//
+// Hostname bucket properties manage their hostnames outside of property
+// versions, each network has its own edge hostname for a bucket hostname.
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#propertyhostname
+type BucketHostname struct {
+	CnameFrom                string `json:"cnameFrom"`
+	CnameType                string `json:"cnameType,omitempty"`
+	StagingCnameTo           string `json:"stagingCnameTo,omitempty"`
+	StagingCertType          string `json:"stagingCertType,omitempty"`
+	StagingEdgeHostnameID    string `json:"stagingEdgeHostnameId,omitempty"`
+	ProductionCnameTo        string `json:"productionCnameTo,omitempty"`
+	ProductionCertType       string `json:"productionCertType,omitempty"`
+	ProductionEdgeHostnameID string `json:"productionEdgeHostnameId,omitempty"`
+}
+
+// Inactive reports whether the hostname is active on neither network
+func (hostname *BucketHostname) Inactive() bool {
+	return hostname.StagingCnameTo == "" && hostname.ProductionCnameTo == ""
+}
+
+// BucketHostnameAdd is a hostname PatchBucketHostnames adds to a hostname bucket
+type BucketHostnameAdd struct {
+	CnameFrom            string `json:"cnameFrom"`
+	CnameType            string `json:"cnameType"`
+	EdgeHostnameID       string `json:"edgeHostnameId"`
+	CertProvisioningType string `json:"certProvisioningType"`
+}
+
+// BucketHostnamesPatch are the hostnames PatchBucketHostnames adds to and
+// removes from a hostname bucket on a network
+type BucketHostnamesPatch struct {
+	Network NetworkValue        `json:"network"`
+	Add     []BucketHostnameAdd `json:"add,omitempty"`
+	Remove  []string            `json:"remove,omitempty"`
+}
+
+// StaleBucketHostname is a bucket hostname FindStaleBucketHostnames suggests
+// removing, Reason is "inactive" or "dns", or "no cname" for a hostname that
+// resolves without a CNAME (e.g. an apex flattened to A/AAAA records), which
+// is only reported and left to the caller to decide on
+type StaleBucketHostname struct {
+	Hostname BucketHostname
+	Reason   string
+}
+
+// ListBucketHostnames lists the hostname bucket of a property, all pages of it
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getpropertyhostnames
+// Endpoint: GET /papi/v1/properties/{propertyId}/hostnames{?contractId,groupId,offset,limit}
+func ListBucketHostnames(contractID string, groupID string, propertyID string) ([]BucketHostname, error) {
+	var hostnames []BucketHostname
+	for {
+		path, err := client.PathWithQuery(
+			fmt.Sprintf("/papi/v1/properties/%s/hostnames", propertyID),
+			struct {
+				ContractID string `query:"contractId"`
+				GroupID    string `query:"groupId"`
+				Offset     int    `query:"offset"`
+				Limit      int    `query:"limit"`
+			}{contractID, groupID, len(hostnames), bucketPageSize},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		response := struct {
+			Hostnames struct {
+				Items      []BucketHostname `json:"items"`
+				TotalItems int              `json:"totalItems"`
+			} `json:"hostnames"`
+		}{}
+		if err := doIncludeRequest("GET", path, nil, &response); err != nil {
+			return nil, err
+		}
+
+		hostnames = append(hostnames, response.Hostnames.Items...)
+		if len(response.Hostnames.Items) == 0 || len(hostnames) >= response.Hostnames.TotalItems {
+			return hostnames, nil
+		}
+	}
+}
+
+// PatchBucketHostnames adds and removes hostnames of the hostname bucket of a
+// property on patch.Network, and returns the ID of the resulting activation
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#patchpropertyhostnames
+// Endpoint: PATCH /papi/v1/properties/{propertyId}/hostnames{?contractId,groupId}
+func PatchBucketHostnames(contractID string, groupID string, propertyID string, patch BucketHostnamesPatch) (string, error) {
+	if patch.Network != NetworkStaging && patch.Network != NetworkProduction {
+		return "", fmt.Errorf("unknown network %q", patch.Network)
+	}
+
+	path := fmt.Sprintf("/papi/v1/properties/%s/hostnames?contractId=%s&groupId=%s", propertyID, contractID, groupID)
+	response := client.JSONBody{}
+	if err := doIncludeRequest("PATCH", path, patch, &response); err != nil {
+		return "", err
+	}
+
+	if id, ok := response["activationId"].(string); ok {
+		return id, nil
+	}
+
+	return linkID(response, "activationLink"), nil
+}
+
+// FindStaleBucketHostnames returns the bucket hostnames that are active on
+// neither network and, when resolve is not nil, those that do not resolve to
+// a CNAME
+//
+// Only a missing record (a not found *net.DNSError) makes a hostname stale:
+// the hostnames resolve fails on with any other error are kept and reported
+// in the returned error, so that a DNS outage does not empty the bucket, and
+// those that resolve without a CNAME are returned with the "no cname" Reason.
+func FindStaleBucketHostnames(hostnames []BucketHostname, resolve CNAMEResolver) ([]StaleBucketHostname, error) {
+	var stale []StaleBucketHostname
+	var failed []string
+	var lastErr error
+	for _, hostname := range hostnames {
+		if hostname.Inactive() {
+			stale = append(stale, StaleBucketHostname{Hostname: hostname, Reason: "inactive"})
+			continue
+		}
+		if resolve == nil {
+			continue
+		}
+
+		chain, err := resolve(hostname.CnameFrom)
+		var dnsErr *net.DNSError
+		switch {
+		case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+			stale = append(stale, StaleBucketHostname{Hostname: hostname, Reason: "dns"})
+		case err == nil && len(chain) == 0:
+			stale = append(stale, StaleBucketHostname{Hostname: hostname, Reason: "no cname"})
+		case err != nil:
+			failed = append(failed, hostname.CnameFrom)
+			lastErr = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return stale, fmt.Errorf("could not resolve %d hostnames (%s...): %w", len(failed), failed[0], lastErr)
+	}
+
+	return stale, nil
+}
+
+// RemoveBucketHostnames removes hostnames from the hostname bucket of a
+// property on network, batchSize (DefaultBucketPatchSize when 0) hostnames per
+// PATCH request, and returns the IDs of the activations of the batches removed
+//
+// It stops at the first failed batch or once ctx is done, the batches before
+// it stay removed.
+func RemoveBucketHostnames(ctx context.Context, contractID string, groupID string, propertyID string, network NetworkValue, hostnames []string, batchSize int) ([]string, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBucketPatchSize
+	}
+
+	var activationIDs []string
+	for start := 0; start < len(hostnames); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return activationIDs, err
+		}
+
+		end := start + batchSize
+		if end > len(hostnames) {
+			end = len(hostnames)
+		}
+		patch := BucketHostnamesPatch{Network: network, Remove: hostnames[start:end]}
+		activationID, err := PatchBucketHostnames(contractID, groupID, propertyID, patch)
+		if err != nil {
+			return activationIDs, fmt.Errorf("hostnames %d to %d: %w", start, end-1, err)
+		}
+		activationIDs = append(activationIDs, activationID)
+	}
+
+	return activationIDs, nil
+}
+
+// StaleHostnames returns the CnameFrom of each stale hostname, for
+// RemoveBucketHostnames; "no cname" hostnames are left out
+func StaleHostnames(stale []StaleBucketHostname) []string {
+	names := make([]string, 0, len(stale))
+	for _, s := range stale {
+		if s.Reason == "no cname" {
+			continue
+		}
+		names = append(names, s.Hostname.CnameFrom)
+	}
+
+	return names
+}
@@ -0,0 +1,100 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestListBucketHostnames(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_1/hostnames").
+		MatchParam("offset", "^0$").
+		Reply(200).
+		JSON(`{"hostnames": {"totalItems": 3, "items": [
+			{"cnameFrom": "a.example.com", "stagingCnameTo": "a.example.com.edgekey.net", "productionCnameTo": "a.example.com.edgekey.net"},
+			{"cnameFrom": "b.example.com"}
+		]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_1/hostnames").
+		MatchParam("offset", "^2$").
+		Reply(200).
+		JSON(`{"hostnames": {"totalItems": 3, "items": [{"cnameFrom": "c.example.com", "stagingCnameTo": "c.example.com.edgekey.net"}]}}`)
+
+	Init(config)
+
+	hostnames, err := ListBucketHostnames("ctr_1", "grp_1", "prp_1")
+	require.NoError(t, err)
+	require.Len(t, hostnames, 3)
+	assert.False(t, hostnames[0].Inactive())
+	assert.True(t, hostnames[1].Inactive())
+	assert.False(t, hostnames[2].Inactive())
+	assert.True(t, gock.IsDone())
+}
+
+func TestFindStaleBucketHostnames(t *testing.T) {
+	hostnames := []BucketHostname{
+		{CnameFrom: "a.example.com", ProductionCnameTo: "a.example.com.edgekey.net"},
+		{CnameFrom: "b.example.com"},
+		{CnameFrom: "c.example.com", ProductionCnameTo: "c.example.com.edgekey.net"},
+		{CnameFrom: "d.example.com", StagingCnameTo: "d.example.com.edgekey.net"},
+		// an apex served by A/AAAA records
+		{CnameFrom: "example.com", ProductionCnameTo: "example.com.edgekey.net"},
+	}
+	resolver := func(hostname string) ([]string, error) {
+		switch hostname {
+		case "a.example.com":
+			return []string{"a.example.com.edgekey.net"}, nil
+		case "c.example.com":
+			return nil, &net.DNSError{Err: "no such host", Name: hostname, IsNotFound: true}
+		case "example.com":
+			return nil, nil
+		}
+		return nil, errors.New("i/o timeout")
+	}
+
+	stale, err := FindStaleBucketHostnames(hostnames, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b.example.com"}, StaleHostnames(stale))
+
+	stale, err = FindStaleBucketHostnames(hostnames, resolver)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "d.example.com")
+	assert.Equal(t, []string{"b.example.com", "c.example.com"}, StaleHostnames(stale))
+	assert.Equal(t, "dns", stale[1].Reason)
+	require.Len(t, stale, 3)
+	assert.Equal(t, "example.com", stale[2].Hostname.CnameFrom)
+	assert.Equal(t, "no cname", stale[2].Reason)
+}
+
+func TestRemoveBucketHostnames(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Patch("/papi/v1/properties/prp_1/hostnames").
+		JSON(map[string]interface{}{"network": "STAGING", "remove": []string{"a.example.com", "b.example.com"}}).
+		Reply(200).
+		JSON(`{"activationLink": "/papi/v1/properties/prp_1/hostname-activations/atv_1"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Patch("/papi/v1/properties/prp_1/hostnames").
+		JSON(map[string]interface{}{"network": "STAGING", "remove": []string{"c.example.com"}}).
+		Reply(200).
+		JSON(`{"activationId": "atv_2"}`)
+
+	Init(config)
+
+	ids, err := RemoveBucketHostnames(context.Background(), "ctr_1", "grp_1", "prp_1", NetworkStaging, []string{"a.example.com", "b.example.com", "c.example.com"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"atv_1", "atv_2"}, ids)
+	assert.True(t, gock.IsDone())
+
+	_, err = PatchBucketHostnames("ctr_1", "grp_1", "prp_1", BucketHostnamesPatch{Remove: []string{"a.example.com"}})
+	assert.Error(t, err)
+}
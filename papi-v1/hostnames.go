@@ -2,6 +2,7 @@ package papi
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
@@ -16,7 +17,9 @@ type Hostnames struct {
 	PropertyID      string `json:"propertyId"`
 	PropertyVersion int    `json:"propertyVersion"`
 	Etag            string `json:"etag"`
-	Hostnames       struct {
+	// IncludeCertStatus requests the Default DV certificate status of each hostname
+	IncludeCertStatus bool `json:"-"`
+	Hostnames         struct {
 		Items []*Hostname `json:"items"`
 	} `json:"hostnames"`
 }
@@ -53,7 +56,7 @@ func (hostnames *Hostnames) PostUnmarshalJSON() error {
 //
 // See: Property.GetHostnames()
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listapropertyshostnames
-// Endpoint: GET /papi/v1/properties/{propertyId}/versions/{propertyVersion}/hostnames/{?contractId,groupId}
+// Endpoint: GET /papi/v1/properties/{propertyId}/versions/{propertyVersion}/hostnames/{?contractId,groupId,includeCertStatus}
 func (hostnames *Hostnames) GetHostnames(version *Version, correlationid string) error {
 	if version == nil {
 		property := NewProperty(NewProperties())
@@ -73,11 +76,12 @@ func (hostnames *Hostnames) GetHostnames(version *Version, correlationid string)
 		Config,
 		"GET",
 		fmt.Sprintf(
-			"/papi/v1/properties/%s/versions/%d/hostnames/?contractId=%s&groupId=%s",
+			"/papi/v1/properties/%s/versions/%d/hostnames/?contractId=%s&groupId=%s%s",
 			hostnames.PropertyID,
 			version.PropertyVersion,
 			hostnames.ContractID,
 			hostnames.GroupID,
+			hostnames.certStatusQuery(),
 		),
 		nil,
 	)
@@ -95,7 +99,7 @@ func (hostnames *Hostnames) GetHostnames(version *Version, correlationid string)
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, hostnames); err != nil {
@@ -112,19 +116,42 @@ func (hostnames *Hostnames) NewHostname() *Hostname {
 	return hostname
 }
 
+func (hostnames *Hostnames) certStatusQuery() string {
+	if hostnames.IncludeCertStatus {
+		return "&includeCertStatus=true"
+	}
+
+	return ""
+}
+
 // Save updates a properties hostnames
+//
+// API Docs: https://developer.akamai.com/api/luna/papi/resources.html#putpropertyversionhostnames
+// Endpoint: PUT /papi/v1/properties/{propertyId}/versions/{propertyVersion}/hostnames{?contractId,groupId,includeCertStatus}
 func (hostnames *Hostnames) Save() error {
+	if conflicts := hostnames.CertProvisioningConflicts(); len(conflicts) > 0 {
+		return &CertProvisioningConflictError{Conflicts: conflicts}
+	}
+
+	// certStatus is read-only
+	items := make([]Hostname, len(hostnames.Hostnames.Items))
+	for key, hostname := range hostnames.Hostnames.Items {
+		items[key] = *hostname
+		items[key].CertStatus = nil
+	}
+
 	req, err := client.NewJSONRequest(
 		Config,
 		"PUT",
 		fmt.Sprintf(
-			"/papi/v1/properties/%s/versions/%d/hostnames?contractId=%s&groupId=%s",
+			"/papi/v1/properties/%s/versions/%d/hostnames?contractId=%s&groupId=%s%s",
 			hostnames.PropertyID,
 			hostnames.PropertyVersion,
 			hostnames.ContractID,
 			hostnames.GroupID,
+			hostnames.certStatusQuery(),
 		),
-		hostnames.Hostnames.Items,
+		items,
 	)
 	if err != nil {
 		return err
@@ -140,7 +167,7 @@ func (hostnames *Hostnames) Save() error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, hostnames); err != nil {
@@ -150,6 +177,78 @@ func (hostnames *Hostnames) Save() error {
 	return nil
 }
 
+// CertProvisioningConflict lists the hostnames of an edge hostname by certificate provisioning type
+type CertProvisioningConflict struct {
+	EdgeHostname string
+	CPSManaged   []string
+	Default      []string
+}
+
+// CertProvisioningConflictError is returned by Hostnames.Save() when
+// CertProvisioningConflicts() finds conflicts, instead of the 400 PAPI returns
+type CertProvisioningConflictError struct {
+	Conflicts []CertProvisioningConflict
+}
+
+func (e *CertProvisioningConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		conflicts = append(conflicts, fmt.Sprintf(
+			"%s (CPS_MANAGED: %s; DEFAULT: %s)",
+			conflict.EdgeHostname,
+			strings.Join(conflict.CPSManaged, ", "),
+			strings.Join(conflict.Default, ", "),
+		))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrorMap[ErrCertProvisioningConflict], strings.Join(conflicts, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrorMap[ErrCertProvisioningConflict])
+func (e *CertProvisioningConflictError) Unwrap() error {
+	return ErrorMap[ErrCertProvisioningConflict]
+}
+
+// CertProvisioningConflicts finds edge hostnames that would serve both
+// CPS-managed and Default DV hostnames, which PAPI rejects as the certificates
+// cannot share the SNI slot of the edge hostname. Hostnames without a
+// CertProvisioningType are CPS_MANAGED.
+func (hostnames *Hostnames) CertProvisioningConflicts() []CertProvisioningConflict {
+	byEdgeHostname := map[string]*CertProvisioningConflict{}
+	var order []string
+	for _, hostname := range hostnames.Hostnames.Items {
+		key := strings.ToLower(hostname.CnameTo)
+		if key == "" {
+			key = hostname.EdgeHostnameID
+		}
+		if key == "" {
+			continue
+		}
+
+		conflict, ok := byEdgeHostname[key]
+		if !ok {
+			conflict = &CertProvisioningConflict{EdgeHostname: key}
+			byEdgeHostname[key] = conflict
+			order = append(order, key)
+		}
+
+		if hostname.CertProvisioningType == CertProvisioningTypeDefault {
+			conflict.Default = append(conflict.Default, hostname.CnameFrom)
+		} else {
+			conflict.CPSManaged = append(conflict.CPSManaged, hostname.CnameFrom)
+		}
+	}
+
+	var conflicts []CertProvisioningConflict
+	for _, key := range order {
+		if conflict := byEdgeHostname[key]; len(conflict.CPSManaged) > 0 && len(conflict.Default) > 0 {
+			conflicts = append(conflicts, *conflict)
+		}
+	}
+
+	return conflicts
+}
+
 // Hostname represents a property hostname resource
 type Hostname struct {
 	client.Resource
@@ -159,6 +258,41 @@ type Hostname struct {
 	CnameFrom        string         `json:"cnameFrom"`
 	CnameTo          string         `json:"cnameTo,omitempty"`
 	CertEnrollmentId string         `json:"certEnrollmentId,omitempty"`
+	// CertProvisioningType is CPS_MANAGED, or DEFAULT for Default DV certificates
+	CertProvisioningType CertProvisioningTypeValue `json:"certProvisioningType,omitempty"`
+	// CertStatus is only returned for DEFAULT hostnames when Hostnames.IncludeCertStatus is set
+	CertStatus *CertStatus `json:"certStatus,omitempty"`
+}
+
+// CertStatus is the Default DV certificate deployment state of a Hostname
+type CertStatus struct {
+	ValidationCname struct {
+		Hostname string `json:"hostname"`
+		Target   string `json:"target"`
+	} `json:"validationCname"`
+	Staging    []CertStatusItem `json:"staging"`
+	Production []CertStatusItem `json:"production"`
+}
+
+// CertStatusItem is the certificate status on a network
+type CertStatusItem struct {
+	Status CertStatusValue `json:"status"`
+}
+
+// Deployed reports whether the certificate is deployed on network
+func (certStatus *CertStatus) Deployed(network NetworkValue) bool {
+	items := certStatus.Staging
+	if network == NetworkProduction {
+		items = certStatus.Production
+	}
+
+	for _, item := range items {
+		if item.Status == CertStatusDeployed {
+			return true
+		}
+	}
+
+	return false
 }
 
 // NewHostname creates a new Hostname
@@ -176,3 +310,27 @@ const (
 	// CnameTypeEdgeHostname Hostname.CnameType value EDGE_HOSTNAME
 	CnameTypeEdgeHostname CnameTypeValue = "EDGE_HOSTNAME"
 )
+
+// CertProvisioningTypeValue is used to create an "enum" of possible Hostname.CertProvisioningType values
+type CertProvisioningTypeValue string
+
+const (
+	// CertProvisioningTypeCPSManaged Hostname.CertProvisioningType value CPS_MANAGED
+	CertProvisioningTypeCPSManaged CertProvisioningTypeValue = "CPS_MANAGED"
+	// CertProvisioningTypeDefault Hostname.CertProvisioningType value DEFAULT
+	CertProvisioningTypeDefault CertProvisioningTypeValue = "DEFAULT"
+)
+
+// CertStatusValue is used to create an "enum" of possible CertStatusItem.Status values
+type CertStatusValue string
+
+const (
+	// CertStatusNeedsValidation CertStatusItem.Status value NEEDS_VALIDATION
+	CertStatusNeedsValidation CertStatusValue = "NEEDS_VALIDATION"
+	// CertStatusPending CertStatusItem.Status value PENDING
+	CertStatusPending CertStatusValue = "PENDING"
+	// CertStatusDeployed CertStatusItem.Status value DEPLOYED
+	CertStatusDeployed CertStatusValue = "DEPLOYED"
+	// CertStatusFailed CertStatusItem.Status value FAILED
+	CertStatusFailed CertStatusValue = "FAILED"
+)
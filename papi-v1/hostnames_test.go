@@ -0,0 +1,106 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGetPropertyVersionHostnames_CertStatus(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/3/hostnames/").
+		MatchParam("includeCertStatus", "true").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+			"propertyId": "prp_173136",
+			"propertyVersion": 3,
+			"hostnames": {"items": [{
+				"cnameType": "EDGE_HOSTNAME",
+				"cnameFrom": "www.example.com",
+				"cnameTo": "www.example.com.edgekey.net",
+				"certProvisioningType": "DEFAULT",
+				"certStatus": {
+					"validationCname": {"hostname": "_acme-challenge.www.example.com", "target": "{token}.www.example.com.akamai-domain.com"},
+					"staging": [{"status": "DEPLOYED"}],
+					"production": [{"status": "PENDING"}]
+				}
+			}]}
+		}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	hostnames, err := GetPropertyVersionHostnames(property, 3, true)
+	require.NoError(t, err)
+	require.Len(t, hostnames.Hostnames.Items, 1)
+
+	hostname := hostnames.Hostnames.Items[0]
+	assert.Equal(t, CertProvisioningTypeDefault, hostname.CertProvisioningType)
+	require.NotNil(t, hostname.CertStatus)
+	assert.Equal(t, "_acme-challenge.www.example.com", hostname.CertStatus.ValidationCname.Hostname)
+	assert.True(t, hostname.CertStatus.Deployed(NetworkStaging))
+	assert.False(t, hostname.CertStatus.Deployed(NetworkProduction))
+	assert.True(t, gock.IsDone())
+}
+
+func TestUpdatePropertyVersionHostnames(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put("/papi/v1/properties/prp_173136/versions/3/hostnames").
+		MatchParam("includeCertStatus", "true").
+		BodyString(`[{"cnameType":"EDGE_HOSTNAME","cnameFrom":"www.example.com","cnameTo":"www.example.com.edgekey.net","certProvisioningType":"DEFAULT"}]`).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "propertyVersion": 3, "hostnames": {"items": [{"cnameType": "EDGE_HOSTNAME", "cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net", "certProvisioningType": "DEFAULT", "certStatus": {"staging": [{"status": "NEEDS_VALIDATION"}]}}]}}`)
+
+	Init(config)
+
+	hostnames := NewHostnames()
+	hostnames.PropertyID = "prp_173136"
+	hostnames.PropertyVersion = 3
+	hostname := hostnames.NewHostname()
+	hostname.CnameFrom = "www.example.com"
+	hostname.CnameTo = "www.example.com.edgekey.net"
+	hostname.CertProvisioningType = CertProvisioningTypeDefault
+	hostname.CertStatus = &CertStatus{}
+
+	hostnames, err := UpdatePropertyVersionHostnames(hostnames, true)
+	require.NoError(t, err)
+	assert.Equal(t, CertStatusNeedsValidation, hostnames.Hostnames.Items[0].CertStatus.Staging[0].Status)
+	assert.True(t, gock.IsDone())
+}
+
+func TestHostnames_Save_CertProvisioningConflict(t *testing.T) {
+	hostnames := NewHostnames()
+	hostnames.PropertyID = "prp_173136"
+	hostnames.PropertyVersion = 3
+	for _, cnameFrom := range []string{"www.example.com", "api.example.com", "static.example.com"} {
+		hostname := hostnames.NewHostname()
+		hostname.CnameFrom = cnameFrom
+		hostname.CnameTo = "www.example.com.edgekey.net"
+	}
+	hostnames.Hostnames.Items[1].CertProvisioningType = CertProvisioningTypeDefault
+	hostname := hostnames.NewHostname()
+	hostname.CnameFrom = "dv.example.com"
+	hostname.CnameTo = "dv.example.com.edgekey.net"
+	hostname.CertProvisioningType = CertProvisioningTypeDefault
+
+	conflicts := hostnames.CertProvisioningConflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "www.example.com.edgekey.net", conflicts[0].EdgeHostname)
+	assert.Equal(t, []string{"www.example.com", "static.example.com"}, conflicts[0].CPSManaged)
+	assert.Equal(t, []string{"api.example.com"}, conflicts[0].Default)
+
+	err := hostnames.Save()
+	assert.True(t, errors.Is(err, ErrorMap[ErrCertProvisioningConflict]))
+	assert.Contains(t, err.Error(), "www.example.com.edgekey.net")
+}
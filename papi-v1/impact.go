@@ -0,0 +1,312 @@
+package papi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ImpactRisk is used to create an "enum" of possible ActivationImpact.Risk values
+type ImpactRisk string
+
+const (
+	// ImpactRiskLow ActivationImpact.Risk value LOW
+	ImpactRiskLow ImpactRisk = "LOW"
+	// ImpactRiskMedium ActivationImpact.Risk value MEDIUM
+	ImpactRiskMedium ImpactRisk = "MEDIUM"
+	// ImpactRiskHigh ActivationImpact.Risk value HIGH
+	ImpactRiskHigh ImpactRisk = "HIGH"
+)
+
+// CriticalBehaviors are behaviors whose changes weigh more in the activation risk score
+var CriticalBehaviors = map[string]bool{
+	"origin":                 true,
+	"cpCode":                 true,
+	"caching":                true,
+	"siteShield":             true,
+	"edgeRedirector":         true,
+	"http2":                  true,
+	"allowPost":              true,
+	"webApplicationFirewall": true,
+}
+
+// ActivationImpact summarizes the difference between the version being activated
+// and the version currently active on a network
+type ActivationImpact struct {
+	PropertyID string       `json:"propertyId"`
+	Network    NetworkValue `json:"network"`
+	// FromVersion is the currently active version, 0 when nothing is active yet
+	FromVersion int `json:"fromVersion"`
+	ToVersion   int `json:"toVersion"`
+
+	RulesAdded       []string `json:"rulesAdded,omitempty"`
+	RulesRemoved     []string `json:"rulesRemoved,omitempty"`
+	BehaviorsAdded   int      `json:"behaviorsAdded"`
+	BehaviorsRemoved int      `json:"behaviorsRemoved"`
+	BehaviorsChanged int      `json:"behaviorsChanged"`
+	CriteriaChanged  int      `json:"criteriaChanged"`
+	// CriticalBehaviors lists the changed behaviors found in CriticalBehaviors, as rule path and name
+	CriticalBehaviors []string `json:"criticalBehaviors,omitempty"`
+
+	HostnamesAdded   []string `json:"hostnamesAdded,omitempty"`
+	HostnamesRemoved []string `json:"hostnamesRemoved,omitempty"`
+	// EdgeHostnamesChanged lists hostnames pointing to another edge hostname
+	EdgeHostnamesChanged []string `json:"edgeHostnamesChanged,omitempty"`
+	// CertificatesChanged lists hostnames served with another certificate enrollment
+	CertificatesChanged []string `json:"certificatesChanged,omitempty"`
+
+	// Score ranges from 0 (no change) to 100
+	Score   int        `json:"score"`
+	Risk    ImpactRisk `json:"risk"`
+	Reasons []string   `json:"reasons,omitempty"`
+}
+
+// Changed reports whether the versions differ at all
+func (impact *ActivationImpact) Changed() bool {
+	return impact.Score > 0
+}
+
+// EstimateActivationImpact compares version of property with the version active on network
+func EstimateActivationImpact(property *Property, version int, network NetworkValue) (*ActivationImpact, error) {
+	if err := property.GetProperty(""); err != nil {
+		return nil, err
+	}
+
+	activeVersion := property.StagingVersion
+	if network == NetworkProduction {
+		activeVersion = property.ProductionVersion
+	}
+
+	newRules, newHostnames, err := getVersionConfiguration(property, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var activeRules *Rules
+	var activeHostnames *Hostnames
+	if activeVersion != 0 {
+		if activeRules, activeHostnames, err = getVersionConfiguration(property, activeVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	impact := CompareForActivation(activeRules, newRules, activeHostnames, newHostnames)
+	impact.PropertyID = property.PropertyID
+	impact.Network = network
+	impact.FromVersion = activeVersion
+	impact.ToVersion = version
+
+	return impact, nil
+}
+
+func getVersionConfiguration(property *Property, version int) (*Rules, *Hostnames, error) {
+	versioned := *property
+	versioned.LatestVersion = version
+
+	rules, err := versioned.GetRules("")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	propertyVersion := NewVersion(NewVersions())
+	propertyVersion.PropertyVersion = version
+	hostnames, err := versioned.GetHostnames(propertyVersion, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rules, hostnames, nil
+}
+
+// CompareForActivation scores the difference between active and next rules and
+// hostnames. active may be nil when nothing is active on the network yet.
+func CompareForActivation(activeRules, nextRules *Rules, activeHostnames, nextHostnames *Hostnames) *ActivationImpact {
+	impact := &ActivationImpact{}
+
+	if activeRules == nil {
+		impact.Score = 30
+		impact.Reasons = append(impact.Reasons, "first activation on this network")
+		activeRules = NewRules()
+		activeHostnames = NewHostnames()
+	}
+
+	compareRules(impact, flattenRules(activeRules), flattenRules(nextRules))
+	compareHostnames(impact, activeHostnames, nextHostnames)
+	scoreImpact(impact)
+
+	return impact
+}
+
+func flattenRules(rules *Rules) map[string]*Rule {
+	flat := map[string]*Rule{}
+	if rules == nil || rules.Rule == nil {
+		return flat
+	}
+
+	var walk func(path string, rule *Rule)
+	walk = func(path string, rule *Rule) {
+		path += "/" + rule.Name
+		flat[path] = rule
+		for _, child := range rule.Children {
+			walk(path, child)
+		}
+	}
+	walk("", rules.Rule)
+
+	return flat
+}
+
+func compareRules(impact *ActivationImpact, active, next map[string]*Rule) {
+	for _, path := range sortedRulePaths(next) {
+		activeRule, ok := active[path]
+		if !ok {
+			impact.RulesAdded = append(impact.RulesAdded, path)
+			activeRule = NewRule()
+		}
+
+		nextRule := next[path]
+		if !reflect.DeepEqual(criteriaOptions(activeRule.Criteria), criteriaOptions(nextRule.Criteria)) {
+			impact.CriteriaChanged++
+		}
+
+		activeBehaviors := keyedBehaviors(activeRule.Behaviors)
+		nextBehaviors := keyedBehaviors(nextRule.Behaviors)
+		for key, behavior := range nextBehaviors {
+			previous, ok := activeBehaviors[key]
+			switch {
+			case !ok:
+				impact.BehaviorsAdded++
+			case !reflect.DeepEqual(previous.Options, behavior.Options):
+				impact.BehaviorsChanged++
+			default:
+				continue
+			}
+			if CriticalBehaviors[behavior.Name] {
+				impact.CriticalBehaviors = append(impact.CriticalBehaviors, path+"/"+behavior.Name)
+			}
+		}
+		for key, behavior := range activeBehaviors {
+			if _, ok := nextBehaviors[key]; !ok {
+				impact.BehaviorsRemoved++
+				if CriticalBehaviors[behavior.Name] {
+					impact.CriticalBehaviors = append(impact.CriticalBehaviors, path+"/"+behavior.Name)
+				}
+			}
+		}
+	}
+
+	for _, path := range sortedRulePaths(active) {
+		if _, ok := next[path]; !ok {
+			impact.RulesRemoved = append(impact.RulesRemoved, path)
+			impact.BehaviorsRemoved += len(active[path].Behaviors)
+		}
+	}
+
+	sort.Strings(impact.CriticalBehaviors)
+}
+
+func sortedRulePaths(rules map[string]*Rule) []string {
+	paths := make([]string, 0, len(rules))
+	for path := range rules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// keyedBehaviors keys behaviors by name and occurrence, e.g. "origin#0"
+func keyedBehaviors(behaviors []*Behavior) map[string]*Behavior {
+	keyed := map[string]*Behavior{}
+	seen := map[string]int{}
+	for _, behavior := range behaviors {
+		keyed[fmt.Sprintf("%s#%d", behavior.Name, seen[behavior.Name])] = behavior
+		seen[behavior.Name]++
+	}
+
+	return keyed
+}
+
+func criteriaOptions(criteria []*Criteria) []interface{} {
+	options := []interface{}{}
+	for _, criterion := range criteria {
+		options = append(options, []interface{}{criterion.Name, map[string]interface{}(criterion.Options)})
+	}
+
+	return options
+}
+
+func compareHostnames(impact *ActivationImpact, active, next *Hostnames) {
+	activeByName := map[string]*Hostname{}
+	if active != nil {
+		for _, hostname := range active.Hostnames.Items {
+			activeByName[strings.ToLower(hostname.CnameFrom)] = hostname
+		}
+	}
+
+	nextByName := map[string]bool{}
+	if next != nil {
+		for _, hostname := range next.Hostnames.Items {
+			name := strings.ToLower(hostname.CnameFrom)
+			nextByName[name] = true
+
+			previous, ok := activeByName[name]
+			switch {
+			case !ok:
+				impact.HostnamesAdded = append(impact.HostnamesAdded, hostname.CnameFrom)
+			case !strings.EqualFold(previous.CnameTo, hostname.CnameTo) || previous.EdgeHostnameID != hostname.EdgeHostnameID:
+				impact.EdgeHostnamesChanged = append(impact.EdgeHostnamesChanged, hostname.CnameFrom)
+			}
+			if ok && previous.CertEnrollmentId != hostname.CertEnrollmentId {
+				impact.CertificatesChanged = append(impact.CertificatesChanged, hostname.CnameFrom)
+			}
+		}
+	}
+
+	for name, hostname := range activeByName {
+		if !nextByName[name] {
+			impact.HostnamesRemoved = append(impact.HostnamesRemoved, hostname.CnameFrom)
+		}
+	}
+
+	sort.Strings(impact.HostnamesAdded)
+	sort.Strings(impact.HostnamesRemoved)
+	sort.Strings(impact.EdgeHostnamesChanged)
+	sort.Strings(impact.CertificatesChanged)
+}
+
+func scoreImpact(impact *ActivationImpact) {
+	add := func(points int, reason string, count int) {
+		if count == 0 {
+			return
+		}
+		impact.Score += points * count
+		impact.Reasons = append(impact.Reasons, fmt.Sprintf("%d %s", count, reason))
+	}
+
+	add(3, "rule(s) added", len(impact.RulesAdded))
+	add(5, "rule(s) removed", len(impact.RulesRemoved))
+	add(2, "behavior(s) added", impact.BehaviorsAdded)
+	add(2, "behavior(s) removed", impact.BehaviorsRemoved)
+	add(2, "behavior(s) changed", impact.BehaviorsChanged)
+	add(2, "criteria change(s)", impact.CriteriaChanged)
+	add(5, "critical behavior change(s)", len(impact.CriticalBehaviors))
+	add(5, "hostname(s) added", len(impact.HostnamesAdded))
+	add(10, "hostname(s) removed", len(impact.HostnamesRemoved))
+	add(10, "edge hostname change(s)", len(impact.EdgeHostnamesChanged))
+	add(15, "certificate change(s)", len(impact.CertificatesChanged))
+
+	if impact.Score > 100 {
+		impact.Score = 100
+	}
+
+	switch {
+	case impact.Score >= 50:
+		impact.Risk = ImpactRiskHigh
+	case impact.Score >= 20:
+		impact.Risk = ImpactRiskMedium
+	default:
+		impact.Risk = ImpactRiskLow
+	}
+}
@@ -0,0 +1,70 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func impactRules(originHost string, withChild bool) *Rules {
+	rules := NewRules()
+	rules.Rule = NewRule()
+	rules.Rule.Name = "default"
+	rules.Rule.Behaviors = []*Behavior{
+		{Name: "origin", Options: OptionValue{"hostname": originHost}},
+		{Name: "cpCode", Options: OptionValue{"id": 1}},
+	}
+	if withChild {
+		child := NewRule()
+		child.Name = "Static"
+		child.Criteria = []*Criteria{{Name: "fileExtension", Options: OptionValue{"values": []string{"css"}}}}
+		child.Behaviors = []*Behavior{{Name: "caching", Options: OptionValue{"ttl": "1d"}}}
+		rules.Rule.Children = []*Rule{child}
+	}
+
+	return rules
+}
+
+func impactHostnames(hostnames ...*Hostname) *Hostnames {
+	collection := NewHostnames()
+	collection.Hostnames.Items = hostnames
+
+	return collection
+}
+
+func TestCompareForActivation(t *testing.T) {
+	active := impactHostnames(
+		&Hostname{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net"},
+		&Hostname{CnameFrom: "old.example.com", CnameTo: "www.example.com.edgesuite.net"},
+	)
+	next := impactHostnames(
+		&Hostname{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgekey.net", CertEnrollmentId: "123"},
+		&Hostname{CnameFrom: "new.example.com", CnameTo: "www.example.com.edgekey.net"},
+	)
+
+	impact := CompareForActivation(impactRules("origin.example.com", false), impactRules("origin2.example.com", true), active, next)
+
+	assert.Equal(t, []string{"/default/Static"}, impact.RulesAdded)
+	assert.Equal(t, 1, impact.BehaviorsAdded)
+	assert.Equal(t, 1, impact.BehaviorsChanged)
+	assert.Equal(t, 1, impact.CriteriaChanged)
+	assert.Equal(t, []string{"/default/Static/caching", "/default/origin"}, impact.CriticalBehaviors)
+	assert.Equal(t, []string{"new.example.com"}, impact.HostnamesAdded)
+	assert.Equal(t, []string{"old.example.com"}, impact.HostnamesRemoved)
+	assert.Equal(t, []string{"www.example.com"}, impact.EdgeHostnamesChanged)
+	assert.Equal(t, []string{"www.example.com"}, impact.CertificatesChanged)
+	assert.Equal(t, 59, impact.Score)
+	assert.Equal(t, ImpactRiskHigh, impact.Risk)
+}
+
+func TestCompareForActivation_Unchanged(t *testing.T) {
+	hostnames := impactHostnames(&Hostname{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net"})
+
+	impact := CompareForActivation(impactRules("origin.example.com", true), impactRules("origin.example.com", true), hostnames, hostnames)
+	assert.False(t, impact.Changed())
+	assert.Equal(t, ImpactRiskLow, impact.Risk)
+
+	impact = CompareForActivation(nil, impactRules("origin.example.com", false), nil, hostnames)
+	assert.Equal(t, ImpactRiskMedium, impact.Risk)
+	assert.Contains(t, impact.Reasons, "first activation on this network")
+}
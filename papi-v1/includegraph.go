@@ -0,0 +1,237 @@
+package papi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IncludeBehaviorName is the behavior a rule tree uses an include with, its
+// "id" option is the include ID
+const IncludeBehaviorName = "include"
+
+// IncludeGraph records which property versions use which includes
+//
+// Includes are activated separately from the properties that use them, and
+// a property version can only be activated on a network once each of its
+// includes has an active version there. ActivationOrder sorts the includes
+// and properties accordingly.
+type IncludeGraph struct {
+	// Includes are the known includes by include ID
+	Includes map[string]*Include
+	// Properties are the property versions added to the graph by property ID
+	Properties map[string]*IncludeGraphProperty
+	// users are the property IDs that use each include ID
+	users map[string]map[string]bool
+}
+
+// IncludeGraphProperty is a property version of an IncludeGraph and the includes it uses
+type IncludeGraphProperty struct {
+	PropertyID      string
+	PropertyName    string
+	PropertyVersion int
+	// IncludeIDs are sorted and unique
+	IncludeIDs []string
+}
+
+// ActivationStep is a set of includes and properties that can be activated
+// together once the previous steps are active
+type ActivationStep struct {
+	IncludeIDs  []string
+	PropertyIDs []string
+}
+
+// NewIncludeGraph creates an IncludeGraph knowing includes
+func NewIncludeGraph(includes ...*Include) *IncludeGraph {
+	graph := &IncludeGraph{
+		Includes:   map[string]*Include{},
+		Properties: map[string]*IncludeGraphProperty{},
+		users:      map[string]map[string]bool{},
+	}
+	for _, include := range includes {
+		graph.Includes[include.IncludeID] = include
+	}
+
+	return graph
+}
+
+// BuildIncludeGraph lists the includes of a contract and group and adds the
+// rule tree of the latest version of each property to the graph
+func BuildIncludeGraph(contractID string, groupID string, properties []*Property) (*IncludeGraph, error) {
+	includes, err := ListIncludes(contractID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := NewIncludeGraph(includes...)
+	for _, property := range properties {
+		rules, err := property.GetRules("")
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", property.PropertyID, err)
+		}
+		graph.AddProperty(property.PropertyName, rules)
+	}
+
+	return graph, nil
+}
+
+// AddProperty adds the property version of rules to the graph, replacing a
+// version of the same property added before, and returns the includes it uses
+func (graph *IncludeGraph) AddProperty(propertyName string, rules *Rules) []string {
+	if previous, ok := graph.Properties[rules.PropertyID]; ok {
+		for _, includeID := range previous.IncludeIDs {
+			delete(graph.users[includeID], rules.PropertyID)
+		}
+	}
+
+	includeIDs := RuleIncludeIDs(rules.Rule)
+	graph.Properties[rules.PropertyID] = &IncludeGraphProperty{
+		PropertyID:      rules.PropertyID,
+		PropertyName:    propertyName,
+		PropertyVersion: rules.PropertyVersion,
+		IncludeIDs:      includeIDs,
+	}
+	for _, includeID := range includeIDs {
+		if graph.users[includeID] == nil {
+			graph.users[includeID] = map[string]bool{}
+		}
+		graph.users[includeID][rules.PropertyID] = true
+	}
+
+	return includeIDs
+}
+
+// RuleIncludeIDs returns the sorted, unique IDs of the includes used by rule and its children
+func RuleIncludeIDs(rule *Rule) []string {
+	found := map[string]bool{}
+	var walk func(rule *Rule)
+	walk = func(rule *Rule) {
+		if rule == nil {
+			return
+		}
+		for _, behavior := range rule.Behaviors {
+			if behavior.Name != IncludeBehaviorName {
+				continue
+			}
+			if id, ok := behavior.Options["id"].(string); ok && id != "" {
+				found[id] = true
+			}
+		}
+		for _, child := range rule.Children {
+			walk(child)
+		}
+	}
+	walk(rule)
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Dependents returns the sorted IDs of the properties using includeID
+func (graph *IncludeGraph) Dependents(includeID string) []string {
+	ids := make([]string, 0, len(graph.users[includeID]))
+	for id := range graph.users[includeID] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Unused returns the sorted IDs of the known includes no property uses
+func (graph *IncludeGraph) Unused() []string {
+	var ids []string
+	for id := range graph.Includes {
+		if len(graph.users[id]) == 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Unknown returns the sorted IDs of the includes used by properties that are
+// not in graph.Includes, e.g. includes of another group
+func (graph *IncludeGraph) Unknown() []string {
+	var ids []string
+	for id, users := range graph.users {
+		if _, ok := graph.Includes[id]; !ok && len(users) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// ActivationOrder returns the steps to roll out the given changed includes:
+// the includes first, then every property that uses one of them
+//
+// The other includes used by those properties are not part of the steps,
+// they must already be active on the target network.
+func (graph *IncludeGraph) ActivationOrder(includeIDs ...string) ([]ActivationStep, error) {
+	changed := map[string]bool{}
+	for _, id := range includeIDs {
+		if _, ok := graph.Includes[id]; !ok {
+			return nil, fmt.Errorf("include %s is not in the graph", id)
+		}
+		changed[id] = true
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	includes := make([]string, 0, len(changed))
+	properties := map[string]bool{}
+	for id := range changed {
+		includes = append(includes, id)
+		for propertyID := range graph.users[id] {
+			properties[propertyID] = true
+		}
+	}
+	sort.Strings(includes)
+
+	steps := []ActivationStep{{IncludeIDs: includes}}
+	if len(properties) > 0 {
+		step := ActivationStep{}
+		for id := range properties {
+			step.PropertyIDs = append(step.PropertyIDs, id)
+		}
+		sort.Strings(step.PropertyIDs)
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+// String describes the graph, one include and its active versions per line
+// followed by the property versions using it
+func (graph *IncludeGraph) String() string {
+	ids := make([]string, 0, len(graph.Includes))
+	for id := range graph.Includes {
+		ids = append(ids, id)
+	}
+	ids = append(ids, graph.Unknown()...)
+	sort.Strings(ids)
+
+	var b strings.Builder
+	for _, id := range ids {
+		if include, ok := graph.Includes[id]; ok {
+			fmt.Fprintf(&b, "%s %s staging v%d production v%d\n", id, include.IncludeName, include.StagingVersion, include.ProductionVersion)
+		} else {
+			fmt.Fprintf(&b, "%s (unknown)\n", id)
+		}
+		for _, propertyID := range graph.Dependents(id) {
+			property := graph.Properties[propertyID]
+			fmt.Fprintf(&b, "  %s %s v%d\n", property.PropertyID, property.PropertyName, property.PropertyVersion)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func includeRule(includeIDs ...string) *Rule {
+	rule := NewRule()
+	rule.Name = "default"
+	for _, id := range includeIDs {
+		child := NewRule()
+		child.Name = "include " + id
+		behavior := NewBehavior()
+		behavior.Name = IncludeBehaviorName
+		behavior.Options = OptionValue{"id": id}
+		child.AddBehavior(behavior)
+		rule.AddChildRule(child)
+	}
+
+	return rule
+}
+
+func TestIncludeGraph(t *testing.T) {
+	graph := NewIncludeGraph(
+		&Include{IncludeID: "inc_1", IncludeName: "security", StagingVersion: 3, ProductionVersion: 2},
+		&Include{IncludeID: "inc_2", IncludeName: "caching"},
+		&Include{IncludeID: "inc_3", IncludeName: "unused"},
+	)
+
+	assert.Equal(t, []string{"inc_1", "inc_2"}, graph.AddProperty("www", &Rules{PropertyID: "prp_1", PropertyVersion: 5, Rule: includeRule("inc_2", "inc_1", "inc_2")}))
+	graph.AddProperty("api", &Rules{PropertyID: "prp_2", PropertyVersion: 1, Rule: includeRule("inc_1")})
+	graph.AddProperty("img", &Rules{PropertyID: "prp_3", PropertyVersion: 7, Rule: includeRule("inc_9")})
+
+	assert.Equal(t, []string{"prp_1", "prp_2"}, graph.Dependents("inc_1"))
+	assert.Equal(t, []string{"inc_3"}, graph.Unused())
+	assert.Equal(t, []string{"inc_9"}, graph.Unknown())
+
+	// a new version replaces the previous one
+	graph.AddProperty("api", &Rules{PropertyID: "prp_2", PropertyVersion: 2, Rule: includeRule("inc_2")})
+	assert.Equal(t, []string{"prp_1"}, graph.Dependents("inc_1"))
+
+	steps, err := graph.ActivationOrder("inc_2", "inc_1")
+	require.NoError(t, err)
+	assert.Equal(t, []ActivationStep{
+		{IncludeIDs: []string{"inc_1", "inc_2"}},
+		{PropertyIDs: []string{"prp_1", "prp_2"}},
+	}, steps)
+
+	steps, err = graph.ActivationOrder("inc_3")
+	require.NoError(t, err)
+	assert.Equal(t, []ActivationStep{{IncludeIDs: []string{"inc_3"}}}, steps)
+
+	_, err = graph.ActivationOrder("inc_9")
+	assert.EqualError(t, err, "include inc_9 is not in the graph")
+
+	assert.Equal(t, "inc_1 security staging v3 production v2\n"+
+		"  prp_1 www v5\n"+
+		"inc_2 caching staging v0 production v0\n"+
+		"  prp_1 www v5\n"+
+		"  prp_2 api v2\n"+
+		"inc_3 unused staging v0 production v0\n"+
+		"inc_9 (unknown)\n"+
+		"  prp_3 img v7\n", graph.String())
+}
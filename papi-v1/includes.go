@@ -0,0 +1,367 @@
+package papi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// IncludeTypeValue is used to create an "enum" of possible Include.IncludeType values
+type IncludeTypeValue string
+
+const (
+	// IncludeTypeMicroServices Include.IncludeType value MICROSERVICES
+	IncludeTypeMicroServices IncludeTypeValue = "MICROSERVICES"
+	// IncludeTypeCommonSettings Include.IncludeType value COMMON_SETTINGS
+	IncludeTypeCommonSettings IncludeTypeValue = "COMMON_SETTINGS"
+)
+
+// Include represents a PAPI include: a rule tree shared by properties
+type Include struct {
+	AccountID         string           `json:"accountId,omitempty"`
+	ContractID        string           `json:"contractId"`
+	GroupID           string           `json:"groupId"`
+	AssetID           string           `json:"assetId,omitempty"`
+	IncludeID         string           `json:"includeId,omitempty"`
+	IncludeName       string           `json:"includeName"`
+	IncludeType       IncludeTypeValue `json:"includeType"`
+	LatestVersion     int              `json:"latestVersion,omitempty"`
+	StagingVersion    int              `json:"stagingVersion,omitempty"`
+	ProductionVersion int              `json:"productionVersion,omitempty"`
+	// ProductID and RuleFormat are only used by CreateInclude
+	ProductID  string `json:"productId,omitempty"`
+	RuleFormat string `json:"ruleFormat,omitempty"`
+}
+
+// IncludeRules is the rule tree of an include version
+type IncludeRules struct {
+	client.Resource
+	AccountID      string           `json:"accountId"`
+	ContractID     string           `json:"contractId"`
+	GroupID        string           `json:"groupId"`
+	IncludeID      string           `json:"includeId"`
+	IncludeName    string           `json:"includeName"`
+	IncludeType    IncludeTypeValue `json:"includeType"`
+	IncludeVersion int              `json:"includeVersion"`
+	Etag           string           `json:"etag"`
+	RuleFormat     string           `json:"ruleFormat"`
+	Rule           *Rule            `json:"rules"`
+	Errors         []*RuleErrors    `json:"errors,omitempty"`
+	Warnings       []*RuleErrors    `json:"warnings,omitempty"`
+}
+
+// PreMarshalJSON is called before JSON marshaling
+//
+// See: jsonhooks-v1/json.Marshal()
+func (rules *IncludeRules) PreMarshalJSON() error {
+	rules.Errors = nil
+	rules.Warnings = nil
+	return nil
+}
+
+// IncludeActivation is the activation of an include version on a network
+type IncludeActivation struct {
+	ActivationID           string          `json:"activationId,omitempty"`
+	ActivationType         ActivationValue `json:"activationType"`
+	IncludeID              string          `json:"includeId,omitempty"`
+	IncludeName            string          `json:"includeName,omitempty"`
+	IncludeVersion         int             `json:"includeVersion"`
+	Network                NetworkValue    `json:"network"`
+	Status                 StatusValue     `json:"status,omitempty"`
+	Note                   string          `json:"note,omitempty"`
+	NotifyEmails           []string        `json:"notifyEmails"`
+	AcknowledgeAllWarnings bool            `json:"acknowledgeAllWarnings"`
+	SubmitDate             string          `json:"submitDate,omitempty"`
+	UpdateDate             string          `json:"updateDate,omitempty"`
+}
+
+// Done reports whether the activation reached a final status
+func (activation *IncludeActivation) Done() bool {
+	switch activation.Status {
+	case StatusActive, StatusFailed, StatusAborted, StatusDeactivated, StatusInactive:
+		return true
+	}
+
+	return false
+}
+
+// ListIncludes lists the includes of a contract and group
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getincludes
+// Endpoint: GET /papi/v1/includes{?contractId,groupId}
+func ListIncludes(contractID string, groupID string) ([]*Include, error) {
+	response := struct {
+		Includes struct {
+			Items []*Include `json:"items"`
+		} `json:"includes"`
+	}{}
+	endpoint := fmt.Sprintf("/papi/v1/includes?contractId=%s&groupId=%s", contractID, groupID)
+	if err := doIncludeRequest("GET", endpoint, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Includes.Items, nil
+}
+
+// GetInclude retrieves an include
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getinclude
+// Endpoint: GET /papi/v1/includes/{includeId}{?contractId,groupId}
+func GetInclude(contractID string, groupID string, includeID string) (*Include, error) {
+	response := struct {
+		Includes struct {
+			Items []*Include `json:"items"`
+		} `json:"includes"`
+	}{}
+	endpoint := fmt.Sprintf("/papi/v1/includes/%s?contractId=%s&groupId=%s", includeID, contractID, groupID)
+	if err := doIncludeRequest("GET", endpoint, nil, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Includes.Items) == 0 {
+		return nil, fmt.Errorf("include %s not found", includeID)
+	}
+
+	return response.Includes.Items[0], nil
+}
+
+// CreateInclude creates include and sets its IncludeID
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postincludes
+// Endpoint: POST /papi/v1/includes{?contractId,groupId}
+func CreateInclude(include *Include) error {
+	var location client.JSONBody
+	endpoint := fmt.Sprintf("/papi/v1/includes?contractId=%s&groupId=%s", include.ContractID, include.GroupID)
+	if err := doIncludeRequest("POST", endpoint, include, &location); err != nil {
+		return err
+	}
+
+	include.IncludeID = linkID(location, "includeLink")
+	include.LatestVersion = 1
+
+	return nil
+}
+
+// CreateIncludeVersion creates a new version of include from createFromVersion
+// and returns its number
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postincludeversions
+// Endpoint: POST /papi/v1/includes/{includeId}/versions{?contractId,groupId}
+func CreateIncludeVersion(include *Include, createFromVersion int) (int, error) {
+	var location client.JSONBody
+	endpoint := fmt.Sprintf("/papi/v1/includes/%s/versions?contractId=%s&groupId=%s", include.IncludeID, include.ContractID, include.GroupID)
+	body := map[string]int{"createFromVersion": createFromVersion}
+	if err := doIncludeRequest("POST", endpoint, body, &location); err != nil {
+		return 0, err
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(linkID(location, "versionLink"), "%d", &version); err != nil {
+		return 0, fmt.Errorf("unexpected versionLink %v", location["versionLink"])
+	}
+	include.LatestVersion = version
+
+	return version, nil
+}
+
+// GetIncludeRuleTree retrieves the rule tree of an include version
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getincluderuletree
+// Endpoint: GET /papi/v1/includes/{includeId}/versions/{includeVersion}/rules{?contractId,groupId}
+func GetIncludeRuleTree(include *Include, version int) (*IncludeRules, error) {
+	rules := &IncludeRules{}
+	endpoint := fmt.Sprintf(
+		"/papi/v1/includes/%s/versions/%d/rules?contractId=%s&groupId=%s",
+		include.IncludeID,
+		version,
+		include.ContractID,
+		include.GroupID,
+	)
+	if err := doIncludeRequest("GET", endpoint, nil, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// UpdateIncludeRuleTree saves the rule tree of an include version, see Rules.Update()
+//
+// IncludeRules.Errors and IncludeRules.Warnings are populated from the
+// response, even when ErrorMap[ErrInvalidRules] is returned because of errors.
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#patchincluderuletree
+// Endpoint: PUT /papi/v1/includes/{includeId}/versions/{includeVersion}/rules{?contractId,groupId,validateRules,dryRun}
+func UpdateIncludeRuleTree(rules *IncludeRules, options RuleTreeOptions) (*IncludeRules, error) {
+	query := options.query()
+	if query == "" {
+		query = "?"
+	} else {
+		query += "&"
+	}
+	endpoint := fmt.Sprintf(
+		"/papi/v1/includes/%s/versions/%d/rules%scontractId=%s&groupId=%s",
+		rules.IncludeID,
+		rules.IncludeVersion,
+		query,
+		rules.ContractID,
+		rules.GroupID,
+	)
+
+	if options.Schema != nil {
+		ruleErrors, err := validateRuleTree(options.Schema, rules.Rule)
+		if err != nil {
+			return rules, err
+		}
+		if len(ruleErrors) != 0 {
+			rules.Errors = ruleErrors
+			return rules, ErrorMap[ErrInvalidRules]
+		}
+	}
+
+	rules.Errors = []*RuleErrors{}
+	rules.Warnings = []*RuleErrors{}
+	if err := doIncludeRequest("PUT", endpoint, rules, rules); err != nil {
+		return rules, err
+	}
+
+	if len(rules.Errors) != 0 {
+		return rules, ErrorMap[ErrInvalidRules]
+	}
+
+	return rules, nil
+}
+
+// ActivateInclude activates (or, with ActivationTypeDeactivate, deactivates)
+// an include version and sets activation.ActivationID
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#postincludeactivation
+// Endpoint: POST /papi/v1/includes/{includeId}/activations{?contractId,groupId}
+func ActivateInclude(include *Include, activation *IncludeActivation) error {
+	if activation.ActivationType == "" {
+		activation.ActivationType = ActivationTypeActivate
+	}
+	notifyEmails, err := activationNotifyEmails(activation.NotifyEmails)
+	if err != nil {
+		return err
+	}
+	activation.NotifyEmails = notifyEmails
+
+	var location client.JSONBody
+	endpoint := fmt.Sprintf("/papi/v1/includes/%s/activations?contractId=%s&groupId=%s", include.IncludeID, include.ContractID, include.GroupID)
+	if err := doIncludeRequest("POST", endpoint, activation, &location); err != nil {
+		return err
+	}
+
+	activation.IncludeID = include.IncludeID
+	activation.ActivationID = linkID(location, "activationLink")
+	activation.Status = StatusPending
+
+	return nil
+}
+
+// GetIncludeActivation populates activation with its current status
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#getincludeactivation
+// Endpoint: GET /papi/v1/includes/{includeId}/activations/{activationId}{?contractId,groupId}
+func GetIncludeActivation(include *Include, activation *IncludeActivation) error {
+	response := struct {
+		Activations struct {
+			Items []*IncludeActivation `json:"items"`
+		} `json:"activations"`
+	}{}
+	endpoint := fmt.Sprintf(
+		"/papi/v1/includes/%s/activations/%s?contractId=%s&groupId=%s",
+		include.IncludeID,
+		activation.ActivationID,
+		include.ContractID,
+		include.GroupID,
+	)
+	if err := doIncludeRequest("GET", endpoint, nil, &response); err != nil {
+		return err
+	}
+
+	if len(response.Activations.Items) == 0 {
+		return fmt.Errorf("activation %s not found", activation.ActivationID)
+	}
+	*activation = *response.Activations.Items[0]
+
+	return nil
+}
+
+// WaitForIncludeActivation polls activation until it is done, see WaitForActivation()
+func WaitForIncludeActivation(ctx context.Context, include *Include, activation *IncludeActivation, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = time.Minute
+	}
+
+	start := time.Now()
+	for !activation.Done() {
+		if err := GetIncludeActivation(include, activation); err != nil {
+			return err
+		}
+		if activation.Done() {
+			break
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &ErrWaitCancelled{LastStatus: string(activation.Status), Elapsed: time.Since(start), Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+
+	switch {
+	case activation.Status == StatusActive && activation.ActivationType != ActivationTypeDeactivate,
+		activation.Status == StatusDeactivated && activation.ActivationType == ActivationTypeDeactivate:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrorMap[ErrActivationFailed], activation.Status)
+}
+
+// linkID returns the last path segment of the key link in location
+func linkID(location client.JSONBody, key string) string {
+	link, _ := location[key].(string)
+	if u, err := url.Parse(link); err == nil {
+		link = u.Path
+	}
+
+	return path.Base(link)
+}
+
+// doIncludeRequest sends body (if any) to endpoint and decodes the JSON response into out
+func doIncludeRequest(method string, endpoint string, body interface{}, out interface{}) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = client.NewJSONRequest(Config, method, endpoint, body)
+	} else {
+		req, err = client.NewRequest(Config, method, endpoint, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return err
+	}
+
+	edge.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return newAPIError(res)
+	}
+
+	return client.BodyJSON(res, out)
+}
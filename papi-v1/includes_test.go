@@ -0,0 +1,86 @@
+package papi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestIncludes(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/includes").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		MatchParam("groupId", "grp_15225").
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"includeLink": "/papi/v1/includes/inc_173136?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put("/papi/v1/includes/inc_173136/versions/1/rules").
+		MatchParam("dryRun", "true").
+		MatchParam("contractId", "ctr_1-1TJZH5").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+			"includeId": "inc_173136",
+			"includeVersion": 1,
+			"rules": {"name": "default"},
+			"errors": [{"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required", "errorLocation": "#/rules/behaviors/0/options/hostname", "detail": "The Origin hostname is required."}]
+		}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/includes/inc_173136/activations").
+		BodyString(`{"activationType": "ACTIVATE", "includeVersion": 1, "network": "STAGING", "notifyEmails": ["you@example.com"], "acknowledgeAllWarnings": true}`).
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activationLink": "/papi/v1/includes/inc_173136/activations/atv_1?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/includes/inc_173136/activations/atv_1").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activations": {"items": [{"activationId": "atv_1", "activationType": "ACTIVATE", "includeId": "inc_173136", "includeVersion": 1, "network": "STAGING", "status": "ACTIVE"}]}}`)
+
+	Init(config)
+
+	include := &Include{
+		ContractID:  "ctr_1-1TJZH5",
+		GroupID:     "grp_15225",
+		IncludeName: "shared-origin",
+		IncludeType: IncludeTypeMicroServices,
+		ProductID:   "prd_Site_Accel",
+		RuleFormat:  "latest",
+	}
+	require.NoError(t, CreateInclude(include))
+	assert.Equal(t, "inc_173136", include.IncludeID)
+
+	rules := &IncludeRules{
+		ContractID:     include.ContractID,
+		GroupID:        include.GroupID,
+		IncludeID:      include.IncludeID,
+		IncludeVersion: 1,
+		Rule:           NewRule(),
+	}
+	rules, err := UpdateIncludeRuleTree(rules, RuleTreeOptions{DryRun: true})
+	assert.Equal(t, ErrorMap[ErrInvalidRules], err)
+	require.Len(t, rules.Errors, 1)
+	assert.Equal(t, "#/rules/behaviors/0/options/hostname", rules.Errors[0].ErrorLocation)
+
+	activation := &IncludeActivation{
+		IncludeVersion:         1,
+		Network:                NetworkStaging,
+		NotifyEmails:           []string{"you@example.com"},
+		AcknowledgeAllWarnings: true,
+	}
+	require.NoError(t, ActivateInclude(include, activation))
+	assert.Equal(t, "atv_1", activation.ActivationID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, WaitForIncludeActivation(ctx, include, activation, time.Millisecond))
+	assert.Equal(t, StatusActive, activation.Status)
+	assert.True(t, gock.IsDone())
+}
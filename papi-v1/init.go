@@ -9,3 +9,16 @@ func Init(config edgegrid.Config) {
 	Config = config
 	edgegrid.SetupLogging()
 }
+
+// SetAccountSwitchKey makes all following PAPI requests act on behalf of the
+// account identified by key, an empty key switches back to the credential's
+// own account. Cached groups, contracts, products, CP codes and edge hostnames
+// belong to the previous account and are flushed.
+func SetAccountSwitchKey(key string) {
+	if Config.AccountKey == key {
+		return
+	}
+
+	Config.AccountKey = key
+	Profilecache.Flush()
+}
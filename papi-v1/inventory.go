@@ -0,0 +1,279 @@
+package papi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InventoryOptions configures ScanHostnames
+type InventoryOptions struct {
+	// Network selects the property version scanned: the version active on
+	// NetworkStaging or NetworkProduction, or the latest version when empty.
+	// Properties without such a version are skipped.
+	Network NetworkValue
+	// Resolve, when set, is used to check that each hostname's DNS points at
+	// its edge hostname, see Hostnames.CheckHostnameDNS()
+	Resolve CNAMEResolver
+	// CheckOrigin, when set, is called once for each origin hostname of the
+	// scanned rule trees, see DialOrigin()
+	CheckOrigin func(origin string) error
+}
+
+// InventoryEntry is a hostname of a property version
+type InventoryEntry struct {
+	Hostname        string   `json:"hostname"`
+	EdgeHostname    string   `json:"edgeHostname"`
+	ContractID      string   `json:"contractId"`
+	GroupID         string   `json:"groupId"`
+	PropertyID      string   `json:"propertyId"`
+	PropertyName    string   `json:"propertyName"`
+	PropertyVersion int      `json:"propertyVersion"`
+	Origins         []string `json:"origins,omitempty"`
+	// Duplicate is set when the hostname is also used by another property
+	Duplicate bool `json:"duplicate"`
+	// DNSMissing is set when the hostname could not be resolved
+	DNSMissing bool `json:"dnsMissing"`
+	// DNSMismatch is set when the hostname resolves, but not to EdgeHostname
+	DNSMismatch bool `json:"dnsMismatch"`
+	// UnreachableOrigins are the Origins CheckOrigin failed for
+	UnreachableOrigins []string `json:"unreachableOrigins,omitempty"`
+}
+
+// Inventory is the property hostnames of the account, see ScanHostnames()
+type Inventory struct {
+	Entries []*InventoryEntry `json:"entries"`
+}
+
+// DialOrigin checks that a TCP connection to origin can be opened on port 443
+func DialOrigin(origin string) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(origin, "443"), 5*time.Second)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// ScanHostnames lists the hostnames of every property of every contract and
+// group the credentials have access to, and flags the hostnames used by more
+// than one property, missing from DNS, or whose origins are unreachable
+func ScanHostnames(options InventoryOptions) (*Inventory, error) {
+	groups, err := GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{}
+	originErrors := map[string]error{}
+	scanned := map[string]bool{}
+
+	for _, group := range groups.Groups.Items {
+		for _, contractID := range group.ContractIDs {
+			contract := NewContract(NewContracts())
+			contract.ContractID = contractID
+
+			properties, err := GetProperties(contract, group)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, property := range properties.Properties.Items {
+				if scanned[property.PropertyID] {
+					continue
+				}
+				scanned[property.PropertyID] = true
+
+				entries, err := scanProperty(property, options, originErrors)
+				if err != nil {
+					return nil, err
+				}
+				inventory.Entries = append(inventory.Entries, entries...)
+			}
+		}
+	}
+
+	inventory.flagDuplicates()
+
+	return inventory, nil
+}
+
+// scanProperty returns the entries of the hostnames of the property version selected by options
+func scanProperty(property *Property, options InventoryOptions, originErrors map[string]error) ([]*InventoryEntry, error) {
+	version := property.LatestVersion
+	switch options.Network {
+	case NetworkStaging:
+		version = property.StagingVersion
+	case NetworkProduction:
+		version = property.ProductionVersion
+	}
+	if version == 0 {
+		return nil, nil
+	}
+
+	hostnames, err := GetPropertyVersionHostnames(property, version, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var origins []string
+	if options.CheckOrigin != nil {
+		versionProperty := NewProperty(NewProperties())
+		versionProperty.PropertyID = property.PropertyID
+		versionProperty.LatestVersion = version
+		rules := NewRules()
+		if err := rules.GetRules(versionProperty, ""); err != nil {
+			return nil, err
+		}
+		origins = originHostnames(rules.Rule)
+	}
+
+	var unreachable []string
+	for _, origin := range origins {
+		err, ok := originErrors[origin]
+		if !ok {
+			err = options.CheckOrigin(origin)
+			originErrors[origin] = err
+		}
+		if err != nil {
+			unreachable = append(unreachable, origin)
+		}
+	}
+
+	dnsMismatches := map[string]DNSMismatch{}
+	if options.Resolve != nil {
+		for _, mismatch := range hostnames.CheckHostnameDNS(options.Resolve) {
+			dnsMismatches[strings.ToLower(mismatch.Hostname)] = mismatch
+		}
+	}
+
+	var entries []*InventoryEntry
+	for _, hostname := range hostnames.Hostnames.Items {
+		entry := &InventoryEntry{
+			Hostname:           strings.ToLower(hostname.CnameFrom),
+			EdgeHostname:       hostname.CnameTo,
+			ContractID:         property.ContractID,
+			GroupID:            property.GroupID,
+			PropertyID:         property.PropertyID,
+			PropertyName:       property.PropertyName,
+			PropertyVersion:    version,
+			Origins:            origins,
+			UnreachableOrigins: unreachable,
+		}
+		if mismatch, ok := dnsMismatches[entry.Hostname]; ok {
+			entry.DNSMissing = mismatch.Err != nil
+			entry.DNSMismatch = mismatch.Err == nil
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// originHostnames returns the hostnames of the origin behaviors of rule and its children
+func originHostnames(rule *Rule) []string {
+	var origins []string
+	seen := map[string]bool{}
+
+	var walk func(rule *Rule)
+	walk = func(rule *Rule) {
+		for _, behavior := range rule.Behaviors {
+			if behavior.Name != "origin" {
+				continue
+			}
+			if hostname, ok := behavior.Options["hostname"].(string); ok && hostname != "" && !seen[hostname] {
+				seen[hostname] = true
+				origins = append(origins, hostname)
+			}
+		}
+		for _, child := range rule.Children {
+			walk(child)
+		}
+	}
+	if rule != nil {
+		walk(rule)
+	}
+
+	return origins
+}
+
+func (inventory *Inventory) flagDuplicates() {
+	for _, entries := range inventory.Duplicates() {
+		for _, entry := range entries {
+			entry.Duplicate = true
+		}
+	}
+}
+
+// Duplicates returns the entries of the hostnames used by more than one property, by hostname
+func (inventory *Inventory) Duplicates() map[string][]*InventoryEntry {
+	byHostname := map[string][]*InventoryEntry{}
+	for _, entry := range inventory.Entries {
+		byHostname[entry.Hostname] = append(byHostname[entry.Hostname], entry)
+	}
+
+	duplicates := map[string][]*InventoryEntry{}
+	for hostname, entries := range byHostname {
+		properties := map[string]bool{}
+		for _, entry := range entries {
+			properties[entry.PropertyID] = true
+		}
+		if len(properties) > 1 {
+			duplicates[hostname] = entries
+		}
+	}
+
+	return duplicates
+}
+
+// inventoryColumns are the columns written by Inventory.WriteCSV
+var inventoryColumns = []string{
+	"hostname", "edgeHostname", "contractId", "groupId", "propertyId", "propertyName", "propertyVersion",
+	"origins", "duplicate", "dnsMissing", "dnsMismatch", "unreachableOrigins",
+}
+
+// WriteCSV writes the inventory as CSV with a header row, sorted by hostname;
+// origins are "|" separated
+func (inventory *Inventory) WriteCSV(w io.Writer) error {
+	entries := append([]*InventoryEntry(nil), inventory.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Hostname < entries[j].Hostname })
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(inventoryColumns); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := writer.Write([]string{
+			entry.Hostname,
+			entry.EdgeHostname,
+			entry.ContractID,
+			entry.GroupID,
+			entry.PropertyID,
+			entry.PropertyName,
+			strconv.Itoa(entry.PropertyVersion),
+			strings.Join(entry.Origins, "|"),
+			strconv.FormatBool(entry.Duplicate),
+			strconv.FormatBool(entry.DNSMissing),
+			strconv.FormatBool(entry.DNSMismatch),
+			strings.Join(entry.UnreachableOrigins, "|"),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteJSON writes the inventory as indented JSON
+func (inventory *Inventory) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(inventory)
+}
@@ -0,0 +1,148 @@
+package papi
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestScanHostnames(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties").
+		MatchParam("groupId", "grp_1").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"properties": {"items": [
+			{"propertyId": "prp_1", "propertyName": "www", "contractId": "ctr_1", "groupId": "grp_1", "latestVersion": 3, "productionVersion": 2},
+			{"propertyId": "prp_2", "propertyName": "api", "contractId": "ctr_1", "groupId": "grp_1", "latestVersion": 1}
+		]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties").
+		MatchParam("groupId", "grp_2").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"properties": {"items": [{"propertyId": "prp_1", "propertyName": "www", "contractId": "ctr_1", "groupId": "grp_1", "latestVersion": 3}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_1/versions/3/hostnames").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"hostnames": {"items": [{"cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net"}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_2/versions/1/hostnames").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"hostnames": {"items": [{"cnameFrom": "WWW.example.com", "cnameTo": "www.example.com.edgekey.net"}, {"cnameFrom": "api.example.com", "cnameTo": "api.example.com.edgekey.net"}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_1/versions/3/rules").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com"}}],
+			"children": [{"name": "images", "behaviors": [{"name": "origin", "options": {"hostname": "images.example.com"}}]}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_2/versions/1/rules").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com"}}]}}`)
+
+	Init(config)
+	primeLookups(t, `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+		`{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}, {"groupId": "grp_2", "contractIds": ["ctr_1"]}]}}`)
+
+	checked := map[string]int{}
+	inventory, err := ScanHostnames(InventoryOptions{
+		Resolve: func(hostname string) ([]string, error) {
+			if hostname == "api.example.com" {
+				return nil, errors.New("no such host")
+			}
+			return []string{"www.example.com.edgekey.net"}, nil
+		},
+		CheckOrigin: func(origin string) error {
+			checked[origin]++
+			if origin == "images.example.com" {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, inventory.Entries, 3)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, map[string]int{"origin.example.com": 1, "images.example.com": 1}, checked)
+
+	www := inventory.Entries[0]
+	assert.Equal(t, "www.example.com", www.Hostname)
+	assert.Equal(t, "prp_1", www.PropertyID)
+	assert.Equal(t, 3, www.PropertyVersion)
+	assert.True(t, www.Duplicate)
+	assert.False(t, www.DNSMissing)
+	assert.Equal(t, []string{"origin.example.com", "images.example.com"}, www.Origins)
+	assert.Equal(t, []string{"images.example.com"}, www.UnreachableOrigins)
+
+	assert.True(t, inventory.Entries[1].Duplicate)
+	api := inventory.Entries[2]
+	assert.False(t, api.Duplicate)
+	assert.True(t, api.DNSMissing)
+	assert.Empty(t, api.UnreachableOrigins)
+
+	duplicates := inventory.Duplicates()
+	require.Len(t, duplicates, 1)
+	assert.Len(t, duplicates["www.example.com"], 2)
+
+	var csv bytes.Buffer
+	require.NoError(t, inventory.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "api.example.com,api.example.com.edgekey.net,ctr_1,grp_1,prp_2,api,1,origin.example.com,false,true,false,", lines[1])
+
+	var out bytes.Buffer
+	require.NoError(t, inventory.WriteJSON(&out))
+	assert.Contains(t, out.String(), `"unreachableOrigins": [`)
+}
+
+func TestScanHostnames_Network(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"properties": {"items": [
+			{"propertyId": "prp_1", "contractId": "ctr_1", "groupId": "grp_1", "latestVersion": 3, "productionVersion": 2},
+			{"propertyId": "prp_2", "contractId": "ctr_1", "groupId": "grp_1", "latestVersion": 1}
+		]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_1/versions/2/hostnames").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"hostnames": {"items": [{"cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net"}]}}`)
+
+	Init(config)
+	primeLookups(t, `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+		`{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}]}}`)
+
+	inventory, err := ScanHostnames(InventoryOptions{Network: NetworkProduction})
+	require.NoError(t, err)
+	require.Len(t, inventory.Entries, 1)
+	assert.Equal(t, 2, inventory.Entries[0].PropertyVersion)
+	assert.True(t, gock.IsDone())
+}
+
+// primeLookups primes the contracts and groups caches for the lookups of each
+// unmarshaled property, so they make no request, and clears the caches at the
+// end of the test
+func primeLookups(t *testing.T, contracts string, groups string) {
+	Profilecache.Set(CacheKeyContracts, []byte(contracts), cache.DefaultExpiration)
+	Profilecache.Set(CacheKeyGroups, []byte(groups), cache.DefaultExpiration)
+	t.Cleanup(func() {
+		Profilecache.Delete(CacheKeyContracts)
+		Profilecache.Delete(CacheKeyGroups)
+	})
+}
@@ -0,0 +1,184 @@
+package papi
+
+import (
+	"strings"
+)
+
+// MessageCode is a stable identifier for a well known PAPI validation or activation message
+//
+// PAPI titles and details are human readable (and may change or be localized);
+// match on a MessageCode instead of string matching on the text.
+type MessageCode string
+
+// MessageSeverity is used to create an "enum" of possible KnownMessage.Severity values
+type MessageSeverity string
+
+const (
+	// SeverityWarning the message does not block saving or activating
+	SeverityWarning MessageSeverity = "WARNING"
+	// SeverityError the message blocks saving or activating
+	SeverityError MessageSeverity = "ERROR"
+)
+
+const (
+	// MessageAttributeRequired a required behavior or criteria option is missing
+	MessageAttributeRequired MessageCode = "ATTRIBUTE_REQUIRED"
+	// MessageIncompatibleCondition a behavior is used under criteria it does not support
+	MessageIncompatibleCondition MessageCode = "INCOMPATIBLE_CONDITION"
+	// MessageUnstableRuleFormat the rule tree uses the "latest" rule format
+	MessageUnstableRuleFormat MessageCode = "UNSTABLE_RULE_FORMAT"
+	// MessageCpCodeIncorrectProduct the CP code belongs to a different product than the property
+	MessageCpCodeIncorrectProduct MessageCode = "CPCODE_INCORRECT_PRODUCT"
+	// MessageOriginHostnameMatchesProperty the origin hostname is also a property hostname
+	MessageOriginHostnameMatchesProperty MessageCode = "ORIGIN_HOSTNAME_MATCHES_PROPERTY_HOSTNAME"
+	// MessageOriginHostnameUnresolvable the origin hostname does not resolve in DNS
+	MessageOriginHostnameUnresolvable MessageCode = "ORIGIN_HOSTNAME_UNRESOLVABLE"
+	// MessageSSLCustomOriginCertificate the origin certificate settings require a custom certificate list
+	MessageSSLCustomOriginCertificate MessageCode = "SSL_CUSTOM_ORIGIN_CERTIFICATE"
+	// MessageHostnameNotInCertificate a secure hostname is not covered by the edge hostname's certificate
+	MessageHostnameNotInCertificate MessageCode = "HOSTNAME_NOT_IN_CERTIFICATE"
+	// MessageVariableNotDefined a behavior references an undeclared user variable
+	MessageVariableNotDefined MessageCode = "VARIABLE_NOT_DEFINED"
+	// MessageBehaviorNotAvailable the behavior is not available on the product or contract
+	MessageBehaviorNotAvailable MessageCode = "BEHAVIOR_NOT_AVAILABLE"
+	// MessageUnknownBehavior the behavior name is not part of the rule format
+	MessageUnknownBehavior MessageCode = "UNKNOWN_BEHAVIOR"
+	// MessageEdgeHostnameNotFound a property hostname references a missing edge hostname
+	MessageEdgeHostnameNotFound MessageCode = "EDGE_HOSTNAME_NOT_FOUND"
+	// MessageHostnameAlreadyActiveElsewhere a hostname is active on another property and will be moved
+	MessageHostnameAlreadyActiveElsewhere MessageCode = "HOSTNAME_ALREADY_ACTIVE_ELSEWHERE"
+	// MessageActivationInProgress another activation of the property is pending
+	MessageActivationInProgress MessageCode = "ACTIVATION_IN_PROGRESS"
+	// MessageNoncomplianceReasonRequired a production activation needs a compliance record
+	MessageNoncomplianceReasonRequired MessageCode = "NONCOMPLIANCE_REASON_REQUIRED"
+	// MessageCacheKeyQueryParams query parameters are excluded from the cache key
+	MessageCacheKeyQueryParams MessageCode = "CACHE_KEY_QUERY_PARAMS"
+)
+
+// KnownMessage describes a PAPI message and how to deal with it
+type KnownMessage struct {
+	Code        MessageCode
+	Severity    MessageSeverity
+	Summary     string
+	Remediation string
+}
+
+// KnownMessages maps the PAPI problem type (the final segment of the "type"
+// URI, or the activation warning "messageId") to a KnownMessage
+//
+// The table is exported so applications can add the message IDs they care
+// about. It is not safe for concurrent modification; add entries during init.
+var KnownMessages = map[string]KnownMessage{
+	"attribute_required": {
+		Code:        MessageAttributeRequired,
+		Severity:    SeverityError,
+		Summary:     "A required behavior or criteria option is missing",
+		Remediation: "Set the option named in the error's instance path; see GetSchema for the rule format's required options",
+	},
+	"incompatible_condition": {
+		Code:        MessageIncompatibleCondition,
+		Severity:    SeverityError,
+		Summary:     "A behavior is used under criteria it does not support",
+		Remediation: "Move the behavior to a rule without the criteria, or remove the criteria",
+	},
+	"unstable_rule_format": {
+		Code:        MessageUnstableRuleFormat,
+		Severity:    SeverityWarning,
+		Summary:     "The rule tree uses the \"latest\" rule format",
+		Remediation: "Freeze the rule tree to a dated rule format with Rules.Freeze",
+	},
+	"product_behavior_issue.cpcode_incorrect_product": {
+		Code:        MessageCpCodeIncorrectProduct,
+		Severity:    SeverityWarning,
+		Summary:     "The CP code was created for a different product than the property",
+		Remediation: "Create a CP code for the property's product, or ignore if reporting is not affected",
+	},
+	"validation_message.origin_hostname_matches_property_hostname": {
+		Code:        MessageOriginHostnameMatchesProperty,
+		Severity:    SeverityWarning,
+		Summary:     "The origin hostname is also one of the property hostnames, which loops requests back to Akamai",
+		Remediation: "Use a dedicated origin hostname (e.g. origin-www.example.com) that does not CNAME to an edge hostname",
+	},
+	"validation_message.origin_hostname_unresolvable": {
+		Code:        MessageOriginHostnameUnresolvable,
+		Severity:    SeverityWarning,
+		Summary:     "The origin hostname does not resolve in DNS",
+		Remediation: "Create the origin DNS record before activating, or ignore for origins that are not live yet",
+	},
+	"validation_message.ssl_custom_origin_certificate": {
+		Code:        MessageSSLCustomOriginCertificate,
+		Severity:    SeverityWarning,
+		Summary:     "The origin certificate verification settings require a custom certificate list",
+		Remediation: "Add the origin certificate or its CA to the origin behavior, or use the Akamai managed CA set",
+	},
+	"validation_message.hostname_not_in_certificate": {
+		Code:        MessageHostnameNotInCertificate,
+		Severity:    SeverityWarning,
+		Summary:     "A secure property hostname is not covered by the edge hostname's certificate",
+		Remediation: "Add the hostname to the CPS enrollment, or use the Default DV certificate for it",
+	},
+	"validation_message.variable_not_defined": {
+		Code:        MessageVariableNotDefined,
+		Severity:    SeverityError,
+		Summary:     "A behavior references a user variable that is not declared",
+		Remediation: "Declare the PMUSER_ variable in the default rule's variables",
+	},
+	"validation_message.behavior_not_available": {
+		Code:        MessageBehaviorNotAvailable,
+		Severity:    SeverityError,
+		Summary:     "The behavior is not available on the property's product or contract",
+		Remediation: "Check GetAvailableBehaviors for the property, or remove the behavior",
+	},
+	"unknown_behavior": {
+		Code:        MessageUnknownBehavior,
+		Severity:    SeverityError,
+		Summary:     "The behavior name is not part of the rule format",
+		Remediation: "Check the behavior name spelling against the rule format schema",
+	},
+	"edge_hostname_not_found": {
+		Code:        MessageEdgeHostnameNotFound,
+		Severity:    SeverityError,
+		Summary:     "A property hostname references an edge hostname that does not exist",
+		Remediation: "Create the edge hostname first, or fix the edgeHostnameId on the hostname",
+	},
+	"activation_warning.hostname_already_active": {
+		Code:        MessageHostnameAlreadyActiveElsewhere,
+		Severity:    SeverityWarning,
+		Summary:     "A hostname is currently active on another property and will be moved",
+		Remediation: "Confirm the move is intended, then acknowledge the warning",
+	},
+	"activation_already_in_progress": {
+		Code:        MessageActivationInProgress,
+		Severity:    SeverityError,
+		Summary:     "Another activation of the property is pending on the network",
+		Remediation: "Wait for the pending activation to finish, or cancel it",
+	},
+	"activation_warning.noncompliance_reason_required": {
+		Code:        MessageNoncomplianceReasonRequired,
+		Severity:    SeverityError,
+		Summary:     "Production activations require a compliance record",
+		Remediation: "Set Activation.ComplianceRecord with a noncompliance reason",
+	},
+	"validation_message.cache_key_query_params": {
+		Code:        MessageCacheKeyQueryParams,
+		Severity:    SeverityWarning,
+		Summary:     "Query parameters are excluded from the cache key, which can serve the wrong content",
+		Remediation: "Review the cacheKeyQueryParams behavior; include the parameters that change the response",
+	},
+}
+
+// FindKnownMessage looks up a problem type URI, problem type suffix or messageId in KnownMessages
+func FindKnownMessage(typeOrMessageID string) (KnownMessage, bool) {
+	key := typeOrMessageID
+	if i := strings.LastIndex(key, "/"); i != -1 {
+		key = key[i+1:]
+	}
+
+	message, ok := KnownMessages[key]
+	return message, ok
+}
+
+// Known returns the KnownMessage for the rule error, if any
+func (ruleErrors *RuleErrors) Known() (KnownMessage, bool) {
+	return FindKnownMessage(ruleErrors.Type)
+}
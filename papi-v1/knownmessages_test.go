@@ -0,0 +1,40 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindKnownMessage(t *testing.T) {
+	tests := map[string]struct {
+		typeOrMessageID string
+		code            MessageCode
+		found           bool
+	}{
+		"problem type URI":  {"https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required", MessageAttributeRequired, true},
+		"type URI suffix":   {"https://problems.luna.akamaiapis.net/papi/v0/validation/validation_message.cache_key_query_params", MessageCacheKeyQueryParams, true},
+		"bare messageId":    {"activation_warning.hostname_already_active", MessageHostnameAlreadyActiveElsewhere, true},
+		"unknown URI":       {"https://problems.luna.akamaiapis.net/papi/v0/validation/not_a_message", "", false},
+		"unknown messageId": {"not_a_message", "", false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			message, ok := FindKnownMessage(test.typeOrMessageID)
+			assert.Equal(t, test.found, ok)
+			assert.Equal(t, test.code, message.Code)
+		})
+	}
+}
+
+func TestRuleErrors_Known(t *testing.T) {
+	ruleErrors := &RuleErrors{Type: "https://problems.luna.akamaiapis.net/papi/v0/validation/validation_message.variable_not_defined"}
+	message, ok := ruleErrors.Known()
+	assert.True(t, ok)
+	assert.Equal(t, MessageVariableNotDefined, message.Code)
+	assert.Equal(t, SeverityError, message.Severity)
+
+	_, ok = (&RuleErrors{Type: "https://problems.luna.akamaiapis.net/papi/v0/validation/not_a_message"}).Known()
+	assert.False(t, ok)
+}
@@ -0,0 +1,173 @@
+package papi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PropertyLocker serializes version create/update/activate sequences on a property
+//
+// Locks are advisory: they only protect callers that use the same PropertyLocker
+// backend (and, for FileLocker, the same directory).
+type PropertyLocker interface {
+	// Lock blocks until the property's lock is held, or returns ErrorMap[ErrLockTimeout]
+	Lock(propertyID string) error
+	// Unlock releases the property's lock
+	Unlock(propertyID string) error
+}
+
+// WithPropertyLock runs fn while holding the lock for propertyID
+func WithPropertyLock(locker PropertyLocker, propertyID string, fn func() error) error {
+	if err := locker.Lock(propertyID); err != nil {
+		return err
+	}
+	defer locker.Unlock(propertyID)
+
+	return fn()
+}
+
+// MemoryLocker is a PropertyLocker for goroutines within a single process
+type MemoryLocker struct {
+	// Timeout is how long Lock waits, zero waits forever
+	Timeout time.Duration
+
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// NewMemoryLocker creates a new MemoryLocker
+func NewMemoryLocker(timeout time.Duration) *MemoryLocker {
+	return &MemoryLocker{Timeout: timeout}
+}
+
+func (locker *MemoryLocker) lockFor(propertyID string) chan struct{} {
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+
+	if locker.locks == nil {
+		locker.locks = make(map[string]chan struct{})
+	}
+	lock, ok := locker.locks[propertyID]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		locker.locks[propertyID] = lock
+	}
+
+	return lock
+}
+
+// Lock acquires the lock for propertyID
+func (locker *MemoryLocker) Lock(propertyID string) error {
+	lock := locker.lockFor(propertyID)
+	if locker.Timeout <= 0 {
+		lock <- struct{}{}
+		return nil
+	}
+
+	timer := time.NewTimer(locker.Timeout)
+	defer timer.Stop()
+	select {
+	case lock <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrorMap[ErrLockTimeout]
+	}
+}
+
+// Unlock releases the lock for propertyID
+func (locker *MemoryLocker) Unlock(propertyID string) error {
+	select {
+	case <-locker.lockFor(propertyID):
+		return nil
+	default:
+		return fmt.Errorf("property %s is not locked", propertyID)
+	}
+}
+
+// FileLocker is a PropertyLocker for processes sharing a directory, using exclusively created lock files
+type FileLocker struct {
+	// Dir holds the lock files
+	Dir string
+	// Timeout is how long Lock waits, zero waits forever
+	Timeout time.Duration
+	// PollInterval is how often Lock retries, defaults to 500ms
+	PollInterval time.Duration
+	// StaleAfter removes lock files older than this (left behind by crashed
+	// processes), zero never removes them. It must be longer than any locked sequence.
+	StaleAfter time.Duration
+}
+
+// NewFileLocker creates a new FileLocker storing lock files in dir
+func NewFileLocker(dir string, timeout time.Duration) *FileLocker {
+	return &FileLocker{Dir: dir, Timeout: timeout}
+}
+
+// path returns the lock file of propertyID, hex encoded so that distinct IDs
+// never share a file
+func (locker *FileLocker) path(propertyID string) string {
+	return filepath.Join(locker.Dir, "papi-"+hex.EncodeToString([]byte(propertyID))+".lock")
+}
+
+// takeOver removes the lock file at path if it is stale
+//
+// The file is renamed away rather than removed, then compared with the one
+// found stale: if another process replaced it with a fresh lock in between,
+// that lock is linked back into place.
+func (locker *FileLocker) takeOver(path string) {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) <= locker.StaleAfter {
+		return
+	}
+
+	taken := fmt.Sprintf("%s.%d.%d.stale", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, taken); err != nil {
+		return
+	}
+	defer os.Remove(taken)
+
+	if takenInfo, err := os.Stat(taken); err == nil && !os.SameFile(info, takenInfo) {
+		os.Link(taken, path)
+	}
+}
+
+// Lock acquires the lock for propertyID
+func (locker *FileLocker) Lock(propertyID string) error {
+	path := locker.path(propertyID)
+	interval := locker.PollInterval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	deadline := time.Now().Add(locker.Timeout)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "pid=%d time=%s\n", os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+			return f.Close()
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+
+		if locker.StaleAfter > 0 {
+			locker.takeOver(path)
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				continue
+			}
+		}
+
+		if locker.Timeout > 0 && time.Now().After(deadline) {
+			return ErrorMap[ErrLockTimeout]
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Unlock releases the lock for propertyID
+func (locker *FileLocker) Unlock(propertyID string) error {
+	return os.Remove(locker.path(propertyID))
+}
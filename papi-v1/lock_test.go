@@ -0,0 +1,106 @@
+package papi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLockerSerializes(t *testing.T, locker PropertyLocker) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		running int
+		maxSeen int
+	)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := WithPropertyLock(locker, "prp_175780", func() error {
+				mu.Lock()
+				running++
+				if running > maxSeen {
+					maxSeen = running
+				}
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxSeen)
+}
+
+func TestMemoryLocker(t *testing.T) {
+	locker := NewMemoryLocker(time.Second)
+	testLockerSerializes(t, locker)
+
+	require.NoError(t, locker.Lock("prp_1"))
+	// other properties are not blocked
+	require.NoError(t, locker.Lock("prp_2"))
+
+	locker.Timeout = 10 * time.Millisecond
+	assert.Equal(t, ErrorMap[ErrLockTimeout], locker.Lock("prp_1"))
+
+	require.NoError(t, locker.Unlock("prp_1"))
+	assert.Error(t, locker.Unlock("prp_1"))
+}
+
+func TestFileLocker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "papi-lock")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	locker := NewFileLocker(dir, time.Second)
+	locker.PollInterval = time.Millisecond
+	testLockerSerializes(t, locker)
+
+	require.NoError(t, locker.Lock("prp_1"))
+	other := &FileLocker{Dir: dir, Timeout: 10 * time.Millisecond, PollInterval: time.Millisecond}
+	assert.Equal(t, ErrorMap[ErrLockTimeout], other.Lock("prp_1"))
+
+	// a stale lock left by a crashed process is taken over
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(locker.path("prp_1"), old, old))
+	other.StaleAfter = time.Minute
+	assert.NoError(t, other.Lock("prp_1"))
+	assert.NoError(t, other.Unlock("prp_1"))
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFileLocker_FreshLockNotTakenOver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "papi-lock")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	locker := &FileLocker{Dir: dir, Timeout: 10 * time.Millisecond, PollInterval: time.Millisecond, StaleAfter: time.Minute}
+	require.NoError(t, locker.Lock("prp_1"))
+	assert.Equal(t, ErrorMap[ErrLockTimeout], locker.Lock("prp_1"))
+	_, err = os.Stat(locker.path("prp_1"))
+	assert.NoError(t, err)
+}
+
+func TestFileLocker_Path(t *testing.T) {
+	locker := NewFileLocker("locks", time.Second)
+	assert.NotEqual(t, locker.path("prp/1"), locker.path("prp_1"))
+	assert.NotEqual(t, locker.path("prp:1"), locker.path("prp_1"))
+	assert.Equal(t, "locks", filepath.Dir(locker.path("../prp_1")))
+}
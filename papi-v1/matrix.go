@@ -0,0 +1,195 @@
+package papi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
+)
+
+// MatrixTarget is a row of a MatrixSpec: a property and the parameters for its transformation
+type MatrixTarget struct {
+	PropertyID string
+	Params     map[string]interface{}
+}
+
+// RuleTransform changes a rule tree in place using a target's parameters
+type RuleTransform func(rules *Rules, params map[string]interface{}) error
+
+// MatrixSpec describes a change applied to many properties at once
+//
+// For each target a new version is created from the latest version, and the
+// transformed rule tree is saved to it. Targets whose rule tree is unchanged by
+// Transform are skipped without creating a version.
+type MatrixSpec struct {
+	Targets   []MatrixTarget
+	Transform RuleTransform
+	// Note is set on each new version
+	Note string
+	// DryRun computes the transformed rule trees without creating versions
+	DryRun bool
+	// Locker, if set, holds each property's lock while its version is created and saved
+	Locker PropertyLocker
+}
+
+// MatrixAction is used to create an "enum" of possible MatrixResult.Action values
+type MatrixAction string
+
+const (
+	// MatrixCreated a new version was created with the transformed rules
+	MatrixCreated MatrixAction = "CREATED"
+	// MatrixWouldCreate a new version would be created (dry-run)
+	MatrixWouldCreate MatrixAction = "WOULD_CREATE"
+	// MatrixUnchanged the transformation did not change the rule tree
+	MatrixUnchanged MatrixAction = "UNCHANGED"
+	// MatrixFailed see MatrixResult.Err
+	MatrixFailed MatrixAction = "FAILED"
+)
+
+// MatrixResult is the outcome for a single MatrixTarget
+type MatrixResult struct {
+	PropertyID   string
+	PropertyName string
+	FromVersion  int
+	// NewVersion is the created version, 0 unless Action is MatrixCreated
+	NewVersion int
+	Action     MatrixAction
+	// Before and After are the indented JSON rule trees, for reviewing a dry-run
+	Before []byte
+	After  []byte
+	Err    error
+}
+
+// Changed reports whether the rule tree was (or would be) changed
+func (result MatrixResult) Changed() bool {
+	return result.Action == MatrixCreated || result.Action == MatrixWouldCreate
+}
+
+// ApplyMatrix applies spec to each of its targets in order, returning one result per target
+//
+// A failure on one property does not stop the others; check each MatrixResult.Err.
+func ApplyMatrix(spec MatrixSpec) []MatrixResult {
+	results := make([]MatrixResult, 0, len(spec.Targets))
+	for _, target := range spec.Targets {
+		result := MatrixResult{PropertyID: target.PropertyID}
+		var err error
+		if spec.Locker != nil && !spec.DryRun {
+			err = WithPropertyLock(spec.Locker, target.PropertyID, func() error {
+				return applyMatrixTarget(spec, target, &result)
+			})
+		} else {
+			err = applyMatrixTarget(spec, target, &result)
+		}
+		if err != nil {
+			result.Action = MatrixFailed
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func applyMatrixTarget(spec MatrixSpec, target MatrixTarget, result *MatrixResult) error {
+	if spec.Transform == nil {
+		return fmt.Errorf("no transform given")
+	}
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = target.PropertyID
+	if err := property.GetProperty(""); err != nil {
+		return err
+	}
+	result.PropertyName = property.PropertyName
+	result.FromVersion = property.LatestVersion
+
+	rules, err := property.GetRules("")
+	if err != nil {
+		return err
+	}
+
+	if result.Before, err = jsonhooks.Marshal(rules.Rule); err != nil {
+		return err
+	}
+	if err = spec.Transform(rules, target.Params); err != nil {
+		return err
+	}
+	if result.After, err = jsonhooks.Marshal(rules.Rule); err != nil {
+		return err
+	}
+	result.Before = indentJSON(result.Before)
+	result.After = indentJSON(result.After)
+
+	if bytes.Equal(result.Before, result.After) {
+		result.Action = MatrixUnchanged
+		return nil
+	}
+	if spec.DryRun {
+		result.Action = MatrixWouldCreate
+		return nil
+	}
+
+	versions := NewVersions()
+	versions.PropertyID = property.PropertyID
+	versions.ContractID = property.ContractID
+	versions.GroupID = property.GroupID
+
+	from := NewVersion(versions)
+	from.PropertyVersion = property.LatestVersion
+	from.Etag = rules.Etag
+
+	version := versions.NewVersion(from, false, "")
+	version.Note = spec.Note
+	if err = version.Save(""); err != nil {
+		return err
+	}
+
+	rules.PropertyID = property.PropertyID
+	rules.PropertyVersion = version.PropertyVersion
+	if err = rules.Save(""); err != nil {
+		return err
+	}
+
+	result.NewVersion = version.PropertyVersion
+	result.Action = MatrixCreated
+
+	return nil
+}
+
+func indentJSON(b []byte) []byte {
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, "", "    "); err != nil {
+		return b
+	}
+
+	return out.Bytes()
+}
+
+// MergeBehaviorOptions returns a RuleTransform that merges the target's
+// parameters into the options of every behavior named name, in all rules
+//
+// For example, to change origin TLS verification everywhere:
+//
+//	papi.MergeBehaviorOptions("origin")
+//	// with params {"verificationMode": "PLATFORM_SETTINGS"}
+func MergeBehaviorOptions(name string) RuleTransform {
+	return func(rules *Rules, params map[string]interface{}) error {
+		mergeBehaviorOptions(rules.Rule, name, OptionValue(params))
+		return nil
+	}
+}
+
+func mergeBehaviorOptions(rule *Rule, name string, options OptionValue) {
+	if rule == nil {
+		return
+	}
+	for _, behavior := range rule.Behaviors {
+		if behavior.Name == name {
+			behavior.MergeOptions(options)
+		}
+	}
+	for _, child := range rule.Children {
+		mergeBehaviorOptions(child, name, options)
+	}
+}
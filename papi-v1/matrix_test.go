@@ -0,0 +1,61 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestApplyMatrix_DryRun(t *testing.T) {
+	defer gock.Off()
+
+	for _, id := range []string{"prp_1", "prp_2"} {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Get("/papi/v1/properties/"+id+"/versions/3/rules").
+			Reply(200).
+			SetHeader("Content-Type", "application/json").
+			BodyString(`{
+				"propertyId": "` + id + `",
+				"propertyVersion": 3,
+				"etag": "etag-3",
+				"ruleFormat": "v2018-02-27",
+				"rules": {
+					"name": "default",
+					"behaviors": [{"name": "origin", "options": {"hostname": "origin.example.com", "verificationMode": "CUSTOM"}}],
+					"children": [{"name": "API", "behaviors": [{"name": "origin", "options": {"hostname": "api-origin.example.com", "verificationMode": "PLATFORM_SETTINGS"}}]}]
+				}
+			}`)
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Get("/papi/v1/properties/"+id).
+			Reply(200).
+			SetHeader("Content-Type", "application/json").
+			BodyString(`{"properties": {"items": [{"propertyId": "` + id + `", "contractId": "ctr_1", "groupId": "grp_1", "propertyName": "` + id + `.example.com", "latestVersion": 3}]}}`)
+	}
+
+	Init(config)
+	primeLookups(t, `{"contracts": {"items": [{"contractId": "ctr_1"}]}}`,
+		`{"groups": {"items": [{"groupId": "grp_1", "contractIds": ["ctr_1"]}]}}`)
+
+	results := ApplyMatrix(MatrixSpec{
+		Targets: []MatrixTarget{
+			{PropertyID: "prp_1", Params: map[string]interface{}{"verificationMode": "PLATFORM_SETTINGS"}},
+			{PropertyID: "prp_2", Params: map[string]interface{}{}},
+		},
+		Transform: MergeBehaviorOptions("origin"),
+		DryRun:    true,
+	})
+	require.Len(t, results, 2)
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, MatrixWouldCreate, results[0].Action)
+	assert.Equal(t, 3, results[0].FromVersion)
+	assert.Equal(t, 0, results[0].NewVersion)
+	assert.Contains(t, string(results[0].Before), `"verificationMode": "CUSTOM"`)
+	assert.NotContains(t, string(results[0].After), `"verificationMode": "CUSTOM"`)
+
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, MatrixUnchanged, results[1].Action)
+	assert.False(t, results[1].Changed())
+}
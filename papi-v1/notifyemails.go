@@ -0,0 +1,78 @@
+package papi
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// MaxNotifyEmails is the maximum number of addresses an activation notifies
+var MaxNotifyEmails = 50
+
+// defaultNotifyEmails are notified of activations that have no NotifyEmails,
+// see SetDefaultNotifyEmails()
+var defaultNotifyEmails []string
+
+// SetDefaultNotifyEmails sets the addresses notified of property, include and
+// bulk activations submitted without NotifyEmails, so each caller doesn't have
+// to repeat the same list. No emails clears the defaults.
+func SetDefaultNotifyEmails(emails ...string) error {
+	normalized, err := NormalizeNotifyEmails(emails)
+	if err != nil {
+		return err
+	}
+
+	defaultNotifyEmails = normalized
+	return nil
+}
+
+// DefaultNotifyEmails returns the addresses set by SetDefaultNotifyEmails()
+func DefaultNotifyEmails() []string {
+	return append([]string(nil), defaultNotifyEmails...)
+}
+
+// NormalizeNotifyEmails validates the syntax of emails and returns them
+// trimmed, without duplicates (compared case-insensitively) and in order
+//
+// An error matching ErrorMap[ErrInvalidNotifyEmails] is returned for invalid
+// addresses, or more than MaxNotifyEmails of them.
+func NormalizeNotifyEmails(emails []string) ([]string, error) {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return nil, fmt.Errorf("%w: %q is not an email address", ErrorMap[ErrInvalidNotifyEmails], email)
+		}
+		if seen[strings.ToLower(email)] {
+			continue
+		}
+		seen[strings.ToLower(email)] = true
+		normalized = append(normalized, email)
+	}
+
+	if len(normalized) > MaxNotifyEmails {
+		return nil, fmt.Errorf("%w: %d addresses, at most %d are allowed", ErrorMap[ErrInvalidNotifyEmails], len(normalized), MaxNotifyEmails)
+	}
+
+	return normalized, nil
+}
+
+// activationNotifyEmails returns the normalized emails, or the defaults when
+// there are none, failing when neither has an address
+func activationNotifyEmails(emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		emails = defaultNotifyEmails
+	}
+
+	normalized, err := NormalizeNotifyEmails(emails)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("%w: at least one address is required, see SetDefaultNotifyEmails()", ErrorMap[ErrInvalidNotifyEmails])
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,54 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNormalizeNotifyEmails(t *testing.T) {
+	emails, err := NormalizeNotifyEmails([]string{" you@example.com", "ops@example.com", "You@Example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"you@example.com", "ops@example.com"}, emails)
+
+	for _, invalid := range []string{"you", "you@", "You <you@example.com>", ""} {
+		_, err = NormalizeNotifyEmails([]string{invalid})
+		assert.True(t, errors.Is(err, ErrorMap[ErrInvalidNotifyEmails]), invalid)
+	}
+
+	defer func(max int) { MaxNotifyEmails = max }(MaxNotifyEmails)
+	MaxNotifyEmails = 1
+	_, err = NormalizeNotifyEmails([]string{"you@example.com", "ops@example.com"})
+	assert.True(t, errors.Is(err, ErrorMap[ErrInvalidNotifyEmails]))
+}
+
+func TestActivateInclude_DefaultNotifyEmails(t *testing.T) {
+	defer gock.Off()
+	defer SetDefaultNotifyEmails()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/includes/inc_173136/activations").
+		BodyString(`{"activationType": "ACTIVATE", "includeVersion": 1, "network": "STAGING", "notifyEmails": ["ops@example.com"], "acknowledgeAllWarnings": false}`).
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"activationLink": "/papi/v1/includes/inc_173136/activations/atv_2?contractId=ctr_1-1TJZH5&groupId=grp_15225"}`)
+
+	Init(config)
+
+	include := &Include{IncludeID: "inc_173136", ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225"}
+
+	err := ActivateInclude(include, &IncludeActivation{IncludeVersion: 1, Network: NetworkStaging})
+	assert.True(t, errors.Is(err, ErrorMap[ErrInvalidNotifyEmails]))
+
+	require.NoError(t, SetDefaultNotifyEmails("ops@example.com", "OPS@example.com"))
+	assert.Equal(t, []string{"ops@example.com"}, DefaultNotifyEmails())
+
+	activation := &IncludeActivation{IncludeVersion: 1, Network: NetworkStaging}
+	require.NoError(t, ActivateInclude(include, activation))
+	assert.Equal(t, "atv_2", activation.ActivationID)
+	assert.Equal(t, []string{"ops@example.com"}, activation.NotifyEmails)
+	assert.True(t, gock.IsDone())
+}
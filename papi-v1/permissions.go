@@ -0,0 +1,141 @@
+package papi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Permission is used to create an "enum" of possible PermissionError.Missing values
+type Permission string
+
+const (
+	// PermissionAPIAccess PermissionError.Missing value: the credential has no
+	// access to the Property Manager API
+	PermissionAPIAccess Permission = "Property Manager API access"
+	// PermissionGroupAccess PermissionError.Missing value: the group is not
+	// among the groups of the credential
+	PermissionGroupAccess Permission = "group access"
+	// PermissionContractAccess PermissionError.Missing value: the group is not
+	// on the contract
+	PermissionContractAccess Permission = "contract access"
+	// PermissionWrite PermissionError.Missing value: the credential can read
+	// but not change the properties of the group
+	PermissionWrite Permission = "Property Manager API write access"
+)
+
+// PermissionError is the permission CheckWritePermission found missing
+//
+// It matches errors.Is(err, ErrorMap[ErrForbidden]).
+type PermissionError struct {
+	ContractID string
+	GroupID    string
+	Missing    Permission
+	// Err is the error of the request that was refused, if any
+	Err error
+}
+
+func (e *PermissionError) Error() string {
+	message := fmt.Sprintf("credential is missing %s for contract %s and group %s", e.Missing, e.ContractID, e.GroupID)
+	switch e.Missing {
+	case PermissionAPIAccess, PermissionWrite:
+		message += ", grant it READ-WRITE access to the Property Manager API"
+	case PermissionGroupAccess:
+		message += ", add the group to the groups of the API client"
+	case PermissionContractAccess:
+		message += ", the group is not on the contract"
+	}
+
+	return message
+}
+
+// Is reports whether target is ErrorMap[ErrForbidden]
+func (e *PermissionError) Is(target error) bool {
+	return target == ErrorMap[ErrForbidden]
+}
+
+// Unwrap returns the error of the refused request
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// selfAPIAccess is the part of the IAM API client of the credential
+// CheckWritePermission reads
+type selfAPIAccess struct {
+	APIAccess struct {
+		AllAccessibleAPIs bool `json:"allAccessibleApis"`
+		APIs              []struct {
+			Endpoint    string `json:"endPoint"`
+			AccessLevel string `json:"accessLevel"`
+		} `json:"apis"`
+	} `json:"apiAccess"`
+}
+
+// CheckWritePermission verifies that the credential can create and change
+// properties of a group on a contract, and returns a *PermissionError naming
+// the missing permission otherwise
+//
+// It only reads: it lists the groups of the credential, then its API grants
+// from GET /identity-management/v3/api-clients/self, which needs READ-ONLY
+// access to the Identity and Access Management API. A credential with all
+// accessible APIs is taken to be allowed.
+func CheckWritePermission(contractID string, groupID string) error {
+	permissionError := func(missing Permission, err error) error {
+		return &PermissionError{ContractID: contractID, GroupID: groupID, Missing: missing, Err: err}
+	}
+
+	groups := NewGroups()
+	if err := groups.GetGroups(""); err != nil {
+		if errors.Is(err, ErrorMap[ErrForbidden]) || isUnauthorized(err) {
+			return permissionError(PermissionAPIAccess, err)
+		}
+		return err
+	}
+
+	var group *Group
+	for _, g := range groups.Groups.Items {
+		if sameID(g.GroupID, groupID, "grp_") {
+			group = g
+			break
+		}
+	}
+	if group == nil {
+		return permissionError(PermissionGroupAccess, nil)
+	}
+
+	onContract := false
+	for _, id := range group.ContractIDs {
+		if sameID(id, contractID, "ctr_") {
+			onContract = true
+		}
+	}
+	if !onContract {
+		return permissionError(PermissionContractAccess, nil)
+	}
+
+	self := selfAPIAccess{}
+	if err := doIncludeRequest("GET", "/identity-management/v3/api-clients/self?apiAccess=true", nil, &self); err != nil {
+		return fmt.Errorf("reading the API grants of the credential: %w", err)
+	}
+	if self.APIAccess.AllAccessibleAPIs {
+		return nil
+	}
+	for _, api := range self.APIAccess.APIs {
+		if strings.Trim(api.Endpoint, "/") == "papi" && api.AccessLevel == "READ-WRITE" {
+			return nil
+		}
+	}
+
+	return permissionError(PermissionWrite, nil)
+}
+
+func isUnauthorized(err error) bool {
+	var papiErr *Error
+	return errors.As(err, &papiErr) && papiErr.Status == http.StatusUnauthorized
+}
+
+// sameID compares two IDs with or without their prefix
+func sameID(a, b, prefix string) bool {
+	return strings.TrimPrefix(a, prefix) == strings.TrimPrefix(b, prefix)
+}
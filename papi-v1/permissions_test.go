@@ -0,0 +1,70 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestCheckWritePermission(t *testing.T) {
+	defer gock.Off()
+	Profilecache.Delete(CacheKeyGroups)
+	defer Profilecache.Delete(CacheKeyGroups)
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/groups").
+		Reply(200).
+		JSON(`{"groups": {"items": [{"groupId": "grp_1", "groupName": "web", "contractIds": ["ctr_1"]}, {"groupId": "grp_2", "groupName": "api", "contractIds": ["ctr_1"]}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/identity-management/v3/api-clients/self").
+		MatchParam("apiAccess", "true").
+		Reply(200).
+		JSON(`{"apiAccess": {"allAccessibleApis": false, "apis": [{"apiName": "Property Manager (PAPI)", "endPoint": "/papi", "accessLevel": "READ-WRITE"}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/identity-management/v3/api-clients/self").
+		MatchParam("apiAccess", "true").
+		Reply(200).
+		JSON(`{"apiAccess": {"allAccessibleApis": false, "apis": [{"apiName": "Property Manager (PAPI)", "endPoint": "/papi", "accessLevel": "READ-ONLY"}]}}`)
+
+	Init(config)
+
+	require.NoError(t, CheckWritePermission("ctr_1", "grp_1"))
+
+	err := CheckWritePermission("1", "2")
+	var permissionErr *PermissionError
+	require.True(t, errors.As(err, &permissionErr))
+	assert.Equal(t, PermissionWrite, permissionErr.Missing)
+	assert.True(t, errors.Is(err, ErrorMap[ErrForbidden]))
+
+	err = CheckWritePermission("ctr_1", "grp_3")
+	require.True(t, errors.As(err, &permissionErr))
+	assert.Equal(t, PermissionGroupAccess, permissionErr.Missing)
+
+	err = CheckWritePermission("ctr_2", "grp_1")
+	require.True(t, errors.As(err, &permissionErr))
+	assert.Equal(t, PermissionContractAccess, permissionErr.Missing)
+	assert.Contains(t, err.Error(), "contract ctr_2")
+	assert.True(t, gock.IsDone())
+}
+
+func TestCheckWritePermission_NoAPIAccess(t *testing.T) {
+	defer gock.Off()
+	Profilecache.Delete(CacheKeyGroups)
+	defer Profilecache.Delete(CacheKeyGroups)
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/groups").
+		Reply(403).
+		JSON(`{"title": "Forbidden", "status": 403}`)
+
+	Init(config)
+
+	err := CheckWritePermission("ctr_1", "grp_1")
+	var permissionErr *PermissionError
+	require.True(t, errors.As(err, &permissionErr))
+	assert.Equal(t, PermissionAPIAccess, permissionErr.Missing)
+	assert.True(t, gock.IsDone())
+}
@@ -48,7 +48,7 @@ func (products *Products) PostUnmarshalJSON() error {
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listproducts
 // Endpoint: GET /papi/v1/products/{?contractId}
 func (products *Products) GetProducts(contract *Contract, correlationid string) error {
-	cacheproducts, found := Profilecache.Get("products")
+	cacheproducts, found := Profilecache.Get(CacheKeyProducts)
 	if found {
 		json.Unmarshal(cacheproducts.([]byte), products)
 		return nil
@@ -76,7 +76,7 @@ func (products *Products) GetProducts(contract *Contract, correlationid string)
 		edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 		if client.IsError(res) {
-			return client.NewAPIError(res)
+			return newAPIError(res)
 		}
 
 		if err = client.BodyJSON(res, products); err != nil {
@@ -84,7 +84,7 @@ func (products *Products) GetProducts(contract *Contract, correlationid string)
 		}
 
 		byt, _ := json.Marshal(products)
-		Profilecache.Set("products", byt, cache.DefaultExpiration)
+		Profilecache.Set(CacheKeyProducts, byt, cache.DefaultExpiration)
 		return nil
 	}
 
@@ -77,7 +77,7 @@ func (properties *Properties) GetProperties(contract *Contract, group *Group, co
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, properties); err != nil {
@@ -205,7 +205,7 @@ func (property *Property) GetProperty(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newProperties := NewProperties()
@@ -352,18 +352,27 @@ func (property *Property) PostUnmarshalJSON() error {
 	property.Group = NewGroup(NewGroups())
 	property.Group.GroupID = property.GroupID
 
-	go property.Group.GetGroup()
-	go property.Contract.GetContract()
+	// the lookups are synchronous, so that none is left running once the
+	// property is decoded; a failed lookup reports nothing on Complete
+	property.Group.GetGroup()
+	property.Contract.GetContract()
 
-	go (func(property *Property) {
-		contractComplete := <-property.Contract.Complete
-		groupComplete := <-property.Group.Complete
-		property.Complete <- (contractComplete && groupComplete)
-	})(property)
+	property.Complete <- (lookupCompleted(property.Contract.Complete) && lookupCompleted(property.Group.Complete))
 
 	return nil
 }
 
+// lookupCompleted returns the result a finished lookup sent on complete, false
+// if it sent none
+func lookupCompleted(complete chan bool) bool {
+	select {
+	case completed := <-complete:
+		return completed
+	default:
+		return false
+	}
+}
+
 // Save will create a property, optionally cloned from another property
 //
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#createorcloneaproperty
@@ -393,7 +402,7 @@ func (property *Property) Save(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	var location client.JSONBody
@@ -421,7 +430,7 @@ func (property *Property) Save(correlationid string) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	properties := NewProperties()
@@ -463,14 +472,22 @@ func (property *Property) Activate(activation *Activation, acknowledgeWarnings b
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#removeaproperty
 // Endpoint: DELETE /papi/v1/properties/{propertyId}{?contractId,groupId}
 func (property *Property) Delete(correlationid string) error {
-	// /papi/v1/properties/{propertyId}{?contractId,groupId}
+	endpoint := fmt.Sprintf("/papi/v1/properties/%s", property.PropertyID)
+	contractID, groupID := property.ContractID, property.GroupID
+	if property.Contract != nil && property.Contract.ContractID != "" {
+		contractID = property.Contract.ContractID
+	}
+	if property.Group != nil && property.Group.GroupID != "" {
+		groupID = property.Group.GroupID
+	}
+	if contractID != "" && groupID != "" {
+		endpoint = fmt.Sprintf("%s?contractId=%s&groupId=%s", endpoint, contractID, groupID)
+	}
+
 	req, err := client.NewRequest(
 		Config,
 		"DELETE",
-		fmt.Sprintf(
-			"/papi/v1/properties/%s",
-			property.PropertyID,
-		),
+		endpoint,
 		nil,
 	)
 	if err != nil {
@@ -487,7 +504,7 @@ func (property *Property) Delete(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	return nil
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"sort"
+	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -51,7 +53,7 @@ func (ruleFormats *RuleFormats) GetRuleFormats(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err := client.BodyJSON(res, ruleFormats); err != nil {
@@ -103,7 +105,7 @@ func (ruleFormats *RuleFormats) GetSchema(product string, ruleFormat string, cor
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return nil, client.NewAPIError(res)
+		return nil, newAPIError(res)
 	}
 
 	schemaBytes, _ := ioutil.ReadAll(res.Body)
@@ -113,3 +115,48 @@ func (ruleFormats *RuleFormats) GetSchema(product string, ruleFormat string, cor
 
 	return schema, err
 }
+
+// ValidateSchema validates the rule tree against schema, as returned by
+// GetSchema(), without calling the API
+//
+// Like Update(), Rules.Errors is populated with the schema violations and
+// ErrorMap[ErrInvalidRules] is returned when there are any. The ErrorLocation
+// of each error is a JSON pointer to the violating value, e.g. "#/rules/behaviors/0".
+func (rules *Rules) ValidateSchema(schema *gojsonschema.Schema) error {
+	ruleErrors, err := validateRuleTree(schema, rules.Rule)
+	if err != nil {
+		return err
+	}
+
+	rules.Errors = ruleErrors
+	if len(rules.Errors) != 0 {
+		return ErrorMap[ErrInvalidRules]
+	}
+
+	return nil
+}
+
+// validateRuleTree returns the schema violations of rule
+func validateRuleTree(schema *gojsonschema.Schema, rule *Rule) ([]*RuleErrors, error) {
+	body, err := jsonhooks.Marshal(map[string]*Rule{"rules": rule})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleErrors := []*RuleErrors{}
+	for _, resultError := range result.Errors() {
+		ruleError := NewRuleErrors()
+		ruleError.Type = resultError.Type()
+		ruleError.Title = "Rule tree does not match the schema"
+		ruleError.Detail = resultError.Description()
+		ruleError.ErrorLocation = "#" + strings.TrimPrefix(resultError.Context().String("/"), gojsonschema.STRING_CONTEXT_ROOT)
+		ruleErrors = append(ruleErrors, ruleError)
+	}
+
+	return ruleErrors, nil
+}
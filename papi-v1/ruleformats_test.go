@@ -0,0 +1,62 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const ruleTreeSchema = `{
+	"type": "object",
+	"required": ["rules"],
+	"properties": {
+		"rules": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string"},
+				"behaviors": {
+					"type": "array",
+					"items": {
+						"type": "object",
+						"properties": {"name": {"enum": ["caching", "cpCode", "origin"]}}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestRules_ValidateSchema(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/schemas/products/prd_SPM/v2020-03-04").
+		Reply(200).
+		SetHeader("Content-Type", "application/schema+json").
+		BodyString(ruleTreeSchema)
+
+	Init(config)
+
+	schema, err := GetSchema("prd_SPM", "v2020-03-04")
+	require.NoError(t, err)
+
+	rules := NewRules()
+	rules.PropertyID = "prp_173136"
+	rules.PropertyVersion = 3
+	rules.Rule.AddBehavior(&Behavior{Name: "caching"})
+	assert.NoError(t, rules.ValidateSchema(schema))
+	assert.Empty(t, rules.Errors)
+
+	rules.Rule.AddBehavior(&Behavior{Name: "cachinng"})
+	assert.Equal(t, ErrorMap[ErrInvalidRules], rules.ValidateSchema(schema))
+	require.Len(t, rules.Errors, 1)
+	assert.Equal(t, "#/rules/behaviors/1/name", rules.Errors[0].ErrorLocation)
+
+	// the rule tree is not sent
+	err = rules.Update(RuleTreeOptions{Schema: schema}, "")
+	assert.Equal(t, ErrorMap[ErrInvalidRules], err)
+	assert.True(t, gock.IsDone())
+}
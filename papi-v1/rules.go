@@ -6,6 +6,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 // Rules is a collection of property rules
@@ -18,8 +19,41 @@ type Rules struct {
 	PropertyVersion int           `json:"propertyVersion"`
 	Etag            string        `json:"etag"`
 	RuleFormat      string        `json:"ruleFormat"`
+	Comments        string        `json:"comments,omitempty"`
 	Rule            *Rule         `json:"rules"`
 	Errors          []*RuleErrors `json:"errors,omitempty"`
+	Warnings        []*RuleErrors `json:"warnings,omitempty"`
+}
+
+// RuleTreeOptions are the optional query parameters of a rule tree update
+type RuleTreeOptions struct {
+	// SkipValidation sets validateRules=false, PAPI then only validates the JSON schema
+	SkipValidation bool
+	// DryRun validates the rule tree without saving it
+	DryRun bool
+	// IfMatch, when set, is sent as If-Match by Rules.Update(), usually with
+	// the Rules.Etag of the rule tree that was read. PAPI then rejects the update with
+	// ErrorMap[ErrConflict] if the rule tree was changed meanwhile.
+	IfMatch string
+	// Schema, when set, is used to validate the rule tree locally with
+	// Rules.ValidateSchema() first, the rule tree is not sent if it is invalid
+	Schema *gojsonschema.Schema
+}
+
+// query returns the query string for options, including the leading "?"
+func (options RuleTreeOptions) query() string {
+	params := []string{}
+	if options.SkipValidation {
+		params = append(params, "validateRules=false")
+	}
+	if options.DryRun {
+		params = append(params, "dryRun=true")
+	}
+	if len(params) == 0 {
+		return ""
+	}
+
+	return "?" + strings.Join(params, "&")
 }
 
 // NewRules creates a new Rules
@@ -37,11 +71,15 @@ func NewRules() *Rules {
 // See: jsonhooks-v1/json.Marshal()
 func (rules *Rules) PreMarshalJSON() error {
 	rules.Errors = nil
+	rules.Warnings = nil
 	return nil
 }
 
 // GetRules populates Rules with rule data for a given property
 //
+// The rule tree is requested in Rules.RuleFormat, or the rule format of the
+// client settings once they were fetched with GetClientSettings()
+//
 // See: Property.GetRules
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#getaruletree
 // Endpoint: GET /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules/{?contractId,groupId}
@@ -60,6 +98,8 @@ func (rules *Rules) GetRules(property *Property, correlationid string) error {
 		return err
 	}
 
+	setRuleFormatHeader(req, "Accept", rules.RuleFormat)
+
 	edge.PrintHttpRequestCorrelation(req, true, correlationid)
 
 	res, err := client.Do(Config, req)
@@ -70,13 +110,17 @@ func (rules *Rules) GetRules(property *Property, correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, rules); err != nil {
 		return err
 	}
 
+	if rules.Etag == "" {
+		rules.Etag = res.Header.Get("ETag")
+	}
+
 	return nil
 }
 
@@ -110,7 +154,7 @@ func (rules *Rules) GetRulesDigest(property *Property, correlationid string) (st
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return "", client.NewAPIError(res)
+		return "", newAPIError(res)
 	}
 
 	return res.Header.Get("Etag"), nil
@@ -121,15 +165,38 @@ func (rules *Rules) GetRulesDigest(property *Property, correlationid string) (st
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#putpropertyversionrules
 // Endpoint: PUT /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules{?contractId,groupId}
 func (rules *Rules) Save(correlationid string) error {
+	return rules.Update(RuleTreeOptions{}, correlationid)
+}
+
+// Update creates/updates a rule tree for a property, optionally skipping rule
+// validation or only validating it (dry run)
+//
+// Rules.Errors and Rules.Warnings are populated from the response, even when
+// ErrorMap[ErrInvalidRules] is returned because of errors.
+//
+// The rule tree is sent as Rules.RuleFormat, or the rule format of the client
+// settings once they were fetched with GetClientSettings().
+//
+// API Docs: https://developer.akamai.com/api/luna/papi/resources.html#putpropertyversionrules
+// Endpoint: PUT /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules{?contractId,groupId,validateRules,dryRun}
+func (rules *Rules) Update(options RuleTreeOptions, correlationid string) error {
+	if options.Schema != nil {
+		if err := rules.ValidateSchema(options.Schema); err != nil {
+			return err
+		}
+	}
+
 	rules.Errors = []*RuleErrors{}
+	rules.Warnings = []*RuleErrors{}
 
 	req, err := client.NewJSONRequest(
 		Config,
 		"PUT",
 		fmt.Sprintf(
-			"/papi/v1/properties/%s/versions/%d/rules",
+			"/papi/v1/properties/%s/versions/%d/rules%s",
 			rules.PropertyID,
 			rules.PropertyVersion,
+			options.query(),
 		),
 		rules,
 	)
@@ -137,6 +204,67 @@ func (rules *Rules) Save(correlationid string) error {
 		return err
 	}
 
+	setRuleFormatHeader(req, "Content-Type", rules.RuleFormat)
+	if options.IfMatch != "" {
+		req.Header.Set("If-Match", options.IfMatch)
+	}
+
+	edge.PrintHttpRequestCorrelation(req, true, correlationid)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return err
+	}
+
+	edge.PrintHttpResponseCorrelation(res, true, correlationid)
+
+	if client.IsError(res) {
+		return newAPIError(res)
+	}
+
+	if err = client.BodyJSON(res, rules); err != nil {
+		return err
+	}
+
+	if len(rules.Errors) != 0 {
+		return ErrorMap[ErrInvalidRules]
+	}
+
+	return nil
+}
+
+// Patch applies RFC 6902 operations to the rule tree of a property version,
+// rules is then populated with the resulting rule tree
+//
+// Only IfMatch, SkipValidation and DryRun of options are used.
+//
+// API Docs: https://developer.akamai.com/api/core_features/property_manager/v1.html#patchpropertyversionrules
+// Endpoint: PATCH /papi/v1/properties/{propertyId}/versions/{propertyVersion}/rules/{?contractId,groupId,validateRules,dryRun}
+func (rules *Rules) Patch(patches []JSONPatchOperation, options RuleTreeOptions, correlationid string) error {
+	rules.Errors = []*RuleErrors{}
+	rules.Warnings = []*RuleErrors{}
+
+	req, err := client.NewJSONRequest(
+		Config,
+		"PATCH",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions/%d/rules%s",
+			rules.PropertyID,
+			rules.PropertyVersion,
+			options.query(),
+		),
+		patches,
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	setRuleFormatHeader(req, "Accept", rules.RuleFormat)
+	if options.IfMatch != "" {
+		req.Header.Set("If-Match", options.IfMatch)
+	}
+
 	edge.PrintHttpRequestCorrelation(req, true, correlationid)
 
 	res, err := client.Do(Config, req)
@@ -147,7 +275,7 @@ func (rules *Rules) Save(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, rules); err != nil {
@@ -191,7 +319,7 @@ func (rules *Rules) Freeze(format string) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	if err = client.BodyJSON(res, rules); err != nil {
@@ -545,11 +673,12 @@ func NewVariable() *Variable {
 // RuleErrors represents an validate error returned for a rule
 type RuleErrors struct {
 	client.Resource
-	Type         string `json:"type"`
-	Title        string `json:"title"`
-	Detail       string `json:"detail"`
-	Instance     string `json:"instance"`
-	BehaviorName string `json:"behaviorName"`
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Detail        string `json:"detail"`
+	Instance      string `json:"instance"`
+	BehaviorName  string `json:"behaviorName"`
+	ErrorLocation string `json:"errorLocation,omitempty"`
 }
 
 // NewRuleErrors creates a new RuleErrors
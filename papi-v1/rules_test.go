@@ -1,6 +1,7 @@
 package papi
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
@@ -1842,6 +1843,91 @@ func TestRules_GetRules_Variables(t *testing.T) {
 	assert.False(t, rules.Rule.Variables[0].Sensitive)
 }
 
+func TestRules_Update_DryRun(t *testing.T) {
+	defer gock.Off()
+
+	mock := gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/papi/v1/properties/prp_123/versions/2/rules")
+	mock.
+		Put("/papi/v1/properties/prp_123/versions/2/rules").
+		MatchParam("dryRun", "true").
+		MatchParam("validateRules", "false").
+		HeaderPresent("Authorization").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{
+				"propertyId": "prp_123",
+				"propertyVersion": 2,
+				"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {}}]},
+				"errors": [
+					{
+						"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+						"title": "Missing required option",
+						"errorLocation": "#/rules/behaviors/0/options/hostname",
+						"detail": "The Origin Server Hostname option is required."
+					}
+				],
+				"warnings": [
+					{
+						"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/product_behavior_issue.cp_code_report_hostname",
+						"title": "Unstable rule format",
+						"detail": "This property is using latest rule format"
+					}
+				]
+			}`)
+
+	Init(config)
+
+	rules := NewRules()
+	rules.PropertyID = "prp_123"
+	rules.PropertyVersion = 2
+	rules.Rule.AddBehavior(&Behavior{Name: "origin", Options: OptionValue{}})
+
+	_, err := UpdateRuleTree(rules, RuleTreeOptions{SkipValidation: true, DryRun: true})
+
+	assert.Equal(t, ErrorMap[ErrInvalidRules], err)
+	assert.Len(t, rules.Errors, 1)
+	assert.Equal(t, "#/rules/behaviors/0/options/hostname", rules.Errors[0].ErrorLocation)
+	assert.Len(t, rules.Warnings, 1)
+	assert.Equal(t, "Unstable rule format", rules.Warnings[0].Title)
+	assert.True(t, gock.IsDone())
+}
+
+func TestRules_Update_IfMatch_Conflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_123/versions/2/rules").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("ETag", `"a9dfe78cf93090516bde891d009eaf57"`).
+		BodyString(`{"propertyId": "prp_123", "propertyVersion": 2, "rules": {"name": "default"}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put("/papi/v1/properties/prp_123/versions/2/rules").
+		MatchHeader("If-Match", `"a9dfe78cf93090516bde891d009eaf57"`).
+		Reply(412).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed", "title": "Precondition Failed", "status": 412}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_123"
+	property.LatestVersion = 2
+	rules := NewRules()
+	assert.NoError(t, rules.GetRules(property, ""))
+	assert.Equal(t, `"a9dfe78cf93090516bde891d009eaf57"`, rules.Etag)
+
+	_, err := UpdateRuleTree(rules, RuleTreeOptions{IfMatch: rules.Etag})
+	assert.True(t, errors.Is(err, ErrorMap[ErrConflict]))
+
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, 412, conflict.Status)
+		assert.Equal(t, "Precondition Failed", conflict.Title)
+	}
+	assert.True(t, gock.IsDone())
+}
+
 func assertRulesMatch(t *testing.T, expected *Rule, actual *Rule) bool {
 	valid := true
 
@@ -1874,3 +1960,77 @@ func assertRulesMatch(t *testing.T, expected *Rule, actual *Rule) bool {
 
 	return valid
 }
+
+func TestUpdateVersionNote(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Patch("/papi/v1/properties/prp_173136/versions/3/rules").
+		MatchHeader("Content-Type", "application/json-patch\\+json").
+		BodyString(`[{"op":"add","path":"/comments","value":"new note"}]`).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "propertyVersion": 3, "etag": "a872ec", "comments": "new note", "rules": {"name": "default"}}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	rules, err := UpdateVersionNote(property, 3, "new note")
+	assert.NoError(t, err)
+	assert.Equal(t, "new note", rules.Comments)
+	assert.Equal(t, "a872ec", rules.Etag)
+	assert.True(t, gock.IsDone())
+}
+
+func TestUpdateVersionRuleFormat(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/3/rules").
+		MatchHeader("Accept", "application/vnd.akamai.papirules.v2018-02-27\\+json").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "propertyVersion": 3, "etag": "a872ec", "ruleFormat": "v2018-02-27", "rules": {"name": "default"}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Put("/papi/v1/properties/prp_173136/versions/3/rules").
+		MatchHeader("Content-Type", "application/vnd.akamai.papirules.v2018-02-27\\+json").
+		MatchHeader("If-Match", "a872ec").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "propertyVersion": 3, "etag": "b983fd", "ruleFormat": "v2018-02-27", "rules": {"name": "default"}}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	rules, err := UpdateVersionRuleFormat(property, 3, "v2018-02-27")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2018-02-27", rules.RuleFormat)
+	assert.Equal(t, "b983fd", rules.Etag)
+	assert.True(t, gock.IsDone())
+}
+
+func TestRemoveProperty(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Delete("/papi/v1/properties/prp_173136").
+		MatchParam("contractId", "ctr_1").
+		MatchParam("groupId", "grp_1").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"message": "Deletion Successful."}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+	property.ContractID = "ctr_1"
+	property.GroupID = "grp_1"
+
+	assert.NoError(t, RemoveProperty(property))
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,363 @@
+package papi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RuleTreeSnapshot is a read-only copy of a rule tree that can be queried with
+// RFC 6901 JSON pointers into its JSON form, e.g.
+// "/rules/children/3/behaviors/0/options/hostname"
+//
+// The snapshot is not affected by later changes to the Rules it was taken
+// from. The values it returns are its own and must not be modified.
+type RuleTreeSnapshot struct {
+	rules *Rules
+}
+
+// BehaviorMatch is a behavior found by RuleTreeSnapshot.FindBehaviors
+type BehaviorMatch struct {
+	// Pointer is the JSON pointer of the behavior, e.g. /rules/children/3/behaviors/0
+	Pointer string
+	// RuleNames are the names of the rules from the default rule to the one of the behavior
+	RuleNames []string
+	Behavior  *Behavior
+}
+
+// CriteriaMatch is a criteria found by RuleTreeSnapshot.FindCriteria
+type CriteriaMatch struct {
+	// Pointer is the JSON pointer of the criteria, e.g. /rules/children/3/criteria/0
+	Pointer string
+	// RuleNames are the names of the rules from the default rule to the one of the criteria
+	RuleNames []string
+	Criteria  *Criteria
+}
+
+// Snapshot returns a read-only copy of the rule tree
+func (rules *Rules) Snapshot() *RuleTreeSnapshot {
+	snapshot := *rules
+	snapshot.Rule = copyRule(rules.Rule)
+	snapshot.Errors = nil
+	snapshot.Warnings = nil
+
+	return &RuleTreeSnapshot{rules: &snapshot}
+}
+
+// Get returns the value at pointer: a *Rule, *Behavior, *Criteria, *Variable,
+// a slice of those, an OptionValue or an option value as decoded from JSON
+// (string, float64, bool, []interface{} or map[string]interface{})
+//
+// The empty pointer returns the whole *Rules. An error wrapping
+// ErrorMap[ErrInvalidPath] is returned when nothing is at pointer.
+func (snapshot *RuleTreeSnapshot) Get(pointer string) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	var current interface{} = snapshot.rules
+	for i, token := range tokens {
+		next, ok := pointerChild(current, token)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s has no %q", ErrorMap[ErrInvalidPath], formatPointer(tokens[:i]), token)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// GetString returns the string at pointer
+func (snapshot *RuleTreeSnapshot) GetString(pointer string) (string, error) {
+	value, err := snapshot.Get(pointer)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s is a %T, not a string", ErrorMap[ErrInvalidPath], pointer, value)
+	}
+
+	return s, nil
+}
+
+// FindBehaviors returns the behaviors named name, case insensitive, in the
+// order of the rule tree
+func (snapshot *RuleTreeSnapshot) FindBehaviors(name string) []BehaviorMatch {
+	var matches []BehaviorMatch
+	walkRules(snapshot.rules.Rule, "/rules", nil, func(rule *Rule, pointer string, ruleNames []string) {
+		for i, behavior := range rule.Behaviors {
+			if strings.EqualFold(behavior.Name, name) {
+				matches = append(matches, BehaviorMatch{
+					Pointer:   pointer + "/behaviors/" + strconv.Itoa(i),
+					RuleNames: ruleNames,
+					Behavior:  behavior,
+				})
+			}
+		}
+	})
+
+	return matches
+}
+
+// FindCriteria returns the criteria named name, case insensitive, in the
+// order of the rule tree
+func (snapshot *RuleTreeSnapshot) FindCriteria(name string) []CriteriaMatch {
+	var matches []CriteriaMatch
+	walkRules(snapshot.rules.Rule, "/rules", nil, func(rule *Rule, pointer string, ruleNames []string) {
+		for i, criteria := range rule.Criteria {
+			if strings.EqualFold(criteria.Name, name) {
+				matches = append(matches, CriteriaMatch{
+					Pointer:   pointer + "/criteria/" + strconv.Itoa(i),
+					RuleNames: ruleNames,
+					Criteria:  criteria,
+				})
+			}
+		}
+	})
+
+	return matches
+}
+
+func walkRules(rule *Rule, pointer string, parentNames []string, visit func(rule *Rule, pointer string, ruleNames []string)) {
+	if rule == nil {
+		return
+	}
+
+	ruleNames := append(append([]string{}, parentNames...), rule.Name)
+	visit(rule, pointer, ruleNames)
+	for i, child := range rule.Children {
+		walkRules(child, pointer+"/children/"+strconv.Itoa(i), ruleNames, visit)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into its unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: JSON pointer %q does not start with /", ErrorMap[ErrInvalidPath], pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return tokens, nil
+}
+
+func formatPointer(tokens []string) string {
+	if len(tokens) == 0 {
+		return "the rule tree"
+	}
+
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1))
+	}
+
+	return b.String()
+}
+
+// pointerChild returns the member token of value, as named in the JSON form of value
+func pointerChild(value interface{}, token string) (interface{}, bool) {
+	switch v := value.(type) {
+	case *Rules:
+		switch token {
+		case "accountId":
+			return v.AccountID, true
+		case "contractId":
+			return v.ContractID, true
+		case "groupId":
+			return v.GroupID, true
+		case "propertyId":
+			return v.PropertyID, true
+		case "propertyVersion":
+			return v.PropertyVersion, true
+		case "etag":
+			return v.Etag, true
+		case "ruleFormat":
+			return v.RuleFormat, true
+		case "comments":
+			return v.Comments, true
+		case "rules":
+			return v.Rule, v.Rule != nil
+		}
+	case *Rule:
+		switch token {
+		case "name":
+			return v.Name, true
+		case "criteria":
+			return v.Criteria, true
+		case "behaviors":
+			return v.Behaviors, true
+		case "children":
+			return v.Children, true
+		case "comments":
+			return v.Comments, true
+		case "criteriaLocked":
+			return v.CriteriaLocked, true
+		case "criteriaMustSatisfy":
+			return string(v.CriteriaMustSatisfy), true
+		case "uuid":
+			return v.UUID, true
+		case "variables":
+			return v.Variables, true
+		case "advancedOverride":
+			return v.AdvancedOverride, true
+		case "options":
+			return OptionValue{"is_secure": v.Options.IsSecure}, true
+		case "customOverride":
+			if v.CustomOverride == nil {
+				return nil, false
+			}
+			return OptionValue{"name": v.CustomOverride.Name, "overrideId": v.CustomOverride.OverrideID}, true
+		}
+	case *Behavior:
+		switch token {
+		case "name":
+			return v.Name, true
+		case "options":
+			return v.Options, true
+		case "locked":
+			return v.Locked, true
+		case "uuid":
+			return v.UUID, true
+		}
+	case *Criteria:
+		switch token {
+		case "name":
+			return v.Name, true
+		case "options":
+			return v.Options, true
+		case "locked":
+			return v.Locked, true
+		case "uuid":
+			return v.UUID, true
+		}
+	case *Variable:
+		switch token {
+		case "name":
+			return v.Name, true
+		case "value":
+			return v.Value, true
+		case "description":
+			return v.Description, true
+		case "hidden":
+			return v.Hidden, true
+		case "sensitive":
+			return v.Sensitive, true
+		}
+	case []*Rule:
+		if i, ok := pointerIndex(token, len(v)); ok {
+			return v[i], true
+		}
+	case []*Behavior:
+		if i, ok := pointerIndex(token, len(v)); ok {
+			return v[i], true
+		}
+	case []*Criteria:
+		if i, ok := pointerIndex(token, len(v)); ok {
+			return v[i], true
+		}
+	case []*Variable:
+		if i, ok := pointerIndex(token, len(v)); ok {
+			return v[i], true
+		}
+	case OptionValue:
+		child, ok := v[token]
+		return child, ok
+	case map[string]interface{}:
+		child, ok := v[token]
+		return child, ok
+	case []interface{}:
+		if i, ok := pointerIndex(token, len(v)); ok {
+			return v[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// pointerIndex parses an array index token, which has no sign or leading zeros
+func pointerIndex(token string, length int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= length {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// copyRule deep copies rule and its children
+func copyRule(rule *Rule) *Rule {
+	if rule == nil {
+		return nil
+	}
+
+	copied := *rule
+	copied.Criteria = make([]*Criteria, len(rule.Criteria))
+	for i, criteria := range rule.Criteria {
+		c := *criteria
+		c.Options = copyOptionValue(criteria.Options)
+		copied.Criteria[i] = &c
+	}
+	copied.Behaviors = make([]*Behavior, len(rule.Behaviors))
+	for i, behavior := range rule.Behaviors {
+		b := *behavior
+		b.Options = copyOptionValue(behavior.Options)
+		copied.Behaviors[i] = &b
+	}
+	copied.Variables = make([]*Variable, len(rule.Variables))
+	for i, variable := range rule.Variables {
+		v := *variable
+		copied.Variables[i] = &v
+	}
+	copied.Children = make([]*Rule, len(rule.Children))
+	for i, child := range rule.Children {
+		copied.Children[i] = copyRule(child)
+	}
+	if rule.CustomOverride != nil {
+		override := *rule.CustomOverride
+		copied.CustomOverride = &override
+	}
+
+	return &copied
+}
+
+func copyOptionValue(options OptionValue) OptionValue {
+	if options == nil {
+		return nil
+	}
+
+	return OptionValue(copyJSONValue(map[string]interface{}(options)).(map[string]interface{}))
+}
+
+// copyJSONValue deep copies a value decoded from JSON
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			copied[key] = copyJSONValue(child)
+		}
+		return copied
+	case OptionValue:
+		return OptionValue(copyJSONValue(map[string]interface{}(v)).(map[string]interface{}))
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, child := range v {
+			copied[i] = copyJSONValue(child)
+		}
+		return copied
+	}
+
+	return value
+}
@@ -0,0 +1,136 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const snapshotRulesJSON = `{
+	"propertyId": "prp_173136",
+	"propertyVersion": 3,
+	"ruleFormat": "v2020-03-04",
+	"rules": {
+		"name": "default",
+		"options": {"is_secure": true},
+		"behaviors": [
+			{"name": "origin", "options": {"hostname": "origin.example.com", "customCertificates": [{"subjectCN": "origin"}]}},
+			{"name": "cpCode", "options": {"value": {"id": 12345}}}
+		],
+		"children": [
+			{
+				"name": "Images",
+				"criteria": [{"name": "fileExtension", "options": {"matchOperator": "IS_ONE_OF", "values": ["jpg", "png"]}}],
+				"behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "7d"}}],
+				"children": [
+					{
+						"name": "API",
+						"criteria": [{"name": "path", "options": {"values": ["/api/*"]}}],
+						"behaviors": [{"name": "origin", "options": {"hostname": "api.example.com", "a/b~c": 1}}]
+					}
+				]
+			}
+		]
+	}
+}`
+
+func snapshotRules(t *testing.T) *Rules {
+	rules := NewRules()
+	require.NoError(t, json.Unmarshal([]byte(snapshotRulesJSON), rules))
+
+	return rules
+}
+
+func TestRuleTreeSnapshot_Get(t *testing.T) {
+	snapshot := snapshotRules(t).Snapshot()
+
+	tests := map[string]struct {
+		pointer  string
+		expected interface{}
+	}{
+		"property":       {"/propertyVersion", 3},
+		"rule name":      {"/rules/children/0/name", "Images"},
+		"behavior name":  {"/rules/behaviors/0/name", "origin"},
+		"option":         {"/rules/children/0/children/0/behaviors/0/options/hostname", "api.example.com"},
+		"nested option":  {"/rules/behaviors/1/options/value/id", float64(12345)},
+		"array option":   {"/rules/children/0/criteria/0/options/values/1", "png"},
+		"escaped":        {"/rules/children/0/children/0/behaviors/0/options/a~1b~0c", float64(1)},
+		"rule options":   {"/rules/options/is_secure", true},
+		"option objects": {"/rules/behaviors/0/options/customCertificates/0/subjectCN", "origin"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := snapshot.Get(test.pointer)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+
+	value, err := snapshot.Get("/rules/children/0")
+	require.NoError(t, err)
+	require.IsType(t, &Rule{}, value)
+	assert.Len(t, value.(*Rule).Children, 1)
+
+	hostname, err := snapshot.GetString("/rules/behaviors/0/options/hostname")
+	require.NoError(t, err)
+	assert.Equal(t, "origin.example.com", hostname)
+}
+
+func TestRuleTreeSnapshot_GetErrors(t *testing.T) {
+	snapshot := snapshotRules(t).Snapshot()
+
+	tests := map[string]string{
+		"rules/children":                     `Invalid Path: JSON pointer "rules/children" does not start with /`,
+		"/rules/children/1":                  `Invalid Path: /rules/children has no "1"`,
+		"/rules/children/00":                 `Invalid Path: /rules/children has no "00"`,
+		"/rules/children/-":                  `Invalid Path: /rules/children has no "-"`,
+		"/rules/behaviors/0/options/missing": `Invalid Path: /rules/behaviors/0/options has no "missing"`,
+		"/rules/behaviors/0/name/more":       `Invalid Path: /rules/behaviors/0/name has no "more"`,
+		"/unknown":                           `Invalid Path: the rule tree has no "unknown"`,
+	}
+
+	for pointer, expected := range tests {
+		t.Run(pointer, func(t *testing.T) {
+			_, err := snapshot.Get(pointer)
+			assert.EqualError(t, err, expected)
+			assert.True(t, errors.Is(err, ErrorMap[ErrInvalidPath]))
+		})
+	}
+
+	_, err := snapshot.GetString("/propertyVersion")
+	assert.EqualError(t, err, "Invalid Path: /propertyVersion is a int, not a string")
+}
+
+func TestRuleTreeSnapshot_Find(t *testing.T) {
+	rules := snapshotRules(t)
+	snapshot := rules.Snapshot()
+
+	origins := snapshot.FindBehaviors("origin")
+	require.Len(t, origins, 2)
+	assert.Equal(t, "/rules/behaviors/0", origins[0].Pointer)
+	assert.Equal(t, []string{"default"}, origins[0].RuleNames)
+	assert.Equal(t, "/rules/children/0/children/0/behaviors/0", origins[1].Pointer)
+	assert.Equal(t, []string{"default", "Images", "API"}, origins[1].RuleNames)
+	assert.Equal(t, "api.example.com", origins[1].Behavior.Options["hostname"])
+
+	criteria := snapshot.FindCriteria("PATH")
+	require.Len(t, criteria, 1)
+	assert.Equal(t, "/rules/children/0/children/0/criteria/0", criteria[0].Pointer)
+
+	assert.Empty(t, snapshot.FindBehaviors("gzipResponse"))
+
+	// changes made after the snapshot are not visible
+	rules.Rule.Behaviors[0].Options["hostname"] = "changed.example.com"
+	rules.Rule.Children[0].Criteria[0].Options["values"].([]interface{})[0] = "gif"
+	rules.Rule.Children = nil
+	hostname, err := snapshot.GetString("/rules/behaviors/0/options/hostname")
+	require.NoError(t, err)
+	assert.Equal(t, "origin.example.com", hostname)
+	extension, err := snapshot.GetString("/rules/children/0/criteria/0/options/values/0")
+	require.NoError(t, err)
+	assert.Equal(t, "jpg", extension)
+}
@@ -7,33 +7,108 @@ import (
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 )
 
+// SearchKey is used to create an "enum" of possible Search() keys
 type SearchKey string
 
 const (
+	// SearchByPropertyName Search() key propertyName
 	SearchByPropertyName SearchKey = "propertyName"
-	SearchByHostname     SearchKey = "hostname"
+	// SearchByHostname Search() key hostname
+	SearchByHostname SearchKey = "hostname"
+	// SearchByEdgeHostname Search() key edgeHostname
 	SearchByEdgeHostname SearchKey = "edgeHostname"
 )
 
+// SearchResult is the property versions matching a Search()
 type SearchResult struct {
 	Versions struct {
-		Items []struct {
-			UpdatedByUser    string    `json:"updatedByUser"`
-			StagingStatus    string    `json:"stagingStatus"`
-			AssetID          string    `json:"assetId"`
-			PropertyName     string    `json:"propertyName"`
-			PropertyVersion  int       `json:"propertyVersion"`
-			UpdatedDate      time.Time `json:"updatedDate"`
-			ContractID       string    `json:"contractId"`
-			AccountID        string    `json:"accountId"`
-			GroupID          string    `json:"groupId"`
-			PropertyID       string    `json:"propertyId"`
-			ProductionStatus string    `json:"productionStatus"`
-		} `json:"items"`
+		Items []SearchResultVersion `json:"items"`
 	} `json:"versions"`
 }
 
-// Search searches for properties
+// SearchResultVersion is a property version matching a Search(), with its
+// contract, group and activation status
+type SearchResultVersion struct {
+	UpdatedByUser    string      `json:"updatedByUser"`
+	StagingStatus    StatusValue `json:"stagingStatus"`
+	AssetID          string      `json:"assetId"`
+	PropertyName     string      `json:"propertyName"`
+	PropertyVersion  int         `json:"propertyVersion"`
+	UpdatedDate      time.Time   `json:"updatedDate"`
+	ContractID       string      `json:"contractId"`
+	AccountID        string      `json:"accountId"`
+	GroupID          string      `json:"groupId"`
+	PropertyID       string      `json:"propertyId"`
+	ProductionStatus StatusValue `json:"productionStatus"`
+	// Hostname and EdgeHostname are only returned by hostname and edge hostname searches
+	Hostname     string `json:"hostname,omitempty"`
+	EdgeHostname string `json:"edgeHostname,omitempty"`
+}
+
+// PropertyIDs returns the IDs of the properties found, in the order of the results
+func (results *SearchResult) PropertyIDs() []string {
+	seen := map[string]bool{}
+	var propertyIDs []string
+	for _, version := range results.Versions.Items {
+		if !seen[version.PropertyID] {
+			seen[version.PropertyID] = true
+			propertyIDs = append(propertyIDs, version.PropertyID)
+		}
+	}
+
+	return propertyIDs
+}
+
+// Active returns the versions found that are active on network
+func (results *SearchResult) Active(network NetworkValue) []SearchResultVersion {
+	var active []SearchResultVersion
+	for _, version := range results.Versions.Items {
+		status := version.StagingStatus
+		if network == NetworkProduction {
+			status = version.ProductionStatus
+		}
+		if status == StatusActive {
+			active = append(active, version)
+		}
+	}
+
+	return active
+}
+
+// Latest returns the highest version found of each property, in the order of PropertyIDs()
+func (results *SearchResult) Latest() []SearchResultVersion {
+	latest := map[string]SearchResultVersion{}
+	for _, version := range results.Versions.Items {
+		if current, ok := latest[version.PropertyID]; !ok || version.PropertyVersion > current.PropertyVersion {
+			latest[version.PropertyID] = version
+		}
+	}
+
+	versions := make([]SearchResultVersion, 0, len(latest))
+	for _, propertyID := range results.PropertyIDs() {
+		versions = append(versions, latest[propertyID])
+	}
+
+	return versions
+}
+
+// Property returns a Property for version, to be used with GetProperty() and friends
+func (version SearchResultVersion) Property() *Property {
+	property := NewProperty(NewProperties())
+	property.PropertyID = version.PropertyID
+	property.PropertyName = version.PropertyName
+	property.ContractID = version.ContractID
+	property.Contract.ContractID = version.ContractID
+	property.GroupID = version.GroupID
+	property.Group.GroupID = version.GroupID
+
+	return property
+}
+
+// Search searches for the property versions with a property name, hostname
+// or edge hostname value
+//
+// nil is returned when nothing was found.
 //
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#postfindbyvalue
 // Endpoint: POST /papi/v1/search/find-by-value
@@ -59,7 +134,7 @@ func Search(searchBy SearchKey, propertyName string, correlationid string) (*Sea
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return nil, client.NewAPIError(res)
+		return nil, newAPIError(res)
 	}
 
 	results := &SearchResult{}
@@ -0,0 +1,42 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearch_Hostname(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/search/find-by-value").
+		BodyString(`{"hostname":"www.example.com"}`).
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"versions": {"items": [
+			{"accountId": "act_1-1TJZFB", "assetId": "aid_101", "contractId": "ctr_1-1TJZH5", "groupId": "grp_15225", "propertyId": "prp_173136", "propertyName": "example.com", "propertyVersion": 2, "productionStatus": "ACTIVE", "stagingStatus": "INACTIVE", "hostname": "www.example.com", "edgeHostname": "www.example.com.edgekey.net", "updatedByUser": "jsmith", "updatedDate": "2020-06-01T12:30:00Z"},
+			{"accountId": "act_1-1TJZFB", "assetId": "aid_101", "contractId": "ctr_1-1TJZH5", "groupId": "grp_15225", "propertyId": "prp_173136", "propertyName": "example.com", "propertyVersion": 3, "productionStatus": "INACTIVE", "stagingStatus": "ACTIVE", "hostname": "www.example.com", "edgeHostname": "www.example.com.edgekey.net", "updatedByUser": "jsmith", "updatedDate": "2020-06-02T12:30:00Z"}
+		]}}`)
+
+	Init(config)
+
+	results, err := Search(SearchByHostname, "www.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prp_173136"}, results.PropertyIDs())
+
+	production := results.Active(NetworkProduction)
+	require.Len(t, production, 1)
+	assert.Equal(t, 2, production[0].PropertyVersion)
+	assert.Equal(t, "www.example.com.edgekey.net", production[0].EdgeHostname)
+
+	latest := results.Latest()
+	require.Len(t, latest, 1)
+	assert.Equal(t, 3, latest[0].PropertyVersion)
+
+	property := latest[0].Property()
+	assert.Equal(t, "grp_15225", property.Group.GroupID)
+	assert.True(t, gock.IsDone())
+}
@@ -1,10 +1,14 @@
 package papi
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"github.com/patrickmn/go-cache"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 var (
@@ -52,6 +56,71 @@ func GetEdgeHostnames(contract *Contract, group *Group, options string) (*EdgeHo
 	return edgeHostnames, nil
 }
 
+// ListEdgeHostnames retrieves all edge hostnames of a contract and group, bypassing the cache
+func ListEdgeHostnames(contractID string, groupID string) (*EdgeHostnames, error) {
+	contract := NewContract(NewContracts())
+	contract.ContractID = contractID
+	group := NewGroup(NewGroups())
+	group.GroupID = groupID
+
+	Profilecache.Delete(CacheKeyEdgeHostnames)
+	return GetEdgeHostnames(contract, group, "")
+}
+
+// GetEdgeHostname retrieves a single edge hostname
+func GetEdgeHostname(contractID string, groupID string, edgeHostnameID string) (*EdgeHostname, error) {
+	edgeHostnames := NewEdgeHostnames()
+	edgeHostnames.ContractID = contractID
+	edgeHostnames.GroupID = groupID
+
+	edgeHostname := edgeHostnames.NewEdgeHostname()
+	edgeHostname.EdgeHostnameID = edgeHostnameID
+	if err := edgeHostname.GetEdgeHostname("", ""); err != nil {
+		return nil, err
+	}
+
+	return edgeHostname, nil
+}
+
+// CreateEdgeHostname creates edgeHostname in a contract and group
+//
+// Secure is set for STANDARD_TLS and ENHANCED_TLS hostnames, DomainSuffix
+// defaults to the suffix of the SecureNetwork (edgesuite.net, edgekey.net or
+// akamaized.net) and IPVersionBehavior defaults to IPV4. ENHANCED_TLS
+// hostnames require the CertEnrollmentId of their CPS enrollment.
+func CreateEdgeHostname(contractID string, groupID string, edgeHostname *EdgeHostname) (*EdgeHostname, error) {
+	edgeHostnames := NewEdgeHostnames()
+	edgeHostnames.ContractID = contractID
+	edgeHostnames.GroupID = groupID
+	edgeHostname.parent = edgeHostnames
+
+	if edgeHostname.IPVersionBehavior == "" {
+		edgeHostname.IPVersionBehavior = IPVersionIPv4
+	}
+
+	if edgeHostname.SecureNetwork == SecureNetworkStandardTLS || edgeHostname.SecureNetwork == SecureNetworkEnhancedTLS {
+		edgeHostname.Secure = true
+	}
+
+	if edgeHostname.DomainSuffix == "" {
+		switch edgeHostname.SecureNetwork {
+		case SecureNetworkEnhancedTLS:
+			edgeHostname.DomainSuffix = "edgekey.net"
+		case SecureNetworkSharedCert:
+			edgeHostname.DomainSuffix = "akamaized.net"
+		default:
+			edgeHostname.DomainSuffix = "edgesuite.net"
+		}
+	}
+
+	if err := edgeHostname.Save("", ""); err != nil {
+		return nil, err
+	}
+
+	Profilecache.Delete(CacheKeyEdgeHostnames)
+	return edgeHostname, nil
+}
+
 // GetCpCodes creates a new CpCodes struct and populates it with all CP Codes associated with a contract/group
 //
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listcpcodes
@@ -84,6 +153,58 @@ func GetVersions(property *Property) (*Versions, error) {
 	return versions, nil
 }
 
+// GetLatestVersionItem retrieves the latest version of property, or the
+// latest version active on activatedOn when it is not empty
+//
+// ErrorMap[ErrVersionNotFound] is returned when no version is active on
+// activatedOn.
+func GetLatestVersionItem(property *Property, activatedOn NetworkValue) (*Version, error) {
+	versions := NewVersions()
+	versions.PropertyID = property.PropertyID
+	versions.ContractID = property.ContractID
+	versions.GroupID = property.GroupID
+
+	version, err := versions.GetLatestVersion(activatedOn, "")
+	if activatedOn != "" && errors.Is(err, ErrorMap[ErrNotFound]) {
+		return nil, fmt.Errorf("%w: no version of %s is active on %s", ErrorMap[ErrVersionNotFound], property.PropertyID, activatedOn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version.parent = versions
+
+	return version, nil
+}
+
+// GetPropertyVersionHostnames retrieves the hostnames of a property version,
+// optionally with the Default DV certificate status of each hostname
+func GetPropertyVersionHostnames(property *Property, version int, includeCertStatus bool) (*Hostnames, error) {
+	hostnames := NewHostnames()
+	hostnames.PropertyID = property.PropertyID
+	hostnames.ContractID = property.Contract.ContractID
+	hostnames.GroupID = property.Group.GroupID
+	hostnames.IncludeCertStatus = includeCertStatus
+
+	propertyVersion := NewVersion(NewVersions())
+	propertyVersion.PropertyVersion = version
+	if err := hostnames.GetHostnames(propertyVersion, ""); err != nil {
+		return nil, err
+	}
+
+	return hostnames, nil
+}
+
+// UpdatePropertyVersionHostnames replaces the hostnames of a property version, see Hostnames.Save()
+func UpdatePropertyVersionHostnames(hostnames *Hostnames, includeCertStatus bool) (*Hostnames, error) {
+	hostnames.IncludeCertStatus = includeCertStatus
+	if err := hostnames.Save(); err != nil {
+		return nil, err
+	}
+
+	return hostnames, nil
+}
+
 // GetAvailableBehaviors retrieves all available behaviors for a property
 func GetAvailableBehaviors(property *Property) (*AvailableBehaviors, error) {
 	availableBehaviors := NewAvailableBehaviors()
@@ -103,3 +224,184 @@ func GetAvailableCriteria(property *Property) (*AvailableCriteria, error) {
 
 	return availableCriteria, nil
 }
+
+// CreateActivation activates version of property on network
+func CreateActivation(property *Property, version int, network NetworkValue, notifyEmails []string, acknowledgeWarnings bool) (*Activation, error) {
+	activation := NewActivation(NewActivations())
+	activation.PropertyVersion = version
+	activation.Network = network
+	activation.NotifyEmails = notifyEmails
+	activation.ActivationType = ActivationTypeActivate
+
+	if err := activation.Save(property, acknowledgeWarnings); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// GetActivation retrieves an activation of property
+func GetActivation(property *Property, activationID string) (*Activation, error) {
+	activation := NewActivation(NewActivations())
+	activation.ActivationID = activationID
+
+	if _, err := activation.GetActivation(property); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// CancelActivation cancels a pending activation of property
+func CancelActivation(property *Property, activationID string) (*Activation, error) {
+	activation := NewActivation(NewActivations())
+	activation.ActivationID = activationID
+
+	if err := activation.Cancel(property); err != nil {
+		return nil, err
+	}
+
+	return activation, nil
+}
+
+// GetRuleTree retrieves the rule tree of a property version
+func GetRuleTree(property *Property, version int) (*Rules, error) {
+	versioned := *property
+	versioned.LatestVersion = version
+
+	return versioned.GetRules("")
+}
+
+// UpdateRuleTree saves rules with the given options, see Rules.Update()
+func UpdateRuleTree(rules *Rules, options RuleTreeOptions) (*Rules, error) {
+	if err := rules.Update(options, ""); err != nil {
+		return rules, err
+	}
+
+	return rules, nil
+}
+
+// UpdateVersionNote sets the notes of a property version with a patch of its
+// rule tree, "add" creates the notes of versions that have none
+func UpdateVersionNote(property *Property, version int, note string) (*Rules, error) {
+	rules := NewRules()
+	rules.PropertyID = property.PropertyID
+	rules.PropertyVersion = version
+	err := rules.Patch([]JSONPatchOperation{{Op: "add", Path: "/comments", Value: note}}, RuleTreeOptions{}, "")
+
+	return rules, err
+}
+
+// UpdateVersionRuleFormat converts the rule tree of a property version to
+// ruleFormat, as a JSON patch cannot change the rule format the rule tree is
+// read in ruleFormat, which PAPI converts it to, and saved back with it
+func UpdateVersionRuleFormat(property *Property, version int, ruleFormat string) (*Rules, error) {
+	versioned := *property
+	versioned.LatestVersion = version
+
+	rules := NewRules()
+	rules.RuleFormat = ruleFormat
+	if err := rules.GetRules(&versioned, ""); err != nil {
+		return nil, err
+	}
+
+	rules.RuleFormat = ruleFormat
+	if err := rules.Update(RuleTreeOptions{IfMatch: rules.Etag}, ""); err != nil {
+		return rules, err
+	}
+
+	return rules, nil
+}
+
+// RemoveProperty deletes a property, see Property.Delete()
+func RemoveProperty(property *Property) error {
+	return property.Delete("")
+}
+
+// SearchRules submits a bulk search of the rule trees of a contract and group
+// and waits for its results, see WaitForBulkSearch()
+func SearchRules(ctx context.Context, contractID string, groupID string, query BulkSearchQuery) ([]*BulkSearchMatch, error) {
+	bulkSearch := NewBulkSearch(query)
+	if err := bulkSearch.Save(contractID, groupID, ""); err != nil {
+		return nil, err
+	}
+
+	if err := WaitForBulkSearch(ctx, bulkSearch, 0); err != nil {
+		return nil, err
+	}
+
+	return bulkSearch.Results, nil
+}
+
+// CreateVersionsInBulk creates a new version of each property from its version
+// in versions, and waits for the versions to be created
+func CreateVersionsInBulk(ctx context.Context, contractID string, groupID string, versions map[string]int) (*BulkVersionCreation, error) {
+	bulkVersionCreation := NewBulkVersionCreation()
+	for propertyID, version := range versions {
+		bulkVersionCreation.AddProperty(propertyID, version)
+	}
+
+	if err := bulkVersionCreation.Save(contractID, groupID, ""); err != nil {
+		return nil, err
+	}
+
+	return bulkVersionCreation, WaitForBulkRequest(ctx, bulkVersionCreation, 0)
+}
+
+// PatchRulesInBulk submits bulkPatch and waits for the rule trees to be patched
+func PatchRulesInBulk(ctx context.Context, contractID string, groupID string, bulkPatch *BulkPatch) (*BulkPatch, error) {
+	if err := bulkPatch.Save(contractID, groupID, ""); err != nil {
+		return nil, err
+	}
+
+	return bulkPatch, WaitForBulkRequest(ctx, bulkPatch, 0)
+}
+
+// ActivateInBulk submits bulkActivation and waits for the activations to be submitted.
+// Use WaitForActivation() to wait for each activation to become active.
+func ActivateInBulk(ctx context.Context, contractID string, groupID string, bulkActivation *BulkActivation) (*BulkActivation, error) {
+	if err := bulkActivation.Save(contractID, groupID, ""); err != nil {
+		return nil, err
+	}
+
+	return bulkActivation, WaitForBulkRequest(ctx, bulkActivation, 0)
+}
+
+// GetClientSettings retrieves the rule format and prefix defaults of the API
+// client, rule tree requests then default to its rule format
+func GetClientSettings() (*ClientSettings, error) {
+	clientSettings := NewClientSettings()
+	if err := clientSettings.GetClientSettings(); err != nil {
+		return nil, err
+	}
+
+	return clientSettings, nil
+}
+
+// UpdateClientSettings sets the rule format and prefix defaults of the API client
+func UpdateClientSettings(ruleFormat string, usePrefixes bool) (*ClientSettings, error) {
+	clientSettings := NewClientSettings()
+	clientSettings.RuleFormat = ruleFormat
+	clientSettings.UsePrefixes = usePrefixes
+	if err := clientSettings.Save(); err != nil {
+		return nil, err
+	}
+
+	return clientSettings, nil
+}
+
+// GetRuleFormats retrieves the available rule formats, sorted oldest first
+func GetRuleFormats() ([]string, error) {
+	ruleFormats := NewRuleFormats()
+	if err := ruleFormats.GetRuleFormats(""); err != nil {
+		return nil, err
+	}
+
+	return ruleFormats.RuleFormats.Items, nil
+}
+
+// GetSchema retrieves the rule tree schema of a product and rule format, for
+// use with Rules.ValidateSchema() or RuleTreeOptions.Schema
+func GetSchema(productID string, ruleFormat string) (*gojsonschema.Schema, error) {
+	return NewRuleFormats().GetSchema(productID, ruleFormat, "")
+}
@@ -0,0 +1,104 @@
+package papi
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RuleSummary is a rule tree flattened into one entry per rule, for policy
+// engines (e.g. OPA) that cannot easily walk nested rule trees
+type RuleSummary struct {
+	PropertyID      string             `json:"propertyId" yaml:"propertyId"`
+	PropertyVersion int                `json:"propertyVersion" yaml:"propertyVersion"`
+	RuleFormat      string             `json:"ruleFormat" yaml:"ruleFormat"`
+	Entries         []RuleSummaryEntry `json:"entries" yaml:"entries"`
+}
+
+// RuleSummaryEntry lists the behaviors of a rule and the conditions, from the
+// default rule down to the rule itself, under which they apply
+type RuleSummaryEntry struct {
+	// Path is the names of the rule and its parents, e.g. "/default/Performance/Compressible Objects"
+	Path       string                 `json:"path" yaml:"path"`
+	Conditions []RuleSummaryCondition `json:"conditions" yaml:"conditions"`
+	Behaviors  []RuleSummaryItem      `json:"behaviors" yaml:"behaviors"`
+}
+
+// RuleSummaryCondition are the criteria of a rule on the path of an entry
+type RuleSummaryCondition struct {
+	Path        string                       `json:"path" yaml:"path"`
+	MustSatisfy RuleCriteriaMustSatisfyValue `json:"mustSatisfy" yaml:"mustSatisfy"`
+	Criteria    []RuleSummaryItem            `json:"criteria" yaml:"criteria"`
+}
+
+// RuleSummaryItem is a behavior or criteria with its options
+type RuleSummaryItem struct {
+	Name    string      `json:"name" yaml:"name"`
+	Options OptionValue `json:"options" yaml:"options"`
+}
+
+// Summarize flattens the rule tree into a RuleSummary. Entries are in rule
+// tree order and rules without behaviors are skipped.
+func (rules *Rules) Summarize() *RuleSummary {
+	summary := &RuleSummary{
+		PropertyID:      rules.PropertyID,
+		PropertyVersion: rules.PropertyVersion,
+		RuleFormat:      rules.RuleFormat,
+		Entries:         []RuleSummaryEntry{},
+	}
+	if rules.Rule == nil {
+		return summary
+	}
+
+	var walk func(path string, rule *Rule, conditions []RuleSummaryCondition)
+	walk = func(path string, rule *Rule, conditions []RuleSummaryCondition) {
+		path += "/" + rule.Name
+		if len(rule.Criteria) > 0 {
+			mustSatisfy := rule.CriteriaMustSatisfy
+			if mustSatisfy == "" {
+				mustSatisfy = RuleCriteriaMustSatisfyAll
+			}
+			condition := RuleSummaryCondition{Path: path, MustSatisfy: mustSatisfy}
+			for _, criteria := range rule.Criteria {
+				condition.Criteria = append(condition.Criteria, summaryItem(criteria.Name, criteria.Options))
+			}
+			conditions = append(conditions[:len(conditions):len(conditions)], condition)
+		}
+
+		if len(rule.Behaviors) > 0 {
+			entry := RuleSummaryEntry{Path: path, Conditions: conditions, Behaviors: []RuleSummaryItem{}}
+			if entry.Conditions == nil {
+				entry.Conditions = []RuleSummaryCondition{}
+			}
+			for _, behavior := range rule.Behaviors {
+				entry.Behaviors = append(entry.Behaviors, summaryItem(behavior.Name, behavior.Options))
+			}
+			summary.Entries = append(summary.Entries, entry)
+		}
+
+		for _, child := range rule.Children {
+			walk(path, child, conditions)
+		}
+	}
+	walk("", rules.Rule, nil)
+
+	return summary
+}
+
+func summaryItem(name string, options OptionValue) RuleSummaryItem {
+	if options == nil {
+		options = OptionValue{}
+	}
+
+	return RuleSummaryItem{Name: name, Options: options}
+}
+
+// JSON encodes the summary as indented JSON
+func (summary *RuleSummary) JSON() ([]byte, error) {
+	return json.MarshalIndent(summary, "", "  ")
+}
+
+// YAML encodes the summary as YAML
+func (summary *RuleSummary) YAML() ([]byte, error) {
+	return yaml.Marshal(summary)
+}
@@ -0,0 +1,54 @@
+package papi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRules_Summarize(t *testing.T) {
+	rules := NewRules()
+	rules.PropertyID = "prp_173136"
+	rules.PropertyVersion = 3
+	rules.Rule.Name = "default"
+	rules.Rule.Behaviors = []*Behavior{{Name: "origin", Options: OptionValue{"hostname": "origin.example.com"}}}
+
+	performance := NewRule()
+	performance.Name = "Performance"
+	performance.Criteria = []*Criteria{{Name: "hostname", Options: OptionValue{"values": []string{"www.example.com"}}}}
+
+	compression := NewRule()
+	compression.Name = "Compression"
+	compression.CriteriaMustSatisfy = RuleCriteriaMustSatisfyAny
+	compression.Criteria = []*Criteria{{Name: "contentType", Options: OptionValue{"values": []string{"text/*"}}}}
+	compression.Behaviors = []*Behavior{{Name: "gzipResponse", Options: OptionValue{"behavior": "ALWAYS"}}}
+
+	performance.Children = []*Rule{compression}
+	rules.Rule.Children = []*Rule{performance}
+
+	summary := rules.Summarize()
+	require.Len(t, summary.Entries, 2)
+	assert.Equal(t, "/default", summary.Entries[0].Path)
+	assert.Empty(t, summary.Entries[0].Conditions)
+
+	entry := summary.Entries[1]
+	assert.Equal(t, "/default/Performance/Compression", entry.Path)
+	require.Len(t, entry.Conditions, 2)
+	assert.Equal(t, "/default/Performance", entry.Conditions[0].Path)
+	assert.Equal(t, RuleCriteriaMustSatisfyAll, entry.Conditions[0].MustSatisfy)
+	assert.Equal(t, RuleCriteriaMustSatisfyAny, entry.Conditions[1].MustSatisfy)
+	assert.Equal(t, "gzipResponse", entry.Behaviors[0].Name)
+
+	body, err := summary.JSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"path": "/default/Performance/Compression"`)
+
+	body, err = summary.YAML()
+	require.NoError(t, err)
+	decoded := RuleSummary{}
+	require.NoError(t, yaml.Unmarshal(body, &decoded))
+	assert.Equal(t, "prp_173136", decoded.PropertyID)
+	assert.Equal(t, "contentType", decoded.Entries[1].Conditions[1].Criteria[0].Name)
+}
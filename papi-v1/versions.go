@@ -3,6 +3,7 @@ package papi
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
@@ -21,6 +22,7 @@ type Versions struct {
 		Items []*Version `json:"items"`
 	} `json:"versions"`
 	RuleFormat string `json:"ruleFormat,omitempty"`
+	etag       string
 }
 
 // NewVersions creates a new Versions
@@ -61,6 +63,9 @@ func (versions *Versions) AddVersion(version *Version) {
 
 // GetVersions retrieves all versions for a a given property
 //
+// Repeated calls for the same property are conditional (If-None-Match), and
+// leave the collection untouched when PAPI replies 304 Not Modified.
+//
 // See: Property.GetVersions()
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listversions
 // Endpoint: GET /papi/v1/properties/{propertyId}/versions/{?contractId,groupId}
@@ -82,6 +87,10 @@ func (versions *Versions) GetVersions(property *Property, correlationid string)
 		return err
 	}
 
+	if versions.etag != "" && versions.PropertyID == property.PropertyID {
+		req.Header.Set("If-None-Match", versions.etag)
+	}
+
 	edge.PrintHttpRequestCorrelation(req, true, correlationid)
 
 	res, err := client.Do(Config, req)
@@ -91,13 +100,160 @@ func (versions *Versions) GetVersions(property *Property, correlationid string)
 
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
+	// Unchanged since the last call, keep the current versions
+	if res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		return nil
+	}
+
 	if err = client.BodyJSON(res, versions); err != nil {
 		return err
 	}
 
+	versions.etag = res.Header.Get("ETag")
+
+	return nil
+}
+
+// DefaultVersionsPageSize is the page size used by VersionIterator when none is given
+const DefaultVersionsPageSize = 100
+
+// VersionIterator pages through the versions of a property
+//
+//	iterator := papi.NewVersionIterator(property, 0, "")
+//	for iterator.Next() {
+//		version := iterator.Version()
+//	}
+//	if err := iterator.Err(); err != nil {
+//	}
+type VersionIterator struct {
+	property      *Property
+	pageSize      int
+	correlationid string
+
+	offset  int
+	page    []*Version
+	current *Version
+	done    bool
+	err     error
+}
+
+// NewVersionIterator creates a VersionIterator for property, fetching pageSize
+// versions per request (DefaultVersionsPageSize when zero)
+func NewVersionIterator(property *Property, pageSize int, correlationid string) *VersionIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultVersionsPageSize
+	}
+
+	return &VersionIterator{property: property, pageSize: pageSize, correlationid: correlationid}
+}
+
+// Next advances to the next version, fetching the next page when needed. It
+// returns false once the collection is exhausted or an error occurred.
+func (iterator *VersionIterator) Next() bool {
+	if iterator.err != nil {
+		return false
+	}
+
+	if len(iterator.page) == 0 && !iterator.done {
+		iterator.page, iterator.err = getVersionsPage(iterator.property, iterator.pageSize, iterator.offset, iterator.correlationid)
+		if iterator.err != nil {
+			return false
+		}
+		iterator.offset += len(iterator.page)
+		iterator.done = len(iterator.page) < iterator.pageSize
+	}
+
+	if len(iterator.page) == 0 {
+		iterator.current = nil
+		return false
+	}
+
+	iterator.current, iterator.page = iterator.page[0], iterator.page[1:]
+
+	return true
+}
+
+// Version returns the current version
+func (iterator *VersionIterator) Version() *Version {
+	return iterator.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (iterator *VersionIterator) Err() error {
+	return iterator.err
+}
+
+// GetVersionsAll retrieves all versions for a given property, following
+// offsets pageSize versions at a time until the collection is exhausted
+//
+// API Docs: https://developer.akamai.com/api/luna/papi/resources.html#listversions
+// Endpoint: GET /papi/v1/properties/{propertyId}/versions/{?contractId,groupId,limit,offset}
+func (versions *Versions) GetVersionsAll(property *Property, pageSize int, correlationid string) error {
+	if property == nil {
+		return errors.New("You must provide a property")
+	}
+
+	items := []*Version{}
+	iterator := NewVersionIterator(property, pageSize, correlationid)
+	for iterator.Next() {
+		version := iterator.Version()
+		version.parent = versions
+		items = append(items, version)
+	}
+	if err := iterator.Err(); err != nil {
+		return err
+	}
+
+	versions.PropertyID = property.PropertyID
+	versions.PropertyName = property.PropertyName
+	versions.Versions.Items = items
+	versions.etag = ""
+
 	return nil
 }
 
+func getVersionsPage(property *Property, limit, offset int, correlationid string) ([]*Version, error) {
+	if property == nil {
+		return nil, errors.New("You must provide a property")
+	}
+
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf(
+			"/papi/v1/properties/%s/versions?limit=%d&offset=%d",
+			property.PropertyID,
+			limit,
+			offset,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpRequestCorrelation(req, true, correlationid)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponseCorrelation(res, true, correlationid)
+
+	if client.IsError(res) {
+		return nil, newAPIError(res)
+	}
+
+	page := NewVersions()
+	if err = client.BodyJSON(res, page); err != nil {
+		return nil, err
+	}
+
+	return page.Versions.Items, nil
+}
+
 // GetLatestVersion retrieves the latest Version for a property
 //
 // See: Property.GetLatestVersion()
@@ -132,7 +288,7 @@ func (versions *Versions) GetLatestVersion(activatedOn NetworkValue, correlation
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return nil, client.NewAPIError(res)
+		return nil, newAPIError(res)
 	}
 
 	newVersions := NewVersions()
@@ -140,6 +296,10 @@ func (versions *Versions) GetLatestVersion(activatedOn NetworkValue, correlation
 		return nil, err
 	}
 
+	if len(newVersions.Versions.Items) == 0 {
+		return nil, ErrorMap[ErrVersionNotFound]
+	}
+
 	return newVersions.Versions.Items[0], nil
 }
 
@@ -180,6 +340,12 @@ type Version struct {
 	CreateFromVersion     int         `json:"createFromVersion,omitempty"`
 	CreateFromVersionEtag string      `json:"createFromVersionEtag,omitempty"`
 	RuleFormat            string      `json:"ruleFormat,omitempty"`
+	// IfMatch, when set, is sent as If-Match by Save, usually with the Etag of
+	// the version created from
+	IfMatch string `json:"-"`
+	// RetryOnEtagMismatch makes Save retry once from the latest version and
+	// its Etag when CreateFromVersionEtag or IfMatch is stale
+	RetryOnEtagMismatch bool `json:"-"`
 }
 
 // NewVersion creates a new Version
@@ -223,7 +389,7 @@ func (version *Version) GetVersion(property *Property, getVersion int) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	newVersions := NewVersions()
@@ -273,6 +439,13 @@ func (version *Version) HasBeenActivated(activatedOn NetworkValue) (bool, error)
 
 // Save creates a new version
 //
+// An *Error matching ErrorMap[ErrConflict] is returned when
+// CreateFromVersionEtag or IfMatch is not the current Etag of the version
+// created from. With RetryOnEtagMismatch the latest version and its Etag are
+// then retrieved and the version is created from those instead, an
+// *ErrEtagRetryFailed with the errors of both attempts is returned when
+// that fails too.
+//
 // API Docs: https://developer.akamai.com/api/luna/papi/resources.html#createanewversion
 // Endpoint: POST /papi/v1/properties/{propertyId}/versions/{?contractId,groupId}
 func (version *Version) Save(correlationid string) error {
@@ -280,6 +453,33 @@ func (version *Version) Save(correlationid string) error {
 		return fmt.Errorf("version (%d) already exists", version.PropertyVersion)
 	}
 
+	err := version.save(correlationid)
+	strict := version.CreateFromVersionEtag != "" || version.IfMatch != ""
+	if err == nil || !version.RetryOnEtagMismatch || !strict || !errors.Is(err, ErrorMap[ErrConflict]) {
+		return err
+	}
+
+	latest, latestErr := version.parent.GetLatestVersion("", correlationid)
+	if latestErr != nil {
+		return &ErrEtagRetryFailed{First: err, Retry: latestErr}
+	}
+	version.CreateFromVersion = latest.PropertyVersion
+	if version.CreateFromVersionEtag != "" {
+		version.CreateFromVersionEtag = latest.Etag
+	}
+	if version.IfMatch != "" {
+		version.IfMatch = latest.Etag
+	}
+
+	if retryErr := version.save(correlationid); retryErr != nil {
+		return &ErrEtagRetryFailed{First: err, Retry: retryErr}
+	}
+
+	return nil
+}
+
+// save sends a single request to create the version
+func (version *Version) save(correlationid string) error {
 	req, err := client.NewJSONRequest(
 		Config,
 		"POST",
@@ -293,6 +493,10 @@ func (version *Version) Save(correlationid string) error {
 		return err
 	}
 
+	if version.IfMatch != "" {
+		req.Header.Set("If-Match", version.IfMatch)
+	}
+
 	edge.PrintHttpRequestCorrelation(req, true, correlationid)
 
 	res, err := client.Do(Config, req)
@@ -303,7 +507,7 @@ func (version *Version) Save(correlationid string) error {
 	edge.PrintHttpResponseCorrelation(res, true, correlationid)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	var location client.JSONBody
@@ -331,7 +535,7 @@ func (version *Version) Save(correlationid string) error {
 	edge.PrintHttpResponse(res, true)
 
 	if client.IsError(res) {
-		return client.NewAPIError(res)
+		return newAPIError(res)
 	}
 
 	versions := NewVersions()
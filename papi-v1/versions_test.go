@@ -0,0 +1,181 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestVersions_GetVersionsAll(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions").
+		MatchParam("limit", "2").
+		MatchParam("offset", "0").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 3}, {"propertyVersion": 2}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions").
+		MatchParam("limit", "2").
+		MatchParam("offset", "2").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 1}]}}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	versions := NewVersions()
+	require.NoError(t, versions.GetVersionsAll(property, 2, ""))
+	require.Len(t, versions.Versions.Items, 3)
+	assert.Equal(t, 1, versions.Versions.Items[2].PropertyVersion)
+	assert.Equal(t, versions, versions.Versions.Items[2].parent)
+	assert.True(t, gock.IsDone())
+}
+
+func TestVersionIterator_Error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions").
+		Reply(403).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"type": "https://problems.luna.akamaiapis.net/papi/v0/forbidden", "title": "Forbidden", "status": 403}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	iterator := NewVersionIterator(property, 0, "")
+	assert.False(t, iterator.Next())
+	assert.Error(t, iterator.Err())
+	assert.Nil(t, iterator.Version())
+}
+
+func TestGetLatestVersionItem(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/latest").
+		MatchParam("activatedOn", "PRODUCTION").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 2, "productionStatus": "ACTIVE", "etag": "4607f363da8bc05b0c0f0f75249"}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/latest").
+		MatchParam("activatedOn", "STAGING").
+		Reply(404).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(`{"type": "https://problems.luna.akamaiapis.net/papi/v0/not-found", "title": "Not Found", "status": 404}`)
+
+	Init(config)
+
+	property := NewProperty(NewProperties())
+	property.PropertyID = "prp_173136"
+
+	version, err := GetLatestVersionItem(property, NetworkProduction)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version.PropertyVersion)
+	assert.Equal(t, StatusActive, version.ProductionStatus)
+	assert.Equal(t, "prp_173136", version.parent.PropertyID)
+
+	_, err = GetLatestVersionItem(property, NetworkStaging)
+	assert.True(t, errors.Is(err, ErrorMap[ErrVersionNotFound]))
+	assert.True(t, gock.IsDone())
+}
+
+func TestSetAccountSwitchKey(t *testing.T) {
+	defer gock.Off()
+	defer SetAccountSwitchKey("")
+
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/latest").
+		MatchParam("activatedOn", "STAGING").
+		MatchParam("accountSwitchKey", "1-ABCDE").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 3}]}}`)
+
+	Init(config)
+	Profilecache.Set(CacheKeyGroups, []byte("{}"), 0)
+	SetAccountSwitchKey("1-ABCDE")
+
+	_, found := Profilecache.Get(CacheKeyGroups)
+	assert.False(t, found)
+
+	versions := NewVersions()
+	versions.PropertyID = "prp_173136"
+	version, err := versions.GetLatestVersion(NetworkStaging, "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, version.PropertyVersion)
+	assert.True(t, gock.IsDone())
+}
+
+func TestVersion_Save_RetryOnEtagMismatch(t *testing.T) {
+	defer gock.Off()
+
+	mismatch := `{"type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed", "title": "Precondition Failed", "status": 412}`
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/properties/prp_173136/versions").
+		JSON(map[string]interface{}{"updatedDate": "0001-01-01T00:00:00Z", "createFromVersion": 3, "createFromVersionEtag": "stale"}).
+		Reply(412).
+		SetHeader("Content-Type", "application/problem+json").
+		BodyString(mismatch)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/latest").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 4, "etag": "current"}]}}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Post("/papi/v1/properties/prp_173136/versions").
+		JSON(map[string]interface{}{"updatedDate": "0001-01-01T00:00:00Z", "createFromVersion": 4, "createFromVersionEtag": "current"}).
+		Reply(201).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"versionLink": "/papi/v1/properties/prp_173136/versions/5"}`)
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/5").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 5, "etag": "new", "createFromVersion": 4}]}}`)
+
+	Init(config)
+
+	versions := NewVersions()
+	versions.PropertyID = "prp_173136"
+	version := versions.NewVersion(&Version{PropertyVersion: 3, Etag: "stale"}, true, "")
+	version.RetryOnEtagMismatch = true
+	require.NoError(t, version.Save(""))
+	assert.Equal(t, 5, version.PropertyVersion)
+	assert.Equal(t, 4, version.CreateFromVersion)
+	assert.True(t, gock.IsDone())
+
+	for i := 0; i < 2; i++ {
+		gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+			Post("/papi/v1/properties/prp_173136/versions").
+			Reply(412).
+			SetHeader("Content-Type", "application/problem+json").
+			BodyString(mismatch)
+	}
+	gock.New("https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net").
+		Get("/papi/v1/properties/prp_173136/versions/latest").
+		Reply(200).
+		SetHeader("Content-Type", "application/json").
+		BodyString(`{"propertyId": "prp_173136", "versions": {"items": [{"propertyVersion": 5, "etag": "new"}]}}`)
+
+	version = versions.NewVersion(&Version{PropertyVersion: 4, Etag: "current"}, true, "")
+	version.RetryOnEtagMismatch = true
+	err := version.Save("")
+	var retryErr *ErrEtagRetryFailed
+	require.True(t, errors.As(err, &retryErr))
+	assert.True(t, errors.Is(retryErr.First, ErrorMap[ErrConflict]))
+	assert.True(t, errors.Is(err, ErrorMap[ErrConflict]))
+	assert.True(t, gock.IsDone())
+}
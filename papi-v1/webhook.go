@@ -0,0 +1,123 @@
+package papi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Profilecache keys used by this package
+const (
+	CacheKeyContracts     = "contracts"
+	CacheKeyCpCodes       = "cpcodes"
+	CacheKeyEdgeHostnames = "edgehostnames"
+	CacheKeyGroups        = "groups"
+	CacheKeyProducts      = "products"
+)
+
+// CacheEvent is a Control Center event notification, as delivered to a webhook
+type CacheEvent struct {
+	EventID    string `json:"eventId"`
+	EventType  string `json:"eventType"`
+	EventTime  string `json:"eventTime,omitempty"`
+	ContractID string `json:"contractId,omitempty"`
+	GroupID    string `json:"groupId,omitempty"`
+	PropertyID string `json:"propertyId,omitempty"`
+}
+
+// cacheKeysByEventPrefix maps event type prefixes to the Profilecache keys they make stale
+var cacheKeysByEventPrefix = map[string][]string{
+	"contract":      {CacheKeyContracts, CacheKeyGroups, CacheKeyProducts},
+	"group":         {CacheKeyGroups},
+	"cpcode":        {CacheKeyCpCodes},
+	"edgehostname":  {CacheKeyEdgeHostnames},
+	"edge-hostname": {CacheKeyEdgeHostnames},
+	"product":       {CacheKeyProducts},
+	// property and rule tree changes are not held in Profilecache, see CacheInvalidationHandler.OnEvent
+	"property": {},
+	"hostname": {},
+	"rules":    {},
+}
+
+// CacheKeysForEvent returns the Profilecache keys invalidated by an event type, e.g.
+// "group.created"; unknown event types invalidate every key
+func CacheKeysForEvent(eventType string) []string {
+	prefix := strings.ToLower(eventType)
+	if i := strings.IndexAny(prefix, "._"); i != -1 {
+		prefix = prefix[:i]
+	}
+
+	if keys, ok := cacheKeysByEventPrefix[prefix]; ok {
+		return keys
+	}
+
+	return []string{CacheKeyContracts, CacheKeyCpCodes, CacheKeyEdgeHostnames, CacheKeyGroups, CacheKeyProducts}
+}
+
+// CacheInvalidationHandler is an http.Handler receiving Control Center event
+// webhooks and dropping the Profilecache entries they make stale
+//
+//	http.Handle("/akamai/events", &papi.CacheInvalidationHandler{
+//		Verify: verifySignature,
+//		OnEvent: func(event papi.CacheEvent) {
+//			if event.PropertyID != "" {
+//				ruleTreeCache.Delete(event.PropertyID)
+//			}
+//		},
+//	})
+type CacheInvalidationHandler struct {
+	// Verify authenticates the request, e.g. by checking a shared secret or
+	// signature header. Requests are rejected with 401 when it returns false.
+	// It is strongly recommended, as anyone able to reach the handler can flush the cache.
+	Verify func(req *http.Request, body []byte) bool
+	// OnEvent is called for each event after Profilecache was invalidated,
+	// so applications can drop their own caches (e.g. rule trees and hostnames)
+	OnEvent func(event CacheEvent)
+}
+
+// ServeHTTP accepts a single event or a JSON array of events
+func (handler *CacheInvalidationHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+
+	if handler.Verify != nil && !handler.Verify(req, body) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var events []CacheEvent
+	body = bytes.TrimSpace(body)
+	if bytes.HasPrefix(body, []byte("[")) {
+		err = json.Unmarshal(body, &events)
+	} else {
+		var event CacheEvent
+		err = json.Unmarshal(body, &event)
+		events = append(events, event)
+	}
+	if err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	for _, event := range events {
+		for _, key := range CacheKeysForEvent(event.EventType) {
+			Profilecache.Delete(key)
+		}
+		if handler.OnEvent != nil {
+			handler.OnEvent(event)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,57 @@
+package papi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheInvalidationHandler(t *testing.T) {
+	defer Profilecache.Flush()
+	Profilecache.Set(CacheKeyGroups, []byte("{}"), cache.DefaultExpiration)
+	Profilecache.Set(CacheKeyCpCodes, []byte("{}"), cache.DefaultExpiration)
+
+	var seen []CacheEvent
+	handler := &CacheInvalidationHandler{
+		Verify: func(req *http.Request, body []byte) bool {
+			return req.Header.Get("X-Webhook-Token") == "secret"
+		},
+		OnEvent: func(event CacheEvent) {
+			seen = append(seen, event)
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/events", strings.NewReader(`{"eventType": "group.created"}`)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	_, found := Profilecache.Get(CacheKeyGroups)
+	assert.True(t, found)
+
+	req := httptest.NewRequest("POST", "/events", strings.NewReader(`[{"eventId": "1", "eventType": "group.created", "groupId": "grp_1"}, {"eventId": "2", "eventType": "property.activated", "propertyId": "prp_1"}]`))
+	req.Header.Set("X-Webhook-Token", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	_, found = Profilecache.Get(CacheKeyGroups)
+	assert.False(t, found)
+	_, found = Profilecache.Get(CacheKeyCpCodes)
+	assert.True(t, found)
+	if assert.Len(t, seen, 2) {
+		assert.Equal(t, "prp_1", seen[1].PropertyID)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/events", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestCacheKeysForEvent(t *testing.T) {
+	assert.Equal(t, []string{CacheKeyCpCodes}, CacheKeysForEvent("CPCODE_CREATED"))
+	assert.Empty(t, CacheKeysForEvent("property.version.created"))
+	assert.Len(t, CacheKeysForEvent("something.new"), 5)
+}
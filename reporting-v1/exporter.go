@@ -0,0 +1,271 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// DefaultExporterInterval is the interval Exporter.Run executes the queries
+// at when none is given, the granularity of most reports is five minutes
+const DefaultExporterInterval = 5 * time.Minute
+
+const (
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	textContentType        = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// MetricsQuery is a report an Exporter executes and exposes as gauges
+//
+// Each row of the report sets a gauge <Name>_<metric> for each metric with a
+// number value, labelled with the Labels columns of the row; the metrics are
+// those of Request.Metrics or, when it is empty, the columns of the report
+// metadata that are neither Labels nor grouped by; rows of the same
+// labels, e.g. the intervals of a time series, overwrite each other so the
+// last one is exposed. Summary statistics with number values are exposed as
+// <Name>_<statistic> gauges without labels.
+type MetricsQuery struct {
+	// Name prefixes the gauges of the query, e.g. akamai_traffic
+	Name    string
+	Report  string
+	Version string
+	// Request selects the data of the report, its Start and End are set from Window
+	Request ReportRequest
+	// Window is the time span, ending at the execution, the report covers
+	Window time.Duration
+	// Labels are the columns exposed as labels, e.g. cpcode
+	Labels []string
+}
+
+// Exporter executes MetricsQuery reports on a schedule and serves their
+// latest results in the OpenMetrics (or Prometheus text) format
+//
+// The gauges of a query that fails keep their previous values, and its
+// <Name>_up gauge is set to 0 until it succeeds again.
+type Exporter struct {
+	Queries []MetricsQuery
+	// Interval is the interval Run executes the queries at
+	Interval time.Duration
+
+	mutex    sync.RWMutex
+	families map[string][]metricFamily
+	up       map[string]bool
+	now      func() time.Time
+}
+
+type metricFamily struct {
+	name    string
+	samples map[string]float64
+}
+
+// NewExporter returns an Exporter of queries executed every interval
+func NewExporter(interval time.Duration, queries ...MetricsQuery) *Exporter {
+	return &Exporter{Queries: queries, Interval: interval}
+}
+
+// Run executes the queries immediately and then every Interval until ctx is
+// done, failed queries are logged and retried at the next interval
+func (exporter *Exporter) Run(ctx context.Context) error {
+	interval := exporter.Interval
+	if interval <= 0 {
+		interval = DefaultExporterInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := exporter.Refresh(ctx); err != nil {
+			edgegrid.EdgegridLog.Warnf("[WARN] reporting exporter: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh executes all queries once and returns the error of the first one that failed
+func (exporter *Exporter) Refresh(ctx context.Context) error {
+	now := time.Now
+	if exporter.now != nil {
+		now = exporter.now
+	}
+
+	var firstErr error
+	for _, query := range exporter.Queries {
+		request := query.Request
+		request.End = now().UTC()
+		request.Start = request.End.Add(-query.Window)
+
+		data, err := ExecuteReport(ctx, query.Report, query.Version, request, 0)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("query %s: %w", query.Name, err)
+			}
+			exporter.setResult(query.Name, nil, false)
+			continue
+		}
+
+		exporter.setResult(query.Name, query.families(data), true)
+	}
+
+	return firstErr
+}
+
+func (exporter *Exporter) setResult(name string, families []metricFamily, up bool) {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	if exporter.families == nil {
+		exporter.families = map[string][]metricFamily{}
+		exporter.up = map[string]bool{}
+	}
+	if families != nil {
+		exporter.families[name] = families
+	}
+	exporter.up[name] = up
+}
+
+// ServeHTTP writes the gauges of the queries, in the OpenMetrics format when
+// the request accepts it and in the Prometheus text format otherwise
+func (exporter *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", openMetricsContentType)
+	} else {
+		w.Header().Set("Content-Type", textContentType)
+	}
+
+	exporter.mutex.RLock()
+	defer exporter.mutex.RUnlock()
+
+	var families []metricFamily
+	for _, query := range exporter.Queries {
+		up, ok := exporter.up[query.Name]
+		if !ok {
+			continue
+		}
+		value := 0.0
+		if up {
+			value = 1
+		}
+		families = append(families, metricFamily{name: metricName(query.Name) + "_up", samples: map[string]float64{"": value}})
+		families = append(families, exporter.families[query.Name]...)
+	}
+
+	for _, family := range families {
+		fmt.Fprintf(w, "# TYPE %s gauge\n", family.name)
+
+		labels := make([]string, 0, len(family.samples))
+		for label := range family.samples {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			fmt.Fprintf(w, "%s%s %s\n", family.name, label, strconv.FormatFloat(family.samples[label], 'g', -1, 64))
+		}
+	}
+	if openMetrics {
+		fmt.Fprint(w, "# EOF\n")
+	}
+}
+
+// families converts the rows and summary statistics of data to gauges
+func (query *MetricsQuery) families(data *ReportData) []metricFamily {
+	prefix := metricName(query.Name)
+	isMetric := map[string]bool{}
+	for _, metric := range query.Request.Metrics {
+		isMetric[metric] = true
+	}
+	if len(isMetric) == 0 {
+		isDimension := map[string]bool{}
+		for _, column := range query.Labels {
+			isDimension[column] = true
+		}
+		for _, column := range data.Metadata.GroupBy {
+			isDimension[column] = true
+		}
+		for _, column := range data.Metadata.Columns {
+			if !isDimension[column.Name] {
+				isMetric[column.Name] = true
+			}
+		}
+	}
+
+	byName := map[string]*metricFamily{}
+	family := func(name string) *metricFamily {
+		name = prefix + "_" + metricName(name)
+		if byName[name] == nil {
+			byName[name] = &metricFamily{name: name, samples: map[string]float64{}}
+		}
+		return byName[name]
+	}
+
+	for _, row := range data.Data {
+		var labels []string
+		for _, label := range query.Labels {
+			labels = append(labels, fmt.Sprintf(`%s="%s"`, metricName(label), escapeLabelValue(formatCell(row[label]))))
+		}
+		labelSet := ""
+		if len(labels) > 0 {
+			labelSet = "{" + strings.Join(labels, ",") + "}"
+		}
+
+		for column, cell := range row {
+			if !isMetric[column] {
+				continue
+			}
+			if value, ok := (SummaryStatistic{Value: cell}).Float64(); ok {
+				family(column).samples[labelSet] = value
+			}
+		}
+	}
+	for name, statistic := range data.SummaryStatistics {
+		if value, ok := statistic.Float64(); ok {
+			family(name).samples[""] = value
+		}
+	}
+
+	families := make([]metricFamily, 0, len(byName))
+	for _, family := range byName {
+		families = append(families, *family)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	return families
+}
+
+// metricName converts a report column, e.g. edgeHits, to a metric or label
+// name, e.g. edge_hits
+func metricName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case r == '_' || r == ':' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) && i > 0):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	return b.String()
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
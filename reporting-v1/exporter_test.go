@@ -0,0 +1,101 @@
+package reporting
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestExporter(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/reporting-api/v1/reports/hits-by-cpcode/versions/1/report-data").
+		MatchParam("start", "2020-06-01T23:00:00Z").
+		MatchParam("end", "2020-06-02T00:00:00Z").
+		JSON(map[string]interface{}{"objectType": "cpcode", "objectIds": "all", "metrics": []string{"edgeHits", "offloadedHitsPercentage"}}).
+		Reply(200).
+		JSON(`{
+			"metadata": {"name": "hits-by-cpcode", "version": "1", "start": "2020-06-01T23:00:00Z", "end": "2020-06-02T00:00:00Z"},
+			"data": [
+				{"cpcode": "12345", "edgeHits": 1500000, "offloadedHitsPercentage": "99.5", "hostnameCount": 3},
+				{"cpcode": "23456", "edgeHits": 1200, "offloadedHitsPercentage": "N/A", "hostnameCount": 1}
+			],
+			"summaryStatistics": {"edgeHitsSum": {"value": "1501200"}}
+		}`)
+	gock.New(baseURL).
+		Post("/reporting-api/v1/reports/bytes-by-time/versions/1/report-data").
+		Reply(500).
+		JSON(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`)
+
+	Init(config)
+
+	exporter := NewExporter(time.Minute,
+		MetricsQuery{
+			Name:    "akamai_traffic",
+			Report:  "hits-by-cpcode",
+			Version: "1",
+			Request: ReportRequest{ObjectType: "cpcode", Metrics: []string{"edgeHits", "offloadedHitsPercentage"}},
+			Window:  time.Hour,
+			Labels:  []string{"cpcode"},
+		},
+		MetricsQuery{Name: "akamai_bytes", Report: "bytes-by-time", Version: "1", Window: time.Hour},
+	)
+	exporter.now = func() time.Time { return time.Date(2020, 6, 2, 0, 0, 0, 0, time.UTC) }
+
+	assert.Error(t, exporter.Refresh(context.Background()))
+	assert.True(t, gock.IsDone())
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/metrics", nil)
+	request.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	exporter.ServeHTTP(recorder, request)
+
+	assert.Equal(t, openMetricsContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `# TYPE akamai_traffic_up gauge
+akamai_traffic_up 1
+# TYPE akamai_traffic_edge_hits gauge
+akamai_traffic_edge_hits{cpcode="12345"} 1.5e+06
+akamai_traffic_edge_hits{cpcode="23456"} 1200
+# TYPE akamai_traffic_edge_hits_sum gauge
+akamai_traffic_edge_hits_sum 1.5012e+06
+# TYPE akamai_traffic_offloaded_hits_percentage gauge
+akamai_traffic_offloaded_hits_percentage{cpcode="12345"} 99.5
+# TYPE akamai_bytes_up gauge
+akamai_bytes_up 0
+# EOF
+`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	exporter.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, textContentType, recorder.Header().Get("Content-Type"))
+	assert.NotContains(t, recorder.Body.String(), "# EOF")
+}
+
+func TestMetricsQueryFamilies_MetadataColumns(t *testing.T) {
+	query := MetricsQuery{Name: "akamai_bytes", Labels: []string{"cpcode"}}
+	data := &ReportData{
+		Metadata: ReportMetadata{
+			GroupBy: []string{"startdatetime", "cpcode"},
+			Columns: []Column{{Name: "startdatetime"}, {Name: "cpcode"}, {Name: "edgeBytes"}},
+		},
+		Data: []map[string]interface{}{{"startdatetime": "1591048800", "cpcode": "12345", "edgeBytes": 2048.0, "objectId": 12345.0}},
+	}
+
+	families := query.families(data)
+	if assert.Len(t, families, 1) {
+		assert.Equal(t, "akamai_bytes_edge_bytes", families[0].name)
+		assert.Equal(t, map[string]float64{`{cpcode="12345"}`: 2048}, families[0].samples)
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	assert.Equal(t, "edge_hits", metricName("edgeHits"))
+	assert.Equal(t, "akamai_traffic", metricName("akamai_traffic"))
+	assert.Equal(t, "_xx_status", metricName("2xx-status"))
+	assert.Equal(t, `a\"b\\c`, escapeLabelValue(`a"b\c`))
+}
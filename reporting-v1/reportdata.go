@@ -0,0 +1,287 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// DefaultPollInterval is the interval ExecuteReport polls a long-running
+// report at when the API does not suggest one
+const DefaultPollInterval = 5 * time.Second
+
+// ReportRequest selects the data ExecuteReport returns
+type ReportRequest struct {
+	Start    time.Time
+	End      time.Time
+	Interval Interval
+	// ObjectType is the business object of the report, e.g. cpcode
+	ObjectType string
+	// ObjectIDs are the objects to report on, all of them when empty
+	ObjectIDs []string
+	// Metrics are the columns to return, all of them when empty
+	Metrics []string
+	// Filters are the accepted values of each filter
+	Filters map[string][]string
+}
+
+// ReportData is the result of ExecuteReport: the rows of the report and its
+// summary statistics
+//
+// API Docs: https://developer.akamai.com/api/core_features/reporting/v1.html#reportdata
+type ReportData struct {
+	Metadata          ReportMetadata              `json:"metadata"`
+	Data              []map[string]interface{}    `json:"data"`
+	SummaryStatistics map[string]SummaryStatistic `json:"summaryStatistics,omitempty"`
+}
+
+// ReportMetadata describes the data of a ReportData
+type ReportMetadata struct {
+	Name              string   `json:"name"`
+	Version           string   `json:"version"`
+	OutputType        string   `json:"outputType,omitempty"`
+	GroupBy           []string `json:"groupBy,omitempty"`
+	Start             string   `json:"start"`
+	End               string   `json:"end"`
+	Interval          Interval `json:"interval,omitempty"`
+	AvailableDataEnds string   `json:"availableDataEnds,omitempty"`
+	ObjectType        string   `json:"objectType,omitempty"`
+	ObjectIDs         []string `json:"objectIds,omitempty"`
+	RowCount          int      `json:"rowCount,omitempty"`
+	Columns           []Column `json:"columns,omitempty"`
+}
+
+// Column is a column of ReportData.Data
+type Column struct {
+	Name  string `json:"name"`
+	Label string `json:"label,omitempty"`
+}
+
+// SummaryStatistic is a statistic of the whole report, e.g. the total of a metric
+type SummaryStatistic struct {
+	Value interface{} `json:"value"`
+}
+
+// Float64 returns the value of the statistic as a number, false when it is not one
+func (statistic SummaryStatistic) Float64() (float64, bool) {
+	switch value := statistic.Value.(type) {
+	case float64:
+		return value, true
+	case string:
+		f, err := strconv.ParseFloat(value, 64)
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// Validate checks request against the intervals, metrics and filters of the report
+func (report *ReportType) Validate(request ReportRequest) error {
+	if request.Start.IsZero() || !request.End.After(request.Start) {
+		return errors.New("a report needs a start before its end")
+	}
+	if request.Interval != "" && len(report.AvailableIntervals) > 0 && !report.SupportsInterval(request.Interval) {
+		return fmt.Errorf("report %s does not support interval %s", report.Name, request.Interval)
+	}
+
+	metrics := map[string]bool{}
+	for _, metric := range report.Metrics {
+		metrics[metric.Name] = true
+	}
+	for _, metric := range request.Metrics {
+		if !metrics[metric] {
+			return fmt.Errorf("report %s has no metric %q", report.Name, metric)
+		}
+	}
+
+	filters := map[string]FilterType{}
+	for _, filter := range report.Filters {
+		filters[filter.Name] = filter
+	}
+	for name, values := range request.Filters {
+		filter, ok := filters[name]
+		if !ok {
+			return fmt.Errorf("report %s has no filter %q", report.Name, name)
+		}
+		for _, value := range values {
+			if len(filter.Values) > 0 && !contains(filter.Values, value) {
+				return fmt.Errorf("filter %s does not accept %q", name, value)
+			}
+		}
+	}
+	for _, name := range report.RequiredFilters {
+		if len(request.Filters[name]) == 0 {
+			return fmt.Errorf("report %s requires filter %q", report.Name, name)
+		}
+	}
+
+	return nil
+}
+
+// ExecuteReport executes a version of a report
+//
+// Long-running reports are accepted with 202 Accepted and polled every
+// pollInterval (the API's Retry-After, then DefaultPollInterval, when 0)
+// until their data is ready or ctx is done.
+//
+// API Docs: https://developer.akamai.com/api/core_features/reporting/v1.html#postreportdata
+// Endpoint: POST /reporting-api/v1/reports/{name}/versions/{version}/report-data{?start,end,interval}
+func ExecuteReport(ctx context.Context, name string, version string, request ReportRequest, pollInterval time.Duration) (*ReportData, error) {
+	if request.Start.IsZero() || !request.End.After(request.Start) {
+		return nil, errors.New("a report needs a start before its end")
+	}
+
+	path, err := client.PathWithQuery(reportPath(name, version)+"/report-data", struct {
+		Start    time.Time `query:"start"`
+		End      time.Time `query:"end"`
+		Interval Interval  `query:"interval,omitempty"`
+	}{request.Start.UTC(), request.End.UTC(), request.Interval})
+	if err != nil {
+		return nil, err
+	}
+
+	var objectIDs interface{} = "all"
+	if len(request.ObjectIDs) > 0 {
+		objectIDs = request.ObjectIDs
+	}
+	body := struct {
+		ObjectType string              `json:"objectType,omitempty"`
+		ObjectIDs  interface{}         `json:"objectIds"`
+		Metrics    []string            `json:"metrics,omitempty"`
+		Filters    map[string][]string `json:"filters,omitempty"`
+	}{request.ObjectType, objectIDs, request.Metrics, request.Filters}
+
+	data := &ReportData{}
+	method := "POST"
+	var payload interface{} = body
+	for {
+		location, retryAfter, err := doReport(method, path, payload, data)
+		if err != nil {
+			return nil, err
+		}
+		if location == "" {
+			return data, nil
+		}
+
+		// the report is still running, its data will be at location
+		method, path, payload = "GET", location, nil
+		wait := pollInterval
+		if wait <= 0 {
+			wait = retryAfter
+		}
+		if wait <= 0 {
+			wait = DefaultPollInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("report %s still running: %w", name, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// Table returns the data as rows of strings, in the order of the columns of
+// the metadata, or of the sorted column names when there is none
+func (data *ReportData) Table() (columns []string, rows [][]string) {
+	for _, column := range data.Metadata.Columns {
+		columns = append(columns, column.Name)
+	}
+	if len(columns) == 0 {
+		found := map[string]bool{}
+		for _, row := range data.Data {
+			for name := range row {
+				if !found[name] {
+					found[name] = true
+					columns = append(columns, name)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	for _, row := range data.Data {
+		cells := make([]string, len(columns))
+		for i, name := range columns {
+			cells[i] = formatCell(row[name])
+		}
+		rows = append(rows, cells)
+	}
+
+	return columns, rows
+}
+
+// doReport sends body (if not nil) and decodes the response into out, unless
+// it is 202 Accepted: the location of the pending data and the suggested
+// wait are returned then
+func doReport(method, path string, body, out interface{}) (string, time.Duration, error) {
+	req, err := client.NewJSONRequest(Config, method, path, body)
+	if err != nil {
+		return "", 0, err
+	}
+
+	edgegrid.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return "", 0, err
+	}
+
+	edgegrid.PrintHttpResponse(res, true)
+
+	if client.IsError(res) {
+		return "", 0, client.NewAPIError(res)
+	}
+
+	if res.StatusCode == http.StatusAccepted {
+		res.Body.Close()
+		location := res.Header.Get("Location")
+		if location == "" {
+			return "", 0, errors.New("report accepted without a Location")
+		}
+		if u, err := url.Parse(location); err == nil && u.IsAbs() {
+			location = u.RequestURI()
+		}
+		seconds, _ := strconv.Atoi(res.Header.Get("Retry-After"))
+		return location, time.Duration(seconds) * time.Second, nil
+	}
+
+	return "", 0, client.BodyJSON(res, out)
+}
+
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func reportPath(name, version string) string {
+	return fmt.Sprintf("/reporting-api/v1/reports/%s/versions/%s", url.PathEscape(name), url.PathEscape(version))
+}
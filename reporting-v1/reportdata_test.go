@@ -0,0 +1,139 @@
+package reporting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+const reportDataJSON = `{
+	"metadata": {"name": "hits-by-time", "version": "1", "start": "2020-06-01T00:00:00Z", "end": "2020-06-02T00:00:00Z", "interval": "HOUR",
+		"columns": [{"name": "startdatetime"}, {"name": "edgeHits"}, {"name": "originHits"}]},
+	"data": [
+		{"startdatetime": "2020-06-01T00:00:00Z", "edgeHits": 1500000, "originHits": 1200},
+		{"startdatetime": "2020-06-01T01:00:00Z", "edgeHits": 1400000.5}
+	],
+	"summaryStatistics": {"edgeHitsSum": {"value": "2900000.5"}, "peakHour": {"value": "01:00"}}
+}`
+
+func TestExecuteReport(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/reporting-api/v1/reports/hits-by-time/versions/1/report-data").
+		MatchParam("start", "2020-06-01T00:00:00Z").
+		MatchParam("end", "2020-06-02T00:00:00Z").
+		MatchParam("interval", "HOUR").
+		JSON(map[string]interface{}{"objectType": "cpcode", "objectIds": []string{"12345"}, "metrics": []string{"edgeHits", "originHits"}}).
+		Reply(202).
+		SetHeader("Location", baseURL+"/reporting-api/v1/reports/hits-by-time/versions/1/report-data/requests/r1")
+	gock.New(baseURL).
+		Get("/reporting-api/v1/reports/hits-by-time/versions/1/report-data/requests/r1").
+		Reply(202).
+		SetHeader("Location", "/reporting-api/v1/reports/hits-by-time/versions/1/report-data/requests/r1")
+	gock.New(baseURL).
+		Get("/reporting-api/v1/reports/hits-by-time/versions/1/report-data/requests/r1").
+		Reply(200).
+		JSON(reportDataJSON)
+
+	Init(config)
+
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	data, err := ExecuteReport(context.Background(), "hits-by-time", "1", ReportRequest{
+		Start:      start,
+		End:        start.Add(24 * time.Hour),
+		Interval:   IntervalHour,
+		ObjectType: "cpcode",
+		ObjectIDs:  []string{"12345"},
+		Metrics:    []string{"edgeHits", "originHits"},
+	}, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	columns, rows := data.Table()
+	assert.Equal(t, []string{"startdatetime", "edgeHits", "originHits"}, columns)
+	assert.Equal(t, [][]string{
+		{"2020-06-01T00:00:00Z", "1500000", "1200"},
+		{"2020-06-01T01:00:00Z", "1400000.5", ""},
+	}, rows)
+
+	sum, ok := data.SummaryStatistics["edgeHitsSum"].Float64()
+	assert.True(t, ok)
+	assert.Equal(t, 2900000.5, sum)
+	_, ok = data.SummaryStatistics["peakHour"].Float64()
+	assert.False(t, ok)
+}
+
+func TestExecuteReport_Cancelled(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/reporting-api/v1/reports/hits-by-time/versions/1/report-data").
+		JSON(map[string]interface{}{"objectIds": "all"}).
+		Reply(202).
+		SetHeader("Location", "/reporting-api/v1/reports/hits-by-time/versions/1/report-data/requests/r2").
+		SetHeader("Retry-After", "60")
+
+	Init(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := ExecuteReport(ctx, "hits-by-time", "1", ReportRequest{Start: start, End: start.Add(time.Hour)}, 0)
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestReportType_Validate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/reporting-api/v1/reports/hits-by-time/versions/1").
+		Reply(200).
+		JSON(`{"name": "hits-by-time", "version": "1", "businessObjectName": "cpcode", "intervals": ["FIVE_MINUTES", "HOUR"],
+			"metrics": [{"name": "edgeHits"}, {"name": "originHits"}],
+			"filters": [{"name": "delivery_type", "type": "enum", "values": ["secure", "non_secure"]}, {"name": "ca"}],
+			"requiredFilters": ["ca"]}`)
+
+	Init(config)
+
+	report, err := GetReportType("hits-by-time", "1")
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	valid := ReportRequest{Start: start, End: start.Add(time.Hour), Interval: IntervalHour, Metrics: []string{"edgeHits"},
+		Filters: map[string][]string{"ca": {"cacheable"}, "delivery_type": {"secure"}}}
+	assert.NoError(t, report.Validate(valid))
+
+	for _, change := range []func(r *ReportRequest){
+		func(r *ReportRequest) { r.Interval = IntervalDay },
+		func(r *ReportRequest) { r.Metrics = []string{"bytes"} },
+		func(r *ReportRequest) {
+			r.Filters = map[string][]string{"ca": {"cacheable"}, "delivery_type": {"plain"}}
+		},
+		func(r *ReportRequest) { r.Filters = map[string][]string{"delivery_type": {"secure"}} },
+		func(r *ReportRequest) { r.End = r.Start },
+	} {
+		request := valid
+		change(&request)
+		assert.Error(t, report.Validate(request))
+	}
+}
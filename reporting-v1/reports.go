@@ -0,0 +1,97 @@
+package reporting
+
+// Interval is used to create an "enum" of possible report data intervals
+type Interval string
+
+const (
+	// IntervalFiveMinutes Interval value FIVE_MINUTES
+	IntervalFiveMinutes Interval = "FIVE_MINUTES"
+	// IntervalHour Interval value HOUR
+	IntervalHour Interval = "HOUR"
+	// IntervalDay Interval value DAY
+	IntervalDay Interval = "DAY"
+	// IntervalWeek Interval value WEEK
+	IntervalWeek Interval = "WEEK"
+	// IntervalMonth Interval value MONTH
+	IntervalMonth Interval = "MONTH"
+)
+
+// ReportType is a report the Reporting API can execute, by name and version
+//
+// API Docs: https://developer.akamai.com/api/core_features/reporting/v1.html#reporttype
+type ReportType struct {
+	Name               string       `json:"name"`
+	Version            string       `json:"version"`
+	Status             string       `json:"status,omitempty"`
+	Description        string       `json:"description,omitempty"`
+	BusinessObjectName string       `json:"businessObjectName"`
+	DataRetentionDays  int          `json:"dataRetentionDays,omitempty"`
+	AvailableIntervals []Interval   `json:"intervals,omitempty"`
+	Dimensions         []Dimension  `json:"dimensions,omitempty"`
+	Metrics            []Metric     `json:"metrics,omitempty"`
+	Filters            []FilterType `json:"filters,omitempty"`
+	RequiredFilters    []string     `json:"requiredFilters,omitempty"`
+	SupportsPagination bool         `json:"supportsPagination,omitempty"`
+}
+
+// Dimension is a column a report groups its data by
+type Dimension struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Metric is a measured column of a report
+type Metric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	// SummaryStatistic reports whether the metric is a summary statistic
+	// rather than a data column
+	SummaryStatistic bool `json:"summaryStatistic,omitempty"`
+}
+
+// FilterType is a filter a report accepts
+type FilterType struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Values are the accepted values, empty when any value is
+	Values []string `json:"values,omitempty"`
+}
+
+// SupportsInterval reports whether the report aggregates data by interval
+func (report *ReportType) SupportsInterval(interval Interval) bool {
+	for _, i := range report.AvailableIntervals {
+		if i == interval {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ListReportTypes lists the reports available to the account
+//
+// API Docs: https://developer.akamai.com/api/core_features/reporting/v1.html#getreports
+// Endpoint: GET /reporting-api/v1/reports
+func ListReportTypes() ([]ReportType, error) {
+	var reports []ReportType
+	if err := doJSON("GET", "/reporting-api/v1/reports", nil, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// GetReportType retrieves a version of a report
+//
+// API Docs: https://developer.akamai.com/api/core_features/reporting/v1.html#getreport
+// Endpoint: GET /reporting-api/v1/reports/{name}/versions/{version}
+func GetReportType(name string, version string) (*ReportType, error) {
+	report := &ReportType{}
+	if err := doJSON("GET", reportPath(name, version), nil, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,124 @@
+package siem
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxBuckets is the most time buckets Aggregate reports per Series
+const MaxBuckets = 10000
+
+// Dimension selects the attribute events are grouped by in Aggregate
+type Dimension string
+
+const (
+	// DimensionRule groups by triggered rule ID; an event counts once for each of its rules
+	DimensionRule Dimension = "rule"
+	// DimensionPolicy groups by security policy ID
+	DimensionPolicy Dimension = "policy"
+	// DimensionHost groups by requested hostname
+	DimensionHost Dimension = "host"
+	// DimensionAction groups by rule action (alert, deny, ...); an event counts once per distinct action
+	DimensionAction Dimension = "action"
+)
+
+// Point is the number of events in the bucket starting at Time
+type Point struct {
+	Time  time.Time `json:"time"`
+	Count int       `json:"count"`
+}
+
+// Series is the event count over time for a single dimension value
+type Series struct {
+	Key    string  `json:"key"`
+	Total  int     `json:"total"`
+	Points []Point `json:"points"`
+}
+
+// Aggregate counts events per dimension value per time bucket
+//
+// Every returned Series covers the same, contiguous range of buckets (from the
+// earliest to the latest event), with empty buckets reported as zero, so the
+// result can be handed directly to a graphing library. Series are sorted by
+// descending Total, then Key. Events without a valid start time are skipped.
+//
+// It returns an error when the range needs more than MaxBuckets buckets, use a
+// larger bucket for events spread over a long time.
+func Aggregate(events []SecurityEvent, dimension Dimension, bucket time.Duration) ([]Series, error) {
+	if bucket <= 0 {
+		bucket = time.Minute
+	}
+
+	counts := make(map[string]map[int64]int)
+	var first, last int64
+	seen := false
+
+	for _, event := range events {
+		start, err := event.HTTPMessage.StartTime()
+		if err != nil {
+			continue
+		}
+		slot := start.Truncate(bucket).UnixNano()
+		if !seen || slot < first {
+			first = slot
+		}
+		if !seen || slot > last {
+			last = slot
+		}
+		seen = true
+
+		for _, key := range dimensionKeys(event, dimension) {
+			if counts[key] == nil {
+				counts[key] = make(map[int64]int)
+			}
+			counts[key][slot]++
+		}
+	}
+
+	if seen && (last-first)/int64(bucket) >= MaxBuckets {
+		return nil, fmt.Errorf("events span more than %d buckets of %s", MaxBuckets, bucket)
+	}
+
+	series := make([]Series, 0, len(counts))
+	for key, slots := range counts {
+		s := Series{Key: key}
+		for slot := first; slot <= last; slot += int64(bucket) {
+			s.Points = append(s.Points, Point{Time: time.Unix(0, slot).UTC(), Count: slots[slot]})
+			s.Total += slots[slot]
+		}
+		series = append(series, s)
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Total != series[j].Total {
+			return series[i].Total > series[j].Total
+		}
+		return series[i].Key < series[j].Key
+	})
+
+	return series, nil
+}
+
+func dimensionKeys(event SecurityEvent, dimension Dimension) []string {
+	switch dimension {
+	case DimensionRule:
+		return event.AttackData.DecodedRules()
+	case DimensionPolicy:
+		return []string{event.AttackData.PolicyID}
+	case DimensionHost:
+		return []string{event.HTTPMessage.Host}
+	case DimensionAction:
+		var actions []string
+		unique := make(map[string]bool)
+		for _, action := range event.AttackData.DecodedRuleActions() {
+			if !unique[action] {
+				unique[action] = true
+				actions = append(actions, action)
+			}
+		}
+		return actions
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package siem
+
+import (
+	"bufio"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// rules "950002;990011", actions "alert;deny", base64 and URL encoded as returned by the API
+const eventsBody = `{"type":"akamai_siem","format":"json","version":"1.0","attackData":{"configId":"14227","policyId":"qik1_26545","clientIP":"192.0.2.82","rules":"OTUwMDAy%3bOTkwMDEx","ruleActions":"YWxlcnQ%3d%3bZGVueQ%3d%3d"},"httpMessage":{"requestId":"1158db1758e37bfe67b7c09","start":"1491303422","host":"www.example.com"}}
+{"type":"akamai_siem","format":"json","version":"1.0","attackData":{"configId":"14227","policyId":"qik1_26545","clientIP":"192.0.2.82","rules":"OTUwMDAy","ruleActions":"YWxlcnQ%3d"},"httpMessage":{"requestId":"1158db1758e37bfe67b7c10","start":"1491303542","host":"api.example.com"}}
+{"total":2,"offset":"faf5e4e6e0bfaa6a3e0f1e7d571d8e6a","limit":2}
+`
+
+func TestParseEvents(t *testing.T) {
+	response, err := parseEvents(bufio.NewScanner(strings.NewReader(eventsBody)))
+	require.NoError(t, err)
+
+	assert.Len(t, response.Events, 2)
+	assert.Equal(t, "faf5e4e6e0bfaa6a3e0f1e7d571d8e6a", response.Offset)
+	assert.Equal(t, 2, response.Total)
+	assert.Equal(t, []string{"950002", "990011"}, response.Events[0].AttackData.DecodedRules())
+	assert.Equal(t, []string{"alert", "deny"}, response.Events[0].AttackData.DecodedRuleActions())
+}
+
+func TestGetEvents_ConfigIDs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/siem/v1/configs/").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return req.URL.EscapedPath() == "/siem/v1/configs/14227;14228", nil
+		}).
+		Reply(200).
+		BodyString(eventsBody)
+
+	Init(config)
+
+	response, err := GetEvents([]string{"14227", "14228"}, EventsQueryArgs{})
+	require.NoError(t, err)
+	assert.Len(t, response.Events, 2)
+	assert.True(t, gock.IsDone())
+}
+
+func TestAggregate(t *testing.T) {
+	response, err := parseEvents(bufio.NewScanner(strings.NewReader(eventsBody)))
+	require.NoError(t, err)
+
+	series, err := Aggregate(response.Events, DimensionRule, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+
+	assert.Equal(t, "950002", series[0].Key)
+	assert.Equal(t, 2, series[0].Total)
+	require.Len(t, series[0].Points, 3)
+	assert.Equal(t, []int{1, 0, 1}, []int{series[0].Points[0].Count, series[0].Points[1].Count, series[0].Points[2].Count})
+	assert.Equal(t, time.Unix(1491303420, 0).UTC(), series[0].Points[0].Time)
+
+	assert.Equal(t, "990011", series[1].Key)
+	assert.Equal(t, 1, series[1].Total)
+	assert.Len(t, series[1].Points, 3)
+
+	hosts, err := Aggregate(response.Events, DimensionHost, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, hosts, 2)
+	assert.Equal(t, "api.example.com", hosts[0].Key)
+	assert.Len(t, hosts[0].Points, 1)
+
+	_, err = Aggregate(response.Events, DimensionHost, time.Millisecond)
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+package siem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpointer persists the offset a consumer resumes from, see Consumer
+//
+// Implementations must make the offset durable before Save returns: Consumer
+// only moves on to the next page once the offset of the previous one is saved.
+type Checkpointer interface {
+	// Load returns the last saved offset, "" when none was saved yet
+	Load() (string, error)
+	// Save persists offset, replacing the previous one
+	Save(offset string) error
+}
+
+// FileCheckpointer keeps the offset in a JSON file, replaced atomically on
+// each Save so that a crash leaves either the previous or the new offset
+type FileCheckpointer struct {
+	Path string
+}
+
+// MemoryCheckpointer keeps the offset in memory, for tests and consumers
+// that do not need to survive restarts
+type MemoryCheckpointer struct {
+	mutex  sync.Mutex
+	offset string
+}
+
+type checkpointFile struct {
+	Offset  string    `json:"offset"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// NewFileCheckpointer creates a FileCheckpointer writing to path
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{Path: path}
+}
+
+// Load reads the offset of the file, "" when the file does not exist
+func (checkpointer *FileCheckpointer) Load() (string, error) {
+	data, err := ioutil.ReadFile(checkpointer.Path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var checkpoint checkpointFile
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return "", err
+	}
+
+	return checkpoint.Offset, nil
+}
+
+// Save writes offset to a temporary file next to Path, syncs it and renames it to Path
+func (checkpointer *FileCheckpointer) Save(offset string) error {
+	data, err := json.Marshal(checkpointFile{Offset: offset, SavedAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(checkpointer.Path), filepath.Base(checkpointer.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), checkpointer.Path)
+}
+
+// Load returns the saved offset
+func (checkpointer *MemoryCheckpointer) Load() (string, error) {
+	checkpointer.mutex.Lock()
+	defer checkpointer.mutex.Unlock()
+
+	return checkpointer.offset, nil
+}
+
+// Save keeps offset
+func (checkpointer *MemoryCheckpointer) Save(offset string) error {
+	checkpointer.mutex.Lock()
+	defer checkpointer.mutex.Unlock()
+	checkpointer.offset = offset
+
+	return nil
+}
+
+// Consumer fetches the events of security configurations page by page from
+// the offset of its Checkpointer
+//
+// Delivery is at least once: the offset of a page is saved after the handler
+// returned, so a crash or a failed Save in between delivers the page again on
+// the next run. Handlers should be idempotent, e.g. by keying on
+// HTTPMessage.RequestID.
+type Consumer struct {
+	ConfigIDs    []string
+	Checkpointer Checkpointer
+	// Limit is the number of events per page, the API default when 0
+	Limit int
+	// From is the epoch second to start from when no offset was saved yet,
+	// 0 starts from the most recent events the API keeps
+	From int64
+}
+
+// Consume hands each page of events to handle and saves its offset, until a
+// page is not full (the consumer caught up), handle fails or ctx is done
+//
+// The error of handle is returned as is, the offset of its page is not saved.
+func (consumer *Consumer) Consume(ctx context.Context, handle func(events []SecurityEvent) error) error {
+	if consumer.Checkpointer == nil {
+		return errors.New("consumer has no checkpointer")
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		offset, err := consumer.Checkpointer.Load()
+		if err != nil {
+			return err
+		}
+		queryArgs := EventsQueryArgs{Offset: offset, Limit: consumer.Limit}
+		if offset == "" {
+			queryArgs.From = consumer.From
+		}
+
+		response, err := GetEvents(consumer.ConfigIDs, queryArgs)
+		if err != nil {
+			return err
+		}
+		if len(response.Events) > 0 {
+			if err := handle(response.Events); err != nil {
+				return err
+			}
+		}
+		if response.Offset != "" {
+			if err := consumer.Checkpointer.Save(response.Offset); err != nil {
+				return err
+			}
+		}
+
+		if len(response.Events) == 0 || len(response.Events) < response.Limit || response.Offset == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package siem
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+const lastPageBody = `{"type":"akamai_siem","format":"json","version":"1.0","attackData":{"configId":"14227"},"httpMessage":{"requestId":"1158db1758e37bfe67b7c11","start":"1491303600"}}
+{"total":1,"offset":"218d9f4a5c4445d8a7b2ed3a1f4d2dbc","limit":2}
+`
+
+func TestFileCheckpointer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "siem")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	checkpointer := NewFileCheckpointer(filepath.Join(dir, "offset.json"))
+	offset, err := checkpointer.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "", offset)
+
+	require.NoError(t, checkpointer.Save("faf5e4e6e0bfaa6a3e0f1e7d571d8e6a"))
+	require.NoError(t, checkpointer.Save("218d9f4a5c4445d8a7b2ed3a1f4d2dbc"))
+
+	offset, err = NewFileCheckpointer(filepath.Join(dir, "offset.json")).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "218d9f4a5c4445d8a7b2ed3a1f4d2dbc", offset)
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "temporary files are removed")
+}
+
+func TestConsumer_Consume(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/siem/v1/configs/14227").
+		MatchParam("from", "1491303000").
+		MatchParam("limit", "2").
+		Reply(200).
+		BodyString(eventsBody)
+	gock.New(baseURL).
+		Get("/siem/v1/configs/14227").
+		MatchParam("offset", "faf5e4e6e0bfaa6a3e0f1e7d571d8e6a").
+		MatchParam("limit", "2").
+		Reply(200).
+		BodyString(lastPageBody)
+
+	Init(config)
+
+	checkpointer := &MemoryCheckpointer{}
+	consumer := &Consumer{ConfigIDs: []string{"14227"}, Checkpointer: checkpointer, Limit: 2, From: 1491303000}
+
+	var requestIDs []string
+	err := consumer.Consume(context.Background(), func(events []SecurityEvent) error {
+		for _, event := range events {
+			requestIDs = append(requestIDs, event.HTTPMessage.RequestID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1158db1758e37bfe67b7c09", "1158db1758e37bfe67b7c10", "1158db1758e37bfe67b7c11"}, requestIDs)
+	assert.True(t, gock.IsDone())
+
+	offset, err := checkpointer.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "218d9f4a5c4445d8a7b2ed3a1f4d2dbc", offset)
+}
+
+func TestConsumer_ConsumeHandlerError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/siem/v1/configs/14227").
+		MatchParam("offset", "faf5e4e6e0bfaa6a3e0f1e7d571d8e6a").
+		Reply(200).
+		BodyString(lastPageBody)
+
+	Init(config)
+
+	checkpointer := &MemoryCheckpointer{}
+	require.NoError(t, checkpointer.Save("faf5e4e6e0bfaa6a3e0f1e7d571d8e6a"))
+	consumer := &Consumer{ConfigIDs: []string{"14227"}, Checkpointer: checkpointer}
+
+	failure := errors.New("index unavailable")
+	err := consumer.Consume(context.Background(), func(events []SecurityEvent) error {
+		return failure
+	})
+	assert.Equal(t, failure, err)
+
+	offset, err := checkpointer.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "faf5e4e6e0bfaa6a3e0f1e7d571d8e6a", offset, "the page is delivered again")
+
+	err = (&Consumer{ConfigIDs: []string{"14227"}}).Consume(context.Background(), nil)
+	assert.EqualError(t, err, "consumer has no checkpointer")
+}
@@ -0,0 +1,226 @@
+package siem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+// SecurityEvent is a single security event as returned by the SIEM API
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/siem/v1.html#securityevent
+type SecurityEvent struct {
+	Type        string      `json:"type"`
+	Format      string      `json:"format"`
+	Version     string      `json:"version"`
+	AttackData  AttackData  `json:"attackData"`
+	HTTPMessage HTTPMessage `json:"httpMessage"`
+	Geo         Geo         `json:"geo"`
+}
+
+// AttackData describes the security configuration and rules that triggered an event
+//
+// The rule fields are URL encoded, semicolon separated lists of base64 values;
+// use the Decoded* methods to read them.
+type AttackData struct {
+	ConfigID      string `json:"configId"`
+	PolicyID      string `json:"policyId"`
+	ClientIP      string `json:"clientIP"`
+	Rules         string `json:"rules"`
+	RuleVersions  string `json:"ruleVersions"`
+	RuleMessages  string `json:"ruleMessages"`
+	RuleTags      string `json:"ruleTags"`
+	RuleData      string `json:"ruleData"`
+	RuleSelectors string `json:"ruleSelectors"`
+	RuleActions   string `json:"ruleActions"`
+}
+
+// HTTPMessage describes the request that triggered an event
+type HTTPMessage struct {
+	RequestID      string `json:"requestId"`
+	Start          string `json:"start"`
+	Protocol       string `json:"protocol"`
+	Method         string `json:"method"`
+	Host           string `json:"host"`
+	Port           string `json:"port"`
+	Path           string `json:"path"`
+	Query          string `json:"query"`
+	RequestHeaders string `json:"requestHeaders"`
+	Status         string `json:"status"`
+	Bytes          string `json:"bytes"`
+}
+
+// Geo is the client location for an event
+type Geo struct {
+	Continent  string `json:"continent"`
+	Country    string `json:"country"`
+	City       string `json:"city"`
+	RegionCode string `json:"regionCode"`
+	ASN        string `json:"asn"`
+}
+
+// EventsQueryArgs are the query parameters for GetEvents
+//
+// Either Offset, or From (and optionally To) epoch seconds should be set.
+type EventsQueryArgs struct {
+	Offset string
+	Limit  int
+	From   int64
+	To     int64
+}
+
+// EventsResponse is a page of events together with the offset to resume from
+type EventsResponse struct {
+	Events []SecurityEvent
+	Offset string
+	Total  int
+	Limit  int
+}
+
+// GetEvents fetches security events for one or more security configurations
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/siem/v1.html#getsecurityevents
+// Endpoint: GET /siem/v1/configs/{configId}{?offset,limit,from,to}
+func GetEvents(configIDs []string, queryArgs EventsQueryArgs) (*EventsResponse, error) {
+	if len(configIDs) == 0 {
+		return nil, errors.New("at least one security configuration ID is required")
+	}
+
+	escaped := make([]string, len(configIDs))
+	for i, id := range configIDs {
+		escaped[i] = url.PathEscape(id)
+	}
+
+	req, err := client.NewRequest(
+		Config,
+		"GET",
+		fmt.Sprintf("/siem/v1/configs/%s", strings.Join(escaped, ";")),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	if queryArgs.Offset != "" {
+		q.Add("offset", queryArgs.Offset)
+	}
+	if queryArgs.Limit > 0 {
+		q.Add("limit", strconv.Itoa(queryArgs.Limit))
+	}
+	if queryArgs.From > 0 {
+		q.Add("from", strconv.FormatInt(queryArgs.From, 10))
+	}
+	if queryArgs.To > 0 {
+		q.Add("to", strconv.FormatInt(queryArgs.To, 10))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	edge.PrintHttpRequest(req, true)
+
+	res, err := client.Do(Config, req)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.PrintHttpResponse(res, false)
+
+	if client.IsError(res) {
+		return nil, client.NewAPIError(res)
+	}
+
+	defer res.Body.Close()
+
+	return parseEvents(bufio.NewScanner(res.Body))
+}
+
+// parseEvents reads the newline delimited response; the final line carries the offset context
+func parseEvents(scanner *bufio.Scanner) (*EventsResponse, error) {
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	response := &EventsResponse{}
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var context struct {
+			Offset *string `json:"offset"`
+			Total  int     `json:"total"`
+			Limit  int     `json:"limit"`
+		}
+		if err := json.Unmarshal(line, &context); err == nil && context.Offset != nil {
+			response.Offset = *context.Offset
+			response.Total = context.Total
+			response.Limit = context.Limit
+			continue
+		}
+
+		var event SecurityEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, err
+		}
+		response.Events = append(response.Events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// StartTime returns the time the request was received
+func (message HTTPMessage) StartTime() (time.Time, error) {
+	seconds, err := strconv.ParseFloat(message.Start, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), nil
+}
+
+// DecodedRules returns the IDs of the rules that triggered
+func (attack AttackData) DecodedRules() []string {
+	return decodeList(attack.Rules)
+}
+
+// DecodedRuleActions returns the action taken for each triggered rule
+func (attack AttackData) DecodedRuleActions() []string {
+	return decodeList(attack.RuleActions)
+}
+
+// DecodedRuleMessages returns the message for each triggered rule
+func (attack AttackData) DecodedRuleMessages() []string {
+	return decodeList(attack.RuleMessages)
+}
+
+func decodeList(value string) []string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+
+	var decoded []string
+	for _, item := range strings.Split(value, ";") {
+		if item == "" {
+			continue
+		}
+		if b, err := base64.StdEncoding.DecodeString(item); err == nil {
+			item = string(b)
+		}
+		decoded = append(decoded, item)
+	}
+
+	return decoded
+}
@@ -0,0 +1,122 @@
+package siteshield
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Map is a Site Shield map: the CIDR blocks of the edge servers allowed to
+// reach an origin, proposed changes must be acknowledged once the origin
+// firewall allows them
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/site_shield/v1.html#sitemap
+type Map struct {
+	ID             int      `json:"id"`
+	RuleName       string   `json:"ruleName"`
+	Type           string   `json:"type"`
+	MapAlias       string   `json:"mapAlias,omitempty"`
+	Service        string   `json:"service,omitempty"`
+	Shared         bool     `json:"shared"`
+	SureRouteName  string   `json:"sureRouteName,omitempty"`
+	McmMapRuleID   int      `json:"mcmMapRuleId,omitempty"`
+	LatestTicketID int      `json:"latestTicketId,omitempty"`
+	Contacts       []string `json:"contacts,omitempty"`
+	CurrentCIDRs   []string `json:"currentCidrs"`
+	ProposedCIDRs  []string `json:"proposedCidrs"`
+	Acknowledged   bool     `json:"acknowledged"`
+	AcknowledgedBy string   `json:"acknowledgedBy,omitempty"`
+	// AcknowledgedOn and the other dates are epoch milliseconds
+	AcknowledgedOn           int64 `json:"acknowledgedOn,omitempty"`
+	AcknowledgeRequiredBy    int64 `json:"acknowledgeRequiredBy,omitempty"`
+	PreviouslyAcknowledgedOn int64 `json:"previouslyAcknowledgedOn,omitempty"`
+}
+
+// Pending reports whether the map has proposed CIDR blocks to acknowledge
+func (siteShieldMap *Map) Pending() bool {
+	return !siteShieldMap.Acknowledged && len(siteShieldMap.ProposedCIDRs) > 0
+}
+
+// AcknowledgeDeadline returns the time the proposed CIDR blocks must be
+// acknowledged by, zero when there is none
+func (siteShieldMap *Map) AcknowledgeDeadline() time.Time {
+	if siteShieldMap.AcknowledgeRequiredBy == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, siteShieldMap.AcknowledgeRequiredBy*int64(time.Millisecond)).UTC()
+}
+
+// CIDRChanges returns the sorted CIDR blocks the proposed ones add to and
+// remove from the current ones, for the firewall rules to update before
+// acknowledging
+func (siteShieldMap *Map) CIDRChanges() (added []string, removed []string) {
+	current := map[string]bool{}
+	for _, cidr := range siteShieldMap.CurrentCIDRs {
+		current[cidr] = true
+	}
+	proposed := map[string]bool{}
+	for _, cidr := range siteShieldMap.ProposedCIDRs {
+		proposed[cidr] = true
+		if !current[cidr] {
+			added = append(added, cidr)
+		}
+	}
+	if len(siteShieldMap.ProposedCIDRs) > 0 {
+		for _, cidr := range siteShieldMap.CurrentCIDRs {
+			if !proposed[cidr] {
+				removed = append(removed, cidr)
+			}
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+// ListMaps lists the Site Shield maps
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/site_shield/v1.html#getmaps
+// Endpoint: GET /siteshield/v1/maps
+func ListMaps() ([]Map, error) {
+	response := struct {
+		SiteShieldMaps []Map `json:"siteShieldMaps"`
+	}{}
+	if err := doJSON("GET", "/siteshield/v1/maps", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.SiteShieldMaps, nil
+}
+
+// GetMap retrieves a Site Shield map
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/site_shield/v1.html#getamap
+// Endpoint: GET /siteshield/v1/maps/{mapId}
+func GetMap(mapID int) (*Map, error) {
+	siteShieldMap := &Map{}
+	if err := doJSON("GET", mapPath(mapID), nil, siteShieldMap); err != nil {
+		return nil, err
+	}
+
+	return siteShieldMap, nil
+}
+
+// AcknowledgeMap acknowledges the proposed CIDR blocks of a Site Shield map,
+// they then become the current ones
+//
+// API Docs: https://developer.akamai.com/api/cloud_security/site_shield/v1.html#postacknowledge
+// Endpoint: POST /siteshield/v1/maps/{mapId}/acknowledge
+func AcknowledgeMap(mapID int) (*Map, error) {
+	siteShieldMap := &Map{}
+	if err := doJSON("POST", mapPath(mapID)+"/acknowledge", nil, siteShieldMap); err != nil {
+		return nil, err
+	}
+
+	return siteShieldMap, nil
+}
+
+func mapPath(mapID int) string {
+	return fmt.Sprintf("/siteshield/v1/maps/%d", mapID)
+}
@@ -0,0 +1,83 @@
+package siteshield
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+const mapJSON = `{
+	"id": 1234,
+	"ruleName": "s1234.akamaiedge.net",
+	"type": "Production",
+	"shared": false,
+	"acknowledged": false,
+	"acknowledgeRequiredBy": 1591002000000,
+	"contacts": ["jsmith@example.com"],
+	"currentCidrs": ["192.0.2.0/24", "198.51.100.0/24"],
+	"proposedCidrs": ["192.0.2.0/24", "203.0.113.0/24"]
+}`
+
+func TestListMaps(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/siteshield/v1/maps").
+		Reply(200).
+		JSON(`{"siteShieldMaps": [` + mapJSON + `, {"id": 1235, "ruleName": "s1235.akamaiedge.net", "acknowledged": true, "currentCidrs": ["192.0.2.0/24"], "proposedCidrs": []}]}`)
+
+	Init(config)
+
+	maps, err := ListMaps()
+	require.NoError(t, err)
+	require.Len(t, maps, 2)
+	assert.True(t, maps[0].Pending())
+	assert.False(t, maps[1].Pending())
+}
+
+func TestGetMapAndAcknowledge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Get("/siteshield/v1/maps/1234").
+		Reply(200).
+		JSON(mapJSON)
+	gock.New(baseURL).
+		Post("/siteshield/v1/maps/1234/acknowledge").
+		Reply(200).
+		JSON(`{"id": 1234, "ruleName": "s1234.akamaiedge.net", "acknowledged": true, "acknowledgedBy": "jsmith", "acknowledgedOn": 1590998400000, "currentCidrs": ["192.0.2.0/24", "203.0.113.0/24"], "proposedCidrs": []}`)
+
+	Init(config)
+
+	siteShieldMap, err := GetMap(1234)
+	require.NoError(t, err)
+	added, removed := siteShieldMap.CIDRChanges()
+	assert.Equal(t, []string{"203.0.113.0/24"}, added)
+	assert.Equal(t, []string{"198.51.100.0/24"}, removed)
+	assert.Equal(t, time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC), siteShieldMap.AcknowledgeDeadline())
+
+	acknowledged, err := AcknowledgeMap(1234)
+	require.NoError(t, err)
+	assert.True(t, acknowledged.Acknowledged)
+	assert.False(t, acknowledged.Pending())
+	added, removed = acknowledged.CIDRChanges()
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,193 @@
+package testcenter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultTestRunInterval is the interval WaitForTestRun polls at by default
+const DefaultTestRunInterval = 15 * time.Second
+
+// TestRunStatus is used to create an "enum" of possible test run and execution statuses
+type TestRunStatus string
+
+// TestResult is used to create an "enum" of possible TestCaseExecution results
+type TestResult string
+
+const (
+	// StatusInProgress TestRun.Status value IN_PROGRESS
+	StatusInProgress TestRunStatus = "IN_PROGRESS"
+	// StatusCompleted TestRun.Status value COMPLETED
+	StatusCompleted TestRunStatus = "COMPLETED"
+	// StatusFailed TestRun.Status value FAILED
+	StatusFailed TestRunStatus = "FAILED"
+
+	// ResultPassed TestCaseExecution.Result value PASSED
+	ResultPassed TestResult = "PASSED"
+	// ResultFailed TestCaseExecution.Result value FAILED
+	ResultFailed TestResult = "FAILED"
+)
+
+// TargetStaging is the only network test runs can target
+const TargetStaging = "STAGING"
+
+// TestRun is an execution of test suites against the staging network
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#testrun
+type TestRun struct {
+	TestRunID             int64             `json:"testRunId,omitempty"`
+	TargetEnvironment     string            `json:"targetEnvironment"`
+	Note                  string            `json:"note,omitempty"`
+	SendEmailOnCompletion bool              `json:"sendEmailOnCompletion"`
+	Status                TestRunStatus     `json:"status,omitempty"`
+	Functional            FunctionalTestRun `json:"functional"`
+	SubmittedBy           string            `json:"submittedBy,omitempty"`
+	SubmittedDate         string            `json:"submittedDate,omitempty"`
+	CompletedDate         string            `json:"completedDate,omitempty"`
+}
+
+// FunctionalTestRun are the functional test suites of a test run, either
+// standalone or those of a property version
+type FunctionalTestRun struct {
+	Status                   TestRunStatus             `json:"status,omitempty"`
+	TestSuiteExecutions      []TestSuiteExecution      `json:"testSuiteExecutions,omitempty"`
+	PropertyManagerExecution *PropertyManagerExecution `json:"propertyManagerExecution,omitempty"`
+}
+
+// PropertyManagerExecution are the test suites of a property version run by a test run
+type PropertyManagerExecution struct {
+	PropertyVersion
+	Status              TestRunStatus        `json:"status,omitempty"`
+	TestSuiteExecutions []TestSuiteExecution `json:"testSuiteExecutions"`
+}
+
+// TestSuiteExecution is the execution of a test suite by a test run
+type TestSuiteExecution struct {
+	TestSuiteExecutionID int64               `json:"testSuiteExecutionId,omitempty"`
+	TestSuiteID          int64               `json:"testSuiteId"`
+	Status               TestRunStatus       `json:"status,omitempty"`
+	TestCaseExecutions   []TestCaseExecution `json:"testCaseExecutions,omitempty"`
+}
+
+// TestCaseExecution is the execution of a test case by a test run
+type TestCaseExecution struct {
+	TestCaseExecutionID int64         `json:"testCaseExecutionId"`
+	TestCaseID          int64         `json:"testCaseId"`
+	Status              TestRunStatus `json:"status"`
+	Result              TestResult    `json:"result,omitempty"`
+}
+
+// Done reports whether the test run finished
+func (run *TestRun) Done() bool {
+	return run.Status != "" && run.Status != StatusInProgress
+}
+
+// TestSuiteExecutions returns the test suite executions of the run, standalone or of a property version
+func (run *TestRun) TestSuiteExecutions() []TestSuiteExecution {
+	executions := run.Functional.TestSuiteExecutions
+	if run.Functional.PropertyManagerExecution != nil {
+		executions = append(executions, run.Functional.PropertyManagerExecution.TestSuiteExecutions...)
+	}
+
+	return executions
+}
+
+// FailedTestCases returns the test case executions that failed to run or whose condition failed
+func (run *TestRun) FailedTestCases() []TestCaseExecution {
+	var failed []TestCaseExecution
+	for _, suite := range run.TestSuiteExecutions() {
+		for _, execution := range suite.TestCaseExecutions {
+			if execution.Status == StatusFailed || execution.Result == ResultFailed {
+				failed = append(failed, execution)
+			}
+		}
+	}
+
+	return failed
+}
+
+// TestRunFailedError is returned by WaitForTestRun when a test run fails or test cases do not pass
+type TestRunFailedError struct {
+	TestRun *TestRun
+}
+
+func (e *TestRunFailedError) Error() string {
+	failed := e.TestRun.FailedTestCases()
+	if len(failed) == 0 {
+		return fmt.Sprintf("test run %d %s", e.TestRun.TestRunID, e.TestRun.Status)
+	}
+
+	return fmt.Sprintf("test run %d %s: %d test cases failed", e.TestRun.TestRunID, e.TestRun.Status, len(failed))
+}
+
+// NewPropertyTestRun returns a test run of the test suites of a property
+// version against staging, to start with CreateTestRun
+func NewPropertyTestRun(propertyName string, propertyVersion int, note string, testSuiteIDs ...int64) *TestRun {
+	execution := &PropertyManagerExecution{
+		PropertyVersion: PropertyVersion{PropertyName: propertyName, PropertyVersion: propertyVersion},
+	}
+	for _, id := range testSuiteIDs {
+		execution.TestSuiteExecutions = append(execution.TestSuiteExecutions, TestSuiteExecution{TestSuiteID: id})
+	}
+
+	return &TestRun{
+		TargetEnvironment: TargetStaging,
+		Note:              note,
+		Functional:        FunctionalTestRun{PropertyManagerExecution: execution},
+	}
+}
+
+// CreateTestRun starts a test run
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#posttestruns
+// Endpoint: POST /test-management/v3/test-runs
+func CreateTestRun(run *TestRun) (*TestRun, error) {
+	created := &TestRun{}
+	if err := doJSON("POST", "/test-management/v3/test-runs", run, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetTestRun retrieves the status and results of a test run
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#gettestrun
+// Endpoint: GET /test-management/v3/test-runs/{testRunId}
+func GetTestRun(testRunID int64) (*TestRun, error) {
+	run := &TestRun{}
+	if err := doJSON("GET", fmt.Sprintf("/test-management/v3/test-runs/%d", testRunID), nil, run); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// WaitForTestRun polls a test run every interval until it is done or ctx is
+// done. A *TestRunFailedError is returned with the run when it did not
+// complete or a test case failed.
+func WaitForTestRun(ctx context.Context, testRunID int64, interval time.Duration) (*TestRun, error) {
+	if interval <= 0 {
+		interval = DefaultTestRunInterval
+	}
+
+	for {
+		run, err := GetTestRun(testRunID)
+		if err != nil {
+			return nil, err
+		}
+		if run.Done() {
+			if run.Status != StatusCompleted || len(run.FailedTestCases()) > 0 {
+				return run, &TestRunFailedError{TestRun: run}
+			}
+			return run, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
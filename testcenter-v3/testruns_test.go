@@ -0,0 +1,94 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+var (
+	config = edgegrid.Config{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+		MaxBody:      2048,
+		Debug:        false,
+	}
+	baseURL = "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net"
+)
+
+func TestCreateTestSuiteAndCases(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/test-management/v3/functional/test-suites").
+		JSON(map[string]interface{}{"testSuiteName": "www smoke", "isLocked": false, "isStateful": false, "configs": map[string]interface{}{"propertyManager": map[string]interface{}{"propertyName": "www.example.com", "propertyVersion": 4}}}).
+		Reply(201).
+		JSON(`{"testSuiteId": 1001, "testSuiteName": "www smoke", "configs": {"propertyManager": {"propertyName": "www.example.com", "propertyVersion": 4}}}`)
+	gock.New(baseURL).
+		Post("/test-management/v3/functional/test-suites/1001/test-cases").
+		Reply(207).
+		JSON(`{"successes": [{"testCaseId": 2001, "order": 1, "testRequest": {"testRequestUrl": "https://www.example.com/"}, "clientProfile": {"client": "CURL", "ipVersion": "IPV4"}, "condition": {"conditionExpression": "Response code is one of \"200\""}}],
+			"failures": [{"index": 1, "title": "Invalid condition expression", "testCase": {"testRequest": {"testRequestUrl": "https://www.example.com/api"}}}]}`)
+
+	Init(config)
+
+	suite, err := CreateTestSuite(NewPropertyTestSuite("www smoke", "www.example.com", 4))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1001), suite.TestSuiteID)
+
+	profile := ClientProfile{Client: ClientCurl, IPVersion: IPv4}
+	added, err := AddTestCases(suite.TestSuiteID,
+		TestCase{TestRequest: TestRequest{TestRequestURL: "https://www.example.com/"}, ClientProfile: profile, Condition: Condition{`Response code is one of "200"`}},
+		TestCase{TestRequest: TestRequest{TestRequestURL: "https://www.example.com/api"}, ClientProfile: profile, Condition: Condition{"Response code is"}},
+	)
+	var addErr *AddTestCasesError
+	require.True(t, errors.As(err, &addErr))
+	assert.Len(t, addErr.Failures, 1)
+	assert.Contains(t, err.Error(), "https://www.example.com/api: Invalid condition expression")
+	require.Len(t, added, 1)
+	assert.Equal(t, int64(2001), added[0].TestCaseID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestWaitForTestRun(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(baseURL).
+		Post("/test-management/v3/test-runs").
+		JSON(map[string]interface{}{"targetEnvironment": "STAGING", "note": "v4", "sendEmailOnCompletion": false, "functional": map[string]interface{}{"propertyManagerExecution": map[string]interface{}{"propertyName": "www.example.com", "propertyVersion": 4, "testSuiteExecutions": []map[string]interface{}{{"testSuiteId": 1001}}}}}).
+		Reply(201).
+		JSON(`{"testRunId": 3001, "status": "IN_PROGRESS", "targetEnvironment": "STAGING"}`)
+	gock.New(baseURL).
+		Get("/test-management/v3/test-runs/3001").
+		Reply(200).
+		JSON(`{"testRunId": 3001, "status": "IN_PROGRESS", "targetEnvironment": "STAGING"}`)
+	gock.New(baseURL).
+		Get("/test-management/v3/test-runs/3001").
+		Reply(200).
+		JSON(`{"testRunId": 3001, "status": "COMPLETED", "targetEnvironment": "STAGING", "functional": {"status": "COMPLETED", "propertyManagerExecution": {"propertyName": "www.example.com", "propertyVersion": 4, "testSuiteExecutions": [
+			{"testSuiteExecutionId": 4001, "testSuiteId": 1001, "status": "COMPLETED", "testCaseExecutions": [
+				{"testCaseExecutionId": 5001, "testCaseId": 2001, "status": "COMPLETED", "result": "PASSED"},
+				{"testCaseExecutionId": 5002, "testCaseId": 2002, "status": "COMPLETED", "result": "FAILED"}
+			]}
+		]}}}`)
+
+	Init(config)
+
+	run, err := CreateTestRun(NewPropertyTestRun("www.example.com", 4, "v4", 1001))
+	require.NoError(t, err)
+
+	run, err = WaitForTestRun(context.Background(), run.TestRunID, 1)
+	var failed *TestRunFailedError
+	require.True(t, errors.As(err, &failed))
+	assert.Equal(t, "test run 3001 COMPLETED: 1 test cases failed", err.Error())
+	require.Len(t, run.FailedTestCases(), 1)
+	assert.Equal(t, int64(2002), run.FailedTestCases()[0].TestCaseID)
+	assert.True(t, gock.IsDone())
+}
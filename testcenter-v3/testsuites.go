@@ -0,0 +1,228 @@
+package testcenter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TestSuite is a set of functional test cases, optionally tied to a property version
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#testsuite
+type TestSuite struct {
+	TestSuiteID          int64            `json:"testSuiteId,omitempty"`
+	TestSuiteName        string           `json:"testSuiteName"`
+	TestSuiteDescription string           `json:"testSuiteDescription,omitempty"`
+	IsLocked             bool             `json:"isLocked"`
+	IsStateful           bool             `json:"isStateful"`
+	Configs              *TestSuiteConfig `json:"configs,omitempty"`
+	CreatedBy            string           `json:"createdBy,omitempty"`
+	CreatedDate          string           `json:"createdDate,omitempty"`
+	ModifiedBy           string           `json:"modifiedBy,omitempty"`
+	ModifiedDate         string           `json:"modifiedDate,omitempty"`
+}
+
+// TestSuiteConfig is the configuration a test suite is associated with
+type TestSuiteConfig struct {
+	PropertyManager *PropertyVersion `json:"propertyManager,omitempty"`
+}
+
+// PropertyVersion is a Property Manager property version tested by a test suite or run
+type PropertyVersion struct {
+	PropertyName    string `json:"propertyName"`
+	PropertyVersion int    `json:"propertyVersion"`
+}
+
+// ClientType is used to create an "enum" of possible TestCase client values
+type ClientType string
+
+// IPVersion is used to create an "enum" of possible TestCase IP versions
+type IPVersion string
+
+const (
+	// ClientCurl ClientProfile.Client value CURL
+	ClientCurl ClientType = "CURL"
+	// ClientChrome ClientProfile.Client value CHROME
+	ClientChrome ClientType = "CHROME"
+
+	// IPv4 ClientProfile.IPVersion value IPV4
+	IPv4 IPVersion = "IPV4"
+	// IPv6 ClientProfile.IPVersion value IPV6
+	IPv6 IPVersion = "IPV6"
+)
+
+// TestCase is a request sent during a test run and the condition its
+// response must meet
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#testcase
+type TestCase struct {
+	TestCaseID    int64         `json:"testCaseId,omitempty"`
+	Order         int           `json:"order,omitempty"`
+	TestRequest   TestRequest   `json:"testRequest"`
+	ClientProfile ClientProfile `json:"clientProfile"`
+	Condition     Condition     `json:"condition"`
+}
+
+// TestRequest is the request of a test case
+type TestRequest struct {
+	TestRequestURL string          `json:"testRequestUrl"`
+	RequestMethod  string          `json:"requestMethod,omitempty"`
+	RequestHeaders []RequestHeader `json:"requestHeaders,omitempty"`
+	RequestBody    string          `json:"requestBody,omitempty"`
+}
+
+// RequestHeader is a header added to, modified in or removed from a test request
+type RequestHeader struct {
+	HeaderName   string `json:"headerName"`
+	HeaderValue  string `json:"headerValue,omitempty"`
+	HeaderAction string `json:"headerAction"`
+}
+
+// ClientProfile is the client a test request is sent with
+type ClientProfile struct {
+	Client      ClientType `json:"client"`
+	IPVersion   IPVersion  `json:"ipVersion"`
+	GeoLocation string     `json:"geoLocation,omitempty"`
+}
+
+// Condition is the condition expression evaluated against the response of a
+// test request, e.g. Response code is one of "200"
+type Condition struct {
+	ConditionExpression string `json:"conditionExpression"`
+}
+
+// TestCaseError is a test case AddTestCases could not add
+type TestCaseError struct {
+	Index    int      `json:"index"`
+	Title    string   `json:"title"`
+	Detail   string   `json:"detail,omitempty"`
+	TestCase TestCase `json:"testCase"`
+}
+
+// AddTestCasesError is returned by AddTestCases when some test cases were not added
+type AddTestCasesError struct {
+	Failures []TestCaseError
+}
+
+func (e *AddTestCasesError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		messages = append(messages, fmt.Sprintf("%s: %s", failure.TestCase.TestRequest.TestRequestURL, failure.Title))
+	}
+
+	return fmt.Sprintf("%d test cases not added: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// NewPropertyTestSuite returns a test suite for a property version, to create with CreateTestSuite
+func NewPropertyTestSuite(name, propertyName string, propertyVersion int) *TestSuite {
+	return &TestSuite{
+		TestSuiteName: name,
+		Configs: &TestSuiteConfig{
+			PropertyManager: &PropertyVersion{PropertyName: propertyName, PropertyVersion: propertyVersion},
+		},
+	}
+}
+
+// ListTestSuites lists the functional test suites
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#gettestsuites
+// Endpoint: GET /test-management/v3/functional/test-suites
+func ListTestSuites() ([]TestSuite, error) {
+	var suites []TestSuite
+	if err := doJSON("GET", "/test-management/v3/functional/test-suites", nil, &suites); err != nil {
+		return nil, err
+	}
+
+	return suites, nil
+}
+
+// GetTestSuite retrieves a functional test suite
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#gettestsuite
+// Endpoint: GET /test-management/v3/functional/test-suites/{testSuiteId}
+func GetTestSuite(testSuiteID int64) (*TestSuite, error) {
+	suite := &TestSuite{}
+	if err := doJSON("GET", testSuitePath(testSuiteID), nil, suite); err != nil {
+		return nil, err
+	}
+
+	return suite, nil
+}
+
+// CreateTestSuite creates a functional test suite
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#posttestsuites
+// Endpoint: POST /test-management/v3/functional/test-suites
+func CreateTestSuite(suite *TestSuite) (*TestSuite, error) {
+	created := &TestSuite{}
+	if err := doJSON("POST", "/test-management/v3/functional/test-suites", suite, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateTestSuite updates a functional test suite, e.g. to test a new property version
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#puttestsuite
+// Endpoint: PUT /test-management/v3/functional/test-suites/{testSuiteId}
+func UpdateTestSuite(suite *TestSuite) (*TestSuite, error) {
+	updated := &TestSuite{}
+	if err := doJSON("PUT", testSuitePath(suite.TestSuiteID), suite, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RemoveTestSuite deletes a functional test suite and its test cases
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#deletetestsuite
+// Endpoint: DELETE /test-management/v3/functional/test-suites/{testSuiteId}
+func RemoveTestSuite(testSuiteID int64) error {
+	return doJSON("DELETE", testSuitePath(testSuiteID), nil, nil)
+}
+
+// ListTestCases lists the test cases of a test suite
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#gettestcases
+// Endpoint: GET /test-management/v3/functional/test-suites/{testSuiteId}/test-cases
+func ListTestCases(testSuiteID int64) ([]TestCase, error) {
+	var cases []TestCase
+	if err := doJSON("GET", testSuitePath(testSuiteID)+"/test-cases", nil, &cases); err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
+
+// AddTestCases adds test cases to a test suite and returns those added, an
+// *AddTestCasesError lists the others
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#posttestcases
+// Endpoint: POST /test-management/v3/functional/test-suites/{testSuiteId}/test-cases
+func AddTestCases(testSuiteID int64, cases ...TestCase) ([]TestCase, error) {
+	response := struct {
+		Successes []TestCase      `json:"successes"`
+		Failures  []TestCaseError `json:"failures"`
+	}{}
+	if err := doJSON("POST", testSuitePath(testSuiteID)+"/test-cases", cases, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Failures) > 0 {
+		return response.Successes, &AddTestCasesError{Failures: response.Failures}
+	}
+
+	return response.Successes, nil
+}
+
+// RemoveTestCase deletes a test case of a test suite
+//
+// API Docs: https://developer.akamai.com/api/core_features/test_center/v3.html#deletetestcase
+// Endpoint: DELETE /test-management/v3/functional/test-suites/{testSuiteId}/test-cases/{testCaseId}
+func RemoveTestCase(testSuiteID, testCaseID int64) error {
+	return doJSON("DELETE", fmt.Sprintf("%s/test-cases/%d", testSuitePath(testSuiteID), testCaseID), nil, nil)
+}
+
+func testSuitePath(testSuiteID int64) string {
+	return fmt.Sprintf("/test-management/v3/functional/test-suites/%d", testSuiteID)
+}